@@ -1,11 +1,8 @@
 package main
 
 import (
-	"log"
-	"unit-management-be/internal/db"
-	"unit-management-be/internal/routes"
-
-	"github.com/joho/godotenv"
+	"os"
+	"unit-management-be/internal/cli"
 )
 
 // @title Unit Management API
@@ -22,17 +19,12 @@ import (
 
 // @host localhost:5000
 // @BasePath /api
-func main() {
-	err := godotenv.Load()
-	if err != nil {
-		log.Println(".env file not found, falling back to system environment variables")
-	}
-
-	// try to connect database
-	db.ConnectDatabase()
 
-	// run migrations
-	db.RunMigrations()
-
-	routes.Run()
+// @securityDefinitions.apikey BearerAuth
+// @in header
+// @name Authorization
+// @description Access token from /auth/login, sent as "Bearer <token>"
+func main() {
+	// the server binary is unitctl serve, it accepts the same -config and -migrations flags
+	os.Exit(cli.Serve(os.Args[1:], os.Stderr))
 }
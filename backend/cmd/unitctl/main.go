@@ -0,0 +1,12 @@
+package main
+
+import (
+	"os"
+	"unit-management-be/internal/cli"
+)
+
+// unitctl manages the backend from a shell: serving, migrating the database, seeding demo data
+// and fixing units, run it without arguments for the list of commands
+func main() {
+	os.Exit(cli.Run(os.Args[1:], os.Stdout, os.Stderr))
+}
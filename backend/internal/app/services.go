@@ -0,0 +1,72 @@
+package app
+
+import (
+	bookingrepository "unit-management-be/pkg/repository/bookings"
+	housekeepingrepository "unit-management-be/pkg/repository/housekeeping"
+	maintenancerepository "unit-management-be/pkg/repository/maintenance"
+	unitrepository "unit-management-be/pkg/repository/units"
+	webhookrepository "unit-management-be/pkg/repository/webhooks"
+	bookingservice "unit-management-be/pkg/service/bookings"
+	housekeepingservice "unit-management-be/pkg/service/housekeeping"
+	maintenanceservice "unit-management-be/pkg/service/maintenance"
+	reportservice "unit-management-be/pkg/service/reports"
+	unitservice "unit-management-be/pkg/service/units"
+	webhookservice "unit-management-be/pkg/service/webhooks"
+
+	"gorm.io/gorm"
+)
+
+// Services are the unit services and the subsystems reacting to unit changes, wired the same way
+// for the server and unitctl so a change made from either one has the same effects. Bookings,
+// Housekeeping and Maintenance are nil when built with NewUnitServices
+type Services struct {
+	Units        unitservice.UnitService
+	Bookings     bookingservice.BookingService
+	Housekeeping housekeepingservice.HousekeepingService
+	Maintenance  maintenanceservice.MaintenanceService
+	Reports      reportservice.ReportService
+	Webhooks     webhookservice.WebhookService
+}
+
+// NewServices builds the services on the database, units are stored in unitRepository
+func NewServices(database *gorm.DB, unitRepository unitrepository.UnitRepository, peakHours housekeepingservice.PeakHours) Services {
+	services := NewUnitServices(database, unitRepository)
+	unitService := services.Units
+
+	bookingRepository := bookingrepository.NewBookingRepository(database)
+	services.Bookings = bookingservice.NewBookingService(bookingRepository, unitService)
+
+	housekeepingRepository := housekeepingrepository.NewHousekeepingRepository(database)
+	housekeepingService := housekeepingservice.NewHousekeepingService(housekeepingRepository, unitService, peakHours)
+	unitService.RegisterStatusHook(housekeepingService.OnUnitStatusChanged)
+	services.Housekeeping = housekeepingService
+
+	maintenanceRepository := maintenancerepository.NewMaintenanceRepository(database)
+	maintenanceService := maintenanceservice.NewMaintenanceService(maintenanceRepository, unitService)
+	unitService.RegisterStatusGuard(maintenanceService.GuardUnitStatusChange)
+	services.Maintenance = maintenanceService
+
+	return services
+}
+
+// NewUnitServices builds the unit, report and webhook services only. Bookings, housekeeping and maintenance
+// reference the units table, so they are left nil and never hook into unit changes when units are not
+// stored in the database
+func NewUnitServices(database *gorm.DB, unitRepository unitrepository.UnitRepository) Services {
+	unitService := unitservice.NewUnitService(unitRepository)
+
+	reportService := reportservice.NewReportService(unitRepository)
+
+	// deliveries are queued in the database in the transaction of the unit change, the dispatcher
+	// of the server also sends the ones queued by unitctl
+	webhookRepository := webhookrepository.NewWebhookRepository(database)
+	webhookService := webhookservice.NewWebhookService(webhookRepository)
+	unitService.RegisterChangeHook(webhookService.OnUnitChanged)
+	unitService.RegisterStatusHook(webhookService.OnUnitStatusChanged)
+
+	return Services{
+		Units:    unitService,
+		Reports:  reportService,
+		Webhooks: webhookService,
+	}
+}
@@ -0,0 +1,64 @@
+package app
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"unit-management-be/internal/db"
+	"unit-management-be/pkg/model/domain/enum"
+	"unit-management-be/pkg/model/dto/request"
+	unitrepository "unit-management-be/pkg/repository/units"
+	webhookrepository "unit-management-be/pkg/repository/webhooks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewUnitServices(t *testing.T) {
+	t.Run("Negative Case: Rolled back bulk change in memory mode queues no delivery", func(t *testing.T) {
+		database, err := db.Open(db.DriverSQLite, "file::memory:?_pragma=foreign_keys(1)&_time_format=sqlite", db.DefaultPool)
+		require.NoError(t, err)
+		sqlDB, err := database.DB()
+		require.NoError(t, err)
+		t.Cleanup(func() { sqlDB.Close() })
+		migrations, err := db.MigrationsFS(db.DriverSQLite, "")
+		require.NoError(t, err)
+		require.NoError(t, db.Migrate(database, db.DriverSQLite, migrations))
+
+		services := NewUnitServices(database, unitrepository.NewMemoryUnitRepository(database))
+		webhook, errWebhook := services.Webhooks.CreateWebhook(context.Background(), request.CreateWebhookDto{
+			URL:    "http://localhost:9999/hook",
+			Events: []string{string(enum.WebhookUnitStatusChanged)},
+			Secret: "webhook-secret-of-the-test",
+		})
+		require.Nil(t, errWebhook)
+
+		cleaning, errCreate := services.Units.CreateUnit(context.Background(), request.CreateUnitDto{Name: "Cabin 1", Type: string(enum.Cabin), Status: string(enum.CleaningInProgress)})
+		require.Nil(t, errCreate)
+		occupied, errCreate := services.Units.CreateUnit(context.Background(), request.CreateUnitDto{Name: "Cabin 2", Type: string(enum.Cabin), Status: string(enum.Available)})
+		require.Nil(t, errCreate)
+		_, errUpdate := services.Units.BulkChangeStatus(context.Background(), request.BulkChangeUnitStatusDto{UnitIDs: []string{occupied.ID.String()}, Status: string(enum.Occupied)})
+		require.Nil(t, errUpdate)
+
+		deliveries := func() int64 {
+			_, total, err := webhookrepository.NewWebhookRepository(database).FindDeliveries(context.Background(), webhook.ID.String(), "", 1, 10)
+			require.NoError(t, err)
+			return total
+		}
+		queued := deliveries()
+		require.Equal(t, int64(1), queued)
+
+		// the cleaned unit can be made available, the occupied one cannot so the whole batch is rolled back
+		_, errBulk := services.Units.BulkChangeStatus(context.Background(), request.BulkChangeUnitStatusDto{
+			UnitIDs: []string{cleaning.ID.String(), occupied.ID.String()},
+			Status:  string(enum.Available),
+		})
+		require.NotNil(t, errBulk)
+		assert.Equal(t, http.StatusConflict, errBulk.Code)
+
+		assert.Equal(t, queued, deliveries())
+		unit, errFind := services.Units.FindByID(context.Background(), cleaning.ID.String())
+		require.Nil(t, errFind)
+		assert.Equal(t, enum.CleaningInProgress, unit.Status)
+	})
+}
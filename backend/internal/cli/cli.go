@@ -0,0 +1,245 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"os/signal"
+	"os/user"
+	"syscall"
+	"unit-management-be/internal/app"
+	"unit-management-be/internal/config"
+	"unit-management-be/internal/db"
+	"unit-management-be/internal/routes"
+	"unit-management-be/pkg/logging"
+	"unit-management-be/pkg/utils"
+
+	unitrepository "unit-management-be/pkg/repository/units"
+
+	"github.com/joho/godotenv"
+	"gorm.io/gorm"
+)
+
+const usage = `Usage: unitctl [-config file] [-migrations dir] <command>
+
+Commands:
+  serve                                  migrate the database up and serve the API, with DB_AUTO_MIGRATE=false
+                                         the schema is only checked
+  migrate up                             apply every pending migration
+  migrate down [steps]                   roll back the last migration, or the last steps migrations
+  migrate to <version>                   apply or roll back migrations until the schema is at version
+  migrate status                         show the schema version and the pending migrations
+  migrate force <version>                record version as applied and clear the dirty flag once a failed
+                                         migration has been fixed by hand, -1 records no migration
+  seed                                   create the demo units that do not exist yet
+  units list [-status s] [-type t] [-name n]
+                                         list the units, sorted by name
+  units set-status [-reason r] <unitId> <status>
+                                         move a unit to another status, with the same rules as the API
+
+The configuration is read like the server does, only the database and log settings are needed
+besides serve. Logs are written to stderr. The migrations are embedded in the binary, -migrations
+reads them from a folder laid out like backend/migrations instead.
+`
+
+const serveUsage = `Usage: main [-config file] [-migrations dir]
+
+Serves the API like unitctl serve. The pending migrations are applied first, with DB_AUTO_MIGRATE=false
+the server refuses to start when the schema is dirty or behind instead.
+`
+
+// options are the flags shared by every command
+type options struct {
+	configFile    string
+	migrationsDir string
+}
+
+// loadConfig loads the configuration with load, the migrations flag wins over the configuration
+func (o options) loadConfig(load func(path string) (config.Config, error)) (config.Config, error) {
+	cfg, err := load(o.configFile)
+	if !utils.IsEmptyString(o.migrationsDir) {
+		cfg.Database.MigrationsDir = o.migrationsDir
+	}
+	return cfg, err
+}
+
+// usageError is a command line that cannot be run, it is reported with the usage
+type usageError struct {
+	message string
+}
+
+func (e usageError) Error() string {
+	return e.message
+}
+
+func usageErrorf(format string, args ...any) error {
+	return usageError{message: fmt.Sprintf(format, args...)}
+}
+
+// newFlagSet declares the flags shared by unitctl and the server binary into options
+func newFlagSet(name, usage string, options *options, stderr io.Writer) *flag.FlagSet {
+	flags := flag.NewFlagSet(name, flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.Usage = func() { fmt.Fprint(stderr, usage) }
+	flags.StringVar(&options.configFile, "config", os.Getenv("CONFIG_FILE"), "optional YAML configuration file, environment variables take precedence over it")
+	flags.StringVar(&options.migrationsDir, "migrations", "", "folder to read the migrations from instead of the ones embedded in the binary")
+	return flags
+}
+
+// Serve runs the server binary, which takes the flags of unitctl and no command. It returns the exit code,
+// 2 when args cannot be run, once the server has stopped
+func Serve(args []string, stderr io.Writer) int {
+	// .env is loaded first, it may set CONFIG_FILE
+	errEnv := godotenv.Load()
+
+	options := options{}
+	flags := newFlagSet("main", serveUsage, &options, stderr)
+	if err := flags.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return 0
+		}
+		return 2
+	}
+	if flags.NArg() > 0 {
+		fmt.Fprintf(stderr, "main: unexpected argument %q\n\n%s", flags.Arg(0), serveUsage)
+		return 2
+	}
+
+	serve(options, errEnv)
+	return 0
+}
+
+// Run runs the unitctl command line in args and returns the exit code, 2 when args cannot be run
+func Run(args []string, stdout, stderr io.Writer) int {
+	// .env is loaded first, it may set CONFIG_FILE
+	errEnv := godotenv.Load()
+
+	options := options{}
+	flags := newFlagSet("unitctl", usage, &options, stderr)
+	if err := flags.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return 0
+		}
+		return 2
+	}
+
+	args = flags.Args()
+	if len(args) == 0 {
+		fmt.Fprint(stderr, usage)
+		return 2
+	}
+
+	if args[0] == "serve" {
+		if len(args) > 1 {
+			fmt.Fprintf(stderr, "unitctl: serve takes no arguments\n\n%s", usage)
+			return 2
+		}
+		serve(options, errEnv)
+		return 0
+	}
+
+	err := run(options, args, stdout, stderr)
+	var errUsage usageError
+	switch {
+	case err == nil:
+		return 0
+	case errors.As(err, &errUsage):
+		fmt.Fprintf(stderr, "unitctl: %s\n\n%s", err, usage)
+		return 2
+	default:
+		fmt.Fprintf(stderr, "unitctl: %s\n", err)
+		return 1
+	}
+}
+
+// serve starts the backend the way it has always started, it only returns once the server has stopped
+func serve(options options, errEnv error) {
+	cfg, errConfig := options.loadConfig(config.Load)
+	if errConfig != nil {
+		// the problems are logged with the default logger, the configured one may be part of them
+		logging.Fatal("invalid configuration", "error", errConfig)
+	}
+
+	// logs are written as JSON unless LOG_FORMAT=text, LOG_LEVEL defaults to info
+	if err := logging.Setup(os.Stdout, cfg.Log.Level, cfg.Log.Format); err != nil {
+		logging.Fatal("failed to set up logging", "error", err)
+	}
+
+	if errEnv != nil {
+		slog.Info(".env file not found, falling back to system environment variables")
+	}
+	slog.Info("configuration loaded", "config", cfg)
+
+	// try to connect database
+	db.ConnectDatabase(cfg.Database.Driver, cfg.Database.DSN, cfg.Database.Pool())
+
+	// run migrations, or only check them when the deployment migrates with unitctl
+	if cfg.Database.AutoMigrate {
+		db.RunMigrations(cfg.Database.Driver, cfg.Database.MigrationsDir)
+	} else {
+		db.VerifyMigrations(cfg.Database.Driver, cfg.Database.MigrationsDir)
+	}
+
+	routes.Run(cfg)
+}
+
+// run runs the commands working on the database
+func run(options options, args []string, stdout, stderr io.Writer) error {
+	switch args[0] {
+	case "migrate", "seed", "units":
+	default:
+		return usageErrorf("unknown command %q", args[0])
+	}
+
+	cfg, err := options.loadConfig(config.LoadDatabase)
+	if err != nil {
+		return fmt.Errorf("invalid configuration: %w", err)
+	}
+
+	// text is easier to read in a terminal than the JSON of the server
+	if err := logging.Setup(stderr, cfg.Log.Level, logging.FormatText); err != nil {
+		return err
+	}
+
+	// a command interrupted by a signal stops at its next query
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	database, err := db.Open(cfg.Database.Driver, cfg.Database.DSN, cfg.Database.Pool())
+	if err != nil {
+		return fmt.Errorf("failed to connect to database: %w", err)
+	}
+	sqlDB, err := database.DB()
+	if err != nil {
+		return err
+	}
+	defer sqlDB.Close()
+
+	switch args[0] {
+	case "migrate":
+		return runMigrate(database, cfg.Database, args[1:], stdout)
+	case "seed":
+		return runSeed(ctx, newServices(cfg, database), args[1:], stdout)
+	default:
+		return runUnits(ctx, newServices(cfg, database), args[1:], stdout)
+	}
+}
+
+// newServices wires the services like the server does, units always come from the database
+// because the memory repository only lives inside the server process
+func newServices(cfg config.Config, database *gorm.DB) app.Services {
+	return app.NewServices(database, unitrepository.NewUnitRepository(database), cfg.Housekeeping.PeakHours)
+}
+
+// actor is recorded in the status history of the changes made from unitctl
+func actor() string {
+	current, err := user.Current()
+	if err != nil || utils.IsEmptyString(current.Username) {
+		return "unitctl"
+	}
+	return "unitctl:" + current.Username
+}
@@ -0,0 +1,187 @@
+package cli
+
+import (
+	"bytes"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"unit-management-be/internal/db"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// setupCLI points unitctl to a new SQLite file and runs it from the backend folder
+func setupCLI(t *testing.T) {
+	dsn := fmt.Sprintf("file:%s?_pragma=foreign_keys(1)&_time_format=sqlite", filepath.Join(t.TempDir(), "unitctl.db"))
+	t.Setenv("DB_DRIVER", db.DriverSQLite)
+	t.Setenv("DB_DSN", dsn)
+	t.Setenv("LOG_LEVEL", "error")
+	t.Setenv("CONFIG_FILE", "")
+
+	wd, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(filepath.Join("..", "..")))
+	t.Cleanup(func() { os.Chdir(wd) })
+}
+
+func mustMigrationsFS(t *testing.T) fs.FS {
+	migrations, err := db.MigrationsFS(db.DriverSQLite, "")
+	require.NoError(t, err)
+	return migrations
+}
+
+func runCLI(args ...string) (int, string, string) {
+	var stdout, stderr bytes.Buffer
+	code := Run(args, &stdout, &stderr)
+	return code, stdout.String(), stderr.String()
+}
+
+// unitID finds the ID of the unit named name in the output of units list
+func unitID(t *testing.T, name string) string {
+	code, stdout, _ := runCLI("units", "list", "-name", name)
+	require.Equal(t, 0, code)
+	lines := strings.Split(strings.TrimSpace(stdout), "\n")
+	require.Len(t, lines, 2)
+	return strings.Fields(lines[1])[0]
+}
+
+func TestMigrateCommands(t *testing.T) {
+	setupCLI(t)
+	versions, err := db.MigrationVersions(mustMigrationsFS(t))
+	require.NoError(t, err)
+	latest := versions[len(versions)-1]
+
+	t.Run("Positive Case: Status of a new database", func(t *testing.T) {
+		code, stdout, _ := runCLI("migrate", "status")
+		assert.Equal(t, 0, code)
+		assert.Contains(t, stdout, "version  none\n")
+		assert.Contains(t, stdout, fmt.Sprintf("pending  %d: %d", len(versions), versions[0]))
+	})
+
+	t.Run("Positive Case: Up, down and to", func(t *testing.T) {
+		code, stdout, _ := runCLI("migrate", "up")
+		assert.Equal(t, 0, code)
+		assert.Contains(t, stdout, fmt.Sprintf("version  %d\n", latest))
+		assert.Contains(t, stdout, "pending  0\n")
+
+		code, stdout, _ = runCLI("migrate", "down", "2")
+		assert.Equal(t, 0, code)
+		assert.Contains(t, stdout, fmt.Sprintf("version  %d\n", versions[len(versions)-3]))
+
+		code, stdout, _ = runCLI("migrate", "to", fmt.Sprint(latest))
+		assert.Equal(t, 0, code)
+		assert.Contains(t, stdout, "pending  0\n")
+	})
+
+	t.Run("Positive Case: Force the current version", func(t *testing.T) {
+		code, stdout, _ := runCLI("migrate", "force", fmt.Sprint(latest))
+		assert.Equal(t, 0, code)
+		assert.Contains(t, stdout, "dirty    no\n")
+	})
+
+	t.Run("Negative Case: Invalid arguments are reported with the usage", func(t *testing.T) {
+		code, _, stderr := runCLI("migrate", "down", "zero")
+		assert.Equal(t, 2, code)
+		assert.Contains(t, stderr, `migrate down needs a positive number of steps, got "zero"`)
+		assert.Contains(t, stderr, "Usage: unitctl")
+
+		code, _, stderr = runCLI("migrate", "sideways")
+		assert.Equal(t, 2, code)
+		assert.Contains(t, stderr, `unknown migrate command "sideways"`)
+	})
+
+	t.Run("Negative Case: Unknown version", func(t *testing.T) {
+		code, _, stderr := runCLI("migrate", "to", "1")
+		assert.Equal(t, 1, code)
+		assert.NotEmpty(t, stderr)
+	})
+}
+
+func TestUnitCommands(t *testing.T) {
+	setupCLI(t)
+	code, _, _ := runCLI("migrate", "up")
+	require.Equal(t, 0, code)
+
+	t.Run("Positive Case: Seed creates the demo units once", func(t *testing.T) {
+		code, stdout, _ := runCLI("seed")
+		assert.Equal(t, 0, code)
+		assert.Equal(t, fmt.Sprintf("created %d demo units, 0 already existed\n", len(demoUnits)), stdout)
+
+		code, stdout, _ = runCLI("seed")
+		assert.Equal(t, 0, code)
+		assert.Equal(t, fmt.Sprintf("created 0 demo units, %d already existed\n", len(demoUnits)), stdout)
+	})
+
+	t.Run("Positive Case: List filtered units", func(t *testing.T) {
+		code, stdout, _ := runCLI("units", "list", "-status", "Occupied", "-type", "capsule")
+		assert.Equal(t, 0, code)
+
+		lines := strings.Split(strings.TrimSpace(stdout), "\n")
+		require.Len(t, lines, 3)
+		assert.Contains(t, lines[0], "STATUS")
+		assert.Contains(t, lines[1], "Capsule A-03")
+		assert.Contains(t, lines[2], "Capsule A-04")
+	})
+
+	t.Run("Positive Case: Set the status of a unit", func(t *testing.T) {
+		code, stdout, _ := runCLI("units", "set-status", "-reason", "guest left", unitID(t, "Capsule A-03"), "Cleaning In Progress")
+		assert.Equal(t, 0, code)
+		assert.Equal(t, "Capsule A-03 moved from Occupied to Cleaning In Progress\n", stdout)
+	})
+
+	t.Run("Negative Case: Status change refused by the state machine", func(t *testing.T) {
+		code, _, stderr := runCLI("units", "set-status", unitID(t, "Capsule A-04"), "Available")
+		assert.Equal(t, 1, code)
+		assert.Contains(t, stderr, "unit cannot transition from 'Occupied' to 'Available'")
+	})
+
+	t.Run("Negative Case: Unknown unit", func(t *testing.T) {
+		code, _, stderr := runCLI("units", "set-status", "00000000-0000-0000-0000-000000000000", "Available")
+		assert.Equal(t, 1, code)
+		assert.NotEmpty(t, stderr)
+	})
+
+	t.Run("Negative Case: Invalid filter", func(t *testing.T) {
+		code, _, stderr := runCLI("units", "list", "-status", "Dirty")
+		assert.Equal(t, 2, code)
+		assert.Contains(t, stderr, `invalid unit status "Dirty"`)
+	})
+}
+
+func TestUsage(t *testing.T) {
+	t.Run("Negative Case: No command", func(t *testing.T) {
+		code, _, stderr := runCLI()
+		assert.Equal(t, 2, code)
+		assert.Contains(t, stderr, "Usage: unitctl")
+	})
+
+	t.Run("Negative Case: Unknown command", func(t *testing.T) {
+		code, _, stderr := runCLI("deploy")
+		assert.Equal(t, 2, code)
+		assert.Contains(t, stderr, `unknown command "deploy"`)
+	})
+
+	t.Run("Negative Case: Serve takes no arguments", func(t *testing.T) {
+		code, _, stderr := runCLI("serve", "now")
+		assert.Equal(t, 2, code)
+		assert.Contains(t, stderr, "serve takes no arguments")
+	})
+
+	t.Run("Negative Case: Server binary only takes flags", func(t *testing.T) {
+		var stderr bytes.Buffer
+		assert.Equal(t, 2, Serve([]string{"-config", "config.yaml", "serve"}, &stderr))
+		assert.Contains(t, stderr.String(), `unexpected argument "serve"`)
+
+		stderr.Reset()
+		assert.Equal(t, 2, Serve([]string{"-port", "8080"}, &stderr))
+		assert.Contains(t, stderr.String(), "Usage: main")
+
+		stderr.Reset()
+		assert.Equal(t, 0, Serve([]string{"-h"}, &stderr))
+		assert.Contains(t, stderr.String(), "DB_AUTO_MIGRATE")
+	})
+}
@@ -0,0 +1,108 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"unit-management-be/internal/config"
+	"unit-management-be/internal/db"
+
+	"gorm.io/gorm"
+)
+
+func runMigrate(database *gorm.DB, cfg config.DatabaseConfig, args []string, stdout io.Writer) error {
+	if len(args) == 0 {
+		return usageErrorf("migrate needs up, down, to, status or force")
+	}
+
+	migrations, err := db.MigrationsFS(cfg.Driver, cfg.MigrationsDir)
+	if err != nil {
+		return err
+	}
+	migrator, err := db.NewMigrator(database, cfg.Driver, migrations)
+	if err != nil {
+		return err
+	}
+
+	switch subcommand, args := args[0], args[1:]; subcommand {
+	case "up":
+		if len(args) > 0 {
+			return usageErrorf("migrate up takes no arguments")
+		}
+		err = migrator.Up()
+	case "down":
+		steps := 1
+		if len(args) > 1 {
+			return usageErrorf("migrate down takes at most the number of steps")
+		}
+		if len(args) == 1 {
+			steps, err = strconv.Atoi(args[0])
+			if err != nil || steps < 1 {
+				return usageErrorf("migrate down needs a positive number of steps, got %q", args[0])
+			}
+		}
+		err = migrator.Down(steps)
+	case "to":
+		if len(args) != 1 {
+			return usageErrorf("migrate to needs the version to migrate to")
+		}
+		version, errParse := strconv.ParseUint(args[0], 10, 64)
+		if errParse != nil {
+			return usageErrorf("migrate to needs a version such as 20251021090100, got %q", args[0])
+		}
+		err = migrator.To(uint(version))
+	case "force":
+		if len(args) != 1 {
+			return usageErrorf("migrate force needs the version to record")
+		}
+		version, errParse := strconv.Atoi(args[0])
+		if errParse != nil || version < -1 {
+			return usageErrorf("migrate force needs a version such as 20251021090100 or -1, got %q", args[0])
+		}
+		err = migrator.Force(version)
+	case "status":
+		if len(args) > 0 {
+			return usageErrorf("migrate status takes no arguments")
+		}
+	default:
+		return usageErrorf("unknown migrate command %q", subcommand)
+	}
+	if err != nil {
+		return err
+	}
+
+	// every command ends with the status, so the outcome of a change is visible right away
+	status, err := migrator.Status()
+	if err != nil {
+		return err
+	}
+	return printMigrationStatus(stdout, status)
+}
+
+func printMigrationStatus(w io.Writer, status db.MigrationStatus) error {
+	version := "none"
+	if status.Version > 0 {
+		version = strconv.FormatUint(uint64(status.Version), 10)
+	}
+	dirty := "no"
+	if status.Dirty {
+		dirty = "yes, fix the failed migration by hand and run migrate force"
+	}
+	pending := strconv.Itoa(len(status.Pending))
+	if len(status.Pending) > 0 {
+		versions := make([]string, 0, len(status.Pending))
+		for _, version := range status.Pending {
+			versions = append(versions, strconv.FormatUint(uint64(version), 10))
+		}
+		pending += ": " + strings.Join(versions, " ")
+	}
+
+	table := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(table, "version\t%s\n", version)
+	fmt.Fprintf(table, "dirty\t%s\n", dirty)
+	fmt.Fprintf(table, "latest\t%d\n", status.Latest)
+	fmt.Fprintf(table, "pending\t%s\n", pending)
+	return table.Flush()
+}
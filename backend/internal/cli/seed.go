@@ -0,0 +1,83 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"unit-management-be/internal/app"
+	"unit-management-be/pkg/model/domain/enum"
+	"unit-management-be/pkg/model/dto/request"
+)
+
+// demoUnits are created by seed, a unit cannot be created occupied so those are created available first
+var demoUnits = []struct {
+	name     string
+	unitType enum.UnitType
+	status   enum.UnitStatus
+}{
+	{"Capsule A-01", enum.Capsule, enum.Available},
+	{"Capsule A-02", enum.Capsule, enum.Available},
+	{"Capsule A-03", enum.Capsule, enum.Occupied},
+	{"Capsule A-04", enum.Capsule, enum.Occupied},
+	{"Capsule A-05", enum.Capsule, enum.CleaningInProgress},
+	{"Capsule A-06", enum.Capsule, enum.MaintenanceNeeded},
+	{"Cabin B-01", enum.Cabin, enum.Available},
+	{"Cabin B-02", enum.Cabin, enum.Occupied},
+	{"Cabin B-03", enum.Cabin, enum.CleaningInProgress},
+	{"Cabin B-04", enum.Cabin, enum.Available},
+}
+
+// runSeed creates the demo units through the unit service, so they get their status history,
+// housekeeping tasks and webhooks like units created from the API. Units already there are kept
+func runSeed(ctx context.Context, services app.Services, args []string, stdout io.Writer) error {
+	if len(args) > 0 {
+		return usageErrorf("seed takes no arguments")
+	}
+
+	units, errFind := services.Units.ExportUnits(ctx, request.UnitFilterDto{})
+	if errFind != nil {
+		return errors.New(errFind.Message)
+	}
+	existing := make(map[string]bool, len(units))
+	for _, unit := range units {
+		existing[unit.Name] = true
+	}
+
+	created := 0
+	for _, demo := range demoUnits {
+		if existing[demo.name] {
+			continue
+		}
+
+		initialStatus := demo.status
+		if initialStatus == enum.Occupied {
+			initialStatus = enum.Available
+		}
+
+		unit, errCreate := services.Units.CreateUnit(ctx, request.CreateUnitDto{
+			Name:   demo.name,
+			Type:   string(demo.unitType),
+			Status: string(initialStatus),
+			Actor:  actor(),
+		})
+		if errCreate != nil {
+			return fmt.Errorf("failed to create %s: %s", demo.name, errCreate.Message)
+		}
+
+		if initialStatus != demo.status {
+			_, errChange := services.Units.ChangeStatus(ctx, unit.ID.String(), request.ChangeUnitStatusDto{
+				Status: string(demo.status),
+				Reason: "demo data",
+				Actor:  actor(),
+			})
+			if errChange != nil {
+				return fmt.Errorf("failed to move %s to %s: %s", demo.name, demo.status, errChange.Message)
+			}
+		}
+		created++
+	}
+
+	fmt.Fprintf(stdout, "created %d demo units, %d already existed\n", created, len(demoUnits)-created)
+	return nil
+}
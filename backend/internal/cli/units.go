@@ -0,0 +1,121 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"time"
+	"unit-management-be/internal/app"
+	"unit-management-be/pkg/model/domain/enum"
+	"unit-management-be/pkg/model/dto/request"
+)
+
+func runUnits(ctx context.Context, services app.Services, args []string, stdout io.Writer) error {
+	if len(args) == 0 {
+		return usageErrorf("units needs list or set-status")
+	}
+
+	switch subcommand, args := args[0], args[1:]; subcommand {
+	case "list":
+		return listUnits(ctx, services, args, stdout)
+	case "set-status":
+		return setUnitStatus(ctx, services, args, stdout)
+	default:
+		return usageErrorf("unknown units command %q", subcommand)
+	}
+}
+
+func listUnits(ctx context.Context, services app.Services, args []string, stdout io.Writer) error {
+	flags := flag.NewFlagSet("units list", flag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+	status := flags.String("status", "", "comma separated statuses")
+	unitType := flags.String("type", "", "comma separated types")
+	name := flags.String("name", "", "part of the name")
+	if err := flags.Parse(args); err != nil {
+		return usageErrorf("units list: %s", err)
+	}
+	if flags.NArg() > 0 {
+		return usageErrorf("units list takes no arguments besides its flags")
+	}
+
+	filter := request.UnitFilterDto{Name: *name}
+	for _, value := range splitList(*status) {
+		parsed, isValid := enum.ParseUnitStatus(value)
+		if !isValid {
+			return usageErrorf("invalid unit status %q, must be one of %s", value, quoteStatuses())
+		}
+		filter.Statuses = append(filter.Statuses, parsed)
+	}
+	for _, value := range splitList(*unitType) {
+		parsed, isValid := enum.ParseUnitType(value)
+		if !isValid {
+			return usageErrorf("invalid unit type %q, must be capsule or cabin", value)
+		}
+		filter.Types = append(filter.Types, parsed)
+	}
+
+	units, errFind := services.Units.ExportUnits(ctx, filter)
+	if errFind != nil {
+		return errors.New(errFind.Message)
+	}
+
+	table := tabwriter.NewWriter(stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(table, "ID\tNAME\tTYPE\tSTATUS\tLAST UPDATED\tVERSION")
+	for _, unit := range units {
+		fmt.Fprintf(table, "%s\t%s\t%s\t%s\t%s\t%d\n", unit.ID, unit.Name, unit.Type, unit.Status, unit.LastUpdated.Format(time.RFC3339), unit.Version)
+	}
+	return table.Flush()
+}
+
+func setUnitStatus(ctx context.Context, services app.Services, args []string, stdout io.Writer) error {
+	flags := flag.NewFlagSet("units set-status", flag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+	reason := flags.String("reason", "", "recorded in the status history")
+	if err := flags.Parse(args); err != nil {
+		return usageErrorf("units set-status: %s", err)
+	}
+	if flags.NArg() != 2 {
+		return usageErrorf("units set-status needs the unit ID and the status, such as \"Cleaning In Progress\"")
+	}
+
+	unitID, status := flags.Arg(0), flags.Arg(1)
+	unit, errFind := services.Units.FindByID(ctx, unitID)
+	if errFind != nil {
+		return errors.New(errFind.Message)
+	}
+
+	// the state machine and the status guards apply, an open maintenance ticket still holds the unit
+	changed, errChange := services.Units.ChangeStatus(ctx, unitID, request.ChangeUnitStatusDto{
+		Status: status,
+		Reason: *reason,
+		Actor:  actor(),
+	})
+	if errChange != nil {
+		return errors.New(errChange.Message)
+	}
+
+	fmt.Fprintf(stdout, "%s moved from %s to %s\n", changed.Name, unit.Status, changed.Status)
+	return nil
+}
+
+func splitList(value string) []string {
+	items := make([]string, 0)
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+func quoteStatuses() string {
+	statuses := make([]string, 0)
+	for _, status := range enum.UnitStatuses() {
+		statuses = append(statuses, fmt.Sprintf("'%s'", status))
+	}
+	return strings.Join(statuses, ", ")
+}
@@ -0,0 +1,293 @@
+package config
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+	"time"
+	"unit-management-be/internal/db"
+	"unit-management-be/pkg/logging"
+	"unit-management-be/pkg/service/housekeeping"
+	"unit-management-be/pkg/utils"
+)
+
+const (
+	UnitRepositoryDatabase = "database"
+	UnitRepositoryMemory   = "memory"
+)
+
+// minJWTSecretLength is the size of the HS256 key, a shorter secret is easier to brute force than the signature
+const minJWTSecretLength = 32
+
+// Config is everything the backend reads at startup, see Load for where the values come from
+type Config struct {
+	Server       ServerConfig       `yaml:"server"`
+	Log          LogConfig          `yaml:"log"`
+	Database     DatabaseConfig     `yaml:"database"`
+	CORS         CORSConfig         `yaml:"cors"`
+	Auth         AuthConfig         `yaml:"auth"`
+	Units        UnitsConfig        `yaml:"units"`
+	Housekeeping HousekeepingConfig `yaml:"housekeeping"`
+}
+
+type ServerConfig struct {
+	Port              int           `yaml:"port"`
+	ReadHeaderTimeout time.Duration `yaml:"read_header_timeout"`
+	// ShutdownTimeout bounds how long in-flight requests and background jobs are waited for on exit
+	ShutdownTimeout time.Duration `yaml:"shutdown_timeout"`
+}
+
+type LogConfig struct {
+	Level  string `yaml:"level"`
+	Format string `yaml:"format"`
+}
+
+type DatabaseConfig struct {
+	// Driver is detected from the DSN when it is not set
+	Driver          string        `yaml:"driver"`
+	DSN             string        `yaml:"dsn"`
+	MaxOpenConns    int           `yaml:"max_open_conns"`
+	MaxIdleConns    int           `yaml:"max_idle_conns"`
+	ConnMaxLifetime time.Duration `yaml:"conn_max_lifetime"`
+	ConnMaxIdleTime time.Duration `yaml:"conn_max_idle_time"`
+	// MigrationsDir replaces the migrations embedded in the binary when it is set
+	MigrationsDir string `yaml:"migrations_dir"`
+	// AutoMigrate applies the pending migrations when the server starts, without it the server
+	// refuses to start on a schema that is dirty or behind
+	AutoMigrate bool `yaml:"auto_migrate"`
+}
+
+type CORSConfig struct {
+	AllowOrigins []string `yaml:"allow_origins"`
+	AllowMethods []string `yaml:"allow_methods"`
+}
+
+type AuthConfig struct {
+	JWTSecret     string        `yaml:"jwt_secret"`
+	TokenTTL      time.Duration `yaml:"token_ttl"`
+	AdminUsername string        `yaml:"admin_username"`
+	AdminPassword string        `yaml:"admin_password"`
+}
+
+type UnitsConfig struct {
+	Repository string `yaml:"repository"`
+	// TrashRetentionDays of 0 keeps deleted units forever
+	TrashRetentionDays int `yaml:"trash_retention_days"`
+	EventsReplaySize   int `yaml:"events_replay_size"`
+}
+
+type HousekeepingConfig struct {
+	PeakHours housekeeping.PeakHours `yaml:"peak_hours"`
+}
+
+// Default returns the configuration used for every value the sources leave unset
+func Default() Config {
+	return Config{
+		Server: ServerConfig{
+			Port:              5000,
+			ReadHeaderTimeout: 10 * time.Second,
+			ShutdownTimeout:   15 * time.Second,
+		},
+		Log: LogConfig{
+			Level:  "info",
+			Format: logging.FormatJSON,
+		},
+		Database: DatabaseConfig{
+			MaxOpenConns:    db.DefaultPool.MaxOpenConns,
+			MaxIdleConns:    db.DefaultPool.MaxIdleConns,
+			ConnMaxLifetime: db.DefaultPool.ConnMaxLifetime,
+			ConnMaxIdleTime: db.DefaultPool.ConnMaxIdleTime,
+			AutoMigrate:     true,
+		},
+		CORS: CORSConfig{
+			AllowOrigins: []string{"http://localhost:3000"},
+			AllowMethods: []string{"GET", "POST", "PUT", "PATCH", "DELETE"},
+		},
+		Auth: AuthConfig{
+			TokenTTL: 12 * time.Hour,
+		},
+		Units: UnitsConfig{
+			Repository:         UnitRepositoryDatabase,
+			TrashRetentionDays: 30,
+			EventsReplaySize:   1000,
+		},
+		Housekeeping: HousekeepingConfig{
+			PeakHours: housekeeping.DefaultPeakHours,
+		},
+	}
+}
+
+// Pool returns the connection pool settings of the database
+func (c DatabaseConfig) Pool() db.Pool {
+	return db.Pool{
+		MaxOpenConns:    c.MaxOpenConns,
+		MaxIdleConns:    c.MaxIdleConns,
+		ConnMaxLifetime: c.ConnMaxLifetime,
+		ConnMaxIdleTime: c.ConnMaxIdleTime,
+	}
+}
+
+// Validate reports every problem of the configuration at once, nil when there is none
+func (c Config) Validate() error {
+	var problems Errors
+
+	if c.Server.Port < 1 || c.Server.Port > 65535 {
+		problems = append(problems, fmt.Sprintf("PORT must be between 1 and 65535, got %d", c.Server.Port))
+	}
+	if c.Server.ReadHeaderTimeout <= 0 {
+		problems = append(problems, "READ_HEADER_TIMEOUT must be a positive duration such as 10s")
+	}
+	if c.Server.ShutdownTimeout <= 0 {
+		problems = append(problems, "SHUTDOWN_TIMEOUT must be a positive duration such as 15s")
+	}
+
+	problems = append(problems, c.validateLog()...)
+	problems = append(problems, c.validateDatabase()...)
+
+	if len(c.CORS.AllowOrigins) == 0 {
+		problems = append(problems, "CORS_ALLOW_ORIGINS must list at least one origin")
+	}
+	for _, origin := range c.CORS.AllowOrigins {
+		// the CORS middleware refuses to start on any other origin
+		if !strings.Contains(origin, "*") && !strings.HasPrefix(origin, "http://") && !strings.HasPrefix(origin, "https://") {
+			problems = append(problems, fmt.Sprintf("CORS_ALLOW_ORIGINS must hold * or origins starting with http:// or https://, got %q", origin))
+		}
+	}
+	if len(c.CORS.AllowMethods) == 0 {
+		problems = append(problems, "CORS_ALLOW_METHOD must list at least one method")
+	}
+
+	if utils.IsEmptyString(c.Auth.JWTSecret) {
+		problems = append(problems, "JWT_SECRET must be set to sign access tokens")
+	} else if len(c.Auth.JWTSecret) < minJWTSecretLength {
+		problems = append(problems, fmt.Sprintf("JWT_SECRET must be at least %d bytes long, got %d", minJWTSecretLength, len(c.Auth.JWTSecret)))
+	}
+	if c.Auth.TokenTTL <= 0 {
+		problems = append(problems, "JWT_TTL must be a positive duration such as 12h")
+	}
+
+	if c.Units.Repository != UnitRepositoryDatabase && c.Units.Repository != UnitRepositoryMemory {
+		problems = append(problems, fmt.Sprintf("UNIT_REPOSITORY must be %s or %s, got %q", UnitRepositoryDatabase, UnitRepositoryMemory, c.Units.Repository))
+	}
+	if c.Units.TrashRetentionDays < 0 {
+		problems = append(problems, "UNIT_TRASH_RETENTION_DAYS must be a number of days, 0 keeps deleted units forever")
+	}
+	if c.Units.EventsReplaySize < 1 {
+		problems = append(problems, "UNIT_EVENTS_REPLAY_SIZE must be a positive number of events")
+	}
+
+	if len(problems) > 0 {
+		return problems
+	}
+	return nil
+}
+
+// ValidateDatabase reports the problems of the log and database settings only, for the tools
+// that connect to the database without serving requests
+func (c Config) ValidateDatabase() error {
+	problems := append(c.validateLog(), c.validateDatabase()...)
+	if len(problems) > 0 {
+		return problems
+	}
+	return nil
+}
+
+func (c Config) validateLog() Errors {
+	var problems Errors
+
+	// the logger is built the same way at startup, so anything it refuses is reported here
+	if _, err := logging.NewLogger(io.Discard, c.Log.Level, ""); err != nil {
+		problems = append(problems, fmt.Sprintf("LOG_LEVEL: %s", err))
+	}
+	if _, err := logging.NewLogger(io.Discard, "", c.Log.Format); err != nil {
+		problems = append(problems, fmt.Sprintf("LOG_FORMAT: %s", err))
+	}
+
+	return problems
+}
+
+func (c Config) validateDatabase() Errors {
+	var problems Errors
+
+	switch c.Database.Driver {
+	case db.DriverMySQL, db.DriverPostgres:
+		if utils.IsEmptyString(c.Database.DSN) {
+			problems = append(problems, fmt.Sprintf("DB_DSN must be set for %s", c.Database.Driver))
+		}
+	case db.DriverSQLite:
+	default:
+		problems = append(problems, fmt.Sprintf("DB_DRIVER must be %s, %s or %s, got %q", db.DriverMySQL, db.DriverPostgres, db.DriverSQLite, c.Database.Driver))
+	}
+	if c.Database.MaxOpenConns < 1 {
+		problems = append(problems, "DB_MAX_OPEN_CONNS must be at least 1")
+	}
+	if c.Database.MaxIdleConns < 0 || c.Database.MaxIdleConns > c.Database.MaxOpenConns {
+		problems = append(problems, "DB_MAX_IDLE_CONNS must be between 0 and DB_MAX_OPEN_CONNS")
+	}
+	if c.Database.ConnMaxLifetime < 0 {
+		problems = append(problems, "DB_CONN_MAX_LIFETIME must not be negative, 0 keeps connections forever")
+	}
+	if c.Database.ConnMaxIdleTime < 0 {
+		problems = append(problems, "DB_CONN_MAX_IDLE_TIME must not be negative, 0 keeps idle connections forever")
+	}
+	if !utils.IsEmptyString(c.Database.MigrationsDir) {
+		if info, err := os.Stat(c.Database.MigrationsDir); err != nil || !info.IsDir() {
+			problems = append(problems, fmt.Sprintf("MIGRATIONS_DIR must be an existing folder, got %q", c.Database.MigrationsDir))
+		}
+	}
+
+	return problems
+}
+
+// LogValue logs the configuration with the secrets redacted, the database password is
+// removed from the DSN and the rest of it is kept to see where the backend connects
+func (c Config) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.Group("server",
+			slog.Int("port", c.Server.Port),
+			slog.String("read_header_timeout", c.Server.ReadHeaderTimeout.String()),
+			slog.String("shutdown_timeout", c.Server.ShutdownTimeout.String()),
+		),
+		slog.Group("log",
+			slog.String("level", c.Log.Level),
+			slog.String("format", c.Log.Format),
+		),
+		slog.Group("database",
+			slog.String("driver", c.Database.Driver),
+			slog.String("dsn", redactDSN(c.Database.Driver, c.Database.DSN)),
+			slog.Int("max_open_conns", c.Database.MaxOpenConns),
+			slog.Int("max_idle_conns", c.Database.MaxIdleConns),
+			slog.String("conn_max_lifetime", c.Database.ConnMaxLifetime.String()),
+			slog.String("conn_max_idle_time", c.Database.ConnMaxIdleTime.String()),
+			slog.String("migrations_dir", c.Database.MigrationsDir),
+			slog.Bool("auto_migrate", c.Database.AutoMigrate),
+		),
+		slog.Group("cors",
+			slog.Any("allow_origins", c.CORS.AllowOrigins),
+			slog.Any("allow_methods", c.CORS.AllowMethods),
+		),
+		slog.Group("auth",
+			slog.String("jwt_secret", redact(c.Auth.JWTSecret)),
+			slog.String("token_ttl", c.Auth.TokenTTL.String()),
+			slog.String("admin_username", c.Auth.AdminUsername),
+			slog.String("admin_password", redact(c.Auth.AdminPassword)),
+		),
+		slog.Group("units",
+			slog.String("repository", c.Units.Repository),
+			slog.Int("trash_retention_days", c.Units.TrashRetentionDays),
+			slog.Int("events_replay_size", c.Units.EventsReplaySize),
+		),
+		slog.Group("housekeeping",
+			slog.String("peak_hours", c.Housekeeping.PeakHours.String()),
+		),
+	)
+}
+
+// Errors holds every problem found while loading the configuration, so they can be fixed at once
+type Errors []string
+
+func (e Errors) Error() string {
+	return strings.Join(e, "; ")
+}
@@ -0,0 +1,244 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+	"unit-management-be/internal/db"
+	"unit-management-be/pkg/service/housekeeping"
+	"unit-management-be/pkg/utils"
+
+	"github.com/go-sql-driver/mysql"
+	"gopkg.in/yaml.v3"
+)
+
+// redacted replaces secrets when the configuration is logged
+const redacted = "REDACTED"
+
+// Load builds the configuration from the defaults, then the YAML file at path when it is not empty,
+// then the environment, where every variable set to a non-empty value wins. Every problem found,
+// whether a value cannot be read or is out of range, is returned at once as Errors
+func Load(path string) (Config, error) {
+	return load(path, Config.Validate)
+}
+
+// LoadDatabase loads the configuration like Load, validating only the log and database settings
+func LoadDatabase(path string) (Config, error) {
+	return load(path, Config.ValidateDatabase)
+}
+
+func load(path string, validate func(Config) error) (Config, error) {
+	cfg := Default()
+	var problems Errors
+
+	if !utils.IsEmptyString(path) {
+		problems = append(problems, readFile(path, &cfg)...)
+	}
+	problems = append(problems, readEnv(&cfg)...)
+
+	if utils.IsEmptyString(cfg.Database.Driver) {
+		cfg.Database.Driver = db.DriverFromDSN(cfg.Database.DSN)
+	}
+
+	var invalid Errors
+	if errors.As(validate(cfg), &invalid) {
+		problems = append(problems, invalid...)
+	}
+
+	if len(problems) > 0 {
+		return cfg, problems
+	}
+	return cfg, nil
+}
+
+// readFile reads the YAML file into cfg, keys that are not part of the configuration are refused so typos are noticed
+func readFile(path string, cfg *Config) Errors {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return Errors{fmt.Sprintf("failed to read config file: %s", err)}
+	}
+
+	decoder := yaml.NewDecoder(bytes.NewReader(content))
+	decoder.KnownFields(true)
+	err = decoder.Decode(cfg)
+
+	var typeErr *yaml.TypeError
+	switch {
+	case err == nil, errors.Is(err, io.EOF):
+		return nil
+	case errors.As(err, &typeErr):
+		problems := make(Errors, 0, len(typeErr.Errors))
+		for _, problem := range typeErr.Errors {
+			problems = append(problems, fmt.Sprintf("%s: %s", path, problem))
+		}
+		return problems
+	default:
+		return Errors{fmt.Sprintf("%s: %s", path, err)}
+	}
+}
+
+func readEnv(cfg *Config) Errors {
+	env := &envReader{}
+
+	env.int("PORT", &cfg.Server.Port)
+	env.duration("READ_HEADER_TIMEOUT", &cfg.Server.ReadHeaderTimeout)
+	env.duration("SHUTDOWN_TIMEOUT", &cfg.Server.ShutdownTimeout)
+
+	env.string("LOG_LEVEL", &cfg.Log.Level)
+	env.string("LOG_FORMAT", &cfg.Log.Format)
+
+	env.string("DB_DRIVER", &cfg.Database.Driver)
+	env.string("DB_DSN", &cfg.Database.DSN)
+	env.int("DB_MAX_OPEN_CONNS", &cfg.Database.MaxOpenConns)
+	env.int("DB_MAX_IDLE_CONNS", &cfg.Database.MaxIdleConns)
+	env.duration("DB_CONN_MAX_LIFETIME", &cfg.Database.ConnMaxLifetime)
+	env.duration("DB_CONN_MAX_IDLE_TIME", &cfg.Database.ConnMaxIdleTime)
+	env.string("MIGRATIONS_DIR", &cfg.Database.MigrationsDir)
+	env.bool("DB_AUTO_MIGRATE", &cfg.Database.AutoMigrate)
+
+	env.list("CORS_ALLOW_ORIGINS", &cfg.CORS.AllowOrigins)
+	env.list("CORS_ALLOW_METHOD", &cfg.CORS.AllowMethods)
+
+	env.string("JWT_SECRET", &cfg.Auth.JWTSecret)
+	env.duration("JWT_TTL", &cfg.Auth.TokenTTL)
+	env.string("ADMIN_USERNAME", &cfg.Auth.AdminUsername)
+	env.string("ADMIN_PASSWORD", &cfg.Auth.AdminPassword)
+
+	env.string("UNIT_REPOSITORY", &cfg.Units.Repository)
+	env.int("UNIT_TRASH_RETENTION_DAYS", &cfg.Units.TrashRetentionDays)
+	env.int("UNIT_EVENTS_REPLAY_SIZE", &cfg.Units.EventsReplaySize)
+
+	env.peakHours("HOUSEKEEPING_PEAK_HOURS", &cfg.Housekeeping.PeakHours)
+
+	return env.problems
+}
+
+// envReader reads the variables that are set, collecting the ones that cannot be parsed instead of stopping at the first
+type envReader struct {
+	problems Errors
+}
+
+// lookup treats a blank variable as unset, the way the empty lines of .env.example are meant
+func (r *envReader) lookup(key string) (string, bool) {
+	value := os.Getenv(key)
+	return value, !utils.IsEmptyString(value)
+}
+
+func (r *envReader) string(key string, dst *string) {
+	if value, ok := r.lookup(key); ok {
+		*dst = value
+	}
+}
+
+func (r *envReader) int(key string, dst *int) {
+	value, ok := r.lookup(key)
+	if !ok {
+		return
+	}
+
+	parsed, err := strconv.Atoi(strings.TrimSpace(value))
+	if err != nil {
+		r.problems = append(r.problems, fmt.Sprintf("%s must be a whole number, got %q", key, value))
+		return
+	}
+	*dst = parsed
+}
+
+func (r *envReader) bool(key string, dst *bool) {
+	value, ok := r.lookup(key)
+	if !ok {
+		return
+	}
+
+	parsed, err := strconv.ParseBool(strings.TrimSpace(value))
+	if err != nil {
+		r.problems = append(r.problems, fmt.Sprintf("%s must be true or false, got %q", key, value))
+		return
+	}
+	*dst = parsed
+}
+
+func (r *envReader) duration(key string, dst *time.Duration) {
+	value, ok := r.lookup(key)
+	if !ok {
+		return
+	}
+
+	parsed, err := time.ParseDuration(strings.TrimSpace(value))
+	if err != nil {
+		r.problems = append(r.problems, fmt.Sprintf("%s must be a duration such as 15s or 12h, got %q", key, value))
+		return
+	}
+	*dst = parsed
+}
+
+// list reads a comma separated list, empty items are dropped
+func (r *envReader) list(key string, dst *[]string) {
+	value, ok := r.lookup(key)
+	if !ok {
+		return
+	}
+
+	items := make([]string, 0)
+	for _, item := range strings.Split(value, ",") {
+		if !utils.IsEmptyString(item) {
+			items = append(items, strings.TrimSpace(item))
+		}
+	}
+	*dst = items
+}
+
+func (r *envReader) peakHours(key string, dst *housekeeping.PeakHours) {
+	value, ok := r.lookup(key)
+	if !ok {
+		return
+	}
+
+	parsed, err := housekeeping.ParsePeakHours(value)
+	if err != nil {
+		r.problems = append(r.problems, fmt.Sprintf("%s: %s", key, err))
+		return
+	}
+	*dst = parsed
+}
+
+func redact(secret string) string {
+	if secret == "" {
+		return ""
+	}
+	return redacted
+}
+
+// redactDSN removes the password from a DSN, a DSN that cannot be parsed is redacted as a whole
+func redactDSN(driver, dsn string) string {
+	if dsn == "" || driver == db.DriverSQLite {
+		return dsn
+	}
+
+	if driver == db.DriverMySQL {
+		parsed, err := mysql.ParseDSN(dsn)
+		if err != nil {
+			return redacted
+		}
+		if parsed.Passwd != "" {
+			parsed.Passwd = redacted
+		}
+		return parsed.FormatDSN()
+	}
+
+	// PostgreSQL also accepts key=value DSNs, which are not parsed here
+	parsed, err := url.Parse(dsn)
+	if err != nil || parsed.Scheme == "" {
+		return redacted
+	}
+	if _, hasPassword := parsed.User.Password(); hasPassword {
+		parsed.User = url.UserPassword(parsed.User.Username(), redacted)
+	}
+	return parsed.String()
+}
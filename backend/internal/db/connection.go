@@ -1,36 +1,113 @@
 package db
 
 import (
-	"log"
-	"os"
+	"fmt"
+	"log/slog"
+	"strings"
 	"time"
+	"unit-management-be/pkg/logging"
+	"unit-management-be/pkg/utils"
 
 	"gorm.io/driver/mysql"
+	"gorm.io/driver/postgres"
+	"gorm.io/driver/sqlite"
 	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	_ "modernc.org/sqlite"
+)
+
+const (
+	DriverMySQL    = "mysql"
+	DriverPostgres = "postgres"
+	DriverSQLite   = "sqlite"
 )
 
+// defaultSQLiteDSN stores the database next to the binary with foreign keys enabled and
+// sortable timestamps, so date range queries compare correctly
+const defaultSQLiteDSN = "file:unit-management.db?_pragma=foreign_keys(1)&_pragma=busy_timeout(5000)&_time_format=sqlite"
+
+// Pool limits the connections kept open to MySQL and PostgreSQL, SQLite always uses a single connection
+type Pool struct {
+	MaxOpenConns    int
+	MaxIdleConns    int
+	ConnMaxLifetime time.Duration
+	ConnMaxIdleTime time.Duration
+}
+
+// DefaultPool is used unless the configuration sets the pool
+var DefaultPool = Pool{MaxOpenConns: 100, MaxIdleConns: 10, ConnMaxLifetime: time.Hour}
+
 var DB *gorm.DB
 
-func ConnectDatabase() {
-	dialect := os.Getenv("DB_DSN")
+func ConnectDatabase(driver, dsn string, pool Pool) {
+	db, err := Open(driver, dsn, pool)
+	if err != nil {
+		logging.Fatal("failed to connect to database", "error", err)
+	}
+
+	DB = db
+	slog.Info("successfully connect to database")
+}
+
+// DriverFromDSN detects PostgreSQL from a postgres:// or postgresql:// URL, any other DSN is treated as MySQL
+func DriverFromDSN(dsn string) string {
+	if strings.HasPrefix(dsn, "postgres://") || strings.HasPrefix(dsn, "postgresql://") {
+		return DriverPostgres
+	}
+	return DriverMySQL
+}
 
-	db, err := gorm.Open(mysql.Open(dialect), &gorm.Config{})
+// Open connects to the database of the given driver and applies the connection pool configuration
+func Open(driver, dsn string, pool Pool) (*gorm.DB, error) {
+	var dialector gorm.Dialector
+	switch driver {
+	case DriverMySQL:
+		dialector = mysql.Open(dsn)
+	case DriverPostgres:
+		dialector = postgres.Open(dsn)
+	case DriverSQLite:
+		if utils.IsEmptyString(dsn) {
+			dsn = defaultSQLiteDSN
+		}
+		// the pure Go driver registers itself as "sqlite", so no cgo is needed
+		dialector = sqlite.New(sqlite.Config{DriverName: "sqlite", DSN: dsn})
+	default:
+		return nil, fmt.Errorf("unsupported database driver %q, must be %s, %s or %s", driver, DriverMySQL, DriverPostgres, DriverSQLite)
+	}
+
+	// queries are logged through the default logger without their values, only the failed and slow ones
+	db, err := gorm.Open(dialector, &gorm.Config{
+		Logger: logger.NewSlogLogger(slog.Default(), logger.Config{
+			LogLevel:                  logger.Warn,
+			SlowThreshold:             200 * time.Millisecond,
+			IgnoreRecordNotFoundError: true,
+			ParameterizedQueries:      true,
+		}),
+	})
 	if err != nil {
-		log.Fatalf("failed to connect to database: %v", err)
+		return nil, err
 	}
 
 	sqlDB, err := db.DB()
 	if err != nil {
-		log.Fatalf("failed to get sql instance. DB: %v", err)
+		return nil, fmt.Errorf("failed to get sql instance. DB: %w", err)
 	}
 
 	// set database connection configuration
-	sqlDB.SetMaxIdleConns(10)
-	sqlDB.SetMaxOpenConns(100)
-	sqlDB.SetConnMaxLifetime(time.Hour)
+	if driver == DriverSQLite {
+		// sqlite allows a single writer, one shared connection avoids busy errors
+		// and keeps in-memory databases alive for the lifetime of the pool
+		sqlDB.SetMaxOpenConns(1)
+		return db, nil
+	}
 
-	DB = db
-	log.Println("successfully connect to database")
+	sqlDB.SetMaxIdleConns(pool.MaxIdleConns)
+	sqlDB.SetMaxOpenConns(pool.MaxOpenConns)
+	sqlDB.SetConnMaxLifetime(pool.ConnMaxLifetime)
+	sqlDB.SetConnMaxIdleTime(pool.ConnMaxIdleTime)
+
+	return db, nil
 }
 
 func GetDB() *gorm.DB {
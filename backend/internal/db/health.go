@@ -0,0 +1,86 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+
+	"github.com/golang-migrate/migrate/v4/source/iofs"
+	"gorm.io/gorm"
+)
+
+// migrationsTable is where golang-migrate keeps the version of the schema, the same for every driver
+const migrationsTable = "schema_migrations"
+
+// Ping checks the database still answers
+func Ping(ctx context.Context, db *gorm.DB) error {
+	sqlDB, err := db.DB()
+	if err != nil {
+		return fmt.Errorf("failed to get DB instance: %w", err)
+	}
+
+	return sqlDB.PingContext(ctx)
+}
+
+// LatestMigrationVersion returns the version of the newest migration
+func LatestMigrationVersion(migrations fs.FS) (uint, error) {
+	versions, err := MigrationVersions(migrations)
+	if err != nil {
+		return 0, err
+	}
+
+	return versions[len(versions)-1], nil
+}
+
+// MigrationVersions returns the versions of the migrations, oldest first
+func MigrationVersions(migrations fs.FS) ([]uint, error) {
+	source, err := iofs.New(migrations, ".")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read migrations: %w", err)
+	}
+	defer source.Close()
+
+	version, err := source.First()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read migrations: %w", err)
+	}
+
+	versions := []uint{version}
+	for {
+		next, err := source.Next(version)
+		if errors.Is(err, os.ErrNotExist) {
+			return versions, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read migrations: %w", err)
+		}
+		versions = append(versions, next)
+		version = next
+	}
+}
+
+// CheckMigrations reports an error when a migration failed halfway or the schema is behind the expected version.
+// A schema ahead of it is fine, a newer release may already run against the same database during a rollout
+func CheckMigrations(ctx context.Context, db *gorm.DB, expected uint) error {
+	var migration struct {
+		Version int64
+		Dirty   bool
+	}
+	result := db.WithContext(ctx).Table(migrationsTable).Select("version, dirty").Limit(1).Scan(&migration)
+	if result.Error != nil {
+		return fmt.Errorf("failed to read the schema version: %w", result.Error)
+	}
+
+	switch {
+	case result.RowsAffected == 0:
+		return errors.New("database has not been migrated")
+	case migration.Dirty:
+		return fmt.Errorf("migration %d failed halfway and must be fixed by hand", migration.Version)
+	case migration.Version < int64(expected):
+		return fmt.Errorf("schema is at version %d, expected at least %d", migration.Version, expected)
+	}
+
+	return nil
+}
@@ -0,0 +1,63 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHealthChecks(t *testing.T) {
+	migrations, err := MigrationsFS(DriverSQLite, "")
+	require.NoError(t, err)
+	latest, err := LatestMigrationVersion(migrations)
+	require.NoError(t, err)
+
+	database, err := Open(DriverSQLite, "file::memory:?_pragma=foreign_keys(1)&_time_format=sqlite", DefaultPool)
+	require.NoError(t, err)
+	sqlDB, err := database.DB()
+	require.NoError(t, err)
+	t.Cleanup(func() { sqlDB.Close() })
+
+	t.Run("Negative Case: Database has not been migrated", func(t *testing.T) {
+		assert.NoError(t, Ping(context.Background(), database))
+		assert.Error(t, CheckMigrations(context.Background(), database, latest))
+	})
+
+	t.Run("Positive Case: Schema is at the latest migration", func(t *testing.T) {
+		require.NoError(t, Migrate(database, DriverSQLite, migrations))
+		assert.NoError(t, CheckMigrations(context.Background(), database, latest))
+	})
+
+	t.Run("Negative Case: Schema is behind the migrations", func(t *testing.T) {
+		expected := fmt.Sprintf("schema is at version %d, expected at least %d", latest, latest+1)
+		assert.EqualError(t, CheckMigrations(context.Background(), database, latest+1), expected)
+	})
+
+	t.Run("Positive Case: Schema is ahead of the migrations", func(t *testing.T) {
+		assert.NoError(t, CheckMigrations(context.Background(), database, latest-1))
+	})
+
+	t.Run("Negative Case: Migration failed halfway", func(t *testing.T) {
+		require.NoError(t, database.Exec("UPDATE schema_migrations SET dirty = ?", true).Error)
+		assert.Error(t, CheckMigrations(context.Background(), database, latest))
+	})
+
+	t.Run("Negative Case: Database is closed", func(t *testing.T) {
+		require.NoError(t, sqlDB.Close())
+		assert.Error(t, Ping(context.Background(), database))
+	})
+
+	t.Run("Negative Case: Missing migrations folder", func(t *testing.T) {
+		_, err := MigrationsFS(DriverSQLite, filepath.Join(t.TempDir(), "missing"))
+		assert.Error(t, err)
+
+		empty, err := MigrationsFS(DriverMySQL, t.TempDir())
+		require.NoError(t, err)
+		_, err = LatestMigrationVersion(empty)
+		assert.Error(t, err)
+	})
+}
@@ -1,33 +1,191 @@
 package db
 
 import (
-	"log"
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"os"
+	"unit-management-be/migrations"
+	"unit-management-be/pkg/logging"
+	"unit-management-be/pkg/utils"
 
 	"github.com/golang-migrate/migrate/v4"
+	"github.com/golang-migrate/migrate/v4/database"
 	"github.com/golang-migrate/migrate/v4/database/mysql"
-	_ "github.com/golang-migrate/migrate/v4/source/file"
+	"github.com/golang-migrate/migrate/v4/database/pgx/v5"
+	"github.com/golang-migrate/migrate/v4/database/sqlite"
+	"github.com/golang-migrate/migrate/v4/source/iofs"
+	"gorm.io/gorm"
 )
 
-func RunMigrations() {
-	sqlDB, err := GetDB().DB()
-	migrationsPath := "file://migrations"
+// RunMigrations applies the migrations of the driver, the ones embedded in the binary unless dir is set
+func RunMigrations(driver, dir string) {
+	migrations, err := MigrationsFS(driver, dir)
 	if err != nil {
-		log.Fatalf("failed to get DB instance: %v", err)
+		logging.Fatal("failed to read migrations", "error", err)
 	}
 
-	driver, err := mysql.WithInstance(sqlDB, &mysql.Config{})
+	if err := Migrate(GetDB(), driver, migrations); err != nil {
+		logging.Fatal("migration failed", "error", err)
+	}
+	slog.Info("database migration completed successfully")
+}
+
+// VerifyMigrations stops the application unless the schema is clean and not behind the migrations of the driver,
+// for deployments where unitctl migrates the database before the server starts
+func VerifyMigrations(driver, dir string) {
+	migrations, err := MigrationsFS(driver, dir)
+	if err != nil {
+		logging.Fatal("failed to read migrations", "error", err)
+	}
+
+	latest, err := LatestMigrationVersion(migrations)
+	if err != nil {
+		logging.Fatal("failed to read migrations", "error", err)
+	}
+
+	if err := CheckMigrations(context.Background(), GetDB(), latest); err != nil {
+		logging.Fatal("database schema is not up to date, run unitctl migrate up", "error", err)
+	}
+	slog.Info("database schema is up to date")
+}
+
+// MigrationsFS returns the migrations of the driver. They are read from dir when it is set, which must be
+// laid out like the migrations folder of the repository, otherwise the copy embedded in the binary is used.
+// PostgreSQL and SQLite keep their own copy of the schema because they have no ENUM columns or inline indexes
+func MigrationsFS(driver, dir string) (fs.FS, error) {
+	var root fs.FS = migrations.FS
+	if !utils.IsEmptyString(dir) {
+		if _, err := os.ReadDir(dir); err != nil {
+			return nil, fmt.Errorf("failed to read migrations: %w", err)
+		}
+		root = os.DirFS(dir)
+	}
+
+	switch driver {
+	case DriverPostgres:
+		return fs.Sub(root, "postgres")
+	case DriverSQLite:
+		return fs.Sub(root, "sqlite")
+	default:
+		return root, nil
+	}
+}
+
+// Migrate applies every pending migration to the database
+func Migrate(db *gorm.DB, driver string, migrations fs.FS) error {
+	migrator, err := NewMigrator(db, driver, migrations)
+	if err != nil {
+		return err
+	}
+
+	return migrator.Up()
+}
+
+// Migrator moves the schema between the migrations
+type Migrator struct {
+	migrate    *migrate.Migrate
+	migrations fs.FS
+}
+
+// MigrationStatus is the version the schema is at and the migrations not applied yet
+type MigrationStatus struct {
+	// Version is 0 when no migration has been applied
+	Version uint
+	Dirty   bool
+	Latest  uint
+	Pending []uint
+}
+
+func NewMigrator(db *gorm.DB, driver string, migrations fs.FS) (*Migrator, error) {
+	sqlDB, err := db.DB()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get DB instance: %w", err)
+	}
+
+	var instance database.Driver
+	switch driver {
+	case DriverMySQL:
+		instance, err = mysql.WithInstance(sqlDB, &mysql.Config{})
+	case DriverPostgres:
+		instance, err = pgx.WithInstance(sqlDB, &pgx.Config{})
+	case DriverSQLite:
+		instance, err = sqlite.WithInstance(sqlDB, &sqlite.Config{})
+	default:
+		return nil, fmt.Errorf("unsupported database driver %q", driver)
+	}
 	if err != nil {
-		log.Fatalf("failed to create MySQL driver: %v", err)
+		return nil, fmt.Errorf("failed to create %s driver: %w", driver, err)
 	}
 
-	m, err := migrate.NewWithDatabaseInstance(migrationsPath, "mysql", driver)
+	source, err := iofs.New(migrations, ".")
 	if err != nil {
-		log.Fatalf("migration initialization has been failed: %v", err)
+		return nil, fmt.Errorf("failed to read migrations: %w", err)
+	}
+
+	m, err := migrate.NewWithInstance("iofs", source, driver, instance)
+	if err != nil {
+		return nil, fmt.Errorf("migration initialization has been failed: %w", err)
+	}
+
+	return &Migrator{migrate: m, migrations: migrations}, nil
+}
+
+// Up applies every pending migration
+func (m *Migrator) Up() error {
+	return ignoreNoChange(m.migrate.Up())
+}
+
+// Down rolls back the last steps migrations applied
+func (m *Migrator) Down(steps int) error {
+	if steps < 1 {
+		return fmt.Errorf("steps must be at least 1, got %d", steps)
 	}
+	return ignoreNoChange(m.migrate.Steps(-steps))
+}
+
+// To applies or rolls back migrations until the schema is at version
+func (m *Migrator) To(version uint) error {
+	return ignoreNoChange(m.migrate.Migrate(version))
+}
+
+// Force records version as applied and clears the dirty flag without running anything, once a
+// migration that failed halfway has been fixed by hand. -1 records that no migration is applied
+func (m *Migrator) Force(version int) error {
+	return m.migrate.Force(version)
+}
+
+func (m *Migrator) Status() (MigrationStatus, error) {
+	var status MigrationStatus
+
+	version, dirty, err := m.migrate.Version()
+	if err != nil && !errors.Is(err, migrate.ErrNilVersion) {
+		return status, fmt.Errorf("failed to read the schema version: %w", err)
+	}
+	status.Version = version
+	status.Dirty = dirty
+
+	versions, err := MigrationVersions(m.migrations)
+	if err != nil {
+		return status, err
+	}
+	status.Latest = versions[len(versions)-1]
+
+	status.Pending = make([]uint, 0)
+	for _, available := range versions {
+		if available > status.Version {
+			status.Pending = append(status.Pending, available)
+		}
+	}
+
+	return status, nil
+}
 
-	err = m.Up()
-	if err != nil && err != migrate.ErrNoChange {
-		log.Fatalf("migration failed: %v", err)
+func ignoreNoChange(err error) error {
+	if errors.Is(err, migrate.ErrNoChange) {
+		return nil
 	}
-	log.Println("database migration completed successfully")
+	return err
 }
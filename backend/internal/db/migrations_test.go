@@ -0,0 +1,168 @@
+package db
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMigrator(t *testing.T) {
+	migrations, err := MigrationsFS(DriverSQLite, "")
+	require.NoError(t, err)
+	versions, err := MigrationVersions(migrations)
+	require.NoError(t, err)
+	require.Greater(t, len(versions), 2)
+	latest := versions[len(versions)-1]
+
+	database, err := Open(DriverSQLite, "file::memory:?_pragma=foreign_keys(1)&_time_format=sqlite", DefaultPool)
+	require.NoError(t, err)
+	sqlDB, err := database.DB()
+	require.NoError(t, err)
+	t.Cleanup(func() { sqlDB.Close() })
+
+	migrator, err := NewMigrator(database, DriverSQLite, migrations)
+	require.NoError(t, err)
+
+	t.Run("Positive Case: Nothing is applied on a new database", func(t *testing.T) {
+		status, err := migrator.Status()
+		require.NoError(t, err)
+		assert.Equal(t, MigrationStatus{Version: 0, Latest: latest, Pending: versions}, status)
+	})
+
+	t.Run("Positive Case: Up applies every migration and is a no-op afterwards", func(t *testing.T) {
+		require.NoError(t, migrator.Up())
+		require.NoError(t, migrator.Up())
+
+		status, err := migrator.Status()
+		require.NoError(t, err)
+		assert.Equal(t, latest, status.Version)
+		assert.Empty(t, status.Pending)
+		assert.True(t, database.Migrator().HasTable("webhook_deliveries"))
+
+		var onDelete string
+		require.NoError(t, database.Raw("SELECT on_delete FROM pragma_foreign_key_list('maintenance_tickets')").Scan(&onDelete).Error)
+		assert.Equal(t, "RESTRICT", onDelete)
+	})
+
+	t.Run("Positive Case: Down rolls back the last migrations", func(t *testing.T) {
+		require.NoError(t, migrator.Down(2))
+
+		status, err := migrator.Status()
+		require.NoError(t, err)
+		assert.Equal(t, versions[len(versions)-3], status.Version)
+		assert.Equal(t, versions[len(versions)-2:], status.Pending)
+		assert.True(t, database.Migrator().HasTable("maintenance_tickets"))
+
+		var onDelete string
+		require.NoError(t, database.Raw("SELECT on_delete FROM pragma_foreign_key_list('maintenance_tickets')").Scan(&onDelete).Error)
+		assert.Equal(t, "CASCADE", onDelete)
+	})
+
+	t.Run("Positive Case: To moves to the given version", func(t *testing.T) {
+		require.NoError(t, migrator.To(latest))
+
+		status, err := migrator.Status()
+		require.NoError(t, err)
+		assert.Equal(t, latest, status.Version)
+	})
+
+	t.Run("Positive Case: Force clears a migration that failed halfway", func(t *testing.T) {
+		require.NoError(t, database.Exec("UPDATE schema_migrations SET dirty = ?", true).Error)
+		status, err := migrator.Status()
+		require.NoError(t, err)
+		assert.True(t, status.Dirty)
+		assert.Error(t, migrator.Up())
+
+		require.NoError(t, migrator.Force(int(latest)))
+		status, err = migrator.Status()
+		require.NoError(t, err)
+		assert.False(t, status.Dirty)
+	})
+
+	t.Run("Negative Case: Down needs at least one step", func(t *testing.T) {
+		assert.EqualError(t, migrator.Down(0), "steps must be at least 1, got 0")
+	})
+}
+
+// TestMigrationsUpAndDown applies every up and then every down migration against the local test databases,
+// SQLite always runs while MySQL and PostgreSQL need TEST_MYSQL_DSN and TEST_POSTGRES_DSN. Their databases
+// must be throwaway ones, they are left migrated to the latest version
+func TestMigrationsUpAndDown(t *testing.T) {
+	databases := []struct {
+		driver string
+		dsn    string
+	}{
+		{DriverSQLite, "file::memory:?_pragma=foreign_keys(1)&_time_format=sqlite"},
+		{DriverMySQL, os.Getenv("TEST_MYSQL_DSN")},
+		{DriverPostgres, os.Getenv("TEST_POSTGRES_DSN")},
+	}
+
+	for _, test := range databases {
+		t.Run("Positive Case: Every migration goes up and down on "+test.driver, func(t *testing.T) {
+			if test.dsn == "" {
+				t.Skipf("TEST_%s_DSN is not set", strings.ToUpper(test.driver))
+			}
+
+			migrations, err := MigrationsFS(test.driver, "")
+			require.NoError(t, err)
+			versions, err := MigrationVersions(migrations)
+			require.NoError(t, err)
+
+			database, err := Open(test.driver, test.dsn, DefaultPool)
+			require.NoError(t, err)
+			sqlDB, err := database.DB()
+			require.NoError(t, err)
+			t.Cleanup(func() { sqlDB.Close() })
+
+			migrator, err := NewMigrator(database, test.driver, migrations)
+			require.NoError(t, err)
+
+			require.NoError(t, migrator.Up())
+			status, err := migrator.Status()
+			require.NoError(t, err)
+			require.Equal(t, versions[len(versions)-1], status.Version)
+
+			// one step at a time, so a failing down migration is named by the version it leaves behind
+			for i := len(versions) - 1; i >= 0; i-- {
+				require.NoError(t, migrator.Down(1), "rolling back %d", versions[i])
+
+				status, err := migrator.Status()
+				require.NoError(t, err)
+				require.False(t, status.Dirty, "rolling back %d", versions[i])
+				require.Equal(t, versions[i:], status.Pending)
+			}
+
+			tables, err := database.Migrator().GetTables()
+			require.NoError(t, err)
+			assert.Equal(t, []string{"schema_migrations"}, tables, "the down migrations must drop everything the up migrations create")
+
+			require.NoError(t, migrator.Up())
+			status, err = migrator.Status()
+			require.NoError(t, err)
+			assert.Equal(t, versions[len(versions)-1], status.Version)
+			assert.Empty(t, status.Pending)
+		})
+	}
+}
+
+func TestMigrationsFS(t *testing.T) {
+	for _, driver := range []string{DriverMySQL, DriverPostgres, DriverSQLite} {
+		t.Run("Positive Case: The folder override reads the same migrations for "+driver, func(t *testing.T) {
+			embedded, err := MigrationsFS(driver, "")
+			require.NoError(t, err)
+			embeddedVersions, err := MigrationVersions(embedded)
+			require.NoError(t, err)
+
+			folder, err := MigrationsFS(driver, filepath.Join("..", "..", "migrations"))
+			require.NoError(t, err)
+			folderVersions, err := MigrationVersions(folder)
+			require.NoError(t, err)
+
+			assert.Equal(t, embeddedVersions, folderVersions)
+		})
+	}
+}
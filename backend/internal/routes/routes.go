@@ -1,61 +1,214 @@
 package routes
 
 import (
+	"context"
 	"fmt"
-	"log"
+	"log/slog"
+	"net/http"
 	"os"
-	"strings"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+	"unit-management-be/internal/app"
+	"unit-management-be/internal/config"
 	"unit-management-be/internal/db"
+	"unit-management-be/pkg/auth"
+	"unit-management-be/pkg/events"
 	"unit-management-be/pkg/handler"
-	"unit-management-be/pkg/utils"
+	"unit-management-be/pkg/logging"
+	"unit-management-be/pkg/metrics"
 
+	bookingcontroller "unit-management-be/pkg/controller/bookings"
+	healthcontroller "unit-management-be/pkg/controller/health"
+	housekeepingcontroller "unit-management-be/pkg/controller/housekeeping"
+	maintenancecontroller "unit-management-be/pkg/controller/maintenance"
+	reportcontroller "unit-management-be/pkg/controller/reports"
 	unitcontroller "unit-management-be/pkg/controller/units"
+	usercontroller "unit-management-be/pkg/controller/users"
+	webhookcontroller "unit-management-be/pkg/controller/webhooks"
 	unitrepository "unit-management-be/pkg/repository/units"
+	userrepository "unit-management-be/pkg/repository/users"
 	unitservice "unit-management-be/pkg/service/units"
+	userservice "unit-management-be/pkg/service/users"
+	webhookservice "unit-management-be/pkg/service/webhooks"
 
 	_ "unit-management-be/docs"
 
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
 
 	files "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
 )
 
-func Run() {
-	r := gin.Default()
-	r.Use(handler.ErrorHandler())
+func Run(cfg config.Config) {
+	// in-flight requests are drained and the background jobs stopped on SIGINT or SIGTERM
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
 
-	allowOrigins := os.Getenv("CORS_ALLOW_ORIGINS")
-	allowMethods := os.Getenv("CORS_ALLOW_METHOD")
+	r := gin.New()
+	// the request logger and metrics wrap the error handler so they see the status code it writes
+	appMetrics := metrics.NewMetrics()
+	r.Use(handler.RequestID(), handler.RequestLogger(), appMetrics.Middleware(), handler.Recovery())
+	r.Use(handler.ErrorHandler())
 
 	// swagger API
 	r.GET("/swagger/*any", ginSwagger.WrapHandler(files.Handler))
 
-	// cors configuration
-	config := cors.Config{
-		AllowOrigins:     strings.Split(allowOrigins, ","),
-		AllowMethods:     strings.Split(allowMethods, ","),
-		AllowHeaders:     []string{"*"},
+	// prometheus metrics, meant to be scraped from inside the network
+	r.GET("/metrics", gin.WrapH(appMetrics.Handler()))
+
+	// cors configuration, a wildcard never covers Authorization so the headers read by the API are listed
+	corsConfig := cors.Config{
+		AllowOrigins:     cfg.CORS.AllowOrigins,
+		AllowMethods:     cfg.CORS.AllowMethods,
+		AllowHeaders:     []string{"Authorization", "Content-Type", "If-Match", "Last-Event-ID", handler.RequestIDHeader},
+		ExposeHeaders:    []string{"ETag", handler.RequestIDHeader},
 		AllowCredentials: true,
 	}
-	r.Use(cors.New(config))
+	r.Use(cors.New(corsConfig))
 
-	db := db.GetDB()
-	unitRepository := unitrepository.NewUnitRepository(db)
-	unitService := unitservice.NewUnitService(unitRepository)
-	unitController := unitcontroller.NewUnitController(unitService)
+	tokenManager := auth.NewTokenManager(cfg.Auth.JWTSecret, cfg.Auth.TokenTTL)
+
+	migrations, err := db.MigrationsFS(cfg.Database.Driver, cfg.Database.MigrationsDir)
+	if err != nil {
+		logging.Fatal("failed to read migrations", "error", err)
+	}
+	latestMigration, err := db.LatestMigrationVersion(migrations)
+	if err != nil {
+		logging.Fatal("failed to read the latest migration", "error", err)
+	}
+
+	database := db.GetDB()
+	sqlDB, err := database.DB()
+	if err != nil {
+		logging.Fatal("failed to read the database connection pool", "error", err)
+	}
+	appMetrics.RegisterDB(sqlDB, database.Dialector.Name())
+
+	userRepository := userrepository.NewUserRepository(database)
+	userService := userservice.NewUserService(userRepository, tokenManager)
+	userController := usercontroller.NewUserController(userService)
+	if err := userService.EnsureAdmin(context.Background(), cfg.Auth.AdminUsername, cfg.Auth.AdminPassword); err != nil {
+		logging.Fatal("failed to bootstrap admin user", "error", err.Message)
+	}
+
+	unitEvents := events.NewBroker(cfg.Units.EventsReplaySize)
+
+	services := newServices(cfg, database)
+	services.Units.RegisterChangeListener(unitservice.PublishChanges(unitEvents))
+	unitMetrics := unitservice.NewUnitMetrics(services.Units)
+	appMetrics.MustRegister(unitMetrics)
+	services.Units.RegisterRejectionListener(unitMetrics.OnStatusChangeRejected)
+
+	unitController := unitcontroller.NewUnitController(services.Units, unitEvents)
+	reportController := reportcontroller.NewReportController(services.Reports)
+	webhookController := webhookcontroller.NewWebhookController(services.Webhooks)
+
+	var jobs sync.WaitGroup
+	if cfg.Units.TrashRetentionDays > 0 {
+		jobs.Add(1)
+		go func() {
+			defer jobs.Done()
+			unitservice.RunTrashRetention(ctx, services.Units, time.Duration(cfg.Units.TrashRetentionDays)*24*time.Hour, time.Hour)
+		}()
+	}
+	// retries are picked up every interval, new deliveries are sent right away
+	jobs.Add(1)
+	go func() {
+		defer jobs.Done()
+		webhookservice.RunWebhookDispatcher(ctx, services.Webhooks, 10*time.Second)
+	}()
+
+	healthController := healthcontroller.NewHealthController(map[string]healthcontroller.Check{
+		"database": func(ctx context.Context) error {
+			return db.Ping(ctx, database)
+		},
+		"migrations": func(ctx context.Context) error {
+			return db.CheckMigrations(ctx, database, latestMigration)
+		},
+	})
+	healthcontroller.SetupHealthRoutes(r, healthController)
 
 	api := r.Group("/api")
-	unitcontroller.SetupUnitRoutes(api, unitController)
+	usercontroller.SetupAuthRoutes(api, userController)
 
-	port := os.Getenv("PORT")
-	if utils.IsEmptyString(port) {
-		port = "5000"
+	protected := api.Group("", handler.Authenticate(tokenManager))
+	usercontroller.SetupUserRoutes(protected, userController)
+	unitcontroller.SetupUnitRoutes(protected, unitController)
+	// the subsystems are missing when units are kept in memory, their endpoints are not served then
+	if services.Bookings != nil {
+		bookingcontroller.SetupBookingRoutes(protected, bookingcontroller.NewBookingController(services.Bookings))
+		housekeepingcontroller.SetupHousekeepingRoutes(protected, housekeepingcontroller.NewHousekeepingController(services.Housekeeping))
+		maintenancecontroller.SetupMaintenanceRoutes(protected, maintenancecontroller.NewMaintenanceController(services.Maintenance))
 	}
+	webhookcontroller.SetupWebhookRoutes(protected, webhookController)
+	reportcontroller.SetupReportRoutes(protected, reportController)
 
-	log.Printf("application running on port : %s", port)
-	if err := r.Run(fmt.Sprintf(":%s", port)); err != nil {
-		log.Fatalf("failed to run backend")
+	server := &http.Server{
+		Addr:              fmt.Sprintf(":%d", cfg.Server.Port),
+		Handler:           r,
+		ReadHeaderTimeout: cfg.Server.ReadHeaderTimeout,
 	}
+	// the event streams never end on their own, they are closed as soon as the shutdown starts
+	server.RegisterOnShutdown(unitEvents.Close)
+
+	serverErr := make(chan error, 1)
+	go func() {
+		slog.Info("application running", "port", cfg.Server.Port)
+		serverErr <- server.ListenAndServe()
+	}()
+
+	select {
+	case err := <-serverErr:
+		logging.Fatal("failed to run backend", "error", err)
+	case <-ctx.Done():
+	}
+	// a second signal stops the application right away
+	stop()
+
+	slog.Info("shutting down, draining in-flight requests", "timeout", cfg.Server.ShutdownTimeout.String())
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), cfg.Server.ShutdownTimeout)
+	defer cancel()
+
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		slog.Error("failed to drain in-flight requests", "error", err)
+	}
+	if err := waitFor(shutdownCtx, &jobs); err != nil {
+		slog.Error("failed to wait for the background jobs to stop", "error", err)
+	}
+	if err := sqlDB.Close(); err != nil {
+		slog.Error("failed to close the database connection pool", "error", err)
+	}
+
+	slog.Info("application stopped")
+}
+
+// waitFor waits until the jobs have returned, at most until ctx is done
+func waitFor(ctx context.Context, jobs *sync.WaitGroup) error {
+	done := make(chan struct{})
+	go func() {
+		jobs.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// newServices picks where units are stored, the database is used unless the repository is memory. Bookings,
+// housekeeping and maintenance reference units in the database, so memory keeps to the unit endpoints
+func newServices(cfg config.Config, db *gorm.DB) app.Services {
+	if cfg.Units.Repository == config.UnitRepositoryMemory {
+		slog.Warn("UNIT_REPOSITORY=memory keeps units in process memory, they are lost on restart and bookings, housekeeping and maintenance are disabled")
+		return app.NewUnitServices(db, unitrepository.NewMemoryUnitRepository(db))
+	}
+
+	return app.NewServices(db, unitrepository.NewUnitRepository(db), cfg.Housekeeping.PeakHours)
 }
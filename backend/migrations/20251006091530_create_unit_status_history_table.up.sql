@@ -0,0 +1,11 @@
+CREATE TABLE unit_status_history (
+    id VARCHAR(36) PRIMARY KEY,
+    unit_id VARCHAR(36) NOT NULL,
+    from_status ENUM('Available', 'Occupied', 'Cleaning In Progress', 'Maintenance Needed') NULL,
+    to_status ENUM('Available', 'Occupied', 'Cleaning In Progress', 'Maintenance Needed') NOT NULL,
+    actor VARCHAR(255) NOT NULL,
+    reason VARCHAR(500) NOT NULL DEFAULT '',
+    changed_at DATETIME(3) NOT NULL,
+    INDEX idx_unit_status_history_unit_changed (unit_id, changed_at),
+    CONSTRAINT fk_unit_status_history_unit FOREIGN KEY (unit_id) REFERENCES units(id) ON DELETE CASCADE
+);
@@ -0,0 +1,15 @@
+CREATE TABLE bookings (
+    id VARCHAR(36) PRIMARY KEY,
+    unit_id VARCHAR(36) NOT NULL,
+    guest_name VARCHAR(255) NOT NULL,
+    guest_contact VARCHAR(255) NOT NULL DEFAULT '',
+    status ENUM('Reserved', 'Checked In', 'Checked Out', 'Cancelled') NOT NULL,
+    start_at DATETIME NOT NULL,
+    end_at DATETIME NOT NULL,
+    checked_in_at DATETIME NULL,
+    checked_out_at DATETIME NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    INDEX idx_bookings_unit_period (unit_id, start_at, end_at),
+    CONSTRAINT fk_bookings_unit FOREIGN KEY (unit_id) REFERENCES units(id) ON DELETE CASCADE
+);
@@ -0,0 +1,14 @@
+CREATE TABLE housekeeping_tasks (
+    id VARCHAR(36) PRIMARY KEY,
+    unit_id VARCHAR(36) NOT NULL,
+    status ENUM('Pending', 'In Progress', 'Completed', 'Cancelled') NOT NULL,
+    assignee VARCHAR(255) NOT NULL DEFAULT '',
+    due_at DATETIME NOT NULL,
+    claimed_at DATETIME NULL,
+    completed_at DATETIME NULL,
+    created_at DATETIME(3) NOT NULL,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    INDEX idx_housekeeping_tasks_status (status, created_at),
+    INDEX idx_housekeeping_tasks_unit (unit_id),
+    CONSTRAINT fk_housekeeping_tasks_unit FOREIGN KEY (unit_id) REFERENCES units(id) ON DELETE CASCADE
+);
@@ -0,0 +1,17 @@
+CREATE TABLE maintenance_tickets (
+    id VARCHAR(36) PRIMARY KEY,
+    unit_id VARCHAR(36) NOT NULL,
+    title VARCHAR(255) NOT NULL,
+    description TEXT NOT NULL,
+    severity ENUM('low', 'medium', 'high', 'critical') NOT NULL,
+    reporter VARCHAR(255) NOT NULL,
+    assignee VARCHAR(255) NOT NULL DEFAULT '',
+    status ENUM('Open', 'In Progress', 'Resolved') NOT NULL,
+    resolution_notes TEXT NULL,
+    resolved_at DATETIME NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    INDEX idx_maintenance_tickets_unit_status (unit_id, status),
+    INDEX idx_maintenance_tickets_status_severity (status, severity),
+    CONSTRAINT fk_maintenance_tickets_unit FOREIGN KEY (unit_id) REFERENCES units(id) ON DELETE CASCADE
+);
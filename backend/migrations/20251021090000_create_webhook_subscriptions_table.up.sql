@@ -0,0 +1,10 @@
+CREATE TABLE webhook_subscriptions (
+    id VARCHAR(36) PRIMARY KEY,
+    url VARCHAR(2048) NOT NULL,
+    events VARCHAR(255) NOT NULL,
+    secret VARCHAR(255) NOT NULL,
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_by VARCHAR(255) NOT NULL DEFAULT '',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
@@ -0,0 +1,17 @@
+CREATE TABLE webhook_deliveries (
+    id VARCHAR(36) PRIMARY KEY,
+    subscription_id VARCHAR(36) NOT NULL,
+    event VARCHAR(64) NOT NULL,
+    payload TEXT NOT NULL,
+    status ENUM('pending', 'delivered', 'dead') NOT NULL,
+    attempts INT NOT NULL DEFAULT 0,
+    next_attempt_at DATETIME NULL,
+    last_status_code INT NOT NULL DEFAULT 0,
+    last_error TEXT NULL,
+    delivered_at DATETIME NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    INDEX idx_webhook_deliveries_status_next_attempt (status, next_attempt_at),
+    INDEX idx_webhook_deliveries_subscription_status (subscription_id, status),
+    CONSTRAINT fk_webhook_deliveries_subscription FOREIGN KEY (subscription_id) REFERENCES webhook_subscriptions(id) ON DELETE CASCADE
+);
@@ -0,0 +1,3 @@
+ALTER TABLE bookings
+DROP FOREIGN KEY fk_bookings_unit,
+ADD CONSTRAINT fk_bookings_unit FOREIGN KEY (unit_id) REFERENCES units(id) ON DELETE RESTRICT;
\ No newline at end of file
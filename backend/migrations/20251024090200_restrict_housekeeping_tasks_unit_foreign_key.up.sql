@@ -0,0 +1,3 @@
+ALTER TABLE housekeeping_tasks
+DROP FOREIGN KEY fk_housekeeping_tasks_unit,
+ADD CONSTRAINT fk_housekeeping_tasks_unit FOREIGN KEY (unit_id) REFERENCES units(id) ON DELETE RESTRICT;
\ No newline at end of file
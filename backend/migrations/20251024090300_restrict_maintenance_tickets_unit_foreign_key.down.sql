@@ -0,0 +1,3 @@
+ALTER TABLE maintenance_tickets
+DROP FOREIGN KEY fk_maintenance_tickets_unit,
+ADD CONSTRAINT fk_maintenance_tickets_unit FOREIGN KEY (unit_id) REFERENCES units(id) ON DELETE CASCADE;
\ No newline at end of file
@@ -0,0 +1,8 @@
+// Package migrations embeds the SQL migrations into the binary, MySQL uses the files at the root
+// while PostgreSQL and SQLite keep their own copy of the schema in their folder
+package migrations
+
+import "embed"
+
+//go:embed *.sql postgres/*.sql sqlite/*.sql
+var FS embed.FS
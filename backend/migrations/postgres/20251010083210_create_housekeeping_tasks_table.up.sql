@@ -0,0 +1,15 @@
+CREATE TABLE housekeeping_tasks (
+    id VARCHAR(36) PRIMARY KEY,
+    unit_id VARCHAR(36) NOT NULL,
+    status TEXT NOT NULL CHECK (status IN ('Pending', 'In Progress', 'Completed', 'Cancelled')),
+    assignee VARCHAR(255) NOT NULL DEFAULT '',
+    due_at TIMESTAMPTZ NOT NULL,
+    claimed_at TIMESTAMPTZ NULL,
+    completed_at TIMESTAMPTZ NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT fk_housekeeping_tasks_unit FOREIGN KEY (unit_id) REFERENCES units(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_housekeeping_tasks_status ON housekeeping_tasks (status, created_at);
+CREATE INDEX idx_housekeeping_tasks_unit ON housekeeping_tasks (unit_id);
@@ -0,0 +1,2 @@
+ALTER TABLE units
+ADD COLUMN version BIGINT NOT NULL DEFAULT 1;
\ No newline at end of file
@@ -0,0 +1,18 @@
+CREATE TABLE webhook_deliveries (
+    id VARCHAR(36) PRIMARY KEY,
+    subscription_id VARCHAR(36) NOT NULL,
+    event VARCHAR(64) NOT NULL,
+    payload TEXT NOT NULL,
+    status TEXT NOT NULL CHECK (status IN ('pending', 'delivered', 'dead')),
+    attempts INT NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMPTZ NULL,
+    last_status_code INT NOT NULL DEFAULT 0,
+    last_error TEXT NULL,
+    delivered_at TIMESTAMPTZ NULL,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT fk_webhook_deliveries_subscription FOREIGN KEY (subscription_id) REFERENCES webhook_subscriptions(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_webhook_deliveries_status_next_attempt ON webhook_deliveries (status, next_attempt_at);
+CREATE INDEX idx_webhook_deliveries_subscription_status ON webhook_deliveries (subscription_id, status);
@@ -0,0 +1,3 @@
+ALTER TABLE unit_status_history
+DROP CONSTRAINT fk_unit_status_history_unit,
+ADD CONSTRAINT fk_unit_status_history_unit FOREIGN KEY (unit_id) REFERENCES units(id) ON DELETE CASCADE;
\ No newline at end of file
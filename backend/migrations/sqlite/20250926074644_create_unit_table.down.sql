@@ -0,0 +1 @@
+DROP TABLE IF EXISTS units;
\ No newline at end of file
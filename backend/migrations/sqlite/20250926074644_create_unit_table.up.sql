@@ -0,0 +1,7 @@
+CREATE TABLE units (
+    id VARCHAR(36) PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    type TEXT NOT NULL CHECK (type IN ('capsule', 'cabin')),
+    status TEXT NOT NULL CHECK (status IN ('Available', 'Occupied', 'Cleaning In Progress', 'Maintenance Needed')),
+    last_updated TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
@@ -0,0 +1,16 @@
+CREATE TABLE bookings (
+    id VARCHAR(36) PRIMARY KEY,
+    unit_id VARCHAR(36) NOT NULL,
+    guest_name VARCHAR(255) NOT NULL,
+    guest_contact VARCHAR(255) NOT NULL DEFAULT '',
+    status TEXT NOT NULL CHECK (status IN ('Reserved', 'Checked In', 'Checked Out', 'Cancelled')),
+    start_at DATETIME NOT NULL,
+    end_at DATETIME NOT NULL,
+    checked_in_at DATETIME NULL,
+    checked_out_at DATETIME NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT fk_bookings_unit FOREIGN KEY (unit_id) REFERENCES units(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_bookings_unit_period ON bookings (unit_id, start_at, end_at);
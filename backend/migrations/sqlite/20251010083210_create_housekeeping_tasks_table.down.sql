@@ -0,0 +1 @@
+DROP TABLE IF EXISTS housekeeping_tasks;
\ No newline at end of file
@@ -0,0 +1,18 @@
+CREATE TABLE maintenance_tickets (
+    id VARCHAR(36) PRIMARY KEY,
+    unit_id VARCHAR(36) NOT NULL,
+    title VARCHAR(255) NOT NULL,
+    description TEXT NOT NULL,
+    severity TEXT NOT NULL CHECK (severity IN ('low', 'medium', 'high', 'critical')),
+    reporter VARCHAR(255) NOT NULL,
+    assignee VARCHAR(255) NOT NULL DEFAULT '',
+    status TEXT NOT NULL CHECK (status IN ('Open', 'In Progress', 'Resolved')),
+    resolution_notes TEXT NULL,
+    resolved_at DATETIME NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT fk_maintenance_tickets_unit FOREIGN KEY (unit_id) REFERENCES units(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_maintenance_tickets_unit_status ON maintenance_tickets (unit_id, status);
+CREATE INDEX idx_maintenance_tickets_status_severity ON maintenance_tickets (status, severity);
@@ -0,0 +1,19 @@
+CREATE TABLE unit_status_history_new (
+    id VARCHAR(36) PRIMARY KEY,
+    unit_id VARCHAR(36) NOT NULL,
+    from_status TEXT NULL CHECK (from_status IN ('Available', 'Occupied', 'Cleaning In Progress', 'Maintenance Needed')),
+    to_status TEXT NOT NULL CHECK (to_status IN ('Available', 'Occupied', 'Cleaning In Progress', 'Maintenance Needed')),
+    actor VARCHAR(255) NOT NULL,
+    reason VARCHAR(500) NOT NULL DEFAULT '',
+    changed_at DATETIME NOT NULL,
+    CONSTRAINT fk_unit_status_history_unit FOREIGN KEY (unit_id) REFERENCES units(id) ON DELETE CASCADE
+);
+
+INSERT INTO unit_status_history_new (id, unit_id, from_status, to_status, actor, reason, changed_at)
+SELECT id, unit_id, from_status, to_status, actor, reason, changed_at FROM unit_status_history;
+
+DROP TABLE unit_status_history;
+
+ALTER TABLE unit_status_history_new RENAME TO unit_status_history;
+
+CREATE INDEX idx_unit_status_history_unit_changed ON unit_status_history (unit_id, changed_at);
@@ -0,0 +1,23 @@
+CREATE TABLE bookings_new (
+    id VARCHAR(36) PRIMARY KEY,
+    unit_id VARCHAR(36) NOT NULL,
+    guest_name VARCHAR(255) NOT NULL,
+    guest_contact VARCHAR(255) NOT NULL DEFAULT '',
+    status TEXT NOT NULL CHECK (status IN ('Reserved', 'Checked In', 'Checked Out', 'Cancelled')),
+    start_at DATETIME NOT NULL,
+    end_at DATETIME NOT NULL,
+    checked_in_at DATETIME NULL,
+    checked_out_at DATETIME NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT fk_bookings_unit FOREIGN KEY (unit_id) REFERENCES units(id) ON DELETE RESTRICT
+);
+
+INSERT INTO bookings_new (id, unit_id, guest_name, guest_contact, status, start_at, end_at, checked_in_at, checked_out_at, created_at, updated_at)
+SELECT id, unit_id, guest_name, guest_contact, status, start_at, end_at, checked_in_at, checked_out_at, created_at, updated_at FROM bookings;
+
+DROP TABLE bookings;
+
+ALTER TABLE bookings_new RENAME TO bookings;
+
+CREATE INDEX idx_bookings_unit_period ON bookings (unit_id, start_at, end_at);
@@ -0,0 +1,22 @@
+CREATE TABLE housekeeping_tasks_new (
+    id VARCHAR(36) PRIMARY KEY,
+    unit_id VARCHAR(36) NOT NULL,
+    status TEXT NOT NULL CHECK (status IN ('Pending', 'In Progress', 'Completed', 'Cancelled')),
+    assignee VARCHAR(255) NOT NULL DEFAULT '',
+    due_at DATETIME NOT NULL,
+    claimed_at DATETIME NULL,
+    completed_at DATETIME NULL,
+    created_at DATETIME NOT NULL,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT fk_housekeeping_tasks_unit FOREIGN KEY (unit_id) REFERENCES units(id) ON DELETE CASCADE
+);
+
+INSERT INTO housekeeping_tasks_new (id, unit_id, status, assignee, due_at, claimed_at, completed_at, created_at, updated_at)
+SELECT id, unit_id, status, assignee, due_at, claimed_at, completed_at, created_at, updated_at FROM housekeeping_tasks;
+
+DROP TABLE housekeeping_tasks;
+
+ALTER TABLE housekeeping_tasks_new RENAME TO housekeeping_tasks;
+
+CREATE INDEX idx_housekeeping_tasks_status ON housekeeping_tasks (status, created_at);
+CREATE INDEX idx_housekeeping_tasks_unit ON housekeeping_tasks (unit_id);
@@ -0,0 +1,25 @@
+CREATE TABLE maintenance_tickets_new (
+    id VARCHAR(36) PRIMARY KEY,
+    unit_id VARCHAR(36) NOT NULL,
+    title VARCHAR(255) NOT NULL,
+    description TEXT NOT NULL,
+    severity TEXT NOT NULL CHECK (severity IN ('low', 'medium', 'high', 'critical')),
+    reporter VARCHAR(255) NOT NULL,
+    assignee VARCHAR(255) NOT NULL DEFAULT '',
+    status TEXT NOT NULL CHECK (status IN ('Open', 'In Progress', 'Resolved')),
+    resolution_notes TEXT NULL,
+    resolved_at DATETIME NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT fk_maintenance_tickets_unit FOREIGN KEY (unit_id) REFERENCES units(id) ON DELETE RESTRICT
+);
+
+INSERT INTO maintenance_tickets_new (id, unit_id, title, description, severity, reporter, assignee, status, resolution_notes, resolved_at, created_at, updated_at)
+SELECT id, unit_id, title, description, severity, reporter, assignee, status, resolution_notes, resolved_at, created_at, updated_at FROM maintenance_tickets;
+
+DROP TABLE maintenance_tickets;
+
+ALTER TABLE maintenance_tickets_new RENAME TO maintenance_tickets;
+
+CREATE INDEX idx_maintenance_tickets_unit_status ON maintenance_tickets (unit_id, status);
+CREATE INDEX idx_maintenance_tickets_status_severity ON maintenance_tickets (status, severity);
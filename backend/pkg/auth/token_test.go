@@ -0,0 +1,53 @@
+package auth
+
+import (
+	"testing"
+	"time"
+
+	"unit-management-be/pkg/model/domain"
+	"unit-management-be/pkg/model/domain/enum"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTokenManager(t *testing.T) {
+	user := domain.User{ID: uuid.New(), Username: "maria", Role: enum.RoleHousekeeping}
+
+	t.Run("Positive Case: Generated token is verified", func(t *testing.T) {
+		tokenManager := NewTokenManager("local-secret", time.Hour)
+
+		token, expiresAt, err := tokenManager.Generate(user)
+		assert.NoError(t, err)
+		assert.WithinDuration(t, time.Now().Add(time.Hour), expiresAt, time.Minute)
+
+		claims, err := tokenManager.Parse(token)
+		assert.NoError(t, err)
+		assert.Equal(t, user.ID.String(), claims.Subject)
+		assert.Equal(t, "maria", claims.Username)
+		assert.Equal(t, enum.RoleHousekeeping, claims.Role)
+	})
+
+	t.Run("Negative Case: Token signed with another key", func(t *testing.T) {
+		token, _, err := NewTokenManager("other-secret", time.Hour).Generate(user)
+		assert.NoError(t, err)
+
+		_, err = NewTokenManager("local-secret", time.Hour).Parse(token)
+		assert.ErrorIs(t, err, ErrInvalidToken)
+	})
+
+	t.Run("Negative Case: Expired token", func(t *testing.T) {
+		tokenManager := NewTokenManager("local-secret", time.Hour)
+		tokenManager.now = func() time.Time { return time.Now().Add(-2 * time.Hour) }
+		token, _, err := tokenManager.Generate(user)
+		assert.NoError(t, err)
+
+		_, err = NewTokenManager("local-secret", time.Hour).Parse(token)
+		assert.ErrorIs(t, err, ErrInvalidToken)
+	})
+
+	t.Run("Negative Case: Malformed token", func(t *testing.T) {
+		_, err := NewTokenManager("local-secret", time.Hour).Parse("not-a-token")
+		assert.ErrorIs(t, err, ErrInvalidToken)
+	})
+}
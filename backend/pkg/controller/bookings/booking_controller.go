@@ -0,0 +1,209 @@
+package bookings
+
+import (
+	"net/http"
+	"unit-management-be/pkg/handler"
+	"unit-management-be/pkg/model/domain/enum"
+	"unit-management-be/pkg/model/dto"
+	"unit-management-be/pkg/model/dto/request"
+	bookingService "unit-management-be/pkg/service/bookings"
+	"unit-management-be/pkg/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+type BookingController struct {
+	bookingService bookingService.BookingService
+}
+
+func NewBookingController(bookingService bookingService.BookingService) *BookingController {
+	return &BookingController{bookingService: bookingService}
+}
+
+func SetupBookingRoutes(r *gin.RouterGroup, bc *BookingController) {
+	bookingGroup := r.Group("/booking")
+	bookingGroup.POST("", handler.RequireRoles(enum.RoleAdmin, enum.RoleFrontDesk), bc.CreateBooking)
+	bookingGroup.GET("", bc.GetBookings)
+	bookingGroup.GET("/:bookingId", bc.GetDetailBookingByID)
+	bookingGroup.POST("/:bookingId/check-in", handler.RequireRoles(enum.RoleAdmin, enum.RoleFrontDesk), bc.CheckIn)
+	bookingGroup.POST("/:bookingId/check-out", handler.RequireRoles(enum.RoleAdmin, enum.RoleFrontDesk), bc.CheckOut)
+	bookingGroup.POST("/:bookingId/cancel", handler.RequireRoles(enum.RoleAdmin, enum.RoleFrontDesk), bc.Cancel)
+}
+
+// @Summary Create Booking
+// @Description Reserve a unit for a guest, the period must not overlap another active booking of the unit
+// @Tags Bookings
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param booking body request.CreateBookingDto true "Booking creation request"
+// @Success 201 {object} dto.Response{data=domain.Booking} "Booking created successfully"
+// @Failure 400 {object} dto.Response "Bad request: Missing required fields or invalid period"
+// @Failure 404 {object} dto.Response "Unit not found"
+// @Failure 409 {object} dto.Response "Unit is already booked for that period"
+// @Failure 401 {object} dto.Response "Missing or invalid bearer token"
+// @Failure 403 {object} dto.Response "Role is not allowed to perform this action"
+// @Failure 500 {object} dto.Response "Internal server error"
+// @Router /booking [post]
+func (bc *BookingController) CreateBooking(c *gin.Context) {
+	var body request.CreateBookingDto
+	if err := c.ShouldBindJSON(&body); err != nil {
+		c.Error(handler.NewBindError(err))
+		return
+	}
+
+	if utils.IsEmptyString(body.UnitID) {
+		c.Error(handler.NewError(http.StatusBadRequest, "unit id is required"))
+		return
+	}
+
+	if utils.IsEmptyString(body.GuestName) {
+		c.Error(handler.NewError(http.StatusBadRequest, "guest name is required"))
+		return
+	}
+
+	if body.StartAt.IsZero() || body.EndAt.IsZero() {
+		c.Error(handler.NewError(http.StatusBadRequest, "booking start and end time are required"))
+		return
+	}
+
+	booking, errBooking := bc.bookingService.CreateBooking(c.Request.Context(), body)
+	if errBooking != nil {
+		c.Error(errBooking)
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.BaseResponse(true, "OK", booking))
+}
+
+// @Summary Get List of Bookings
+// @Description Retrieve list of bookings with optional filtering and pagination
+// @Tags Bookings
+// @Security BearerAuth
+// @Produce json
+// @Param page query int false "Page number (default 1)"
+// @Param size query int false "Number of items per page (default 10, max 100)"
+// @Param unitId query string false "Filter by unit ID"
+// @Param status query string false "Filter by booking status (Reserved, Checked In, Checked Out, Cancelled)"
+// @Success 200 {object} dto.Response{data=dto.PaginationResponse{content=[]domain.Booking}} "Successfully retrieved list of bookings"
+// @Failure 400 {object} dto.Response "Bad request (invalid page/size/status parameter)"
+// @Failure 401 {object} dto.Response "Missing or invalid bearer token"
+// @Failure 500 {object} dto.Response "Internal server error"
+// @Router /booking [get]
+func (bc *BookingController) GetBookings(c *gin.Context) {
+	unitIdStr := c.DefaultQuery("unitId", "")
+	statusStr := c.DefaultQuery("status", "")
+
+	page, size, errPagination := handler.ParsePagination(c)
+	if errPagination != nil {
+		c.Error(errPagination)
+		return
+	}
+
+	bookings, errBookings := bc.bookingService.FindBookings(c.Request.Context(), unitIdStr, statusStr, page, size)
+	if errBookings != nil {
+		c.Error(handler.NewError(errBookings.Code, errBookings.Message))
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.BaseResponse(true, "OK", bookings))
+}
+
+// @Summary Get Booking Detail by ID
+// @Description Retrieve details of specific booking using its ID
+// @Tags Bookings
+// @Security BearerAuth
+// @Produce json
+// @Param bookingId path string true "Booking ID"
+// @Success 200 {object} dto.Response{data=domain.Booking} "Successfully retrieved booking detail"
+// @Failure 404 {object} dto.Response "Booking not found"
+// @Failure 401 {object} dto.Response "Missing or invalid bearer token"
+// @Failure 500 {object} dto.Response "Internal server error"
+// @Router /booking/{bookingId} [get]
+func (bc *BookingController) GetDetailBookingByID(c *gin.Context) {
+	bookingId := c.Param("bookingId")
+
+	booking, err := bc.bookingService.FindByID(c.Request.Context(), bookingId)
+	if err != nil {
+		c.Error(handler.NewError(err.Code, err.Message))
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.BaseResponse(true, "OK", booking))
+}
+
+// @Summary Check In Booking
+// @Description Check the guest in and move the unit to Occupied
+// @Tags Bookings
+// @Security BearerAuth
+// @Produce json
+// @Param bookingId path string true "Booking ID"
+// @Success 200 {object} dto.Response{data=domain.Booking} "Guest checked in"
+// @Failure 400 {object} dto.Response "Booking cannot be checked in or unit status transition not allowed"
+// @Failure 404 {object} dto.Response "Booking not found"
+// @Failure 409 {object} dto.Response "Unit is held by another guest"
+// @Failure 401 {object} dto.Response "Missing or invalid bearer token"
+// @Failure 403 {object} dto.Response "Role is not allowed to perform this action"
+// @Failure 500 {object} dto.Response "Internal server error"
+// @Router /booking/{bookingId}/check-in [post]
+func (bc *BookingController) CheckIn(c *gin.Context) {
+	bookingId := c.Param("bookingId")
+
+	booking, err := bc.bookingService.CheckIn(c.Request.Context(), bookingId, handler.GetActor(c))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.BaseResponse(true, "OK", booking))
+}
+
+// @Summary Check Out Booking
+// @Description Check the guest out and move the unit to Cleaning In Progress
+// @Tags Bookings
+// @Security BearerAuth
+// @Produce json
+// @Param bookingId path string true "Booking ID"
+// @Success 200 {object} dto.Response{data=domain.Booking} "Guest checked out"
+// @Failure 400 {object} dto.Response "Booking cannot be checked out"
+// @Failure 404 {object} dto.Response "Booking not found"
+// @Failure 401 {object} dto.Response "Missing or invalid bearer token"
+// @Failure 403 {object} dto.Response "Role is not allowed to perform this action"
+// @Failure 500 {object} dto.Response "Internal server error"
+// @Router /booking/{bookingId}/check-out [post]
+func (bc *BookingController) CheckOut(c *gin.Context) {
+	bookingId := c.Param("bookingId")
+
+	booking, err := bc.bookingService.CheckOut(c.Request.Context(), bookingId, handler.GetActor(c))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.BaseResponse(true, "OK", booking))
+}
+
+// @Summary Cancel Booking
+// @Description Cancel a reservation that has not been checked in yet
+// @Tags Bookings
+// @Security BearerAuth
+// @Produce json
+// @Param bookingId path string true "Booking ID"
+// @Success 200 {object} dto.Response{data=domain.Booking} "Booking cancelled"
+// @Failure 400 {object} dto.Response "Booking cannot be cancelled"
+// @Failure 404 {object} dto.Response "Booking not found"
+// @Failure 401 {object} dto.Response "Missing or invalid bearer token"
+// @Failure 403 {object} dto.Response "Role is not allowed to perform this action"
+// @Failure 500 {object} dto.Response "Internal server error"
+// @Router /booking/{bookingId}/cancel [post]
+func (bc *BookingController) Cancel(c *gin.Context) {
+	bookingId := c.Param("bookingId")
+
+	booking, err := bc.bookingService.Cancel(c.Request.Context(), bookingId)
+	if err != nil {
+		c.Error(handler.NewError(err.Code, err.Message))
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.BaseResponse(true, "OK", booking))
+}
@@ -0,0 +1,71 @@
+package health
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"sort"
+	"time"
+	"unit-management-be/pkg/model/dto"
+	"unit-management-be/pkg/model/dto/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+// checkTimeout bounds every readiness check, so a hanging database fails the probe instead of blocking it
+const checkTimeout = 2 * time.Second
+
+// Check reports why a dependency of the application cannot serve requests, nil when it can
+type Check func(ctx context.Context) error
+
+type HealthController struct {
+	checks  map[string]Check
+	timeout time.Duration
+}
+
+func NewHealthController(checks map[string]Check) *HealthController {
+	return &HealthController{checks: checks, timeout: checkTimeout}
+}
+
+// SetupHealthRoutes registers the probes outside of /api, they are not authenticated
+func SetupHealthRoutes(r gin.IRoutes, hc *HealthController) {
+	r.GET("/healthz", hc.GetHealth)
+	r.GET("/readyz", hc.GetReadiness)
+}
+
+// GetHealth answers as long as the process is able to serve requests, it does not check any dependency
+func (hc *HealthController) GetHealth(c *gin.Context) {
+	c.JSON(http.StatusOK, dto.BaseResponse(true, "OK", response.HealthResponse{Status: response.HealthOK}))
+}
+
+// GetReadiness runs every check and answers 503 when one of them fails. The reason is only logged,
+// the probe is reachable without authentication
+func (hc *HealthController) GetReadiness(c *gin.Context) {
+	names := make([]string, 0, len(hc.checks))
+	for name := range hc.checks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	readiness := response.HealthResponse{Status: response.HealthOK, Checks: make(map[string]string, len(names))}
+	for _, name := range names {
+		ctx, cancel := context.WithTimeout(c.Request.Context(), hc.timeout)
+		err := hc.checks[name](ctx)
+		cancel()
+
+		if err != nil {
+			slog.WarnContext(c.Request.Context(), "readiness check failed", "check", name, "error", err)
+			readiness.Status = response.HealthFailing
+			readiness.Checks[name] = response.HealthFailing
+			continue
+		}
+		readiness.Checks[name] = response.HealthOK
+	}
+
+	if readiness.Status != response.HealthOK {
+		c.JSON(http.StatusServiceUnavailable, dto.BaseResponse(false, "service is not ready", readiness))
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.BaseResponse(true, "OK", readiness))
+}
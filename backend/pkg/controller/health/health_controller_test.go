@@ -0,0 +1,59 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func serveProbe(hc *HealthController, path string) *httptest.ResponseRecorder {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	SetupHealthRoutes(router, hc)
+
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, path, nil))
+	return recorder
+}
+
+func TestHealthProbes(t *testing.T) {
+	passing := func(ctx context.Context) error { return nil }
+	failing := func(ctx context.Context) error { return errors.New("dial tcp 10.0.0.5:3306: connection refused") }
+
+	t.Run("Positive Case: Process is alive even when a dependency fails", func(t *testing.T) {
+		recorder := serveProbe(NewHealthController(map[string]Check{"database": failing}), "/healthz")
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		assert.JSONEq(t, `{"success":true,"message":"OK","data":{"status":"ok"}}`, recorder.Body.String())
+	})
+
+	t.Run("Positive Case: Ready when every check passes", func(t *testing.T) {
+		recorder := serveProbe(NewHealthController(map[string]Check{"database": passing, "migrations": passing}), "/readyz")
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		assert.JSONEq(t, `{"success":true,"message":"OK","data":{"status":"ok","checks":{"database":"ok","migrations":"ok"}}}`, recorder.Body.String())
+	})
+
+	t.Run("Negative Case: Not ready when a check fails, without its reason", func(t *testing.T) {
+		recorder := serveProbe(NewHealthController(map[string]Check{"database": failing, "migrations": passing}), "/readyz")
+		assert.Equal(t, http.StatusServiceUnavailable, recorder.Code)
+		assert.JSONEq(t, `{"success":false,"message":"service is not ready","data":{"status":"failing","checks":{"database":"failing","migrations":"ok"}}}`, recorder.Body.String())
+	})
+
+	t.Run("Negative Case: Check exceeding its timeout fails", func(t *testing.T) {
+		hanging := func(ctx context.Context) error {
+			<-ctx.Done()
+			return ctx.Err()
+		}
+
+		hc := NewHealthController(map[string]Check{"database": hanging})
+		hc.timeout = 10 * time.Millisecond
+
+		recorder := serveProbe(hc, "/readyz")
+		assert.Equal(t, http.StatusServiceUnavailable, recorder.Code)
+	})
+}
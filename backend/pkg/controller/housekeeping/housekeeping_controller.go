@@ -0,0 +1,155 @@
+package housekeeping
+
+import (
+	"net/http"
+	"unit-management-be/pkg/handler"
+	"unit-management-be/pkg/model/domain/enum"
+	"unit-management-be/pkg/model/dto"
+	housekeepingService "unit-management-be/pkg/service/housekeeping"
+
+	"github.com/gin-gonic/gin"
+)
+
+type HousekeepingController struct {
+	housekeepingService housekeepingService.HousekeepingService
+}
+
+func NewHousekeepingController(housekeepingService housekeepingService.HousekeepingService) *HousekeepingController {
+	return &HousekeepingController{housekeepingService: housekeepingService}
+}
+
+func SetupHousekeepingRoutes(r *gin.RouterGroup, hc *HousekeepingController) {
+	housekeepingGroup := r.Group("/housekeeping")
+	housekeepingGroup.GET("", hc.GetTasks)
+	housekeepingGroup.GET("/next", hc.GetNextTask)
+	housekeepingGroup.GET("/:taskId", hc.GetDetailTaskByID)
+	housekeepingGroup.POST("/:taskId/claim", handler.RequireRoles(enum.RoleAdmin, enum.RoleHousekeeping), hc.ClaimTask)
+	housekeepingGroup.POST("/:taskId/complete", handler.RequireRoles(enum.RoleAdmin, enum.RoleHousekeeping), hc.CompleteTask)
+}
+
+// @Summary Get List of Housekeeping Tasks
+// @Description Retrieve housekeeping tasks with optional filtering and pagination, oldest first
+// @Tags Housekeeping
+// @Security BearerAuth
+// @Produce json
+// @Param page query int false "Page number (default 1)"
+// @Param size query int false "Number of items per page (default 10, max 100)"
+// @Param status query string false "Filter by task status (Pending, In Progress, Completed, Cancelled)"
+// @Param assignee query string false "Filter by assignee"
+// @Success 200 {object} dto.Response{data=dto.PaginationResponse{content=[]response.HousekeepingTaskResponse}} "Successfully retrieved list of housekeeping tasks"
+// @Failure 400 {object} dto.Response "Bad request (invalid page/size/status parameter)"
+// @Failure 401 {object} dto.Response "Missing or invalid bearer token"
+// @Failure 500 {object} dto.Response "Internal server error"
+// @Router /housekeeping [get]
+func (hc *HousekeepingController) GetTasks(c *gin.Context) {
+	statusStr := c.DefaultQuery("status", "")
+	assigneeStr := c.DefaultQuery("assignee", "")
+
+	page, size, errPagination := handler.ParsePagination(c)
+	if errPagination != nil {
+		c.Error(errPagination)
+		return
+	}
+
+	tasks, errTasks := hc.housekeepingService.FindTasks(c.Request.Context(), statusStr, assigneeStr, page, size)
+	if errTasks != nil {
+		c.Error(handler.NewError(errTasks.Code, errTasks.Message))
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.BaseResponse(true, "OK", tasks))
+}
+
+// @Summary Get Next Housekeeping Task
+// @Description Retrieve the pending task to work on next, prioritized by waiting time and by unit type during peak hours
+// @Tags Housekeeping
+// @Security BearerAuth
+// @Produce json
+// @Success 200 {object} dto.Response{data=response.HousekeepingTaskResponse} "Successfully retrieved next housekeeping task"
+// @Failure 404 {object} dto.Response "No pending housekeeping task"
+// @Failure 401 {object} dto.Response "Missing or invalid bearer token"
+// @Failure 500 {object} dto.Response "Internal server error"
+// @Router /housekeeping/next [get]
+func (hc *HousekeepingController) GetNextTask(c *gin.Context) {
+	task, err := hc.housekeepingService.NextTask(c.Request.Context())
+	if err != nil {
+		c.Error(handler.NewError(err.Code, err.Message))
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.BaseResponse(true, "OK", task))
+}
+
+// @Summary Get Housekeeping Task Detail by ID
+// @Description Retrieve details of specific housekeeping task using its ID
+// @Tags Housekeeping
+// @Security BearerAuth
+// @Produce json
+// @Param taskId path string true "Task ID"
+// @Success 200 {object} dto.Response{data=domain.HousekeepingTask} "Successfully retrieved housekeeping task detail"
+// @Failure 404 {object} dto.Response "Housekeeping task not found"
+// @Failure 401 {object} dto.Response "Missing or invalid bearer token"
+// @Failure 500 {object} dto.Response "Internal server error"
+// @Router /housekeeping/{taskId} [get]
+func (hc *HousekeepingController) GetDetailTaskByID(c *gin.Context) {
+	taskId := c.Param("taskId")
+
+	task, err := hc.housekeepingService.FindByID(c.Request.Context(), taskId)
+	if err != nil {
+		c.Error(handler.NewError(err.Code, err.Message))
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.BaseResponse(true, "OK", task))
+}
+
+// @Summary Claim Housekeeping Task
+// @Description Assign a pending housekeeping task to the requesting staff member
+// @Tags Housekeeping
+// @Security BearerAuth
+// @Produce json
+// @Param taskId path string true "Task ID"
+// @Success 200 {object} dto.Response{data=domain.HousekeepingTask} "Housekeeping task claimed"
+// @Failure 404 {object} dto.Response "Housekeeping task not found"
+// @Failure 409 {object} dto.Response "Housekeeping task is no longer pending"
+// @Failure 401 {object} dto.Response "Missing or invalid bearer token"
+// @Failure 403 {object} dto.Response "Role is not allowed to perform this action"
+// @Failure 500 {object} dto.Response "Internal server error"
+// @Router /housekeeping/{taskId}/claim [post]
+func (hc *HousekeepingController) ClaimTask(c *gin.Context) {
+	taskId := c.Param("taskId")
+
+	task, err := hc.housekeepingService.Claim(c.Request.Context(), taskId, handler.GetActor(c))
+	if err != nil {
+		c.Error(handler.NewError(err.Code, err.Message))
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.BaseResponse(true, "OK", task))
+}
+
+// @Summary Complete Housekeeping Task
+// @Description Complete a housekeeping task and move the unit to Available
+// @Tags Housekeeping
+// @Security BearerAuth
+// @Produce json
+// @Param taskId path string true "Task ID"
+// @Success 200 {object} dto.Response{data=domain.HousekeepingTask} "Housekeeping task completed"
+// @Failure 400 {object} dto.Response "Unit status transition not allowed"
+// @Failure 404 {object} dto.Response "Housekeeping task not found"
+// @Failure 409 {object} dto.Response "Housekeeping task is already closed"
+// @Failure 401 {object} dto.Response "Missing or invalid bearer token"
+// @Failure 403 {object} dto.Response "Role is not allowed to perform this action"
+// @Failure 500 {object} dto.Response "Internal server error"
+// @Router /housekeeping/{taskId}/complete [post]
+func (hc *HousekeepingController) CompleteTask(c *gin.Context) {
+	taskId := c.Param("taskId")
+
+	task, err := hc.housekeepingService.Complete(c.Request.Context(), taskId, handler.GetActor(c))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.BaseResponse(true, "OK", task))
+}
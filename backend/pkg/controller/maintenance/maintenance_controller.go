@@ -0,0 +1,253 @@
+package maintenance
+
+import (
+	"net/http"
+	"unit-management-be/pkg/handler"
+	"unit-management-be/pkg/model/domain/enum"
+	"unit-management-be/pkg/model/dto"
+	"unit-management-be/pkg/model/dto/request"
+	maintenanceService "unit-management-be/pkg/service/maintenance"
+	"unit-management-be/pkg/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+type MaintenanceController struct {
+	maintenanceService maintenanceService.MaintenanceService
+}
+
+func NewMaintenanceController(maintenanceService maintenanceService.MaintenanceService) *MaintenanceController {
+	return &MaintenanceController{maintenanceService: maintenanceService}
+}
+
+func SetupMaintenanceRoutes(r *gin.RouterGroup, mc *MaintenanceController) {
+	maintenanceGroup := r.Group("/maintenance")
+	maintenanceGroup.POST("", handler.RequireRoles(enum.RoleAdmin, enum.RoleFrontDesk, enum.RoleHousekeeping), mc.CreateTicket)
+	maintenanceGroup.GET("", mc.GetTickets)
+	maintenanceGroup.GET("/:ticketId", mc.GetDetailTicketByID)
+	maintenanceGroup.PUT("/:ticketId", handler.RequireRoles(enum.RoleAdmin, enum.RoleFrontDesk), mc.UpdateTicket)
+	maintenanceGroup.POST("/:ticketId/resolve", handler.RequireRoles(enum.RoleAdmin, enum.RoleFrontDesk), mc.ResolveTicket)
+
+	r.GET("/unit/:unitId/maintenance", mc.GetUnitTickets)
+}
+
+// @Summary Create Maintenance Ticket
+// @Description Report a problem on a unit, the unit is moved to Maintenance Needed until every ticket is resolved
+// @Tags Maintenance
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param ticket body request.CreateMaintenanceTicketDto true "Maintenance ticket creation request"
+// @Success 201 {object} dto.Response{data=domain.MaintenanceTicket} "Maintenance ticket created successfully"
+// @Failure 400 {object} dto.Response "Bad request: Missing required fields or invalid severity"
+// @Failure 404 {object} dto.Response "Unit not found"
+// @Failure 401 {object} dto.Response "Missing or invalid bearer token"
+// @Failure 403 {object} dto.Response "Role is not allowed to perform this action"
+// @Failure 500 {object} dto.Response "Internal server error"
+// @Router /maintenance [post]
+func (mc *MaintenanceController) CreateTicket(c *gin.Context) {
+	var body request.CreateMaintenanceTicketDto
+	if err := c.ShouldBindJSON(&body); err != nil {
+		c.Error(handler.NewBindError(err))
+		return
+	}
+
+	if utils.IsEmptyString(body.UnitID) {
+		c.Error(handler.NewError(http.StatusBadRequest, "unit id is required"))
+		return
+	}
+
+	if utils.IsEmptyString(body.Title) {
+		c.Error(handler.NewError(http.StatusBadRequest, "ticket title is required"))
+		return
+	}
+
+	if utils.IsEmptyString(body.Severity) {
+		c.Error(handler.NewError(http.StatusBadRequest, "ticket severity is required"))
+		return
+	}
+
+	body.Reporter = handler.GetActor(c)
+	ticket, errTicket := mc.maintenanceService.CreateTicket(c.Request.Context(), body)
+	if errTicket != nil {
+		c.Error(errTicket)
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.BaseResponse(true, "OK", ticket))
+}
+
+// @Summary Get List of Maintenance Tickets
+// @Description Retrieve maintenance tickets across the property with optional filtering and pagination
+// @Tags Maintenance
+// @Security BearerAuth
+// @Produce json
+// @Param page query int false "Page number (default 1)"
+// @Param size query int false "Number of items per page (default 10, max 100)"
+// @Param unitId query string false "Filter by unit ID"
+// @Param status query string false "Filter by ticket status (Open, In Progress, Resolved)"
+// @Param severity query string false "Filter by ticket severity (low, medium, high, critical)"
+// @Success 200 {object} dto.Response{data=dto.PaginationResponse{content=[]domain.MaintenanceTicket}} "Successfully retrieved list of maintenance tickets"
+// @Failure 400 {object} dto.Response "Bad request (invalid page/size/status/severity parameter)"
+// @Failure 401 {object} dto.Response "Missing or invalid bearer token"
+// @Failure 500 {object} dto.Response "Internal server error"
+// @Router /maintenance [get]
+func (mc *MaintenanceController) GetTickets(c *gin.Context) {
+	page, size, errPagination := handler.ParsePagination(c)
+	if errPagination != nil {
+		c.Error(errPagination)
+		return
+	}
+
+	tickets, errTickets := mc.maintenanceService.FindTickets(c.Request.Context(), c.DefaultQuery("unitId", ""), c.DefaultQuery("status", ""), c.DefaultQuery("severity", ""), page, size)
+	if errTickets != nil {
+		c.Error(handler.NewError(errTickets.Code, errTickets.Message))
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.BaseResponse(true, "OK", tickets))
+}
+
+// @Summary Get Maintenance Tickets of Unit
+// @Description Retrieve maintenance tickets of a unit with optional status filter and pagination
+// @Tags Maintenance
+// @Security BearerAuth
+// @Produce json
+// @Param unitId path string true "Unit ID"
+// @Param page query int false "Page number (default 1)"
+// @Param size query int false "Number of items per page (default 10, max 100)"
+// @Param status query string false "Filter by ticket status (Open, In Progress, Resolved)"
+// @Success 200 {object} dto.Response{data=dto.PaginationResponse{content=[]domain.MaintenanceTicket}} "Successfully retrieved maintenance tickets of unit"
+// @Failure 400 {object} dto.Response "Bad request (invalid page/size/status parameter)"
+// @Failure 404 {object} dto.Response "Unit not found"
+// @Failure 401 {object} dto.Response "Missing or invalid bearer token"
+// @Failure 500 {object} dto.Response "Internal server error"
+// @Router /unit/{unitId}/maintenance [get]
+func (mc *MaintenanceController) GetUnitTickets(c *gin.Context) {
+	unitId := c.Param("unitId")
+	page, size, errPagination := handler.ParsePagination(c)
+	if errPagination != nil {
+		c.Error(errPagination)
+		return
+	}
+
+	tickets, errTickets := mc.maintenanceService.FindUnitTickets(c.Request.Context(), unitId, c.DefaultQuery("status", ""), page, size)
+	if errTickets != nil {
+		c.Error(handler.NewError(errTickets.Code, errTickets.Message))
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.BaseResponse(true, "OK", tickets))
+}
+
+// @Summary Get Maintenance Ticket Detail by ID
+// @Description Retrieve details of specific maintenance ticket using its ID
+// @Tags Maintenance
+// @Security BearerAuth
+// @Produce json
+// @Param ticketId path string true "Ticket ID"
+// @Success 200 {object} dto.Response{data=domain.MaintenanceTicket} "Successfully retrieved maintenance ticket detail"
+// @Failure 404 {object} dto.Response "Maintenance ticket not found"
+// @Failure 401 {object} dto.Response "Missing or invalid bearer token"
+// @Failure 500 {object} dto.Response "Internal server error"
+// @Router /maintenance/{ticketId} [get]
+func (mc *MaintenanceController) GetDetailTicketByID(c *gin.Context) {
+	ticketId := c.Param("ticketId")
+
+	ticket, err := mc.maintenanceService.FindByID(c.Request.Context(), ticketId)
+	if err != nil {
+		c.Error(handler.NewError(err.Code, err.Message))
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.BaseResponse(true, "OK", ticket))
+}
+
+// @Summary Update Maintenance Ticket
+// @Description Update title, description, severity, assignee or progress of an open maintenance ticket
+// @Tags Maintenance
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param ticketId path string true "Ticket ID"
+// @Param ticket body request.UpdateMaintenanceTicketDto true "Maintenance ticket update request"
+// @Success 200 {object} dto.Response{data=domain.MaintenanceTicket} "Maintenance ticket successfully updated"
+// @Failure 400 {object} dto.Response "Bad request (missing required fields or invalid severity/status)"
+// @Failure 404 {object} dto.Response "Maintenance ticket not found"
+// @Failure 409 {object} dto.Response "Maintenance ticket is already resolved"
+// @Failure 401 {object} dto.Response "Missing or invalid bearer token"
+// @Failure 403 {object} dto.Response "Role is not allowed to perform this action"
+// @Failure 500 {object} dto.Response "Internal server error"
+// @Router /maintenance/{ticketId} [put]
+func (mc *MaintenanceController) UpdateTicket(c *gin.Context) {
+	ticketId := c.Param("ticketId")
+
+	var body request.UpdateMaintenanceTicketDto
+	if err := c.ShouldBindJSON(&body); err != nil {
+		c.Error(handler.NewBindError(err))
+		return
+	}
+
+	if utils.IsEmptyString(body.Title) {
+		c.Error(handler.NewError(http.StatusBadRequest, "ticket title is required"))
+		return
+	}
+
+	if utils.IsEmptyString(body.Severity) {
+		c.Error(handler.NewError(http.StatusBadRequest, "ticket severity is required"))
+		return
+	}
+
+	if utils.IsEmptyString(body.Status) {
+		c.Error(handler.NewError(http.StatusBadRequest, "ticket status is required"))
+		return
+	}
+
+	ticket, errTicket := mc.maintenanceService.UpdateTicket(c.Request.Context(), ticketId, body)
+	if errTicket != nil {
+		c.Error(handler.NewError(errTicket.Code, errTicket.Message))
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.BaseResponse(true, "OK", ticket))
+}
+
+// @Summary Resolve Maintenance Ticket
+// @Description Resolve a maintenance ticket with resolution notes, the unit can leave maintenance once no ticket is open
+// @Tags Maintenance
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param ticketId path string true "Ticket ID"
+// @Param ticket body request.ResolveMaintenanceTicketDto true "Maintenance ticket resolution request"
+// @Success 200 {object} dto.Response{data=domain.MaintenanceTicket} "Maintenance ticket resolved"
+// @Failure 400 {object} dto.Response "Bad request (missing resolution notes)"
+// @Failure 404 {object} dto.Response "Maintenance ticket not found"
+// @Failure 409 {object} dto.Response "Maintenance ticket is already resolved"
+// @Failure 401 {object} dto.Response "Missing or invalid bearer token"
+// @Failure 403 {object} dto.Response "Role is not allowed to perform this action"
+// @Failure 500 {object} dto.Response "Internal server error"
+// @Router /maintenance/{ticketId}/resolve [post]
+func (mc *MaintenanceController) ResolveTicket(c *gin.Context) {
+	ticketId := c.Param("ticketId")
+
+	var body request.ResolveMaintenanceTicketDto
+	if err := c.ShouldBindJSON(&body); err != nil {
+		c.Error(handler.NewBindError(err))
+		return
+	}
+
+	if utils.IsEmptyString(body.ResolutionNotes) {
+		c.Error(handler.NewError(http.StatusBadRequest, "resolution notes are required"))
+		return
+	}
+
+	body.Actor = handler.GetActor(c)
+	ticket, errTicket := mc.maintenanceService.ResolveTicket(c.Request.Context(), ticketId, body)
+	if errTicket != nil {
+		c.Error(handler.NewError(errTicket.Code, errTicket.Message))
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.BaseResponse(true, "OK", ticket))
+}
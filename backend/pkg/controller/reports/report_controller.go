@@ -0,0 +1,118 @@
+package reports
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+	"unit-management-be/pkg/handler"
+	"unit-management-be/pkg/model/domain/enum"
+	"unit-management-be/pkg/model/dto"
+	"unit-management-be/pkg/model/dto/request"
+	reportService "unit-management-be/pkg/service/reports"
+	"unit-management-be/pkg/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// dateLayout is accepted by the from and to parameters next to RFC 3339 timestamps
+const dateLayout = "2006-01-02"
+
+type ReportController struct {
+	reportService reportService.ReportService
+}
+
+func NewReportController(reportService reportService.ReportService) *ReportController {
+	return &ReportController{reportService: reportService}
+}
+
+func SetupReportRoutes(r *gin.RouterGroup, rc *ReportController) {
+	reportGroup := r.Group("/reports")
+	reportGroup.GET("/utilization", rc.GetUtilization)
+}
+
+// @Summary Get Utilization Report
+// @Description Occupancy percentage, average time in Cleaning In Progress and downtime in Maintenance Needed of every unit
+// @Description and every unit type, per day or week, derived from the recorded status changes. Durations are in seconds,
+// @Description a unit is only tracked from its creation and a cleaning spanning several periods is counted in each of them
+// @Tags Reports
+// @Security BearerAuth
+// @Produce json,text/csv
+// @Param from query string true "Start of the report, an RFC 3339 timestamp or a YYYY-MM-DD date"
+// @Param to query string true "End of the report, exclusive for a timestamp while a YYYY-MM-DD date includes the whole day"
+// @Param granularity query string false "Period of the report, day or week starting on Monday (default day)"
+// @Param format query string false "Output format, json or csv (default json)"
+// @Success 200 {object} dto.Response{data=response.UtilizationReportResponse} "Successfully built the utilization report, or a CSV file with a row per period of every unit type and unit"
+// @Failure 400 {object} dto.Response "Bad request (missing or invalid range, granularity or format)"
+// @Failure 401 {object} dto.Response "Missing or invalid bearer token"
+// @Failure 500 {object} dto.Response "Internal server error"
+// @Router /reports/utilization [get]
+func (rc *ReportController) GetUtilization(c *gin.Context) {
+	format := strings.ToLower(c.DefaultQuery("format", "json"))
+	if format != "json" && format != "csv" {
+		c.Error(handler.NewError(http.StatusBadRequest, "unsupported report format, must be 'json' or 'csv'"))
+		return
+	}
+
+	from, err := parseReportBound(c.Query("from"), "from", false)
+	if err != nil {
+		c.Error(handler.NewError(http.StatusBadRequest, err.Error()))
+		return
+	}
+
+	to, err := parseReportBound(c.Query("to"), "to", true)
+	if err != nil {
+		c.Error(handler.NewError(http.StatusBadRequest, err.Error()))
+		return
+	}
+
+	granularity, isValidGranularity := enum.ParseReportGranularity(strings.ToLower(c.DefaultQuery("granularity", string(enum.GranularityDay))))
+	if !isValidGranularity {
+		c.Error(handler.NewError(http.StatusBadRequest, "invalid report granularity, must be 'day' or 'week'"))
+		return
+	}
+
+	report, errReport := rc.reportService.GetUtilization(c.Request.Context(), request.UtilizationReportDto{From: from, To: to, Granularity: granularity})
+	if errReport != nil {
+		c.Error(handler.NewError(errReport.Code, errReport.Message))
+		return
+	}
+
+	if format == "json" {
+		c.JSON(http.StatusOK, dto.BaseResponse(true, "OK", report))
+		return
+	}
+
+	var buffer bytes.Buffer
+	if err := writeUtilizationCSV(&buffer, report); err != nil {
+		c.Error(handler.NewError(http.StatusInternalServerError, err.Error()))
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=utilization-%s-%s.csv", from.Format("20060102"), to.Format("20060102")))
+	c.Data(http.StatusOK, "text/csv; charset=utf-8", buffer.Bytes())
+}
+
+// parseReportBound reads an end of the report range, a date used as the end covers the whole day
+// so the range stops at the start of the next day
+func parseReportBound(value, parameter string, endOfDay bool) (time.Time, error) {
+	if utils.IsEmptyString(value) {
+		return time.Time{}, fmt.Errorf("%s parameter is required", parameter)
+	}
+
+	if bound, err := time.Parse(time.RFC3339Nano, value); err == nil {
+		// status changes are stamped with the server time, comparing in the same zone keeps text timestamps ordered
+		return bound.Local(), nil
+	}
+
+	bound, err := time.ParseInLocation(dateLayout, value, time.Local)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid %s parameter, must be an RFC 3339 timestamp or a YYYY-MM-DD date", parameter)
+	}
+	if endOfDay {
+		bound = bound.AddDate(0, 0, 1)
+	}
+
+	return bound, nil
+}
@@ -0,0 +1,66 @@
+package reports
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+	"time"
+	"unit-management-be/pkg/model/dto/response"
+	"unit-management-be/pkg/utils"
+)
+
+const (
+	scopeType = "type"
+	scopeUnit = "unit"
+)
+
+var utilizationHeader = []string{
+	"periodStart", "periodEnd", "scope", "type", "unitId", "unitName", "trackedSeconds", "occupiedSeconds",
+	"occupancyPercentage", "cleanings", "cleaningSeconds", "averageCleaningSeconds", "maintenanceDowntimeSeconds",
+}
+
+// writeUtilizationCSV writes a row per period of every unit type followed by a row per period of every unit,
+// the unit columns are left empty on the unit type rows and unit names starting like a formula are escaped
+func writeUtilizationCSV(w io.Writer, report *response.UtilizationReportResponse) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(utilizationHeader); err != nil {
+		return err
+	}
+
+	for _, typeReport := range report.Types {
+		for _, period := range typeReport.Periods {
+			if err := writer.Write(utilizationRecord(period, scopeType, string(typeReport.Type), "", "")); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, unitReport := range report.Units {
+		for _, period := range unitReport.Periods {
+			if err := writer.Write(utilizationRecord(period, scopeUnit, string(unitReport.Type), unitReport.UnitID.String(), unitReport.Name)); err != nil {
+				return err
+			}
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+func utilizationRecord(period response.UtilizationPeriod, scope, unitType, unitID, unitName string) []string {
+	return []string{
+		period.Start.Format(time.RFC3339),
+		period.End.Format(time.RFC3339),
+		scope,
+		unitType,
+		unitID,
+		utils.EscapeCSVCell(unitName),
+		strconv.FormatInt(period.TrackedSeconds, 10),
+		strconv.FormatInt(period.OccupiedSeconds, 10),
+		strconv.FormatFloat(period.OccupancyPercentage, 'f', 2, 64),
+		strconv.FormatInt(period.Cleanings, 10),
+		strconv.FormatInt(period.CleaningSeconds, 10),
+		strconv.FormatInt(period.AverageCleaningSeconds, 10),
+		strconv.FormatInt(period.MaintenanceDowntimeSeconds, 10),
+	}
+}
@@ -0,0 +1,87 @@
+package reports
+
+import (
+	"bytes"
+	"encoding/csv"
+	"testing"
+	"time"
+
+	"unit-management-be/pkg/model/domain/enum"
+	"unit-management-be/pkg/model/dto/response"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWriteUtilizationCSV(t *testing.T) {
+	t.Run("Positive Case: Unit type rows come before unit rows", func(t *testing.T) {
+		start := time.Date(2025, time.October, 6, 0, 0, 0, 0, time.UTC)
+		period := response.UtilizationPeriod{
+			Start:                      start,
+			End:                        start.AddDate(0, 0, 1),
+			TrackedSeconds:             86400,
+			OccupiedSeconds:            43200,
+			OccupancyPercentage:        50,
+			Cleanings:                  1,
+			CleaningSeconds:            3600,
+			AverageCleaningSeconds:     3600,
+			MaintenanceDowntimeSeconds: 600,
+		}
+		unitID := uuid.New()
+		report := &response.UtilizationReportResponse{
+			Types: []response.UnitTypeUtilization{{Type: enum.Cabin, Periods: []response.UtilizationPeriod{period}}},
+			Units: []response.UnitUtilization{{UnitID: unitID, Name: "Cabin, East", Type: enum.Cabin, Periods: []response.UtilizationPeriod{period}}},
+		}
+
+		var buffer bytes.Buffer
+		assert.NoError(t, writeUtilizationCSV(&buffer, report))
+
+		records, err := csv.NewReader(&buffer).ReadAll()
+		assert.NoError(t, err)
+		assert.Equal(t, [][]string{
+			utilizationHeader,
+			{"2025-10-06T00:00:00Z", "2025-10-07T00:00:00Z", "type", "cabin", "", "", "86400", "43200", "50.00", "1", "3600", "3600", "600"},
+			{"2025-10-06T00:00:00Z", "2025-10-07T00:00:00Z", "unit", "cabin", unitID.String(), "Cabin, East", "86400", "43200", "50.00", "1", "3600", "3600", "600"},
+		}, records)
+	})
+
+	t.Run("Positive Case: Unit name starting like a formula is escaped", func(t *testing.T) {
+		start := time.Date(2025, time.October, 6, 0, 0, 0, 0, time.UTC)
+		period := response.UtilizationPeriod{Start: start, End: start.AddDate(0, 0, 1)}
+		report := &response.UtilizationReportResponse{
+			Units: []response.UnitUtilization{{UnitID: uuid.New(), Name: "=HYPERLINK(\"x\")", Type: enum.Cabin, Periods: []response.UtilizationPeriod{period}}},
+		}
+
+		var buffer bytes.Buffer
+		assert.NoError(t, writeUtilizationCSV(&buffer, report))
+
+		records, err := csv.NewReader(&buffer).ReadAll()
+		assert.NoError(t, err)
+		assert.Len(t, records, 2)
+		assert.Equal(t, "'=HYPERLINK(\"x\")", records[1][5])
+	})
+}
+
+func TestParseReportBound(t *testing.T) {
+	t.Run("Positive Case: A date as the end covers the whole day", func(t *testing.T) {
+		from, err := parseReportBound("2025-10-06", "from", false)
+		assert.NoError(t, err)
+		assert.Equal(t, time.Date(2025, time.October, 6, 0, 0, 0, 0, time.Local), from)
+
+		to, err := parseReportBound("2025-10-06", "to", true)
+		assert.NoError(t, err)
+		assert.Equal(t, time.Date(2025, time.October, 7, 0, 0, 0, 0, time.Local), to)
+
+		to, err = parseReportBound("2025-10-06T12:00:00Z", "to", true)
+		assert.NoError(t, err)
+		assert.True(t, time.Date(2025, time.October, 6, 12, 0, 0, 0, time.UTC).Equal(to))
+	})
+
+	t.Run("Negative Case: Missing or invalid bound", func(t *testing.T) {
+		_, err := parseReportBound("", "from", false)
+		assert.ErrorContains(t, err, "from parameter is required")
+
+		_, err = parseReportBound("06/10/2025", "to", true)
+		assert.ErrorContains(t, err, "invalid to parameter")
+	})
+}
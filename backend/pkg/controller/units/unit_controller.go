@@ -1,48 +1,81 @@
 package units
 
 import (
+	"bytes"
+	"fmt"
+	"io"
 	"net/http"
 	"strconv"
+	"strings"
+	"time"
+	"unit-management-be/pkg/events"
 	"unit-management-be/pkg/handler"
+	"unit-management-be/pkg/model/domain/enum"
 	"unit-management-be/pkg/model/dto"
 	"unit-management-be/pkg/model/dto/request"
+	"unit-management-be/pkg/model/dto/response"
 	unitService "unit-management-be/pkg/service/units"
 	"unit-management-be/pkg/utils"
 
 	"github.com/gin-gonic/gin"
 )
 
+const (
+	// maxPatchSize is the largest accepted patch document, in bytes
+	maxPatchSize = 64 << 10
+	// defaultCursorLimit and maxCursorLimit bound the units of a cursor page
+	defaultCursorLimit = 10
+	maxCursorLimit     = handler.MaxPageSize
+)
+
 type UnitController struct {
 	unitService unitService.UnitService
+	unitEvents  *events.Broker
 }
 
-func NewUnitController(unitService unitService.UnitService) *UnitController {
-	return &UnitController{unitService: unitService}
+func NewUnitController(unitService unitService.UnitService, unitEvents *events.Broker) *UnitController {
+	return &UnitController{unitService: unitService, unitEvents: unitEvents}
 }
 
 func SetupUnitRoutes(r *gin.RouterGroup, uc *UnitController) {
 	unitGroup := r.Group("/unit")
-	unitGroup.POST("", uc.CreateUnit)
+	unitGroup.POST("", handler.RequireRoles(enum.RoleAdmin, enum.RoleFrontDesk), uc.CreateUnit)
 	unitGroup.GET("/:unitId", uc.GetDetailUnitByID)
-	unitGroup.DELETE("/:unitId", uc.DeleteUnit)
+	unitGroup.DELETE("/:unitId", handler.RequireRoles(enum.RoleAdmin), uc.DeleteUnit)
+	unitGroup.GET("/trash", handler.RequireRoles(enum.RoleAdmin), uc.GetTrash)
+	unitGroup.POST("/:unitId/restore", handler.RequireRoles(enum.RoleAdmin), uc.RestoreUnit)
+	unitGroup.DELETE("/:unitId/purge", handler.RequireRoles(enum.RoleAdmin), uc.PurgeUnit)
 	unitGroup.GET("", uc.GetUnits)
-	unitGroup.PUT("/:unitId", uc.UpdateUnit)
+	unitGroup.GET("/export", uc.ExportUnits)
+	unitGroup.GET("/events", uc.StreamUnitEvents)
+	unitGroup.GET("/stats", uc.GetUnitStats)
+	unitGroup.POST("/import", handler.RequireRoles(enum.RoleAdmin, enum.RoleFrontDesk), uc.ImportUnits)
+	unitGroup.PUT("/:unitId", handler.RequireRoles(enum.RoleAdmin, enum.RoleFrontDesk), uc.UpdateUnit)
+	unitGroup.PATCH("/:unitId", handler.RequireRoles(enum.RoleAdmin, enum.RoleFrontDesk, enum.RoleHousekeeping), uc.PatchUnit)
+	unitGroup.PUT("/:unitId/status", handler.RequireRoles(enum.RoleAdmin, enum.RoleFrontDesk, enum.RoleHousekeeping), uc.ChangeUnitStatus)
+	unitGroup.POST("/bulk/status", handler.RequireRoles(enum.RoleAdmin, enum.RoleFrontDesk, enum.RoleHousekeeping), uc.BulkChangeUnitStatus)
+	unitGroup.GET("/:unitId/transitions", uc.GetUnitTransitions)
+	unitGroup.GET("/:unitId/history", uc.GetUnitStatusHistory)
 }
 
 // @Summary Create Unit
 // @Description Create new unit with name, status and type
 // @Tags Units
+// @Security BearerAuth
 // @Accept json
 // @Produce json
 // @Param unit body request.CreateUnitDto true "Unit creation request"
 // @Success 201 {object} dto.Response "Unit created successfully"
-// @Failure 400 {object} dto.Response "Bad request: Missing required fields"
+// @Header 201 {string} ETag "Version of the unit, send it back as If-Match when updating"
+// @Failure 400 {object} dto.Response{data=response.UnitTransitionErrorResponse} "Bad request: Missing required fields or status not allowed for new unit"
+// @Failure 401 {object} dto.Response "Missing or invalid bearer token"
+// @Failure 403 {object} dto.Response "Role is not allowed to perform this action"
 // @Failure 500 {object} dto.Response "Internal server error"
 // @Router /unit [post]
 func (uc *UnitController) CreateUnit(c *gin.Context) {
 	var body request.CreateUnitDto
 	if err := c.ShouldBindJSON(&body); err != nil {
-		c.Error(handler.NewError(http.StatusInternalServerError, err.Error()))
+		c.Error(handler.NewBindError(err))
 		return
 	}
 
@@ -61,49 +94,60 @@ func (uc *UnitController) CreateUnit(c *gin.Context) {
 		return
 	}
 
-	unit, errUnit := uc.unitService.CreateUnit(body)
+	body.Actor = handler.GetActor(c)
+	unit, errUnit := uc.unitService.CreateUnit(c.Request.Context(), body)
 	if errUnit != nil {
-		c.Error(handler.NewError(errUnit.Code, errUnit.Message))
+		c.Error(errUnit)
 		return
 	}
 
+	c.Header("ETag", handler.FormatETag(unit.Version))
 	c.JSON(http.StatusCreated, dto.BaseResponse(true, "OK", unit))
 }
 
 // @Summary Get Unit Detail by ID
 // @Description Retrieve details of specific unit using its ID
 // @Tags Units
+// @Security BearerAuth
 // @Produce json
 // @Param unitId path string true "Unit ID"
-// @Success 200 {object} dto.Response "Successfully retrieved unit detail"
+// @Success 200 {object} dto.Response{data=response.UnitDetailResponse} "Successfully retrieved unit detail"
+// @Header 200 {string} ETag "Version of the unit, send it back as If-Match when updating"
 // @Failure 400 {object} dto.Response "Bad request"
+// @Failure 401 {object} dto.Response "Missing or invalid bearer token"
 // @Failure 500 {object} dto.Response "Internal server error"
 // @Router /unit/{unitId} [get]
 func (uc *UnitController) GetDetailUnitByID(c *gin.Context) {
 	unitId := c.Param("unitId")
 
-	unit, err := uc.unitService.GetDetailByID(unitId)
+	unit, err := uc.unitService.GetDetailByID(c.Request.Context(), unitId)
 	if err != nil {
 		c.Error(handler.NewError(err.Code, err.Message))
 		return
 	}
 
+	c.Header("ETag", handler.FormatETag(unit.Version))
 	c.JSON(http.StatusOK, dto.BaseResponse(true, "OK", unit))
 }
 
 // @Summary Delete Unit by ID
-// @Description Delete unit using its ID
+// @Description Move the unit to the trash, it can be restored until it is purged
+// @Description A unit with a reserved or checked in booking cannot be deleted until the booking is checked out or cancelled.
 // @Tags Units
+// @Security BearerAuth
 // @Produce json
 // @Param unitId path string true "Unit ID"
 // @Success 200 {object} dto.Response "Unit successfully deleted"
 // @Failure 404 {object} dto.Response "Unit not found"
+// @Failure 409 {object} dto.Response "Unit has a reserved or checked in booking"
+// @Failure 401 {object} dto.Response "Missing or invalid bearer token"
+// @Failure 403 {object} dto.Response "Role is not allowed to perform this action"
 // @Failure 500 {object} dto.Response "Internal server error"
 // @Router /unit/{unitId} [delete]
 func (uc *UnitController) DeleteUnit(c *gin.Context) {
 	unitId := c.Param("unitId")
 
-	err := uc.unitService.DeleteByID(unitId)
+	err := uc.unitService.DeleteByID(c.Request.Context(), unitId)
 	if err != nil {
 		c.Error(handler.NewError(err.Code, err.Message))
 		return
@@ -112,39 +156,169 @@ func (uc *UnitController) DeleteUnit(c *gin.Context) {
 	c.JSON(http.StatusOK, dto.BaseResponse(true, "OK", nil))
 }
 
-// @Summary Get List of Units
-// @Description Retrieve list of units with optional filtering and pagination
+// @Summary Get Units in Trash
+// @Description Retrieve deleted units that can still be restored, the most recently deleted first
 // @Tags Units
+// @Security BearerAuth
 // @Produce json
 // @Param page query int false "Page number (default 1)"
-// @Param size query int false "Number of items per page (default 10)"
+// @Param size query int false "Number of items per page (default 10, max 100)"
 // @Param name query string false "Filter by unit name"
-// @Param status query string false "Filter by unit status (Available, Occupied)"
-// @Param type query string false "Filter by unit type (capsule, cabin)"
-// @Success 200 {object} dto.Response{data=dto.PaginationResponse} "Successfully retrieved list of units"
+// @Success 200 {object} dto.Response{data=dto.PaginationResponse{content=[]response.DeletedUnitResponse}} "Successfully retrieved deleted units"
 // @Failure 400 {object} dto.Response "Bad request (invalid page/size parameter)"
+// @Failure 401 {object} dto.Response "Missing or invalid bearer token"
+// @Failure 403 {object} dto.Response "Role is not allowed to perform this action"
+// @Failure 500 {object} dto.Response "Internal server error"
+// @Router /unit/trash [get]
+func (uc *UnitController) GetTrash(c *gin.Context) {
+	nameStr := c.DefaultQuery("name", "")
+
+	page, size, errPagination := handler.ParsePagination(c)
+	if errPagination != nil {
+		c.Error(errPagination)
+		return
+	}
+
+	units, errUnits := uc.unitService.FindTrash(c.Request.Context(), nameStr, page, size)
+	if errUnits != nil {
+		c.Error(handler.NewError(errUnits.Code, errUnits.Message))
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.BaseResponse(true, "OK", units))
+}
+
+// @Summary Restore Unit from Trash
+// @Description Restore a deleted unit with the status it had when it was deleted
+// @Tags Units
+// @Security BearerAuth
+// @Produce json
+// @Param unitId path string true "Unit ID"
+// @Success 200 {object} dto.Response "Unit successfully restored"
+// @Header 200 {string} ETag "Version of the unit, send it back as If-Match when updating"
+// @Failure 404 {object} dto.Response "Unit not found in the trash"
+// @Failure 401 {object} dto.Response "Missing or invalid bearer token"
+// @Failure 403 {object} dto.Response "Role is not allowed to perform this action"
+// @Failure 500 {object} dto.Response "Internal server error"
+// @Router /unit/{unitId}/restore [post]
+func (uc *UnitController) RestoreUnit(c *gin.Context) {
+	unitId := c.Param("unitId")
+
+	unit, err := uc.unitService.RestoreByID(c.Request.Context(), unitId)
+	if err != nil {
+		c.Error(handler.NewError(err.Code, err.Message))
+		return
+	}
+
+	c.Header("ETag", handler.FormatETag(unit.Version))
+	c.JSON(http.StatusOK, dto.BaseResponse(true, "OK", unit))
+}
+
+// @Summary Purge Unit from Trash
+// @Description Permanently delete a unit in the trash together with its status history, units with bookings, housekeeping tasks or maintenance tickets cannot be purged
+// @Tags Units
+// @Security BearerAuth
+// @Produce json
+// @Param unitId path string true "Unit ID"
+// @Success 200 {object} dto.Response "Unit permanently deleted"
+// @Failure 404 {object} dto.Response "Unit not found in the trash"
+// @Failure 409 {object} dto.Response "Unit is still referenced by bookings, housekeeping tasks or maintenance tickets"
+// @Failure 401 {object} dto.Response "Missing or invalid bearer token"
+// @Failure 403 {object} dto.Response "Role is not allowed to perform this action"
+// @Failure 500 {object} dto.Response "Internal server error"
+// @Router /unit/{unitId}/purge [delete]
+func (uc *UnitController) PurgeUnit(c *gin.Context) {
+	unitId := c.Param("unitId")
+
+	err := uc.unitService.PurgeByID(c.Request.Context(), unitId)
+	if err != nil {
+		c.Error(handler.NewError(err.Code, err.Message))
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.BaseResponse(true, "OK", nil))
+}
+
+// @Summary Get List of Units
+// @Description Retrieve list of units with optional filtering, sorting and pagination.
+// @Description Status and type filters take several comma separated values, sort takes comma separated field:direction pairs.
+// @Description Sending cursor or limit switches to cursor pagination: the data holds nextCursor and prevCursor instead of page totals,
+// @Description pass them back as cursor with the same filters to move between pages. A cursor keeps the sort order it was issued with.
+// @Tags Units
+// @Security BearerAuth
+// @Produce json
+// @Param page query int false "Page number (default 1)"
+// @Param size query int false "Number of items per page (default 10, max 100)"
+// @Param name query string false "Filter by unit name"
+// @Param status query string false "Filter by unit status, comma separated (Available,Occupied,Cleaning In Progress,Maintenance Needed)"
+// @Param type query string false "Filter by unit type, comma separated (capsule,cabin)"
+// @Param updatedFrom query string false "Only units last updated at or after this RFC 3339 timestamp or YYYY-MM-DD date"
+// @Param updatedTo query string false "Only units last updated at or before this RFC 3339 timestamp or YYYY-MM-DD date (the whole day)"
+// @Param sort query string false "Sort fields name, type, status or lastUpdated with optional :asc or :desc, like lastUpdated:desc,name:asc (default name:asc)"
+// @Param cursor query string false "Cursor from nextCursor or prevCursor of the previous cursor page"
+// @Param limit query int false "Number of items per cursor page (default 10, max 100)"
+// @Success 200 {object} dto.Response{data=dto.PaginationResponse{content=[]response.UnitDetailResponse}} "Successfully retrieved list of units, a dto.CursorPaginationResponse in cursor mode"
+// @Failure 400 {object} dto.Response "Bad request (invalid page/size, limit, cursor, filter or sort parameter)"
+// @Failure 401 {object} dto.Response "Missing or invalid bearer token"
 // @Failure 500 {object} dto.Response "Internal server error"
 // @Router /unit [get]
 func (uc *UnitController) GetUnits(c *gin.Context) {
-	pageStr := c.DefaultQuery("page", "1")
-	sizeStr := c.DefaultQuery("size", "10")
-	nameStr := c.DefaultQuery("name", "")
-	statusStr := c.DefaultQuery("status", "")
-	typeStr := c.DefaultQuery("type", "")
+	cursorStr, isCursorMode := c.GetQuery("cursor")
+	limitStr, hasLimit := c.GetQuery("limit")
+	if isCursorMode || hasLimit {
+		uc.getUnitsByCursor(c, cursorStr, limitStr)
+		return
+	}
 
-	page, err := strconv.Atoi(pageStr)
+	page, size, errPagination := handler.ParsePagination(c)
+	if errPagination != nil {
+		c.Error(errPagination)
+		return
+	}
+
+	filter, err := parseUnitFilter(c.Request.URL.Query())
 	if err != nil {
-		c.Error(handler.NewError(http.StatusBadRequest, "invalid page parameter, must be number"))
+		c.Error(handler.NewError(http.StatusBadRequest, err.Error()))
 		return
 	}
 
-	size, err := strconv.Atoi(sizeStr)
+	units, errUnits := uc.unitService.FindUnits(c.Request.Context(), filter, page, size)
+	if errUnits != nil {
+		c.Error(handler.NewError(errUnits.Code, errUnits.Message))
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.BaseResponse(true, "OK", units))
+}
+
+// getUnitsByCursor serves the cursor mode of GetUnits
+func (uc *UnitController) getUnitsByCursor(c *gin.Context, cursorStr, limitStr string) {
+	if _, hasPage := c.GetQuery("page"); hasPage {
+		c.Error(handler.NewError(http.StatusBadRequest, "page cannot be combined with cursor or limit, use one pagination mode"))
+		return
+	}
+	if _, hasSize := c.GetQuery("size"); hasSize {
+		c.Error(handler.NewError(http.StatusBadRequest, "size cannot be combined with cursor or limit, use limit instead"))
+		return
+	}
+
+	limit := defaultCursorLimit
+	if !utils.IsEmptyString(limitStr) {
+		var err error
+		limit, err = strconv.Atoi(limitStr)
+		if err != nil || limit < 1 || limit > maxCursorLimit {
+			c.Error(handler.NewError(http.StatusBadRequest, fmt.Sprintf("invalid limit parameter, must be a number between 1 and %d", maxCursorLimit)))
+			return
+		}
+	}
+
+	filter, err := parseUnitFilter(c.Request.URL.Query())
 	if err != nil {
-		c.Error(handler.NewError(http.StatusBadRequest, "invalid size parameter, must be number"))
+		c.Error(handler.NewError(http.StatusBadRequest, err.Error()))
 		return
 	}
 
-	units, errUnits := uc.unitService.FindUnits(statusStr, typeStr, nameStr, page, size)
+	units, errUnits := uc.unitService.FindUnitsByCursor(c.Request.Context(), filter, cursorStr, limit)
 	if errUnits != nil {
 		c.Error(handler.NewError(errUnits.Code, errUnits.Message))
 		return
@@ -153,16 +327,157 @@ func (uc *UnitController) GetUnits(c *gin.Context) {
 	c.JSON(http.StatusOK, dto.BaseResponse(true, "OK", units))
 }
 
+// @Summary Export Units
+// @Description Download units matching the same filters and sort order as the unit list, the file can be imported back
+// @Tags Units
+// @Security BearerAuth
+// @Produce text/csv
+// @Param format query string false "Export format, only csv is supported (default csv)"
+// @Param name query string false "Filter by unit name"
+// @Param status query string false "Filter by unit status, comma separated (Available,Occupied,Cleaning In Progress,Maintenance Needed)"
+// @Param type query string false "Filter by unit type, comma separated (capsule,cabin)"
+// @Param updatedFrom query string false "Only units last updated at or after this RFC 3339 timestamp or YYYY-MM-DD date"
+// @Param updatedTo query string false "Only units last updated at or before this RFC 3339 timestamp or YYYY-MM-DD date (the whole day)"
+// @Param sort query string false "Sort fields name, type, status or lastUpdated with optional :asc or :desc (default name:asc)"
+// @Success 200 {file} file "CSV file with id, name, type, status, version and lastUpdated columns"
+// @Failure 400 {object} dto.Response "Bad request (unsupported format, invalid filter or sort parameter)"
+// @Failure 401 {object} dto.Response "Missing or invalid bearer token"
+// @Failure 500 {object} dto.Response "Internal server error"
+// @Router /unit/export [get]
+func (uc *UnitController) ExportUnits(c *gin.Context) {
+	formatStr := c.DefaultQuery("format", "csv")
+
+	if !strings.EqualFold(formatStr, "csv") {
+		c.Error(handler.NewError(http.StatusBadRequest, "unsupported export format, must be 'csv'"))
+		return
+	}
+
+	filter, err := parseUnitFilter(c.Request.URL.Query())
+	if err != nil {
+		c.Error(handler.NewError(http.StatusBadRequest, err.Error()))
+		return
+	}
+
+	units, errUnits := uc.unitService.ExportUnits(c.Request.Context(), filter)
+	if errUnits != nil {
+		c.Error(handler.NewError(errUnits.Code, errUnits.Message))
+		return
+	}
+
+	var buffer bytes.Buffer
+	if err := writeUnitsCSV(&buffer, units); err != nil {
+		c.Error(handler.NewError(http.StatusInternalServerError, err.Error()))
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=units-%s.csv", time.Now().Format("20060102")))
+	c.Data(http.StatusOK, "text/csv; charset=utf-8", buffer.Bytes())
+}
+
+// @Summary Get Unit Statistics
+// @Description Count the units by type and status, with the occupancy percentage of every type and the oldest
+// @Description last update of every status. Every type and status is listed, units in the trash are not counted
+// @Tags Units
+// @Security BearerAuth
+// @Produce json
+// @Success 200 {object} dto.Response{data=response.UnitStatsResponse} "Successfully retrieved unit statistics"
+// @Failure 401 {object} dto.Response "Missing or invalid bearer token"
+// @Failure 500 {object} dto.Response "Internal server error"
+// @Router /unit/stats [get]
+func (uc *UnitController) GetUnitStats(c *gin.Context) {
+	stats, err := uc.unitService.GetStats(c.Request.Context())
+	if err != nil {
+		c.Error(handler.NewError(err.Code, err.Message))
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.BaseResponse(true, "OK", stats))
+}
+
+// @Summary Import Units
+// @Description Create units from a CSV file with name, type and status columns (other columns are ignored).
+// @Description Every row is validated like a single unit creation, valid rows are created in one transaction and invalid rows are reported.
+// @Description The file is sent as the "file" field of a multipart form or as the raw request body.
+// @Tags Units
+// @Security BearerAuth
+// @Accept multipart/form-data,text/csv
+// @Produce json
+// @Param file formData file false "CSV file"
+// @Param dryRun query bool false "Only validate the file without creating units (default false)"
+// @Success 200 {object} dto.Response{data=response.ImportUnitsResponse} "Validation report, nothing was created"
+// @Success 201 {object} dto.Response{data=response.ImportUnitsResponse} "Units imported"
+// @Failure 400 {object} dto.Response "Bad request (missing file, invalid csv or header)"
+// @Failure 401 {object} dto.Response "Missing or invalid bearer token"
+// @Failure 403 {object} dto.Response "Role is not allowed to perform this action"
+// @Failure 500 {object} dto.Response "Internal server error"
+// @Router /unit/import [post]
+func (uc *UnitController) ImportUnits(c *gin.Context) {
+	dryRun, err := strconv.ParseBool(c.DefaultQuery("dryRun", "false"))
+	if err != nil {
+		c.Error(handler.NewError(http.StatusBadRequest, "invalid dryRun parameter, must be true or false"))
+		return
+	}
+
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxImportSize)
+
+	var reader io.Reader = c.Request.Body
+	if c.ContentType() == "multipart/form-data" {
+		fileHeader, errFile := c.FormFile("file")
+		if errFile != nil {
+			c.Error(handler.NewError(http.StatusBadRequest, "csv file is required in the 'file' field"))
+			return
+		}
+
+		file, errOpen := fileHeader.Open()
+		if errOpen != nil {
+			c.Error(handler.NewError(http.StatusInternalServerError, errOpen.Error()))
+			return
+		}
+		defer file.Close()
+		reader = file
+	}
+
+	rows, errRead := readUnitsCSV(reader)
+	if errRead != nil {
+		c.Error(handler.NewError(http.StatusBadRequest, errRead.Error()))
+		return
+	}
+
+	if len(rows) == 0 {
+		c.Error(handler.NewError(http.StatusBadRequest, "csv file has no unit rows"))
+		return
+	}
+
+	result, errImport := uc.unitService.ImportUnits(c.Request.Context(), rows, dryRun, handler.GetActor(c))
+	if errImport != nil {
+		c.Error(handler.NewError(errImport.Code, errImport.Message))
+		return
+	}
+
+	statusCode := http.StatusOK
+	if result.Imported > 0 {
+		statusCode = http.StatusCreated
+	}
+	c.JSON(statusCode, dto.BaseResponse(true, "OK", result))
+}
+
 // @Summary Update Unit
-// @Description Update existing units name, status or type.
+// @Description Update existing units name, status or type. The If-Match header must carry the ETag of the unit being edited.
 // @Tags Units
+// @Security BearerAuth
 // @Accept json
 // @Produce json
 // @Param unitId path string true "Unit ID"
+// @Param If-Match header string true "ETag from the last read of the unit, or * to overwrite any version"
 // @Param unit body request.UpdateUnitDto true "Unit update request"
 // @Success 200 {object} dto.Response "Unit successfully updated"
-// @Failure 400 {object} dto.Response "Bad request (missing required fields or invalid ID)"
+// @Header 200 {string} ETag "New version of the unit"
+// @Failure 400 {object} dto.Response{data=response.UnitTransitionErrorResponse} "Bad request (missing required fields, invalid ID or status transition not allowed)"
 // @Failure 404 {object} dto.Response "Unit not found"
+// @Failure 401 {object} dto.Response "Missing or invalid bearer token"
+// @Failure 403 {object} dto.Response "Role is not allowed to perform this action"
+// @Failure 412 {object} dto.Response{data=response.UnitDetailResponse} "Unit was modified since it was read, the current unit is returned, or If-Match carries a weak ETag"
+// @Failure 428 {object} dto.Response "If-Match header is required"
 // @Failure 500 {object} dto.Response "Internal server error"
 // @Router /unit/{unitId} [put]
 func (uc *UnitController) UpdateUnit(c *gin.Context) {
@@ -170,7 +485,7 @@ func (uc *UnitController) UpdateUnit(c *gin.Context) {
 
 	var body request.UpdateUnitDto
 	if err := c.ShouldBindJSON(&body); err != nil {
-		c.Error(handler.NewError(http.StatusInternalServerError, err.Error()))
+		c.Error(handler.NewBindError(err))
 		return
 	}
 
@@ -189,11 +504,231 @@ func (uc *UnitController) UpdateUnit(c *gin.Context) {
 		return
 	}
 
-	unit, errUnit := uc.unitService.Update(unitId, body)
+	ifMatch := c.GetHeader("If-Match")
+	if utils.IsEmptyString(ifMatch) {
+		c.Error(handler.NewError(http.StatusPreconditionRequired, "If-Match header is required, send the ETag of the unit being edited"))
+		return
+	}
+
+	expectedVersion, err := handler.ParseIfMatch(ifMatch)
+	if err != nil {
+		c.Error(handler.NewIfMatchError(err))
+		return
+	}
+
+	body.Actor = handler.GetActor(c)
+	body.ExpectedVersion = expectedVersion
+	unit, errUnit := uc.unitService.Update(c.Request.Context(), unitId, body)
+	if errUnit != nil {
+		if current, ok := errUnit.Data.(response.UnitDetailResponse); ok {
+			c.Header("ETag", handler.FormatETag(current.Version))
+		}
+		c.Error(errUnit)
+		return
+	}
+
+	c.Header("ETag", handler.FormatETag(unit.Version))
+	c.JSON(http.StatusOK, dto.BaseResponse(true, "OK", unit))
+}
+
+// @Summary Patch Unit
+// @Description Partially update a unit with a JSON Merge Patch (RFC 7396) or a JSON Patch (RFC 6902), chosen by the Content-Type header.
+// @Description The patch is applied to {"id", "name", "type", "status", "version", "reason"} and the result is validated like a full update.
+// @Description Without If-Match the patch is applied to the current version of the unit, a JSON Patch "test" operation can check fields instead.
+// @Description Housekeeping staff may only patch the status and reason.
+// @Tags Units
+// @Security BearerAuth
+// @Accept application/merge-patch+json,application/json-patch+json
+// @Produce json
+// @Param unitId path string true "Unit ID"
+// @Param If-Match header string false "ETag from the last read of the unit, or * to patch any version"
+// @Param unit body request.UpdateUnitDto true "Merge patch with the fields to change, or an array of JSON Patch operations"
+// @Success 200 {object} dto.Response "Unit successfully patched"
+// @Header 200 {string} ETag "New version of the unit"
+// @Failure 400 {object} dto.Response{data=response.UnitTransitionErrorResponse} "Bad request (invalid patch document, invalid field value or status transition not allowed)"
+// @Failure 401 {object} dto.Response "Missing or invalid bearer token"
+// @Failure 403 {object} dto.Response "Role is not allowed to perform this action or to patch these fields"
+// @Failure 404 {object} dto.Response "Unit not found"
+// @Failure 409 {object} dto.Response "JSON Patch test operation failed or unit is held by open maintenance tickets"
+// @Failure 412 {object} dto.Response{data=response.UnitDetailResponse} "Unit was modified since it was read, the current unit is returned, or If-Match carries a weak ETag"
+// @Failure 415 {object} dto.Response "Content type is not a supported patch format"
+// @Failure 422 {object} dto.Response "Patch cannot be applied (missing path, unknown or read-only field)"
+// @Failure 500 {object} dto.Response "Internal server error"
+// @Router /unit/{unitId} [patch]
+func (uc *UnitController) PatchUnit(c *gin.Context) {
+	unitId := c.Param("unitId")
+
+	body := request.PatchUnitDto{Format: c.ContentType()}
+
+	if ifMatch := c.GetHeader("If-Match"); !utils.IsEmptyString(ifMatch) {
+		expectedVersion, err := handler.ParseIfMatch(ifMatch)
+		if err != nil {
+			c.Error(handler.NewIfMatchError(err))
+			return
+		}
+		body.ExpectedVersion = expectedVersion
+	}
+
+	patch, err := io.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, maxPatchSize))
+	if err != nil {
+		c.Error(handler.NewError(http.StatusBadRequest, "patch document is too large or cannot be read"))
+		return
+	}
+
+	if claims, ok := handler.GetClaims(c); ok {
+		body.StatusOnly = claims.Role == enum.RoleHousekeeping
+	}
+	body.Patch = patch
+	body.Actor = handler.GetActor(c)
+	unit, errUnit := uc.unitService.Patch(c.Request.Context(), unitId, body)
+	if errUnit != nil {
+		if current, ok := errUnit.Data.(response.UnitDetailResponse); ok {
+			c.Header("ETag", handler.FormatETag(current.Version))
+		}
+		c.Error(errUnit)
+		return
+	}
+
+	c.Header("ETag", handler.FormatETag(unit.Version))
+	c.JSON(http.StatusOK, dto.BaseResponse(true, "OK", unit))
+}
+
+// @Summary Change Unit Status
+// @Description Move a unit to another status without resending its name and type
+// @Tags Units
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param unitId path string true "Unit ID"
+// @Param unit body request.ChangeUnitStatusDto true "Unit status change request"
+// @Success 200 {object} dto.Response "Unit status successfully changed"
+// @Header 200 {string} ETag "New version of the unit"
+// @Failure 400 {object} dto.Response{data=response.UnitTransitionErrorResponse} "Bad request (missing status or status transition not allowed)"
+// @Failure 401 {object} dto.Response "Missing or invalid bearer token"
+// @Failure 403 {object} dto.Response "Role is not allowed to perform this action"
+// @Failure 404 {object} dto.Response "Unit not found"
+// @Failure 409 {object} dto.Response "Unit already has the status or is held by open maintenance tickets"
+// @Failure 500 {object} dto.Response "Internal server error"
+// @Router /unit/{unitId}/status [put]
+func (uc *UnitController) ChangeUnitStatus(c *gin.Context) {
+	unitId := c.Param("unitId")
+
+	var body request.ChangeUnitStatusDto
+	if err := c.ShouldBindJSON(&body); err != nil {
+		c.Error(handler.NewBindError(err))
+		return
+	}
+
+	if utils.IsEmptyString(body.Status) {
+		c.Error(handler.NewError(http.StatusBadRequest, "unit status is required"))
+		return
+	}
+
+	body.Actor = handler.GetActor(c)
+	unit, errUnit := uc.unitService.ChangeStatus(c.Request.Context(), unitId, body)
 	if errUnit != nil {
-		c.Error(handler.NewError(errUnit.Code, errUnit.Message))
+		c.Error(errUnit)
 		return
 	}
 
+	c.Header("ETag", handler.FormatETag(unit.Version))
 	c.JSON(http.StatusOK, dto.BaseResponse(true, "OK", unit))
 }
+
+// @Summary Bulk Change Unit Status
+// @Description Move several units to the same status with the same transition rules as a single update.
+// @Description In atomic mode (default) either every unit is changed or none, in best_effort mode the valid units are changed and the others reported.
+// @Description Units already in the target status are skipped.
+// @Tags Units
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param units body request.BulkChangeUnitStatusDto true "Bulk status change request"
+// @Success 200 {object} dto.Response{data=response.BulkUnitStatusResponse} "Per unit result of the status change"
+// @Failure 400 {object} dto.Response "Bad request (missing unit ids, invalid status or mode, too many units)"
+// @Failure 401 {object} dto.Response "Missing or invalid bearer token"
+// @Failure 403 {object} dto.Response "Role is not allowed to perform this action"
+// @Failure 409 {object} dto.Response{data=response.BulkUnitStatusResponse} "Atomic change rolled back because some units cannot change status"
+// @Failure 500 {object} dto.Response "Internal server error"
+// @Router /unit/bulk/status [post]
+func (uc *UnitController) BulkChangeUnitStatus(c *gin.Context) {
+	var body request.BulkChangeUnitStatusDto
+	if err := c.ShouldBindJSON(&body); err != nil {
+		c.Error(handler.NewBindError(err))
+		return
+	}
+
+	if len(body.UnitIDs) == 0 {
+		c.Error(handler.NewError(http.StatusBadRequest, "unit ids are required"))
+		return
+	}
+
+	if utils.IsEmptyString(body.Status) {
+		c.Error(handler.NewError(http.StatusBadRequest, "unit status is required"))
+		return
+	}
+
+	body.Actor = handler.GetActor(c)
+	result, errBulk := uc.unitService.BulkChangeStatus(c.Request.Context(), body)
+	if errBulk != nil {
+		c.Error(errBulk)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.BaseResponse(true, "OK", result))
+}
+
+// @Summary Get Unit Status Transitions
+// @Description Retrieve the statuses a unit is allowed to move to from its current status
+// @Tags Units
+// @Security BearerAuth
+// @Produce json
+// @Param unitId path string true "Unit ID"
+// @Success 200 {object} dto.Response{data=response.UnitTransitionsResponse} "Successfully retrieved unit transitions"
+// @Failure 404 {object} dto.Response "Unit not found"
+// @Failure 401 {object} dto.Response "Missing or invalid bearer token"
+// @Failure 500 {object} dto.Response "Internal server error"
+// @Router /unit/{unitId}/transitions [get]
+func (uc *UnitController) GetUnitTransitions(c *gin.Context) {
+	unitId := c.Param("unitId")
+
+	transitions, err := uc.unitService.GetTransitionsByID(c.Request.Context(), unitId)
+	if err != nil {
+		c.Error(handler.NewError(err.Code, err.Message))
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.BaseResponse(true, "OK", transitions))
+}
+
+// @Summary Get Unit Status History
+// @Description Retrieve status transitions of a unit, newest first
+// @Tags Units
+// @Security BearerAuth
+// @Produce json
+// @Param unitId path string true "Unit ID"
+// @Param page query int false "Page number (default 1)"
+// @Param size query int false "Number of items per page (default 10, max 100)"
+// @Success 200 {object} dto.Response{data=dto.PaginationResponse{content=[]domain.UnitStatusHistory}} "Successfully retrieved unit status history"
+// @Failure 400 {object} dto.Response "Bad request (invalid page/size parameter)"
+// @Failure 404 {object} dto.Response "Unit not found"
+// @Failure 401 {object} dto.Response "Missing or invalid bearer token"
+// @Failure 500 {object} dto.Response "Internal server error"
+// @Router /unit/{unitId}/history [get]
+func (uc *UnitController) GetUnitStatusHistory(c *gin.Context) {
+	unitId := c.Param("unitId")
+
+	page, size, errPagination := handler.ParsePagination(c)
+	if errPagination != nil {
+		c.Error(errPagination)
+		return
+	}
+
+	histories, errHistory := uc.unitService.FindStatusHistory(c.Request.Context(), unitId, page, size)
+	if errHistory != nil {
+		c.Error(handler.NewError(errHistory.Code, errHistory.Message))
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.BaseResponse(true, "OK", histories))
+}
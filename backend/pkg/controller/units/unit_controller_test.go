@@ -0,0 +1,46 @@
+package units
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"unit-management-be/pkg/handler"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMalformedBody(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	uc := &UnitController{}
+	router := gin.New()
+	router.Use(handler.ErrorHandler())
+	router.POST("/unit", uc.CreateUnit)
+	router.PUT("/unit/:unitId", uc.UpdateUnit)
+	router.PUT("/unit/:unitId/status", uc.ChangeUnitStatus)
+
+	for _, route := range []struct{ method, path string }{
+		{http.MethodPost, "/unit"},
+		{http.MethodPut, "/unit/6f1d2c1e"},
+		{http.MethodPut, "/unit/6f1d2c1e/status"},
+	} {
+		t.Run("Negative Case: "+route.method+" "+route.path+" answers a bad request", func(t *testing.T) {
+			req := httptest.NewRequest(route.method, route.path, strings.NewReader(`{"name": "Cabin 1",`))
+			req.Header.Set("Content-Type", "application/json")
+			recorder := httptest.NewRecorder()
+			router.ServeHTTP(recorder, req)
+
+			assert.Equal(t, http.StatusBadRequest, recorder.Code)
+			var body struct {
+				Success bool   `json:"success"`
+				Message string `json:"message"`
+			}
+			require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &body))
+			assert.False(t, body.Success)
+			assert.Equal(t, "request body is not valid JSON, it ends unexpectedly", body.Message)
+		})
+	}
+}
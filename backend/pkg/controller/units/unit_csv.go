@@ -0,0 +1,129 @@
+package units
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+	"unit-management-be/pkg/model/domain"
+	"unit-management-be/pkg/model/dto/request"
+	"unit-management-be/pkg/utils"
+)
+
+const (
+	// maxImportRows keeps an import within a single reasonable transaction
+	maxImportRows = 5000
+	// maxImportSize is the largest accepted CSV upload, in bytes
+	maxImportSize = 5 << 20
+)
+
+var (
+	exportHeader   = []string{"id", "name", "type", "status", "version", "lastUpdated"}
+	requiredHeader = []string{"name", "type", "status"}
+)
+
+// writeUnitsCSV writes units with the export header, the file can be imported back as is. Cells starting
+// like a formula are escaped so a spreadsheet shows them as text
+func writeUnitsCSV(w io.Writer, units []domain.Units) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(exportHeader); err != nil {
+		return err
+	}
+
+	for _, unit := range units {
+		record := []string{
+			unit.ID.String(),
+			utils.EscapeCSVCell(unit.Name),
+			string(unit.Type),
+			string(unit.Status),
+			strconv.FormatInt(unit.Version, 10),
+			unit.LastUpdated.UTC().Format(time.RFC3339),
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+// readUnitsCSV reads the unit rows of an imported file, columns are matched by header name
+// in any order and unknown columns such as id are ignored, the formula escaping of the export is removed
+func readUnitsCSV(r io.Reader) ([]request.ImportUnitRowDto, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, errors.New("csv file is empty")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid csv header: %w", err)
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, column := range header {
+		column = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(column, "\ufeff")))
+		columns[column] = i
+	}
+
+	missing := make([]string, 0)
+	for _, column := range requiredHeader {
+		if _, exists := columns[column]; !exists {
+			missing = append(missing, column)
+		}
+	}
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("csv header must contain name, type and status columns, missing: %s", strings.Join(missing, ", "))
+	}
+
+	rows := make([]request.ImportUnitRowDto, 0)
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid csv content: %w", err)
+		}
+
+		if isBlankRecord(record) {
+			continue
+		}
+
+		if len(rows) == maxImportRows {
+			return nil, fmt.Errorf("csv file must contain at most %d units", maxImportRows)
+		}
+
+		line, _ := reader.FieldPos(0)
+		rows = append(rows, request.ImportUnitRowDto{
+			Row:    line,
+			Name:   field(record, columns["name"]),
+			Type:   field(record, columns["type"]),
+			Status: field(record, columns["status"]),
+		})
+	}
+
+	return rows, nil
+}
+
+func field(record []string, index int) string {
+	if index >= len(record) {
+		return ""
+	}
+	return utils.UnescapeCSVCell(record[index])
+}
+
+func isBlankRecord(record []string) bool {
+	for _, value := range record {
+		if strings.TrimSpace(value) != "" {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,70 @@
+package units
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"unit-management-be/pkg/model/domain"
+	"unit-management-be/pkg/model/domain/enum"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadUnitsCSV(t *testing.T) {
+	t.Run("Positive Case: Columns are matched by header in any order", func(t *testing.T) {
+		content := "\ufeffStatus, id ,Name,type\nAvailable,x,C-01,capsule\n\n\"Cleaning In Progress\",,\"Cabin, East\",cabin\n"
+
+		rows, err := readUnitsCSV(strings.NewReader(content))
+		assert.NoError(t, err)
+		assert.Len(t, rows, 2)
+		assert.Equal(t, 2, rows[0].Row)
+		assert.Equal(t, "C-01", rows[0].Name)
+		assert.Equal(t, 4, rows[1].Row)
+		assert.Equal(t, "Cabin, East", rows[1].Name)
+		assert.Equal(t, "cabin", rows[1].Type)
+		assert.Equal(t, "Cleaning In Progress", rows[1].Status)
+	})
+
+	t.Run("Positive Case: Exported file can be read back", func(t *testing.T) {
+		var buffer bytes.Buffer
+		units := []domain.Units{{ID: uuid.New(), Name: "C-01", Type: enum.Capsule, Status: enum.Available, Version: 2, LastUpdated: time.Now()}}
+		assert.NoError(t, writeUnitsCSV(&buffer, units))
+
+		rows, err := readUnitsCSV(&buffer)
+		assert.NoError(t, err)
+		assert.Len(t, rows, 1)
+		assert.Equal(t, "C-01", rows[0].Name)
+		assert.Equal(t, "capsule", rows[0].Type)
+		assert.Equal(t, "Available", rows[0].Status)
+	})
+
+	t.Run("Positive Case: Names starting like a formula are escaped on export and restored on import", func(t *testing.T) {
+		var buffer bytes.Buffer
+		units := []domain.Units{
+			{ID: uuid.New(), Name: "=HYPERLINK(\"x\")", Type: enum.Capsule, Status: enum.Available, LastUpdated: time.Now()},
+			{ID: uuid.New(), Name: "-C-02", Type: enum.Cabin, Status: enum.Available, LastUpdated: time.Now()},
+		}
+		assert.NoError(t, writeUnitsCSV(&buffer, units))
+		assert.Contains(t, buffer.String(), "'=HYPERLINK")
+		assert.Contains(t, buffer.String(), "'-C-02")
+
+		rows, err := readUnitsCSV(&buffer)
+		assert.NoError(t, err)
+		assert.Len(t, rows, 2)
+		assert.Equal(t, "=HYPERLINK(\"x\")", rows[0].Name)
+		assert.Equal(t, "-C-02", rows[1].Name)
+	})
+
+	t.Run("Negative Case: Missing required column", func(t *testing.T) {
+		_, err := readUnitsCSV(strings.NewReader("name,type\nC-01,capsule\n"))
+		assert.ErrorContains(t, err, "missing: status")
+	})
+
+	t.Run("Negative Case: Empty file", func(t *testing.T) {
+		_, err := readUnitsCSV(strings.NewReader(""))
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,102 @@
+package units
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+	"unit-management-be/pkg/handler"
+	"unit-management-be/pkg/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// resetEvent tells the client that events were missed and its units must be reloaded
+	resetEvent = "reset"
+	// eventRetry is how long a browser waits before reconnecting a dropped stream, in milliseconds
+	eventRetry = 3000
+)
+
+// eventHeartbeat is how often a comment is sent on an idle stream so proxies keep it open
+var eventHeartbeat = 15 * time.Second
+
+// @Summary Stream Unit Events
+// @Description Server-sent events for units created, updated, deleted or restored by anyone. Each event carries the unit
+// @Description in its data and an id, a client reconnecting with the Last-Event-ID header first receives the events it missed.
+// @Description When they are no longer buffered a 'reset' event is sent instead and the client should reload the units
+// @Tags Units
+// @Security BearerAuth
+// @Produce text/event-stream
+// @Param Last-Event-ID header string false "Id of the last event received"
+// @Success 200 {object} response.UnitDetailResponse "Stream of unit.created, unit.updated, unit.deleted and unit.restored events"
+// @Failure 400 {object} dto.Response "Bad request (invalid Last-Event-ID header)"
+// @Failure 401 {object} dto.Response "Missing or invalid bearer token"
+// @Router /unit/events [get]
+func (uc *UnitController) StreamUnitEvents(c *gin.Context) {
+	var lastEventID *uint64
+	if lastEventIDStr := c.GetHeader("Last-Event-ID"); !utils.IsEmptyString(lastEventIDStr) {
+		parsedLastEventID, err := strconv.ParseUint(lastEventIDStr, 10, 64)
+		if err != nil {
+			c.Error(handler.NewError(http.StatusBadRequest, "invalid Last-Event-ID header, must be the id of a received event"))
+			return
+		}
+		lastEventID = &parsedLastEventID
+	}
+
+	subscription, replay, missed := uc.unitEvents.Subscribe(lastEventID)
+	defer subscription.Close()
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	// keeps reverse proxies such as nginx from buffering the stream
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+
+	if _, err := fmt.Fprintf(c.Writer, "retry: %d\n\n", eventRetry); err != nil {
+		return
+	}
+
+	if missed {
+		if err := writeEvent(c.Writer, subscription.LastEventID, resetEvent, []byte("{}")); err != nil {
+			return
+		}
+	}
+
+	for _, event := range replay {
+		if err := writeEvent(c.Writer, event.ID, event.Type, event.Data); err != nil {
+			return
+		}
+	}
+	c.Writer.Flush()
+
+	heartbeat := time.NewTicker(eventHeartbeat)
+	defer heartbeat.Stop()
+	for {
+		select {
+		case <-c.Request.Context().Done():
+			return
+		case event, isOpen := <-subscription.Events():
+			// the subscription is dropped when the client falls behind, it reconnects with its last event id
+			if !isOpen {
+				return
+			}
+			if err := writeEvent(c.Writer, event.ID, event.Type, event.Data); err != nil {
+				return
+			}
+		case <-heartbeat.C:
+			if _, err := io.WriteString(c.Writer, ": keep-alive\n\n"); err != nil {
+				return
+			}
+		}
+		c.Writer.Flush()
+	}
+}
+
+// writeEvent writes one server-sent event, data is a single line of JSON
+func writeEvent(w io.Writer, id uint64, eventType string, data []byte) error {
+	_, err := fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", id, eventType, data)
+	return err
+}
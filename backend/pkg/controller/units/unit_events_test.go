@@ -0,0 +1,119 @@
+package units
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"unit-management-be/pkg/events"
+	"unit-management-be/pkg/handler"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func setupEventServer(t *testing.T) (*events.Broker, *httptest.Server) {
+	gin.SetMode(gin.TestMode)
+	broker := events.NewBroker(3)
+
+	router := gin.New()
+	router.Use(handler.ErrorHandler())
+	router.GET("/unit/events", (&UnitController{unitEvents: broker}).StreamUnitEvents)
+
+	server := httptest.NewServer(router)
+	t.Cleanup(server.Close)
+	return broker, server
+}
+
+// openEventStream connects to the stream, the connection is closed when the test ends
+func openEventStream(t *testing.T, server *httptest.Server, lastEventID string) (*http.Response, *bufio.Scanner) {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+"/unit/events", nil)
+	assert.NoError(t, err)
+	if lastEventID != "" {
+		req.Header.Set("Last-Event-ID", lastEventID)
+	}
+
+	res, err := http.DefaultClient.Do(req)
+	assert.NoError(t, err)
+	t.Cleanup(func() { res.Body.Close() })
+	return res, bufio.NewScanner(res.Body)
+}
+
+// readEvents returns the next count events of the stream as "id event data"
+func readEvents(t *testing.T, scanner *bufio.Scanner, count int) []string {
+	received := make([]string, 0, count)
+	fields := make([]string, 0, 3)
+	for len(received) < count && scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case line == "":
+			if len(fields) > 0 {
+				received = append(received, strings.Join(fields, " "))
+				fields = fields[:0]
+			}
+		case strings.HasPrefix(line, "id: "), strings.HasPrefix(line, "event: "), strings.HasPrefix(line, "data: "):
+			_, value, _ := strings.Cut(line, ": ")
+			fields = append(fields, value)
+		}
+	}
+
+	assert.Len(t, received, count)
+	return received
+}
+
+func publishUnitEvents(t *testing.T, broker *events.Broker, count int) {
+	for i := 0; i < count; i++ {
+		_, err := broker.Publish("unit.updated", map[string]int{"version": i + 1})
+		assert.NoError(t, err)
+	}
+}
+
+func TestStreamUnitEvents(t *testing.T) {
+	t.Run("Positive Case: Published events are streamed", func(t *testing.T) {
+		broker, server := setupEventServer(t)
+
+		res, scanner := openEventStream(t, server, "")
+		assert.Equal(t, http.StatusOK, res.StatusCode)
+		assert.Equal(t, "text/event-stream", res.Header.Get("Content-Type"))
+
+		publishUnitEvents(t, broker, 2)
+		assert.Equal(t, []string{
+			`1 unit.updated {"version":1}`,
+			`2 unit.updated {"version":2}`,
+		}, readEvents(t, scanner, 2))
+	})
+
+	t.Run("Positive Case: Missed events are replayed after Last-Event-ID", func(t *testing.T) {
+		broker, server := setupEventServer(t)
+		publishUnitEvents(t, broker, 3)
+
+		_, scanner := openEventStream(t, server, "1")
+		publishUnitEvents(t, broker, 1)
+		assert.Equal(t, []string{
+			`2 unit.updated {"version":2}`,
+			`3 unit.updated {"version":3}`,
+			`4 unit.updated {"version":1}`,
+		}, readEvents(t, scanner, 3))
+	})
+
+	t.Run("Negative Case: Reset is sent when missed events are no longer buffered", func(t *testing.T) {
+		broker, server := setupEventServer(t)
+		publishUnitEvents(t, broker, 5)
+
+		_, scanner := openEventStream(t, server, "1")
+		assert.Equal(t, []string{fmt.Sprintf("5 %s {}", resetEvent)}, readEvents(t, scanner, 1))
+	})
+
+	t.Run("Negative Case: Invalid Last-Event-ID header", func(t *testing.T) {
+		_, server := setupEventServer(t)
+
+		res, _ := openEventStream(t, server, "latest")
+		assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+	})
+}
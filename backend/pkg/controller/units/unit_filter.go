@@ -0,0 +1,123 @@
+package units
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+	"unit-management-be/pkg/model/domain/enum"
+	"unit-management-be/pkg/model/dto/request"
+	"unit-management-be/pkg/utils"
+)
+
+// dateLayout is accepted by the updatedFrom and updatedTo filters next to RFC 3339 timestamps
+const dateLayout = "2006-01-02"
+
+// parseUnitFilter reads the filters and sort order shared by the unit listing and export.
+// Filters take comma separated values, either in one parameter or repeated,
+// sort takes fields with an optional direction, like "lastUpdated:desc,name:asc"
+func parseUnitFilter(query url.Values) (request.UnitFilterDto, error) {
+	filter := request.UnitFilterDto{Name: query.Get("name")}
+
+	for _, value := range queryValues(query, "status") {
+		status, isValidStatus := enum.ParseUnitStatus(value)
+		if !isValidStatus {
+			return filter, fmt.Errorf("invalid status filter '%s', must be one of 'Available', 'Occupied', 'Cleaning In Progress', 'Maintenance Needed'", value)
+		}
+		filter.Statuses = append(filter.Statuses, status)
+	}
+
+	for _, value := range queryValues(query, "type") {
+		unitType, isValidUnitType := enum.ParseUnitType(value)
+		if !isValidUnitType {
+			return filter, fmt.Errorf("invalid type filter '%s', must be 'cabin' or 'capsule'", value)
+		}
+		filter.Types = append(filter.Types, unitType)
+	}
+
+	var err error
+	if filter.UpdatedFrom, err = parseUpdatedBound(query.Get("updatedFrom"), "updatedFrom", false); err != nil {
+		return filter, err
+	}
+	if filter.UpdatedTo, err = parseUpdatedBound(query.Get("updatedTo"), "updatedTo", true); err != nil {
+		return filter, err
+	}
+	if filter.UpdatedFrom != nil && filter.UpdatedTo != nil && filter.UpdatedFrom.After(*filter.UpdatedTo) {
+		return filter, fmt.Errorf("updatedFrom must not be after updatedTo")
+	}
+
+	if filter.Sort, err = parseUnitSort(queryValues(query, "sort")); err != nil {
+		return filter, err
+	}
+
+	return filter, nil
+}
+
+// queryValues splits every value of the parameter on commas and drops blank entries
+func queryValues(query url.Values, key string) []string {
+	values := make([]string, 0)
+	for _, parameter := range query[key] {
+		for _, value := range strings.Split(parameter, ",") {
+			value = strings.TrimSpace(value)
+			if !utils.IsEmptyString(value) {
+				values = append(values, value)
+			}
+		}
+	}
+
+	return values
+}
+
+// parseUpdatedBound reads a bound of the last updated range, a date covers the whole day
+// so a date used as upper bound ends at the last instant of that day
+func parseUpdatedBound(value, parameter string, endOfDay bool) (*time.Time, error) {
+	if utils.IsEmptyString(value) {
+		return nil, nil
+	}
+
+	if bound, err := time.Parse(time.RFC3339Nano, value); err == nil {
+		// units are stamped with the server time, comparing in the same zone keeps text timestamps ordered
+		bound = bound.Local()
+		return &bound, nil
+	}
+
+	bound, err := time.ParseInLocation(dateLayout, value, time.Local)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s parameter, must be an RFC 3339 timestamp or a YYYY-MM-DD date", parameter)
+	}
+	if endOfDay {
+		bound = bound.AddDate(0, 0, 1).Add(-time.Nanosecond)
+	}
+
+	return &bound, nil
+}
+
+// parseUnitSort reads "field" or "field:direction" entries, only whitelisted fields can be sorted on
+func parseUnitSort(values []string) ([]request.UnitSortDto, error) {
+	sort := make([]request.UnitSortDto, 0, len(values))
+	seen := make(map[enum.UnitSortField]bool, len(values))
+	for _, value := range values {
+		name, direction, _ := strings.Cut(value, ":")
+
+		field, isValidField := enum.ParseUnitSortField(strings.TrimSpace(name))
+		if !isValidField {
+			return nil, fmt.Errorf("invalid sort field '%s', must be one of 'name', 'type', 'status', 'lastUpdated'", name)
+		}
+
+		if seen[field] {
+			return nil, fmt.Errorf("sort field '%s' is given more than once", field)
+		}
+		seen[field] = true
+
+		switch strings.ToLower(strings.TrimSpace(direction)) {
+		case "", "asc":
+			sort = append(sort, request.UnitSortDto{Field: field})
+		case "desc":
+			sort = append(sort, request.UnitSortDto{Field: field, Descending: true})
+		default:
+			return nil, fmt.Errorf("invalid sort direction '%s' for '%s', must be 'asc' or 'desc'", direction, field)
+		}
+	}
+
+	return sort, nil
+}
@@ -0,0 +1,82 @@
+package units
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"unit-management-be/pkg/model/domain/enum"
+	"unit-management-be/pkg/model/dto/request"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseUnitFilter(t *testing.T) {
+	t.Run("Positive Case: Empty query matches every unit", func(t *testing.T) {
+		filter, err := parseUnitFilter(url.Values{})
+		assert.NoError(t, err)
+		assert.Empty(t, filter.Statuses)
+		assert.Empty(t, filter.Types)
+		assert.Nil(t, filter.UpdatedFrom)
+		assert.Nil(t, filter.UpdatedTo)
+		assert.Empty(t, filter.Sort)
+	})
+
+	t.Run("Positive Case: Multi-value filters are comma separated or repeated", func(t *testing.T) {
+		query := url.Values{
+			"status": {"Occupied, Maintenance Needed", "Available"},
+			"type":   {"cabin,"},
+			"name":   {"East"},
+		}
+
+		filter, err := parseUnitFilter(query)
+		assert.NoError(t, err)
+		assert.Equal(t, []enum.UnitStatus{enum.Occupied, enum.MaintenanceNeeded, enum.Available}, filter.Statuses)
+		assert.Equal(t, []enum.UnitType{enum.Cabin}, filter.Types)
+		assert.Equal(t, "East", filter.Name)
+	})
+
+	t.Run("Positive Case: Sort fields keep their order and direction", func(t *testing.T) {
+		filter, err := parseUnitFilter(url.Values{"sort": {"lastUpdated:desc,name:ASC,status"}})
+		assert.NoError(t, err)
+		assert.Equal(t, []request.UnitSortDto{
+			{Field: enum.SortUnitLastUpdated, Descending: true},
+			{Field: enum.SortUnitName},
+			{Field: enum.SortUnitStatus},
+		}, filter.Sort)
+	})
+
+	t.Run("Positive Case: Updated range accepts timestamps and whole days", func(t *testing.T) {
+		filter, err := parseUnitFilter(url.Values{"updatedFrom": {"2025-03-10T08:30:00Z"}, "updatedTo": {"2025-03-12"}})
+		assert.NoError(t, err)
+		assert.True(t, filter.UpdatedFrom.Equal(time.Date(2025, time.March, 10, 8, 30, 0, 0, time.UTC)))
+
+		endOfDay := time.Date(2025, time.March, 13, 0, 0, 0, 0, time.Local).Add(-time.Nanosecond)
+		assert.True(t, filter.UpdatedTo.Equal(endOfDay))
+
+		filter, err = parseUnitFilter(url.Values{"updatedFrom": {"2025-03-12"}, "updatedTo": {"2025-03-12"}})
+		assert.NoError(t, err)
+		assert.True(t, filter.UpdatedFrom.Before(*filter.UpdatedTo))
+	})
+
+	t.Run("Negative Case: Invalid values are rejected", func(t *testing.T) {
+		testCases := []struct {
+			query   url.Values
+			message string
+		}{
+			{url.Values{"status": {"Available,Dirty"}}, "invalid status filter 'Dirty'"},
+			{url.Values{"type": {"suite"}}, "invalid type filter 'suite'"},
+			{url.Values{"updatedFrom": {"yesterday"}}, "invalid updatedFrom parameter"},
+			{url.Values{"updatedTo": {"2025-13-01"}}, "invalid updatedTo parameter"},
+			{url.Values{"updatedFrom": {"2025-03-12"}, "updatedTo": {"2025-03-11"}}, "updatedFrom must not be after updatedTo"},
+			{url.Values{"sort": {"id"}}, "invalid sort field 'id'"},
+			{url.Values{"sort": {"name:up"}}, "invalid sort direction 'up' for 'name'"},
+			{url.Values{"sort": {"name,name:desc"}}, "sort field 'name' is given more than once"},
+		}
+
+		for _, testCase := range testCases {
+			_, err := parseUnitFilter(testCase.query)
+			assert.ErrorContains(t, err, testCase.message, testCase.query.Encode())
+		}
+	})
+}
@@ -0,0 +1,174 @@
+package users
+
+import (
+	"net/http"
+	"unit-management-be/pkg/handler"
+	"unit-management-be/pkg/model/domain/enum"
+	"unit-management-be/pkg/model/dto"
+	"unit-management-be/pkg/model/dto/request"
+	userService "unit-management-be/pkg/service/users"
+	"unit-management-be/pkg/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+type UserController struct {
+	userService userService.UserService
+}
+
+func NewUserController(userService userService.UserService) *UserController {
+	return &UserController{userService: userService}
+}
+
+// SetupAuthRoutes registers the routes reachable without a token
+func SetupAuthRoutes(r *gin.RouterGroup, uc *UserController) {
+	r.POST("/auth/login", uc.Login)
+}
+
+func SetupUserRoutes(r *gin.RouterGroup, uc *UserController) {
+	r.GET("/auth/me", uc.GetCurrentUser)
+
+	userGroup := r.Group("/user", handler.RequireRoles(enum.RoleAdmin))
+	userGroup.POST("", uc.CreateUser)
+	userGroup.GET("", uc.GetUsers)
+}
+
+// @Summary Login
+// @Description Exchange username and password for a signed access token
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param credentials body request.LoginDto true "Login request"
+// @Success 200 {object} dto.Response{data=response.LoginResponse} "Successfully logged in"
+// @Failure 400 {object} dto.Response "Bad request: Missing username or password"
+// @Failure 401 {object} dto.Response "Invalid username or password"
+// @Failure 500 {object} dto.Response "Internal server error"
+// @Router /auth/login [post]
+func (uc *UserController) Login(c *gin.Context) {
+	var body request.LoginDto
+	if err := c.ShouldBindJSON(&body); err != nil {
+		c.Error(handler.NewBindError(err))
+		return
+	}
+
+	if utils.IsEmptyString(body.Username) {
+		c.Error(handler.NewError(http.StatusBadRequest, "username is required"))
+		return
+	}
+
+	if utils.IsEmptyString(body.Password) {
+		c.Error(handler.NewError(http.StatusBadRequest, "password is required"))
+		return
+	}
+
+	login, err := uc.userService.Login(c.Request.Context(), body)
+	if err != nil {
+		c.Error(handler.NewError(err.Code, err.Message))
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.BaseResponse(true, "OK", login))
+}
+
+// @Summary Get Current User
+// @Description Retrieve the user owning the access token
+// @Tags Auth
+// @Security BearerAuth
+// @Produce json
+// @Success 200 {object} dto.Response{data=domain.User} "Successfully retrieved current user"
+// @Failure 401 {object} dto.Response "Missing or invalid bearer token"
+// @Failure 404 {object} dto.Response "User not found"
+// @Failure 500 {object} dto.Response "Internal server error"
+// @Router /auth/me [get]
+func (uc *UserController) GetCurrentUser(c *gin.Context) {
+	claims, isAuthenticated := handler.GetClaims(c)
+	if !isAuthenticated {
+		c.Error(handler.NewError(http.StatusUnauthorized, "missing bearer token"))
+		return
+	}
+
+	user, err := uc.userService.FindByID(c.Request.Context(), claims.Subject)
+	if err != nil {
+		c.Error(handler.NewError(err.Code, err.Message))
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.BaseResponse(true, "OK", user))
+}
+
+// @Summary Create User
+// @Description Create staff account with username, password and role
+// @Tags Users
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param user body request.CreateUserDto true "User creation request"
+// @Success 201 {object} dto.Response{data=domain.User} "User created successfully"
+// @Failure 400 {object} dto.Response "Bad request: Missing required fields, weak password or invalid role"
+// @Failure 401 {object} dto.Response "Missing or invalid bearer token"
+// @Failure 403 {object} dto.Response "Role is not allowed to perform this action"
+// @Failure 409 {object} dto.Response "Username is already taken"
+// @Failure 500 {object} dto.Response "Internal server error"
+// @Router /user [post]
+func (uc *UserController) CreateUser(c *gin.Context) {
+	var body request.CreateUserDto
+	if err := c.ShouldBindJSON(&body); err != nil {
+		c.Error(handler.NewBindError(err))
+		return
+	}
+
+	if utils.IsEmptyString(body.Username) {
+		c.Error(handler.NewError(http.StatusBadRequest, "username is required"))
+		return
+	}
+
+	if utils.IsEmptyString(body.Password) {
+		c.Error(handler.NewError(http.StatusBadRequest, "password is required"))
+		return
+	}
+
+	if utils.IsEmptyString(body.Role) {
+		c.Error(handler.NewError(http.StatusBadRequest, "user role is required"))
+		return
+	}
+
+	user, err := uc.userService.CreateUser(c.Request.Context(), body)
+	if err != nil {
+		c.Error(handler.NewError(err.Code, err.Message))
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.BaseResponse(true, "OK", user))
+}
+
+// @Summary Get List of Users
+// @Description Retrieve staff accounts with optional role filter and pagination
+// @Tags Users
+// @Security BearerAuth
+// @Produce json
+// @Param page query int false "Page number (default 1)"
+// @Param size query int false "Number of items per page (default 10, max 100)"
+// @Param role query string false "Filter by role (admin, front_desk, housekeeping, read_only)"
+// @Success 200 {object} dto.Response{data=dto.PaginationResponse{content=[]domain.User}} "Successfully retrieved list of users"
+// @Failure 400 {object} dto.Response "Bad request (invalid page/size/role parameter)"
+// @Failure 401 {object} dto.Response "Missing or invalid bearer token"
+// @Failure 403 {object} dto.Response "Role is not allowed to perform this action"
+// @Failure 500 {object} dto.Response "Internal server error"
+// @Router /user [get]
+func (uc *UserController) GetUsers(c *gin.Context) {
+	roleStr := c.DefaultQuery("role", "")
+
+	page, size, errPagination := handler.ParsePagination(c)
+	if errPagination != nil {
+		c.Error(errPagination)
+		return
+	}
+
+	users, errUsers := uc.userService.FindUsers(c.Request.Context(), roleStr, page, size)
+	if errUsers != nil {
+		c.Error(handler.NewError(errUsers.Code, errUsers.Message))
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.BaseResponse(true, "OK", users))
+}
@@ -0,0 +1,268 @@
+package webhooks
+
+import (
+	"net/http"
+	"unit-management-be/pkg/handler"
+	"unit-management-be/pkg/model/domain/enum"
+	"unit-management-be/pkg/model/dto"
+	"unit-management-be/pkg/model/dto/request"
+	webhookService "unit-management-be/pkg/service/webhooks"
+	"unit-management-be/pkg/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+type WebhookController struct {
+	webhookService webhookService.WebhookService
+}
+
+func NewWebhookController(webhookService webhookService.WebhookService) *WebhookController {
+	return &WebhookController{webhookService: webhookService}
+}
+
+func SetupWebhookRoutes(r *gin.RouterGroup, wc *WebhookController) {
+	webhookGroup := r.Group("/webhook", handler.RequireRoles(enum.RoleAdmin))
+	webhookGroup.POST("", wc.CreateWebhook)
+	webhookGroup.GET("", wc.GetWebhooks)
+	webhookGroup.GET("/deliveries", wc.GetDeliveries)
+	webhookGroup.POST("/deliveries/:deliveryId/replay", wc.ReplayDelivery)
+	webhookGroup.GET("/:webhookId", wc.GetDetailWebhookByID)
+	webhookGroup.PUT("/:webhookId", wc.UpdateWebhook)
+	webhookGroup.DELETE("/:webhookId", wc.DeleteWebhook)
+	webhookGroup.POST("/:webhookId/replay", wc.ReplayDeadLetters)
+}
+
+// @Summary Create Webhook
+// @Description Subscribe a URL to unit events, every delivery is signed with the secret (HMAC-SHA256)
+// @Tags Webhooks
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param webhook body request.CreateWebhookDto true "Webhook creation request, events default to every event"
+// @Success 201 {object} dto.Response{data=response.WebhookResponse} "Webhook created successfully"
+// @Failure 400 {object} dto.Response "Bad request: Invalid url, unknown event or secret too short"
+// @Failure 401 {object} dto.Response "Missing or invalid bearer token"
+// @Failure 403 {object} dto.Response "Role is not allowed to perform this action"
+// @Failure 500 {object} dto.Response "Internal server error"
+// @Router /webhook [post]
+func (wc *WebhookController) CreateWebhook(c *gin.Context) {
+	var body request.CreateWebhookDto
+	if err := c.ShouldBindJSON(&body); err != nil {
+		c.Error(handler.NewBindError(err))
+		return
+	}
+
+	if utils.IsEmptyString(body.URL) {
+		c.Error(handler.NewError(http.StatusBadRequest, "webhook url is required"))
+		return
+	}
+
+	if utils.IsEmptyString(body.Secret) {
+		c.Error(handler.NewError(http.StatusBadRequest, "webhook secret is required"))
+		return
+	}
+
+	body.CreatedBy = handler.GetActor(c)
+	webhook, errWebhook := wc.webhookService.CreateWebhook(c.Request.Context(), body)
+	if errWebhook != nil {
+		c.Error(handler.NewError(errWebhook.Code, errWebhook.Message))
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.BaseResponse(true, "OK", webhook))
+}
+
+// @Summary Get List of Webhooks
+// @Description Retrieve webhook subscriptions with pagination, secrets are never returned
+// @Tags Webhooks
+// @Security BearerAuth
+// @Produce json
+// @Param page query int false "Page number (default 1)"
+// @Param size query int false "Number of items per page (default 10, max 100)"
+// @Success 200 {object} dto.Response{data=dto.PaginationResponse{content=[]response.WebhookResponse}} "Successfully retrieved list of webhooks"
+// @Failure 400 {object} dto.Response "Bad request (invalid page/size parameter)"
+// @Failure 401 {object} dto.Response "Missing or invalid bearer token"
+// @Failure 403 {object} dto.Response "Role is not allowed to perform this action"
+// @Failure 500 {object} dto.Response "Internal server error"
+// @Router /webhook [get]
+func (wc *WebhookController) GetWebhooks(c *gin.Context) {
+	page, size, errPagination := handler.ParsePagination(c)
+	if errPagination != nil {
+		c.Error(errPagination)
+		return
+	}
+
+	webhooks, errWebhooks := wc.webhookService.FindWebhooks(c.Request.Context(), page, size)
+	if errWebhooks != nil {
+		c.Error(handler.NewError(errWebhooks.Code, errWebhooks.Message))
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.BaseResponse(true, "OK", webhooks))
+}
+
+// @Summary Get Webhook Detail by ID
+// @Description Retrieve details of specific webhook subscription using its ID
+// @Tags Webhooks
+// @Security BearerAuth
+// @Produce json
+// @Param webhookId path string true "Webhook ID"
+// @Success 200 {object} dto.Response{data=response.WebhookResponse} "Successfully retrieved webhook detail"
+// @Failure 404 {object} dto.Response "Webhook not found"
+// @Failure 401 {object} dto.Response "Missing or invalid bearer token"
+// @Failure 403 {object} dto.Response "Role is not allowed to perform this action"
+// @Failure 500 {object} dto.Response "Internal server error"
+// @Router /webhook/{webhookId} [get]
+func (wc *WebhookController) GetDetailWebhookByID(c *gin.Context) {
+	webhookId := c.Param("webhookId")
+
+	webhook, err := wc.webhookService.GetWebhookByID(c.Request.Context(), webhookId)
+	if err != nil {
+		c.Error(handler.NewError(err.Code, err.Message))
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.BaseResponse(true, "OK", webhook))
+}
+
+// @Summary Update Webhook
+// @Description Change the url, events or state of a webhook, an empty secret keeps the current one
+// @Tags Webhooks
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param webhookId path string true "Webhook ID"
+// @Param webhook body request.UpdateWebhookDto true "Webhook update request"
+// @Success 200 {object} dto.Response{data=response.WebhookResponse} "Webhook successfully updated"
+// @Failure 400 {object} dto.Response "Bad request: Invalid url, unknown event or secret too short"
+// @Failure 404 {object} dto.Response "Webhook not found"
+// @Failure 401 {object} dto.Response "Missing or invalid bearer token"
+// @Failure 403 {object} dto.Response "Role is not allowed to perform this action"
+// @Failure 500 {object} dto.Response "Internal server error"
+// @Router /webhook/{webhookId} [put]
+func (wc *WebhookController) UpdateWebhook(c *gin.Context) {
+	webhookId := c.Param("webhookId")
+
+	var body request.UpdateWebhookDto
+	if err := c.ShouldBindJSON(&body); err != nil {
+		c.Error(handler.NewBindError(err))
+		return
+	}
+
+	if utils.IsEmptyString(body.URL) {
+		c.Error(handler.NewError(http.StatusBadRequest, "webhook url is required"))
+		return
+	}
+
+	webhook, errWebhook := wc.webhookService.UpdateWebhook(c.Request.Context(), webhookId, body)
+	if errWebhook != nil {
+		c.Error(handler.NewError(errWebhook.Code, errWebhook.Message))
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.BaseResponse(true, "OK", webhook))
+}
+
+// @Summary Delete Webhook
+// @Description Delete a webhook subscription together with its deliveries
+// @Tags Webhooks
+// @Security BearerAuth
+// @Produce json
+// @Param webhookId path string true "Webhook ID"
+// @Success 200 {object} dto.Response "Webhook successfully deleted"
+// @Failure 404 {object} dto.Response "Webhook not found"
+// @Failure 401 {object} dto.Response "Missing or invalid bearer token"
+// @Failure 403 {object} dto.Response "Role is not allowed to perform this action"
+// @Failure 500 {object} dto.Response "Internal server error"
+// @Router /webhook/{webhookId} [delete]
+func (wc *WebhookController) DeleteWebhook(c *gin.Context) {
+	webhookId := c.Param("webhookId")
+
+	if err := wc.webhookService.DeleteWebhook(c.Request.Context(), webhookId); err != nil {
+		c.Error(handler.NewError(err.Code, err.Message))
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.BaseResponse(true, "OK", nil))
+}
+
+// @Summary Get List of Webhook Deliveries
+// @Description Retrieve webhook deliveries, newest first. Deliveries with status 'dead' are the dead letters, every attempt failed
+// @Tags Webhooks
+// @Security BearerAuth
+// @Produce json
+// @Param page query int false "Page number (default 1)"
+// @Param size query int false "Number of items per page (default 10, max 100)"
+// @Param webhookId query string false "Filter by webhook ID"
+// @Param status query string false "Filter by delivery status (pending, delivered, dead)"
+// @Success 200 {object} dto.Response{data=dto.PaginationResponse{content=[]domain.WebhookDelivery}} "Successfully retrieved list of webhook deliveries"
+// @Failure 400 {object} dto.Response "Bad request (invalid page/size/status parameter)"
+// @Failure 404 {object} dto.Response "Webhook not found"
+// @Failure 401 {object} dto.Response "Missing or invalid bearer token"
+// @Failure 403 {object} dto.Response "Role is not allowed to perform this action"
+// @Failure 500 {object} dto.Response "Internal server error"
+// @Router /webhook/deliveries [get]
+func (wc *WebhookController) GetDeliveries(c *gin.Context) {
+	page, size, errPagination := handler.ParsePagination(c)
+	if errPagination != nil {
+		c.Error(errPagination)
+		return
+	}
+
+	deliveries, errDeliveries := wc.webhookService.FindDeliveries(c.Request.Context(), c.DefaultQuery("webhookId", ""), c.DefaultQuery("status", ""), page, size)
+	if errDeliveries != nil {
+		c.Error(handler.NewError(errDeliveries.Code, errDeliveries.Message))
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.BaseResponse(true, "OK", deliveries))
+}
+
+// @Summary Replay Webhook Delivery
+// @Description Queue a dead letter to be delivered again with the same payload and a fresh set of attempts
+// @Tags Webhooks
+// @Security BearerAuth
+// @Produce json
+// @Param deliveryId path string true "Delivery ID"
+// @Success 202 {object} dto.Response{data=domain.WebhookDelivery} "Delivery queued"
+// @Failure 404 {object} dto.Response "Webhook delivery not found"
+// @Failure 409 {object} dto.Response "Delivery is not a dead letter"
+// @Failure 401 {object} dto.Response "Missing or invalid bearer token"
+// @Failure 403 {object} dto.Response "Role is not allowed to perform this action"
+// @Failure 500 {object} dto.Response "Internal server error"
+// @Router /webhook/deliveries/{deliveryId}/replay [post]
+func (wc *WebhookController) ReplayDelivery(c *gin.Context) {
+	deliveryId := c.Param("deliveryId")
+
+	delivery, err := wc.webhookService.ReplayDelivery(c.Request.Context(), deliveryId)
+	if err != nil {
+		c.Error(handler.NewError(err.Code, err.Message))
+		return
+	}
+
+	c.JSON(http.StatusAccepted, dto.BaseResponse(true, "OK", delivery))
+}
+
+// @Summary Replay Webhook Dead Letters
+// @Description Queue every dead letter of the webhook to be delivered again
+// @Tags Webhooks
+// @Security BearerAuth
+// @Produce json
+// @Param webhookId path string true "Webhook ID"
+// @Success 202 {object} dto.Response{data=response.ReplayWebhookDeliveriesResponse} "Dead letters queued"
+// @Failure 404 {object} dto.Response "Webhook not found"
+// @Failure 401 {object} dto.Response "Missing or invalid bearer token"
+// @Failure 403 {object} dto.Response "Role is not allowed to perform this action"
+// @Failure 500 {object} dto.Response "Internal server error"
+// @Router /webhook/{webhookId}/replay [post]
+func (wc *WebhookController) ReplayDeadLetters(c *gin.Context) {
+	webhookId := c.Param("webhookId")
+
+	replay, err := wc.webhookService.ReplayDeadLetters(c.Request.Context(), webhookId)
+	if err != nil {
+		c.Error(handler.NewError(err.Code, err.Message))
+		return
+	}
+
+	c.JSON(http.StatusAccepted, dto.BaseResponse(true, "OK", replay))
+}
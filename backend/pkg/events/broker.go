@@ -0,0 +1,147 @@
+package events
+
+import (
+	"encoding/json"
+	"sync"
+)
+
+// subscriberBuffer is how many events a subscriber can fall behind before it is dropped,
+// a dropped client reconnects with its last event id and catches up from the replay buffer
+const subscriberBuffer = 64
+
+// Event is a message of the stream, ids increase by one with every published event
+type Event struct {
+	ID   uint64
+	Type string
+	Data []byte
+}
+
+// Broker fans published events out to the subscribers and keeps the latest ones
+// in a bounded buffer so a reconnecting subscriber can replay what it missed
+type Broker struct {
+	mu          sync.Mutex
+	lastID      uint64
+	replay      []Event
+	buffered    int
+	subscribers map[*Subscription]struct{}
+	closed      bool
+}
+
+// Subscription receives the events published after it was created until it is closed
+type Subscription struct {
+	// LastEventID is the id of the latest event published when the subscription was created
+	LastEventID uint64
+
+	broker *Broker
+	events chan Event
+}
+
+func NewBroker(replaySize int) *Broker {
+	if replaySize < 1 {
+		replaySize = 1
+	}
+
+	return &Broker{
+		replay:      make([]Event, replaySize),
+		subscribers: make(map[*Subscription]struct{}),
+	}
+}
+
+// Publish sends the data encoded as JSON to every subscriber. A subscriber that does not keep up
+// is dropped instead of blocking the publisher, its events channel is closed
+func (b *Broker) Publish(eventType string, data any) (Event, error) {
+	content, err := json.Marshal(data)
+	if err != nil {
+		return Event{}, err
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.lastID++
+	event := Event{ID: b.lastID, Type: eventType, Data: content}
+	b.replay[b.index(event.ID)] = event
+	b.buffered = min(b.buffered+1, len(b.replay))
+
+	for subscription := range b.subscribers {
+		select {
+		case subscription.events <- event:
+		default:
+			b.remove(subscription)
+		}
+	}
+
+	return event, nil
+}
+
+// Subscribe starts delivering the events published from now on. When lastEventID is given the buffered
+// events published after it are returned to be replayed first, missed is true when some of them are no
+// longer buffered or the id was never published, the subscriber then has to reload its state instead
+func (b *Broker) Subscribe(lastEventID *uint64) (subscription *Subscription, replay []Event, missed bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	subscription = &Subscription{
+		LastEventID: b.lastID,
+		broker:      b,
+		events:      make(chan Event, subscriberBuffer),
+	}
+	if b.closed {
+		close(subscription.events)
+		return subscription, nil, false
+	}
+	b.subscribers[subscription] = struct{}{}
+
+	if lastEventID == nil {
+		return subscription, nil, false
+	}
+
+	oldestID := b.lastID - uint64(b.buffered) + 1
+	if *lastEventID > b.lastID || *lastEventID+1 < oldestID {
+		return subscription, nil, true
+	}
+
+	for id := *lastEventID + 1; id <= b.lastID; id++ {
+		replay = append(replay, b.replay[b.index(id)])
+	}
+
+	return subscription, replay, false
+}
+
+// Close ends every subscription, including the ones made afterwards, so the streams serving them
+// return while the server shuts down. Events are still published and buffered for replay
+func (b *Broker) Close() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.closed = true
+	for subscription := range b.subscribers {
+		b.remove(subscription)
+	}
+}
+
+// Events is closed when the subscription is closed or dropped for falling behind
+func (s *Subscription) Events() <-chan Event {
+	return s.events
+}
+
+func (s *Subscription) Close() {
+	s.broker.mu.Lock()
+	defer s.broker.mu.Unlock()
+
+	s.broker.remove(s)
+}
+
+// remove must be called with the lock held
+func (b *Broker) remove(subscription *Subscription) {
+	if _, ok := b.subscribers[subscription]; !ok {
+		return
+	}
+
+	delete(b.subscribers, subscription)
+	close(subscription.events)
+}
+
+func (b *Broker) index(id uint64) int {
+	return int((id - 1) % uint64(len(b.replay)))
+}
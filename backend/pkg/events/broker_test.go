@@ -0,0 +1,130 @@
+package events
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func publish(t *testing.T, broker *Broker, count int) {
+	for i := 0; i < count; i++ {
+		_, err := broker.Publish("unit.updated", map[string]int{"n": i})
+		assert.NoError(t, err)
+	}
+}
+
+func eventIDs(events []Event) []uint64 {
+	ids := make([]uint64, 0, len(events))
+	for _, event := range events {
+		ids = append(ids, event.ID)
+	}
+	return ids
+}
+
+func TestBroker(t *testing.T) {
+	t.Run("Positive Case: Subscribers receive published events", func(t *testing.T) {
+		broker := NewBroker(10)
+		first, _, _ := broker.Subscribe(nil)
+		second, _, _ := broker.Subscribe(nil)
+		defer second.Close()
+
+		event, err := broker.Publish("unit.created", map[string]string{"name": "Cabin 1"})
+		assert.NoError(t, err)
+		assert.Equal(t, uint64(1), event.ID)
+
+		for _, subscription := range []*Subscription{first, second} {
+			received := <-subscription.Events()
+			assert.Equal(t, "unit.created", received.Type)
+			assert.JSONEq(t, `{"name":"Cabin 1"}`, string(received.Data))
+		}
+
+		first.Close()
+		first.Close()
+		_, isOpen := <-first.Events()
+		assert.False(t, isOpen)
+
+		publish(t, broker, 1)
+		assert.Equal(t, uint64(2), (<-second.Events()).ID)
+	})
+
+	t.Run("Positive Case: Buffered events after the last event id are replayed", func(t *testing.T) {
+		broker := NewBroker(5)
+		publish(t, broker, 8)
+
+		lastEventID := uint64(5)
+		subscription, replay, missed := broker.Subscribe(&lastEventID)
+		defer subscription.Close()
+		assert.False(t, missed)
+		assert.Equal(t, []uint64{6, 7, 8}, eventIDs(replay))
+		assert.Equal(t, uint64(8), subscription.LastEventID)
+
+		lastEventID = 8
+		_, replay, missed = broker.Subscribe(&lastEventID)
+		assert.False(t, missed)
+		assert.Empty(t, replay)
+
+		lastEventID = 0
+		_, replay, missed = NewBroker(5).Subscribe(&lastEventID)
+		assert.False(t, missed)
+		assert.Empty(t, replay)
+	})
+
+	t.Run("Negative Case: Events no longer buffered or unknown id are reported missed", func(t *testing.T) {
+		broker := NewBroker(5)
+		publish(t, broker, 8)
+
+		for _, lastEventID := range []uint64{0, 2, 9} {
+			_, replay, missed := broker.Subscribe(&lastEventID)
+			assert.True(t, missed, lastEventID)
+			assert.Empty(t, replay)
+		}
+
+		lastEventID := uint64(3)
+		_, replay, missed := broker.Subscribe(&lastEventID)
+		assert.False(t, missed)
+		assert.Equal(t, []uint64{4, 5, 6, 7, 8}, eventIDs(replay))
+	})
+
+	t.Run("Negative Case: Subscriber falling behind is dropped", func(t *testing.T) {
+		broker := NewBroker(10)
+		slow, _, _ := broker.Subscribe(nil)
+
+		publish(t, broker, subscriberBuffer+1)
+
+		received := 0
+		for range slow.Events() {
+			received++
+		}
+		assert.Equal(t, subscriberBuffer, received)
+		slow.Close()
+	})
+
+	t.Run("Negative Case: Data that cannot be encoded is not published", func(t *testing.T) {
+		broker := NewBroker(10)
+		_, err := broker.Publish("unit.updated", make(chan int))
+		assert.Error(t, err)
+
+		event, err := broker.Publish("unit.updated", nil)
+		assert.NoError(t, err)
+		assert.Equal(t, uint64(1), event.ID)
+	})
+
+	t.Run("Negative Case: Closed broker ends every subscription", func(t *testing.T) {
+		broker := NewBroker(10)
+		subscription, _, _ := broker.Subscribe(nil)
+
+		broker.Close()
+		_, isOpen := <-subscription.Events()
+		assert.False(t, isOpen)
+		subscription.Close()
+
+		late, _, _ := broker.Subscribe(nil)
+		_, isOpen = <-late.Events()
+		assert.False(t, isOpen)
+		late.Close()
+
+		event, err := broker.Publish("unit.updated", nil)
+		assert.NoError(t, err)
+		assert.Equal(t, uint64(1), event.ID)
+	})
+}
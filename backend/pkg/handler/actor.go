@@ -0,0 +1,17 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+)
+
+const DefaultActor = "anonymous"
+
+// GetActor returns the username of the authenticated staff performing the request, used to audit unit changes
+func GetActor(c *gin.Context) string {
+	claims, isAuthenticated := GetClaims(c)
+	if !isAuthenticated {
+		return DefaultActor
+	}
+
+	return claims.Username
+}
@@ -0,0 +1,67 @@
+package handler
+
+import (
+	"net/http"
+	"slices"
+	"strings"
+	"unit-management-be/pkg/auth"
+	"unit-management-be/pkg/model/domain/enum"
+
+	"github.com/gin-gonic/gin"
+)
+
+const claimsKey = "authClaims"
+
+// Authenticate verifies the bearer token of the request and keeps its claims in the context
+func Authenticate(tokenManager *auth.TokenManager) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		header := c.GetHeader("Authorization")
+		token, isBearer := strings.CutPrefix(header, "Bearer ")
+		if !isBearer || strings.TrimSpace(token) == "" {
+			c.Error(NewError(http.StatusUnauthorized, "missing bearer token"))
+			c.Abort()
+			return
+		}
+
+		claims, err := tokenManager.Parse(strings.TrimSpace(token))
+		if err != nil {
+			c.Error(NewError(http.StatusUnauthorized, err.Error()))
+			c.Abort()
+			return
+		}
+
+		c.Set(claimsKey, claims)
+		c.Next()
+	}
+}
+
+// RequireRoles only lets through authenticated users having one of the given roles
+func RequireRoles(roles ...enum.UserRole) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		claims, isAuthenticated := GetClaims(c)
+		if !isAuthenticated {
+			c.Error(NewError(http.StatusUnauthorized, "missing bearer token"))
+			c.Abort()
+			return
+		}
+
+		if !slices.Contains(roles, claims.Role) {
+			c.Error(NewError(http.StatusForbidden, "your role is not allowed to perform this action"))
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// GetClaims returns the claims of the authenticated user, set by Authenticate
+func GetClaims(c *gin.Context) (*auth.Claims, bool) {
+	value, exists := c.Get(claimsKey)
+	if !exists {
+		return nil, false
+	}
+
+	claims, ok := value.(*auth.Claims)
+	return claims, ok
+}
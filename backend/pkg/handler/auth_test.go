@@ -0,0 +1,71 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"unit-management-be/pkg/auth"
+	"unit-management-be/pkg/model/domain"
+	"unit-management-be/pkg/model/domain/enum"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// setupRouter registers a delete route only admins may call, answering with the actor of the request
+func setupRouter(tokenManager *auth.TokenManager) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(ErrorHandler())
+
+	protected := r.Group("", Authenticate(tokenManager))
+	protected.DELETE("/unit", RequireRoles(enum.RoleAdmin), func(c *gin.Context) {
+		c.String(http.StatusOK, GetActor(c))
+	})
+	return r
+}
+
+func performRequest(r *gin.Engine, authorization string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodDelete, "/unit", nil)
+	if authorization != "" {
+		req.Header.Set("Authorization", authorization)
+	}
+	recorder := httptest.NewRecorder()
+	r.ServeHTTP(recorder, req)
+	return recorder
+}
+
+func TestAuthorization(t *testing.T) {
+	tokenManager := auth.NewTokenManager("test-secret", time.Hour)
+	r := setupRouter(tokenManager)
+
+	issueToken := func(role enum.UserRole) string {
+		token, _, err := tokenManager.Generate(domain.User{ID: uuid.New(), Username: "staff", Role: role})
+		assert.NoError(t, err)
+		return "Bearer " + token
+	}
+
+	t.Run("Positive Case: Admin is allowed and recorded as actor", func(t *testing.T) {
+		recorder := performRequest(r, issueToken(enum.RoleAdmin))
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		assert.Equal(t, "staff", recorder.Body.String())
+	})
+
+	t.Run("Negative Case: Housekeeping is forbidden", func(t *testing.T) {
+		recorder := performRequest(r, issueToken(enum.RoleHousekeeping))
+		assert.Equal(t, http.StatusForbidden, recorder.Code)
+	})
+
+	t.Run("Negative Case: Missing token", func(t *testing.T) {
+		recorder := performRequest(r, "")
+		assert.Equal(t, http.StatusUnauthorized, recorder.Code)
+	})
+
+	t.Run("Negative Case: Invalid token", func(t *testing.T) {
+		recorder := performRequest(r, "Bearer invalid")
+		assert.Equal(t, http.StatusUnauthorized, recorder.Code)
+	})
+}
@@ -1,9 +1,11 @@
 package handler
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"net/http"
-	"unit-management-be/pkg/model/dto"
 
 	"github.com/gin-gonic/gin"
 )
@@ -11,6 +13,7 @@ import (
 type CustomError struct {
 	Message string
 	Code    int
+	Data    interface{}
 }
 
 func (e *CustomError) Error() string {
@@ -24,6 +27,52 @@ func NewError(code int, message string) *CustomError {
 	}
 }
 
+// NewErrorWithData creates error that carries additional detail returned to the client
+func NewErrorWithData(code int, message string, data interface{}) *CustomError {
+	return &CustomError{
+		Code:    code,
+		Message: message,
+		Data:    data,
+	}
+}
+
+// AsCustomError returns the CustomError carried by err, any other error becomes an internal server error
+func AsCustomError(err error) *CustomError {
+	if err == nil {
+		return nil
+	}
+
+	var customErr *CustomError
+	if errors.As(err, &customErr) {
+		return customErr
+	}
+	return NewError(http.StatusInternalServerError, err.Error())
+}
+
+// NewBindError answers a request body that cannot be read into the request DTO as a bad request,
+// naming the field of a wrong type or where the JSON is broken
+func NewBindError(err error) *CustomError {
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+	switch {
+	case errors.Is(err, io.EOF):
+		return NewError(http.StatusBadRequest, "request body is required")
+	case errors.Is(err, io.ErrUnexpectedEOF):
+		return NewError(http.StatusBadRequest, "request body is not valid JSON, it ends unexpectedly")
+	case errors.As(err, &syntaxErr):
+		return NewError(http.StatusBadRequest, fmt.Sprintf("request body is not valid JSON at offset %d: %s", syntaxErr.Offset, syntaxErr))
+	case errors.As(err, &typeErr) && typeErr.Field != "":
+		return NewError(http.StatusBadRequest, fmt.Sprintf("field %s must be a %s, got %s", typeErr.Field, typeErr.Type, typeErr.Value))
+	default:
+		return NewError(http.StatusBadRequest, fmt.Sprintf("invalid request body: %s", err))
+	}
+}
+
+const internalErrorMessage = "Internal Server Error"
+
+// ErrorHandler answers the last error of the request. The message of a server error may hold database
+// or other internal details, it is logged by RequestLogger and the client only gets a generic message
+// along with the request ID to report
 func ErrorHandler() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		c.Next()
@@ -32,16 +81,16 @@ func ErrorHandler() gin.HandlerFunc {
 			err := c.Errors.Last().Err
 
 			var customErr *CustomError
-			if ok := err.(*CustomError); ok != nil {
-				customErr = ok
-			} else {
-				customErr = &CustomError{
-					Message: "Internal Server Error",
-					Code:    http.StatusInternalServerError,
-				}
+			if !errors.As(err, &customErr) {
+				customErr = NewError(http.StatusInternalServerError, internalErrorMessage)
+			}
+
+			message, data := customErr.Message, customErr.Data
+			if customErr.Code >= http.StatusInternalServerError {
+				message, data = internalErrorMessage, nil
 			}
 
-			c.JSON(customErr.Code, dto.BaseResponse(false, customErr.Message, nil))
+			c.JSON(customErr.Code, errorResponse(c, message, data))
 			c.Abort()
 		}
 	}
@@ -0,0 +1,47 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewBindError(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(ErrorHandler())
+	r.POST("/unit", func(c *gin.Context) {
+		var body struct {
+			Name string `json:"name"`
+		}
+		if err := c.ShouldBindJSON(&body); err != nil {
+			c.Error(NewBindError(err))
+			return
+		}
+		c.Status(http.StatusCreated)
+	})
+
+	cases := []struct {
+		name, body, message string
+	}{
+		{"Empty body", ``, "request body is required"},
+		{"Truncated JSON", `{"name": "Cabin 1",`, "request body is not valid JSON, it ends unexpectedly"},
+		{"Broken JSON", `{"name": Cabin 1}`, "request body is not valid JSON at offset 10: invalid character 'C' looking for beginning of value"},
+		{"Field of the wrong type", `{"name": 1}`, "field name must be a string, got number"},
+	}
+	for _, tc := range cases {
+		t.Run("Negative Case: "+tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/unit", strings.NewReader(tc.body))
+			req.Header.Set("Content-Type", "application/json")
+			recorder := httptest.NewRecorder()
+			r.ServeHTTP(recorder, req)
+
+			assert.Equal(t, http.StatusBadRequest, recorder.Code)
+			assert.JSONEq(t, `{"success":false,"message":"`+tc.message+`","data":null}`, recorder.Body.String())
+		})
+	}
+}
@@ -0,0 +1,49 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+var ErrInvalidETag = errors.New("invalid entity tag")
+
+// ErrWeakETag is returned for a weak entity tag, If-Match compares tags strongly so a weak one never matches
+var ErrWeakETag = errors.New("weak entity tag cannot match")
+
+// FormatETag renders the unit version as a strong entity tag
+func FormatETag(version int64) string {
+	return fmt.Sprintf("\"%d\"", version)
+}
+
+// ParseIfMatch reads the version expected by an If-Match header, nil is returned for "*" which matches any version
+func ParseIfMatch(header string) (*int64, error) {
+	value := strings.TrimSpace(header)
+	if value == "*" {
+		return nil, nil
+	}
+
+	if strings.HasPrefix(value, "W/") {
+		return nil, ErrWeakETag
+	}
+	if len(value) < 2 || !strings.HasPrefix(value, "\"") || !strings.HasSuffix(value, "\"") {
+		return nil, ErrInvalidETag
+	}
+
+	version, err := strconv.ParseInt(value[1:len(value)-1], 10, 64)
+	if err != nil {
+		return nil, ErrInvalidETag
+	}
+
+	return &version, nil
+}
+
+// NewIfMatchError answers an If-Match header that cannot be used, a weak entity tag fails the precondition
+func NewIfMatchError(err error) *CustomError {
+	if errors.Is(err, ErrWeakETag) {
+		return NewError(http.StatusPreconditionFailed, "If-Match must carry a strong ETag, weak entity tags never match")
+	}
+	return NewError(http.StatusBadRequest, "invalid If-Match header, must be an ETag returned by the API")
+}
@@ -0,0 +1,37 @@
+package handler
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseIfMatch(t *testing.T) {
+	t.Run("Positive Case: Strong entity tag", func(t *testing.T) {
+		version, err := ParseIfMatch(FormatETag(12))
+		assert.NoError(t, err)
+		assert.Equal(t, int64(12), *version)
+	})
+
+	t.Run("Positive Case: Wildcard matches any version", func(t *testing.T) {
+		version, err := ParseIfMatch("*")
+		assert.NoError(t, err)
+		assert.Nil(t, version)
+	})
+
+	t.Run("Negative Case: Malformed entity tags", func(t *testing.T) {
+		for _, header := range []string{"3", `"abc"`, `"`, `"3`} {
+			_, err := ParseIfMatch(header)
+			assert.ErrorIs(t, err, ErrInvalidETag, header)
+		}
+	})
+
+	t.Run("Negative Case: Weak entity tag never matches", func(t *testing.T) {
+		version, err := ParseIfMatch(`W/"3"`)
+		assert.Nil(t, version)
+		assert.ErrorIs(t, err, ErrWeakETag)
+		assert.Equal(t, http.StatusPreconditionFailed, NewIfMatchError(err).Code)
+		assert.Equal(t, http.StatusBadRequest, NewIfMatchError(ErrInvalidETag).Code)
+	})
+}
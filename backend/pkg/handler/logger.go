@@ -0,0 +1,99 @@
+package handler
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"runtime/debug"
+	"time"
+	"unit-management-be/pkg/model/dto"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RequestLogger writes one record per request with its route, status, latency and, for a failed request,
+// the error and its class. It must run after RequestID and before the error handler so it sees the
+// status code written by it
+func RequestLogger() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+
+		status := c.Writer.Status()
+		attrs := []slog.Attr{
+			slog.String("method", c.Request.Method),
+			slog.String("path", c.Request.URL.Path),
+			slog.Int("status", status),
+			slog.Float64("latency_ms", float64(time.Since(start).Microseconds())/1000),
+			slog.String("client_ip", c.ClientIP()),
+		}
+		if c.FullPath() == "" {
+			attrs = append(attrs, slog.String("route", "unmatched"))
+		}
+
+		level := slog.LevelInfo
+		if status >= http.StatusBadRequest {
+			level = slog.LevelWarn
+			attrs = append(attrs, slog.String("error_class", ErrorClass(status)))
+			if len(c.Errors) > 0 {
+				attrs = append(attrs, slog.String("error", errorMessage(c.Errors.Last().Err)))
+			}
+		}
+		if status >= http.StatusInternalServerError {
+			level = slog.LevelError
+		}
+
+		slog.LogAttrs(c.Request.Context(), level, "request completed", attrs...)
+	}
+}
+
+// Recovery answers a request whose handler panicked with an internal server error and logs the panic
+func Recovery() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		defer func() {
+			if recovered := recover(); recovered != nil {
+				slog.ErrorContext(c.Request.Context(), "recovered from panic", "panic", recovered, "stack", string(debug.Stack()))
+				c.AbortWithStatusJSON(http.StatusInternalServerError, errorResponse(c, internalErrorMessage, nil))
+			}
+		}()
+
+		c.Next()
+	}
+}
+
+// ErrorClass names the kind of failure of a response status, so logs can be grouped by it
+func ErrorClass(status int) string {
+	switch {
+	case status == http.StatusBadRequest, status == http.StatusUnprocessableEntity:
+		return "validation"
+	case status == http.StatusUnauthorized:
+		return "unauthorized"
+	case status == http.StatusForbidden:
+		return "forbidden"
+	case status == http.StatusNotFound:
+		return "not_found"
+	case status == http.StatusConflict:
+		return "conflict"
+	case status == http.StatusPreconditionFailed, status == http.StatusPreconditionRequired:
+		return "precondition_failed"
+	case status >= http.StatusInternalServerError:
+		return "internal"
+	default:
+		return "client_error"
+	}
+}
+
+// errorMessage returns the message of the error as it was raised, before it is sanitized for the client
+func errorMessage(err error) string {
+	var customErr *CustomError
+	if errors.As(err, &customErr) {
+		return customErr.Message
+	}
+	return err.Error()
+}
+
+func errorResponse(c *gin.Context, message string, data interface{}) dto.Response {
+	response := dto.BaseResponse(false, message, data)
+	response.RequestID = GetRequestID(c)
+	return response
+}
@@ -0,0 +1,157 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"unit-management-be/pkg/logging"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+// setupLoggedRouter captures the records logged as JSON while the test runs
+func setupLoggedRouter(t *testing.T) (*gin.Engine, *bytes.Buffer) {
+	gin.SetMode(gin.TestMode)
+
+	var logs bytes.Buffer
+	logger, err := logging.NewLogger(&logs, "debug", logging.FormatJSON)
+	assert.NoError(t, err)
+	previous := slog.Default()
+	slog.SetDefault(logger)
+	t.Cleanup(func() { slog.SetDefault(previous) })
+
+	r := gin.New()
+	r.Use(RequestID(), RequestLogger(), Recovery(), ErrorHandler())
+	r.GET("/unit/:unitId", func(c *gin.Context) {
+		slog.InfoContext(c.Request.Context(), "reading unit")
+		c.String(http.StatusOK, GetRequestID(c))
+	})
+	r.GET("/unit/:unitId/history", func(c *gin.Context) {
+		c.Error(NewError(http.StatusInternalServerError, "dial tcp 10.0.0.5:3306: connection refused"))
+	})
+	r.GET("/unit/:unitId/transitions", func(c *gin.Context) {
+		c.Error(NewErrorWithData(http.StatusBadRequest, "invalid status transition", []string{"Available"}))
+	})
+	r.GET("/panic", func(c *gin.Context) {
+		panic("unexpected")
+	})
+	return r, &logs
+}
+
+func serve(r *gin.Engine, path, requestID string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	if requestID != "" {
+		req.Header.Set(RequestIDHeader, requestID)
+	}
+	recorder := httptest.NewRecorder()
+	r.ServeHTTP(recorder, req)
+	return recorder
+}
+
+// readRecords decodes the JSON records logged so far
+func readRecords(t *testing.T, logs *bytes.Buffer) []map[string]interface{} {
+	records := make([]map[string]interface{}, 0)
+	for _, line := range strings.Split(strings.TrimSpace(logs.String()), "\n") {
+		record := map[string]interface{}{}
+		assert.NoError(t, json.Unmarshal([]byte(line), &record))
+		records = append(records, record)
+	}
+	return records
+}
+
+func TestRequestLogging(t *testing.T) {
+	t.Run("Positive Case: Request ID is assigned and logged with the route and unit ID", func(t *testing.T) {
+		r, logs := setupLoggedRouter(t)
+
+		recorder := serve(r, "/unit/6f1d2c1e", "")
+		requestID := recorder.Header().Get(RequestIDHeader)
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		assert.NotEmpty(t, requestID)
+		assert.Equal(t, requestID, recorder.Body.String())
+
+		records := readRecords(t, logs)
+		assert.Len(t, records, 2)
+		for _, record := range records {
+			assert.Equal(t, requestID, record["request_id"])
+			assert.Equal(t, "/unit/:unitId", record["route"])
+			assert.Equal(t, "6f1d2c1e", record["unit_id"])
+		}
+		assert.Equal(t, "request completed", records[1]["msg"])
+		assert.Equal(t, float64(http.StatusOK), records[1]["status"])
+		assert.Contains(t, records[1], "latency_ms")
+		assert.NotContains(t, records[1], "error_class")
+	})
+
+	t.Run("Positive Case: Request ID of the client is kept", func(t *testing.T) {
+		r, _ := setupLoggedRouter(t)
+
+		recorder := serve(r, "/unit/6f1d2c1e", "checkout-42")
+		assert.Equal(t, "checkout-42", recorder.Header().Get(RequestIDHeader))
+	})
+
+	t.Run("Negative Case: Unsafe request ID of the client is replaced", func(t *testing.T) {
+		r, _ := setupLoggedRouter(t)
+
+		recorder := serve(r, "/unit/6f1d2c1e", "forged\" id")
+		assert.NotEqual(t, "forged\" id", recorder.Header().Get(RequestIDHeader))
+		assert.NotEmpty(t, recorder.Header().Get(RequestIDHeader))
+	})
+
+	t.Run("Negative Case: Server error is sanitized for the client and logged in full", func(t *testing.T) {
+		r, logs := setupLoggedRouter(t)
+
+		recorder := serve(r, "/unit/6f1d2c1e/history", "trace-500")
+		assert.Equal(t, http.StatusInternalServerError, recorder.Code)
+		assert.JSONEq(t, `{"success":false,"message":"Internal Server Error","data":null,"requestId":"trace-500"}`, recorder.Body.String())
+
+		records := readRecords(t, logs)
+		assert.Len(t, records, 1)
+		assert.Equal(t, "ERROR", records[0]["level"])
+		assert.Equal(t, "internal", records[0]["error_class"])
+		assert.Equal(t, "dial tcp 10.0.0.5:3306: connection refused", records[0]["error"])
+	})
+
+	t.Run("Negative Case: Client error keeps its message and data", func(t *testing.T) {
+		r, logs := setupLoggedRouter(t)
+
+		recorder := serve(r, "/unit/6f1d2c1e/transitions", "trace-400")
+		assert.Equal(t, http.StatusBadRequest, recorder.Code)
+		assert.JSONEq(t, `{"success":false,"message":"invalid status transition","data":["Available"],"requestId":"trace-400"}`, recorder.Body.String())
+
+		records := readRecords(t, logs)
+		assert.Equal(t, "WARN", records[0]["level"])
+		assert.Equal(t, "validation", records[0]["error_class"])
+	})
+
+	t.Run("Negative Case: Panic is answered with an internal server error", func(t *testing.T) {
+		r, logs := setupLoggedRouter(t)
+
+		recorder := serve(r, "/panic", "trace-panic")
+		assert.Equal(t, http.StatusInternalServerError, recorder.Code)
+		assert.JSONEq(t, `{"success":false,"message":"Internal Server Error","data":null,"requestId":"trace-panic"}`, recorder.Body.String())
+
+		records := readRecords(t, logs)
+		assert.Len(t, records, 2)
+		assert.Equal(t, "recovered from panic", records[0]["msg"])
+		assert.Equal(t, float64(http.StatusInternalServerError), records[1]["status"])
+	})
+
+	t.Run("Negative Case: Error other than a custom error is answered with an internal server error", func(t *testing.T) {
+		gin.SetMode(gin.TestMode)
+		r := gin.New()
+		r.Use(ErrorHandler())
+		r.GET("/unit", func(c *gin.Context) {
+			c.Error(errors.New("unexpected"))
+		})
+
+		recorder := serve(r, "/unit", "")
+		assert.Equal(t, http.StatusInternalServerError, recorder.Code)
+		assert.JSONEq(t, `{"success":false,"message":"Internal Server Error","data":null}`, recorder.Body.String())
+	})
+}
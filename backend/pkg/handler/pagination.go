@@ -0,0 +1,32 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// DefaultPageSize is used when the request does not ask for a size
+	DefaultPageSize = 10
+	// MaxPageSize caps a page the same way the limit of a cursor page is capped
+	MaxPageSize = 100
+)
+
+// ParsePagination reads the page and size query parameters, pages start at 1 and a page holds
+// between 1 and MaxPageSize items
+func ParsePagination(c *gin.Context) (int, int, *CustomError) {
+	page, err := strconv.Atoi(c.DefaultQuery("page", "1"))
+	if err != nil || page < 1 {
+		return 0, 0, NewError(http.StatusBadRequest, "invalid page parameter, must be a number of at least 1")
+	}
+
+	size, err := strconv.Atoi(c.DefaultQuery("size", strconv.Itoa(DefaultPageSize)))
+	if err != nil || size < 1 || size > MaxPageSize {
+		return 0, 0, NewError(http.StatusBadRequest, fmt.Sprintf("invalid size parameter, must be a number between 1 and %d", MaxPageSize))
+	}
+
+	return page, size, nil
+}
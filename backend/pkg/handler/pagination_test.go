@@ -0,0 +1,53 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func parsePaginationQuery(query string) (int, int, *CustomError) {
+	gin.SetMode(gin.TestMode)
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Request = httptest.NewRequest(http.MethodGet, "/unit?"+query, nil)
+	return ParsePagination(c)
+}
+
+func TestParsePagination(t *testing.T) {
+	t.Run("Positive Case: Defaults", func(t *testing.T) {
+		page, size, err := parsePaginationQuery("")
+		assert.Nil(t, err)
+		assert.Equal(t, 1, page)
+		assert.Equal(t, DefaultPageSize, size)
+	})
+
+	t.Run("Positive Case: Largest page", func(t *testing.T) {
+		page, size, err := parsePaginationQuery("page=3&size=100")
+		assert.Nil(t, err)
+		assert.Equal(t, 3, page)
+		assert.Equal(t, MaxPageSize, size)
+	})
+
+	t.Run("Negative Case: Invalid page", func(t *testing.T) {
+		for _, query := range []string{"page=abc", "page=0", "page=-1"} {
+			_, _, err := parsePaginationQuery(query)
+			if assert.NotNil(t, err, query) {
+				assert.Equal(t, http.StatusBadRequest, err.Code)
+				assert.Equal(t, "invalid page parameter, must be a number of at least 1", err.Message)
+			}
+		}
+	})
+
+	t.Run("Negative Case: Invalid size", func(t *testing.T) {
+		for _, query := range []string{"size=abc", "size=0", "size=-5", "size=101"} {
+			_, _, err := parsePaginationQuery(query)
+			if assert.NotNil(t, err, query) {
+				assert.Equal(t, http.StatusBadRequest, err.Code)
+				assert.Equal(t, "invalid size parameter, must be a number between 1 and 100", err.Message)
+			}
+		}
+	})
+}
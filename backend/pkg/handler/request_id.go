@@ -0,0 +1,48 @@
+package handler
+
+import (
+	"log/slog"
+	"regexp"
+	"strings"
+	"unit-management-be/pkg/logging"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+const RequestIDHeader = "X-Request-ID"
+
+// requestIDPattern limits the request IDs taken from the client to ones that are safe to log and echo back
+var requestIDPattern = regexp.MustCompile(`^[A-Za-z0-9._:-]{1,128}$`)
+
+// RequestID keeps the X-Request-ID sent by the client, or assigns a new one, and returns it in the response.
+// The request context carries it along with the route and its IDs, such as the unit ID, so every record
+// logged while serving the request can be traced back to it
+func RequestID() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		requestID := c.GetHeader(RequestIDHeader)
+		if !requestIDPattern.MatchString(requestID) {
+			requestID = uuid.NewString()
+		}
+		c.Header(RequestIDHeader, requestID)
+
+		ctx := logging.WithRequestID(c.Request.Context(), requestID)
+		attrs := make([]slog.Attr, 0, len(c.Params)+1)
+		if route := c.FullPath(); route != "" {
+			attrs = append(attrs, slog.String("route", route))
+		}
+		for _, param := range c.Params {
+			if name, isID := strings.CutSuffix(param.Key, "Id"); isID {
+				attrs = append(attrs, slog.String(name+"_id", param.Value))
+			}
+		}
+		c.Request = c.Request.WithContext(logging.With(ctx, attrs...))
+
+		c.Next()
+	}
+}
+
+// GetRequestID returns the ID assigned to the request by RequestID
+func GetRequestID(c *gin.Context) string {
+	return logging.RequestID(c.Request.Context())
+}
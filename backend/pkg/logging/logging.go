@@ -0,0 +1,123 @@
+package logging
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+const (
+	FormatJSON = "json"
+	FormatText = "text"
+)
+
+type attrsKey struct{}
+
+type requestIDKey struct{}
+
+// contextHandler adds the attributes kept in the context, such as the request ID, to every record
+type contextHandler struct {
+	slog.Handler
+}
+
+func (h contextHandler) Handle(ctx context.Context, record slog.Record) error {
+	if attrs, ok := ctx.Value(attrsKey{}).([]slog.Attr); ok {
+		record.AddAttrs(attrs...)
+	}
+	return h.Handler.Handle(ctx, record)
+}
+
+func (h contextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return contextHandler{Handler: h.Handler.WithAttrs(attrs)}
+}
+
+func (h contextHandler) WithGroup(name string) slog.Handler {
+	return contextHandler{Handler: h.Handler.WithGroup(name)}
+}
+
+// NewLogger creates a logger writing records of the level and above in the format, json or text
+func NewLogger(w io.Writer, level, format string) (*slog.Logger, error) {
+	var logLevel slog.Level
+	if level != "" {
+		if err := logLevel.UnmarshalText([]byte(level)); err != nil {
+			return nil, fmt.Errorf("unsupported log level %q, must be debug, info, warn or error", level)
+		}
+	}
+
+	options := &slog.HandlerOptions{Level: logLevel}
+	switch strings.ToLower(format) {
+	case "", FormatJSON:
+		return slog.New(contextHandler{Handler: slog.NewJSONHandler(w, options)}), nil
+	case FormatText:
+		return slog.New(contextHandler{Handler: slog.NewTextHandler(w, options)}), nil
+	default:
+		return nil, fmt.Errorf("unsupported log format %q, must be json or text", format)
+	}
+}
+
+// Setup makes the logger the default one, the standard log package writes through it as well
+func Setup(w io.Writer, level, format string) error {
+	logger, err := NewLogger(w, level, format)
+	if err != nil {
+		return err
+	}
+
+	slog.SetDefault(logger)
+	return nil
+}
+
+// With returns a context whose log records carry the attributes in addition to the ones already kept
+func With(ctx context.Context, attrs ...slog.Attr) context.Context {
+	current, _ := ctx.Value(attrsKey{}).([]slog.Attr)
+	return context.WithValue(ctx, attrsKey{}, append(current[:len(current):len(current)], attrs...))
+}
+
+// WithRequestID returns a context carrying the request ID, it is added to every record logged with it
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	ctx = context.WithValue(ctx, requestIDKey{}, requestID)
+	return With(ctx, slog.String("request_id", requestID))
+}
+
+// RequestID returns the request ID kept in the context, empty outside of a request
+func RequestID(ctx context.Context) string {
+	requestID, _ := ctx.Value(requestIDKey{}).(string)
+	return requestID
+}
+
+// ErrorClass names the kind of failure of an error returned by the database, so logs can be grouped by it
+func ErrorClass(err error) string {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return "not_found"
+	case errors.Is(err, context.Canceled):
+		return "canceled"
+	case errors.Is(err, context.DeadlineExceeded):
+		return "timeout"
+	default:
+		return "database"
+	}
+}
+
+// Error logs the failed operation with its error and error class. A missing record is usually answered
+// with a not found to the client, it is only logged at debug level
+func Error(ctx context.Context, msg string, err error, args ...any) {
+	level := slog.LevelError
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		level = slog.LevelDebug
+	}
+
+	args = append(args, slog.String("error", err.Error()), slog.String("error_class", ErrorClass(err)))
+	slog.Log(ctx, level, msg, args...)
+}
+
+// Fatal logs the error that keeps the application from starting and exits
+func Fatal(msg string, args ...any) {
+	slog.Error(msg, args...)
+	os.Exit(1)
+}
@@ -0,0 +1,115 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+// setupLogger makes a JSON logger writing to the returned buffer the default one while the test runs
+func setupLogger(t *testing.T, level string) *bytes.Buffer {
+	var logs bytes.Buffer
+	logger, err := NewLogger(&logs, level, FormatJSON)
+	assert.NoError(t, err)
+
+	previous := slog.Default()
+	slog.SetDefault(logger)
+	t.Cleanup(func() { slog.SetDefault(previous) })
+	return &logs
+}
+
+func readRecord(t *testing.T, logs *bytes.Buffer) map[string]interface{} {
+	record := map[string]interface{}{}
+	assert.NoError(t, json.Unmarshal([]byte(strings.TrimSpace(logs.String())), &record))
+	return record
+}
+
+func TestNewLogger(t *testing.T) {
+	t.Run("Positive Case: Text format and level", func(t *testing.T) {
+		var logs bytes.Buffer
+		logger, err := NewLogger(&logs, "warn", "TEXT")
+		assert.NoError(t, err)
+
+		logger.Info("skipped")
+		logger.Warn("kept", "unit_id", "42")
+		assert.Equal(t, 1, strings.Count(logs.String(), "\n"))
+		assert.Contains(t, logs.String(), "msg=kept unit_id=42")
+	})
+
+	t.Run("Negative Case: Unsupported level", func(t *testing.T) {
+		_, err := NewLogger(&bytes.Buffer{}, "verbose", FormatJSON)
+		assert.EqualError(t, err, `unsupported log level "verbose", must be debug, info, warn or error`)
+	})
+
+	t.Run("Negative Case: Unsupported format", func(t *testing.T) {
+		_, err := NewLogger(&bytes.Buffer{}, "info", "xml")
+		assert.EqualError(t, err, `unsupported log format "xml", must be json or text`)
+	})
+}
+
+func TestContextAttributes(t *testing.T) {
+	t.Run("Positive Case: Request ID and attributes of the context are logged", func(t *testing.T) {
+		logs := setupLogger(t, "info")
+
+		ctx := WithRequestID(context.Background(), "req-1")
+		ctx = With(ctx, slog.String("unit_id", "42"))
+		slog.InfoContext(ctx, "unit read")
+
+		record := readRecord(t, logs)
+		assert.Equal(t, "req-1", RequestID(ctx))
+		assert.Equal(t, "req-1", record["request_id"])
+		assert.Equal(t, "42", record["unit_id"])
+	})
+
+	t.Run("Positive Case: Derived contexts do not share attributes", func(t *testing.T) {
+		logs := setupLogger(t, "info")
+
+		parent := With(context.Background(), slog.String("route", "/unit"))
+		first := With(parent, slog.String("unit_id", "1"))
+		_ = With(parent, slog.String("unit_id", "2"))
+		slog.InfoContext(first, "unit read")
+
+		record := readRecord(t, logs)
+		assert.Equal(t, "1", record["unit_id"])
+	})
+
+	t.Run("Negative Case: No request ID outside of a request", func(t *testing.T) {
+		assert.Empty(t, RequestID(context.Background()))
+	})
+}
+
+func TestError(t *testing.T) {
+	t.Run("Positive Case: Database error is logged with its class", func(t *testing.T) {
+		logs := setupLogger(t, "info")
+
+		Error(WithRequestID(context.Background(), "req-1"), "failed to save unit", errors.New("connection refused"), "unit_id", "42")
+
+		record := readRecord(t, logs)
+		assert.Equal(t, "ERROR", record["level"])
+		assert.Equal(t, "failed to save unit", record["msg"])
+		assert.Equal(t, "connection refused", record["error"])
+		assert.Equal(t, "database", record["error_class"])
+		assert.Equal(t, "42", record["unit_id"])
+		assert.Equal(t, "req-1", record["request_id"])
+	})
+
+	t.Run("Positive Case: Canceled request", func(t *testing.T) {
+		assert.Equal(t, "canceled", ErrorClass(context.Canceled))
+		assert.Equal(t, "timeout", ErrorClass(context.DeadlineExceeded))
+	})
+
+	t.Run("Negative Case: Missing record is only logged at debug level", func(t *testing.T) {
+		logs := setupLogger(t, "info")
+
+		Error(context.Background(), "failed to get unit by id", gorm.ErrRecordNotFound)
+		assert.Empty(t, logs.String())
+		assert.Equal(t, "not_found", ErrorClass(gorm.ErrRecordNotFound))
+	})
+}
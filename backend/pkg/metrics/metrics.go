@@ -0,0 +1,90 @@
+package metrics
+
+import (
+	"database/sql"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Namespace prefixes the metrics of the application
+const Namespace = "unit_management"
+
+// unmatchedRoute labels the requests that did not match any route, so unknown paths cannot grow the label set
+const unmatchedRoute = "unmatched"
+
+// Metrics holds the collectors exposed on /metrics, in a registry of its own next to the Go runtime
+// and process collectors
+type Metrics struct {
+	registry        *prometheus.Registry
+	requests        *prometheus.CounterVec
+	requestDuration *prometheus.HistogramVec
+}
+
+func NewMetrics() *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Name:      "http_requests_total",
+			Help:      "HTTP requests handled, by method, route and status code.",
+		}, []string{"method", "route", "code"}),
+		requestDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: Namespace,
+			Name:      "http_request_duration_seconds",
+			Help:      "Time taken to handle HTTP requests, by method, route and status code.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method", "route", "code"}),
+	}
+
+	m.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.requests,
+		m.requestDuration,
+	)
+	return m
+}
+
+// MustRegister adds collectors to the registry, it panics when a metric is registered twice
+func (m *Metrics) MustRegister(collectors ...prometheus.Collector) {
+	m.registry.MustRegister(collectors...)
+}
+
+// RegisterDB exposes the connection pool statistics of the database, labelled with its name
+func (m *Metrics) RegisterDB(db *sql.DB, name string) {
+	m.MustRegister(collectors.NewDBStatsCollector(db, name))
+}
+
+// Handler serves the metrics in the Prometheus text format, a collector failing is logged
+// and the other metrics are still served
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{
+		ErrorLog:      log.Default(),
+		ErrorHandling: promhttp.ContinueOnError,
+	})
+}
+
+// Middleware counts and times every request by its route pattern rather than its path,
+// it must run before the error handler so the status code written by it is recorded
+func (m *Metrics) Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+
+		route := c.FullPath()
+		if route == "" {
+			route = unmatchedRoute
+		}
+		code := strconv.Itoa(c.Writer.Status())
+
+		m.requests.WithLabelValues(c.Request.Method, route, code).Inc()
+		m.requestDuration.WithLabelValues(c.Request.Method, route, code).Observe(time.Since(start).Seconds())
+	}
+}
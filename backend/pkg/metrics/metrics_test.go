@@ -0,0 +1,52 @@
+package metrics
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"unit-management-be/pkg/handler"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func scrape(t *testing.T, m *Metrics) string {
+	recorder := httptest.NewRecorder()
+	m.Handler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	assert.Equal(t, http.StatusOK, recorder.Code)
+
+	body, err := io.ReadAll(recorder.Body)
+	assert.NoError(t, err)
+	return string(body)
+}
+
+func TestMiddleware(t *testing.T) {
+	t.Run("Positive Case: Requests are counted by route and status code", func(t *testing.T) {
+		gin.SetMode(gin.TestMode)
+		m := NewMetrics()
+
+		router := gin.New()
+		router.Use(m.Middleware())
+		router.Use(handler.ErrorHandler())
+		router.GET("/unit/:unitId", func(c *gin.Context) {
+			if c.Param("unitId") == "missing" {
+				c.Error(handler.NewError(http.StatusNotFound, "unit not found"))
+				return
+			}
+			c.Status(http.StatusOK)
+		})
+
+		for _, path := range []string{"/unit/1", "/unit/2", "/unit/missing", "/nowhere"} {
+			router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+		}
+
+		body := scrape(t, m)
+		assert.Contains(t, body, `unit_management_http_requests_total{code="200",method="GET",route="/unit/:unitId"} 2`)
+		assert.Contains(t, body, `unit_management_http_requests_total{code="404",method="GET",route="/unit/:unitId"} 1`)
+		assert.Contains(t, body, `unit_management_http_requests_total{code="404",method="GET",route="unmatched"} 1`)
+		assert.Contains(t, body, `unit_management_http_request_duration_seconds_count{code="200",method="GET",route="/unit/:unitId"} 2`)
+		assert.False(t, strings.Contains(body, "/unit/1"))
+	})
+}
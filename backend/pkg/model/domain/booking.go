@@ -0,0 +1,37 @@
+package domain
+
+import (
+	"time"
+	"unit-management-be/pkg/model/domain/enum"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type Booking struct {
+	ID           uuid.UUID          `gorm:"type:varchar(36);primary_key" json:"id"`
+	UnitID       uuid.UUID          `gorm:"type:varchar(36);index" json:"unitId"`
+	GuestName    string             `gorm:"type:varchar(255)" json:"guestName"`
+	GuestContact string             `gorm:"type:varchar(255)" json:"guestContact"`
+	Status       enum.BookingStatus `gorm:"type:enum('Reserved', 'Checked In', 'Checked Out', 'Cancelled')" json:"status"`
+	StartAt      time.Time          `json:"startAt"`
+	EndAt        time.Time          `json:"endAt"`
+	CheckedInAt  *time.Time         `json:"checkedInAt"`
+	CheckedOutAt *time.Time         `json:"checkedOutAt"`
+	CreatedAt    time.Time          `gorm:"autoCreateTime" json:"createdAt"`
+	UpdatedAt    time.Time          `gorm:"autoUpdateTime" json:"updatedAt"`
+}
+
+func (b *Booking) BeforeCreate(tx *gorm.DB) (err error) {
+	b.ID = uuid.New()
+	return
+}
+
+func (b *Booking) TableName() string {
+	return "bookings"
+}
+
+// IsActive reports whether the booking still holds its unit for the booked period
+func (b *Booking) IsActive() bool {
+	return b.Status == enum.BookingReserved || b.Status == enum.BookingCheckedIn
+}
@@ -2,6 +2,18 @@ package enum
 
 type UnitType string
 type UnitStatus string
+type BookingStatus string
+type HousekeepingTaskStatus string
+type TicketSeverity string
+type TicketStatus string
+type UserRole string
+type BulkMode string
+type PatchFormat string
+type UnitSortField string
+type UnitEventType string
+type WebhookEvent string
+type WebhookDeliveryStatus string
+type ReportGranularity string
 
 const (
 	Capsule UnitType = "capsule"
@@ -11,6 +23,58 @@ const (
 	Occupied           UnitStatus = "Occupied"
 	CleaningInProgress UnitStatus = "Cleaning In Progress"
 	MaintenanceNeeded  UnitStatus = "Maintenance Needed"
+
+	BookingReserved   BookingStatus = "Reserved"
+	BookingCheckedIn  BookingStatus = "Checked In"
+	BookingCheckedOut BookingStatus = "Checked Out"
+	BookingCancelled  BookingStatus = "Cancelled"
+
+	HousekeepingPending    HousekeepingTaskStatus = "Pending"
+	HousekeepingInProgress HousekeepingTaskStatus = "In Progress"
+	HousekeepingCompleted  HousekeepingTaskStatus = "Completed"
+	HousekeepingCancelled  HousekeepingTaskStatus = "Cancelled"
+
+	SeverityLow      TicketSeverity = "low"
+	SeverityMedium   TicketSeverity = "medium"
+	SeverityHigh     TicketSeverity = "high"
+	SeverityCritical TicketSeverity = "critical"
+
+	TicketOpen       TicketStatus = "Open"
+	TicketInProgress TicketStatus = "In Progress"
+	TicketResolved   TicketStatus = "Resolved"
+
+	RoleAdmin        UserRole = "admin"
+	RoleFrontDesk    UserRole = "front_desk"
+	RoleHousekeeping UserRole = "housekeeping"
+	RoleReadOnly     UserRole = "read_only"
+
+	BulkAtomic     BulkMode = "atomic"
+	BulkBestEffort BulkMode = "best_effort"
+
+	MergePatch PatchFormat = "application/merge-patch+json"
+	JSONPatch  PatchFormat = "application/json-patch+json"
+
+	SortUnitName        UnitSortField = "name"
+	SortUnitType        UnitSortField = "type"
+	SortUnitStatus      UnitSortField = "status"
+	SortUnitLastUpdated UnitSortField = "lastUpdated"
+
+	UnitCreated  UnitEventType = "unit.created"
+	UnitUpdated  UnitEventType = "unit.updated"
+	UnitDeleted  UnitEventType = "unit.deleted"
+	UnitRestored UnitEventType = "unit.restored"
+
+	WebhookUnitCreated       WebhookEvent = "unit.created"
+	WebhookUnitUpdated       WebhookEvent = "unit.updated"
+	WebhookUnitStatusChanged WebhookEvent = "unit.status_changed"
+	WebhookUnitDeleted       WebhookEvent = "unit.deleted"
+
+	DeliveryPending   WebhookDeliveryStatus = "pending"
+	DeliveryDelivered WebhookDeliveryStatus = "delivered"
+	DeliveryDead      WebhookDeliveryStatus = "dead"
+
+	GranularityDay  ReportGranularity = "day"
+	GranularityWeek ReportGranularity = "week"
 )
 
 func ParseUnitType(value string) (UnitType, bool) {
@@ -24,6 +88,16 @@ func ParseUnitType(value string) (UnitType, bool) {
 	}
 }
 
+// UnitTypes returns every unit type in declaration order, which is also their sort order
+func UnitTypes() []UnitType {
+	return []UnitType{Capsule, Cabin}
+}
+
+// UnitStatuses returns every unit status in declaration order, which is also their sort order
+func UnitStatuses() []UnitStatus {
+	return []UnitStatus{Available, Occupied, CleaningInProgress, MaintenanceNeeded}
+}
+
 func ParseUnitStatus(value string) (UnitStatus, bool) {
 	switch value {
 	case string(Available):
@@ -38,3 +112,159 @@ func ParseUnitStatus(value string) (UnitStatus, bool) {
 		return "", false
 	}
 }
+
+func ParseBookingStatus(value string) (BookingStatus, bool) {
+	switch value {
+	case string(BookingReserved):
+		return BookingReserved, true
+	case string(BookingCheckedIn):
+		return BookingCheckedIn, true
+	case string(BookingCheckedOut):
+		return BookingCheckedOut, true
+	case string(BookingCancelled):
+		return BookingCancelled, true
+	default:
+		return "", false
+	}
+}
+
+func ParseHousekeepingTaskStatus(value string) (HousekeepingTaskStatus, bool) {
+	switch value {
+	case string(HousekeepingPending):
+		return HousekeepingPending, true
+	case string(HousekeepingInProgress):
+		return HousekeepingInProgress, true
+	case string(HousekeepingCompleted):
+		return HousekeepingCompleted, true
+	case string(HousekeepingCancelled):
+		return HousekeepingCancelled, true
+	default:
+		return "", false
+	}
+}
+
+func ParseTicketSeverity(value string) (TicketSeverity, bool) {
+	switch value {
+	case string(SeverityLow):
+		return SeverityLow, true
+	case string(SeverityMedium):
+		return SeverityMedium, true
+	case string(SeverityHigh):
+		return SeverityHigh, true
+	case string(SeverityCritical):
+		return SeverityCritical, true
+	default:
+		return "", false
+	}
+}
+
+func ParseTicketStatus(value string) (TicketStatus, bool) {
+	switch value {
+	case string(TicketOpen):
+		return TicketOpen, true
+	case string(TicketInProgress):
+		return TicketInProgress, true
+	case string(TicketResolved):
+		return TicketResolved, true
+	default:
+		return "", false
+	}
+}
+
+func ParseUserRole(value string) (UserRole, bool) {
+	switch value {
+	case string(RoleAdmin):
+		return RoleAdmin, true
+	case string(RoleFrontDesk):
+		return RoleFrontDesk, true
+	case string(RoleHousekeeping):
+		return RoleHousekeeping, true
+	case string(RoleReadOnly):
+		return RoleReadOnly, true
+	default:
+		return "", false
+	}
+}
+
+func ParseBulkMode(value string) (BulkMode, bool) {
+	switch value {
+	case string(BulkAtomic):
+		return BulkAtomic, true
+	case string(BulkBestEffort):
+		return BulkBestEffort, true
+	default:
+		return "", false
+	}
+}
+
+// ParsePatchFormat reads the patch format from the media type of the request,
+// plain JSON is treated as a merge patch
+func ParsePatchFormat(value string) (PatchFormat, bool) {
+	switch value {
+	case string(MergePatch), "application/json":
+		return MergePatch, true
+	case string(JSONPatch):
+		return JSONPatch, true
+	default:
+		return "", false
+	}
+}
+
+func ParseUnitSortField(value string) (UnitSortField, bool) {
+	switch value {
+	case string(SortUnitName):
+		return SortUnitName, true
+	case string(SortUnitType):
+		return SortUnitType, true
+	case string(SortUnitStatus):
+		return SortUnitStatus, true
+	case string(SortUnitLastUpdated):
+		return SortUnitLastUpdated, true
+	default:
+		return "", false
+	}
+}
+
+func ParseWebhookEvent(value string) (WebhookEvent, bool) {
+	switch value {
+	case string(WebhookUnitCreated):
+		return WebhookUnitCreated, true
+	case string(WebhookUnitUpdated):
+		return WebhookUnitUpdated, true
+	case string(WebhookUnitStatusChanged):
+		return WebhookUnitStatusChanged, true
+	case string(WebhookUnitDeleted):
+		return WebhookUnitDeleted, true
+	default:
+		return "", false
+	}
+}
+
+// WebhookEvents returns every event a webhook can subscribe to
+func WebhookEvents() []WebhookEvent {
+	return []WebhookEvent{WebhookUnitCreated, WebhookUnitUpdated, WebhookUnitStatusChanged, WebhookUnitDeleted}
+}
+
+func ParseWebhookDeliveryStatus(value string) (WebhookDeliveryStatus, bool) {
+	switch value {
+	case string(DeliveryPending):
+		return DeliveryPending, true
+	case string(DeliveryDelivered):
+		return DeliveryDelivered, true
+	case string(DeliveryDead):
+		return DeliveryDead, true
+	default:
+		return "", false
+	}
+}
+
+func ParseReportGranularity(value string) (ReportGranularity, bool) {
+	switch value {
+	case string(GranularityDay):
+		return GranularityDay, true
+	case string(GranularityWeek):
+		return GranularityWeek, true
+	default:
+		return "", false
+	}
+}
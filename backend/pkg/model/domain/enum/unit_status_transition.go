@@ -0,0 +1,33 @@
+package enum
+
+// unitStatusTransitions lists every status a unit is allowed to move to from its current status.
+var unitStatusTransitions = map[UnitStatus][]UnitStatus{
+	Available:          {Occupied, CleaningInProgress, MaintenanceNeeded},
+	Occupied:           {CleaningInProgress, MaintenanceNeeded},
+	CleaningInProgress: {Available, MaintenanceNeeded},
+	MaintenanceNeeded:  {Available, CleaningInProgress},
+}
+
+// initialUnitStatuses lists the statuses a new unit can be created with, a unit cannot start as occupied.
+var initialUnitStatuses = []UnitStatus{Available, CleaningInProgress, MaintenanceNeeded}
+
+// AllowedUnitStatusTransitions returns the statuses reachable from the given status.
+// An empty status means the unit does not exist yet, so the initial statuses are returned.
+func AllowedUnitStatusTransitions(from UnitStatus) []UnitStatus {
+	if from == "" {
+		return append([]UnitStatus{}, initialUnitStatuses...)
+	}
+
+	return append([]UnitStatus{}, unitStatusTransitions[from]...)
+}
+
+// CanTransitionUnitStatus reports whether a unit can move from one status to another.
+func CanTransitionUnitStatus(from, to UnitStatus) bool {
+	for _, allowed := range AllowedUnitStatusTransitions(from) {
+		if allowed == to {
+			return true
+		}
+	}
+
+	return false
+}
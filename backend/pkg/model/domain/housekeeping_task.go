@@ -0,0 +1,35 @@
+package domain
+
+import (
+	"time"
+	"unit-management-be/pkg/model/domain/enum"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type HousekeepingTask struct {
+	ID          uuid.UUID                   `gorm:"type:varchar(36);primary_key" json:"id"`
+	UnitID      uuid.UUID                   `gorm:"type:varchar(36);index" json:"unitId"`
+	Status      enum.HousekeepingTaskStatus `gorm:"type:enum('Pending', 'In Progress', 'Completed', 'Cancelled')" json:"status"`
+	Assignee    string                      `gorm:"type:varchar(255)" json:"assignee"`
+	DueAt       time.Time                   `json:"dueAt"`
+	ClaimedAt   *time.Time                  `json:"claimedAt"`
+	CompletedAt *time.Time                  `json:"completedAt"`
+	CreatedAt   time.Time                   `json:"createdAt"`
+	UpdatedAt   time.Time                   `gorm:"autoUpdateTime" json:"updatedAt"`
+}
+
+func (h *HousekeepingTask) BeforeCreate(tx *gorm.DB) (err error) {
+	h.ID = uuid.New()
+	return
+}
+
+func (h *HousekeepingTask) TableName() string {
+	return "housekeeping_tasks"
+}
+
+// IsOpen reports whether the task still waits for the unit to be cleaned
+func (h *HousekeepingTask) IsOpen() bool {
+	return h.Status == enum.HousekeepingPending || h.Status == enum.HousekeepingInProgress
+}
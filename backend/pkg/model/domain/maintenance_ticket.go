@@ -0,0 +1,38 @@
+package domain
+
+import (
+	"time"
+	"unit-management-be/pkg/model/domain/enum"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type MaintenanceTicket struct {
+	ID              uuid.UUID           `gorm:"type:varchar(36);primary_key" json:"id"`
+	UnitID          uuid.UUID           `gorm:"type:varchar(36);index" json:"unitId"`
+	Title           string              `gorm:"type:varchar(255)" json:"title"`
+	Description     string              `gorm:"type:text" json:"description"`
+	Severity        enum.TicketSeverity `gorm:"type:enum('low', 'medium', 'high', 'critical')" json:"severity"`
+	Reporter        string              `gorm:"type:varchar(255)" json:"reporter"`
+	Assignee        string              `gorm:"type:varchar(255)" json:"assignee"`
+	Status          enum.TicketStatus   `gorm:"type:enum('Open', 'In Progress', 'Resolved')" json:"status"`
+	ResolutionNotes string              `gorm:"type:text" json:"resolutionNotes"`
+	ResolvedAt      *time.Time          `json:"resolvedAt"`
+	CreatedAt       time.Time           `gorm:"autoCreateTime" json:"createdAt"`
+	UpdatedAt       time.Time           `gorm:"autoUpdateTime" json:"updatedAt"`
+}
+
+func (m *MaintenanceTicket) BeforeCreate(tx *gorm.DB) (err error) {
+	m.ID = uuid.New()
+	return
+}
+
+func (m *MaintenanceTicket) TableName() string {
+	return "maintenance_tickets"
+}
+
+// IsOpen reports whether the ticket still keeps its unit in maintenance
+func (m *MaintenanceTicket) IsOpen() bool {
+	return m.Status == enum.TicketOpen || m.Status == enum.TicketInProgress
+}
@@ -0,0 +1,28 @@
+package domain
+
+import (
+	"time"
+	"unit-management-be/pkg/model/domain/enum"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type UnitStatusHistory struct {
+	ID         uuid.UUID        `gorm:"type:varchar(36);primary_key" json:"id"`
+	UnitID     uuid.UUID        `gorm:"type:varchar(36);index" json:"unitId"`
+	FromStatus *enum.UnitStatus `gorm:"type:enum('Available', 'Occupied', 'Cleaning In Progress', 'Maintenance Needed')" json:"fromStatus"`
+	ToStatus   enum.UnitStatus  `gorm:"type:enum('Available', 'Occupied', 'Cleaning In Progress', 'Maintenance Needed')" json:"toStatus"`
+	Actor      string           `gorm:"type:varchar(255)" json:"actor"`
+	Reason     string           `gorm:"type:varchar(500)" json:"reason"`
+	ChangedAt  time.Time        `json:"changedAt"`
+}
+
+func (h *UnitStatusHistory) BeforeCreate(tx *gorm.DB) (err error) {
+	h.ID = uuid.New()
+	return
+}
+
+func (h *UnitStatusHistory) TableName() string {
+	return "unit_status_history"
+}
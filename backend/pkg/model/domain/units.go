@@ -15,6 +15,7 @@ type Units struct {
 	Status      enum.UnitStatus `gorm:"type:enum('Available', 'Occupied', 'Cleaning In Progress', 'Maintenance Needed')" json:"status"`
 	DeletedAt   gorm.DeletedAt  `gorm:"index" json:"-"`
 	LastUpdated time.Time       `gorm:"autoUpdateTime" json:"lastUpdated"`
+	Version     int64           `gorm:"not null;default:1" json:"version"`
 }
 
 func (u *Units) BeforeCreate(tx *gorm.DB) (err error) {
@@ -0,0 +1,27 @@
+package domain
+
+import (
+	"time"
+	"unit-management-be/pkg/model/domain/enum"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type User struct {
+	ID           uuid.UUID     `gorm:"type:varchar(36);primary_key" json:"id"`
+	Username     string        `gorm:"type:varchar(100);uniqueIndex" json:"username"`
+	PasswordHash string        `gorm:"type:varchar(255)" json:"-"`
+	Role         enum.UserRole `gorm:"type:enum('admin', 'front_desk', 'housekeeping', 'read_only')" json:"role"`
+	CreatedAt    time.Time     `gorm:"autoCreateTime" json:"createdAt"`
+	UpdatedAt    time.Time     `gorm:"autoUpdateTime" json:"updatedAt"`
+}
+
+func (u *User) BeforeCreate(tx *gorm.DB) (err error) {
+	u.ID = uuid.New()
+	return
+}
+
+func (u *User) TableName() string {
+	return "users"
+}
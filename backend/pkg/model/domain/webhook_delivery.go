@@ -0,0 +1,37 @@
+package domain
+
+import (
+	"time"
+	"unit-management-be/pkg/model/domain/enum"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// WebhookDelivery is one event sent to one webhook, it is retried until delivered
+// or moved to the dead letters once every attempt failed
+type WebhookDelivery struct {
+	ID             uuid.UUID                  `gorm:"type:varchar(36);primary_key" json:"id"`
+	SubscriptionID uuid.UUID                  `gorm:"type:varchar(36);index" json:"webhookId"`
+	Event          enum.WebhookEvent          `gorm:"type:varchar(64)" json:"event"`
+	Payload        string                     `gorm:"type:text" json:"payload"`
+	Status         enum.WebhookDeliveryStatus `gorm:"type:enum('pending', 'delivered', 'dead')" json:"status"`
+	Attempts       int                        `json:"attempts"`
+	NextAttemptAt  *time.Time                 `json:"nextAttemptAt"`
+	LastStatusCode int                        `json:"lastStatusCode"`
+	LastError      string                     `gorm:"type:text" json:"lastError"`
+	DeliveredAt    *time.Time                 `json:"deliveredAt"`
+	CreatedAt      time.Time                  `gorm:"autoCreateTime" json:"createdAt"`
+	UpdatedAt      time.Time                  `gorm:"autoUpdateTime" json:"updatedAt"`
+}
+
+func (w *WebhookDelivery) BeforeCreate(tx *gorm.DB) (err error) {
+	if w.ID == uuid.Nil {
+		w.ID = uuid.New()
+	}
+	return
+}
+
+func (w *WebhookDelivery) TableName() string {
+	return "webhook_deliveries"
+}
@@ -0,0 +1,57 @@
+package domain
+
+import (
+	"slices"
+	"strings"
+	"time"
+	"unit-management-be/pkg/model/domain/enum"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type WebhookSubscription struct {
+	ID  uuid.UUID `gorm:"type:varchar(36);primary_key" json:"id"`
+	URL string    `gorm:"type:varchar(2048)" json:"url"`
+	// Events is the comma separated list of subscribed events
+	Events    string    `gorm:"type:varchar(255)" json:"-"`
+	Secret    string    `gorm:"type:varchar(255)" json:"-"`
+	Active    bool      `json:"active"`
+	CreatedBy string    `gorm:"type:varchar(255)" json:"createdBy"`
+	CreatedAt time.Time `gorm:"autoCreateTime" json:"createdAt"`
+	UpdatedAt time.Time `gorm:"autoUpdateTime" json:"updatedAt"`
+}
+
+func (w *WebhookSubscription) BeforeCreate(tx *gorm.DB) (err error) {
+	w.ID = uuid.New()
+	return
+}
+
+func (w *WebhookSubscription) TableName() string {
+	return "webhook_subscriptions"
+}
+
+// EventList returns the subscribed events
+func (w *WebhookSubscription) EventList() []enum.WebhookEvent {
+	events := make([]enum.WebhookEvent, 0)
+	for _, event := range strings.Split(w.Events, ",") {
+		if event != "" {
+			events = append(events, enum.WebhookEvent(event))
+		}
+	}
+	return events
+}
+
+// SetEvents stores the subscribed events
+func (w *WebhookSubscription) SetEvents(events []enum.WebhookEvent) {
+	values := make([]string, 0, len(events))
+	for _, event := range events {
+		values = append(values, string(event))
+	}
+	w.Events = strings.Join(values, ",")
+}
+
+// Subscribes reports whether the event has to be delivered to the webhook
+func (w *WebhookSubscription) Subscribes(event enum.WebhookEvent) bool {
+	return w.Active && slices.Contains(w.EventList(), event)
+}
@@ -0,0 +1,28 @@
+package dto
+
+type CursorPaginationData struct {
+	Limit int `json:"limit"`
+	// NextCursor and PrevCursor are null when there is no page in that direction
+	NextCursor *string `json:"nextCursor"`
+	PrevCursor *string `json:"prevCursor"`
+}
+
+type CursorPaginationResponse struct {
+	Content    interface{}          `json:"content"`
+	Pagination CursorPaginationData `json:"pagination"`
+}
+
+func NewCursorPaginationResponse(limit int, nextCursor, prevCursor string, data interface{}) *CursorPaginationResponse {
+	pagination := CursorPaginationData{Limit: limit}
+	if nextCursor != "" {
+		pagination.NextCursor = &nextCursor
+	}
+	if prevCursor != "" {
+		pagination.PrevCursor = &prevCursor
+	}
+
+	return &CursorPaginationResponse{
+		Content:    data,
+		Pagination: pagination,
+	}
+}
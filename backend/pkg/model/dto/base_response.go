@@ -4,6 +4,8 @@ type Response struct {
 	Success bool        `json:"success"`
 	Message string      `json:"message"`
 	Data    interface{} `json:"data"`
+	// RequestID is only set on errors, so a failed request can be found in the logs
+	RequestID string `json:"requestId,omitempty"`
 }
 
 func BaseResponse(success bool, msg string, data interface{}) Response {
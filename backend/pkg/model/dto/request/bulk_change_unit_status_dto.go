@@ -0,0 +1,10 @@
+package request
+
+type BulkChangeUnitStatusDto struct {
+	UnitIDs []string `json:"unitIds"`
+	Status  string   `json:"status"`
+	Reason  string   `json:"reason"`
+	// Mode is "atomic" (default) to apply every change or none, or "best_effort" to apply the valid ones
+	Mode  string `json:"mode"`
+	Actor string `json:"-"`
+}
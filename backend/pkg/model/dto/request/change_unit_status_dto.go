@@ -0,0 +1,7 @@
+package request
+
+type ChangeUnitStatusDto struct {
+	Status string `json:"status"`
+	Reason string `json:"reason"`
+	Actor  string `json:"-"`
+}
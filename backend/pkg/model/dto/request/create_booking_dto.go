@@ -0,0 +1,11 @@
+package request
+
+import "time"
+
+type CreateBookingDto struct {
+	UnitID       string    `json:"unitId"`
+	GuestName    string    `json:"guestName"`
+	GuestContact string    `json:"guestContact"`
+	StartAt      time.Time `json:"startAt"`
+	EndAt        time.Time `json:"endAt"`
+}
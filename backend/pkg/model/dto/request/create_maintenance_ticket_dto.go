@@ -0,0 +1,10 @@
+package request
+
+type CreateMaintenanceTicketDto struct {
+	UnitID      string `json:"unitId"`
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	Severity    string `json:"severity"`
+	Assignee    string `json:"assignee"`
+	Reporter    string `json:"-"`
+}
@@ -4,4 +4,5 @@ type CreateUnitDto struct {
 	Name   string `json:"name"`
 	Type   string `json:"type"`
 	Status string `json:"status"`
+	Actor  string `json:"-"`
 }
@@ -0,0 +1,7 @@
+package request
+
+type CreateUserDto struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+	Role     string `json:"role"`
+}
@@ -0,0 +1,11 @@
+package request
+
+type CreateWebhookDto struct {
+	URL string `json:"url"`
+	// Events to deliver, every event when empty
+	Events []string `json:"events"`
+	Secret string   `json:"secret"`
+	// Active defaults to true
+	Active    *bool  `json:"active"`
+	CreatedBy string `json:"-"`
+}
@@ -0,0 +1,9 @@
+package request
+
+// ImportUnitRowDto is a unit read from an imported CSV file, Row is the line number in the file
+type ImportUnitRowDto struct {
+	Row    int
+	Name   string
+	Type   string
+	Status string
+}
@@ -0,0 +1,6 @@
+package request
+
+type LoginDto struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
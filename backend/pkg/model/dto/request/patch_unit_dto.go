@@ -0,0 +1,12 @@
+package request
+
+type PatchUnitDto struct {
+	// Format is the media type of Patch, a JSON Merge Patch (RFC 7396) or a JSON Patch (RFC 6902)
+	Format string
+	Patch  []byte
+	Actor  string
+	// ExpectedVersion comes from the optional If-Match header, nil applies the patch to the current version
+	ExpectedVersion *int64
+	// StatusOnly rejects patches changing anything but the status and reason
+	StatusOnly bool
+}
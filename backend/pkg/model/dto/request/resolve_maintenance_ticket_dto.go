@@ -0,0 +1,6 @@
+package request
+
+type ResolveMaintenanceTicketDto struct {
+	ResolutionNotes string `json:"resolutionNotes"`
+	Actor           string `json:"-"`
+}
@@ -0,0 +1,19 @@
+package request
+
+import (
+	"time"
+	"unit-management-be/pkg/model/domain/enum"
+
+	"github.com/google/uuid"
+)
+
+// UnitCursorDto is the position a cursor page starts from, the page holds the units sorted
+// right after the unit with these values, or right before it when Backward is set
+type UnitCursorDto struct {
+	ID          uuid.UUID
+	Name        string
+	Type        enum.UnitType
+	Status      enum.UnitStatus
+	LastUpdated time.Time
+	Backward    bool
+}
@@ -0,0 +1,19 @@
+package request
+
+import (
+	"time"
+	"unit-management-be/pkg/model/domain/enum"
+)
+
+// UnitFilterDto holds the validated filters and ordering shared by the unit listing and export,
+// empty filters match every unit
+type UnitFilterDto struct {
+	Statuses []enum.UnitStatus
+	Types    []enum.UnitType
+	Name     string
+	// UpdatedFrom and UpdatedTo bound last_updated, both ends are inclusive
+	UpdatedFrom *time.Time
+	UpdatedTo   *time.Time
+	// Sort is applied in order, units are sorted by name when it is empty
+	Sort []UnitSortDto
+}
@@ -0,0 +1,13 @@
+package request
+
+import "unit-management-be/pkg/model/domain/enum"
+
+type UnitSortDto struct {
+	Field      enum.UnitSortField
+	Descending bool
+}
+
+// DefaultUnitSort is the order used when no sort is requested
+func DefaultUnitSort() []UnitSortDto {
+	return []UnitSortDto{{Field: enum.SortUnitName}}
+}
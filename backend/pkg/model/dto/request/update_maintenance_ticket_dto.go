@@ -0,0 +1,9 @@
+package request
+
+type UpdateMaintenanceTicketDto struct {
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	Severity    string `json:"severity"`
+	Assignee    string `json:"assignee"`
+	Status      string `json:"status"`
+}
@@ -2,4 +2,7 @@ package request
 
 type UpdateUnitDto struct {
 	CreateUnitDto
+	Reason string `json:"reason"`
+	// ExpectedVersion comes from the If-Match header, nil skips the check ("*")
+	ExpectedVersion *int64 `json:"-"`
 }
@@ -0,0 +1,11 @@
+package request
+
+type UpdateWebhookDto struct {
+	URL string `json:"url"`
+	// Events to deliver, every event when empty
+	Events []string `json:"events"`
+	// Secret keeps the current secret when empty
+	Secret string `json:"secret"`
+	// Active keeps the current state when omitted
+	Active *bool `json:"active"`
+}
@@ -0,0 +1,13 @@
+package request
+
+import (
+	"time"
+	"unit-management-be/pkg/model/domain/enum"
+)
+
+// UtilizationReportDto selects the range of a utilization report, From is inclusive and To exclusive
+type UtilizationReportDto struct {
+	From        time.Time
+	To          time.Time
+	Granularity enum.ReportGranularity
+}
@@ -0,0 +1,49 @@
+package response
+
+import "unit-management-be/pkg/model/domain/enum"
+
+const (
+	BulkOutcomeUpdated    = "updated"
+	BulkOutcomeSkipped    = "skipped"
+	BulkOutcomeFailed     = "failed"
+	BulkOutcomeRolledBack = "rolled_back"
+)
+
+type BulkUnitStatusResult struct {
+	UnitID     string           `json:"unitId"`
+	Outcome    string           `json:"outcome"`
+	FromStatus *enum.UnitStatus `json:"fromStatus,omitempty"`
+	Version    int64            `json:"version,omitempty"`
+	Code       int              `json:"code,omitempty"`
+	Error      string           `json:"error,omitempty"`
+	Detail     interface{}      `json:"detail,omitempty"`
+}
+
+type BulkUnitStatusResponse struct {
+	Mode       enum.BulkMode          `json:"mode"`
+	Status     enum.UnitStatus        `json:"status"`
+	Total      int                    `json:"total"`
+	Updated    int                    `json:"updated"`
+	Skipped    int                    `json:"skipped"`
+	Failed     int                    `json:"failed"`
+	RolledBack int                    `json:"rolledBack"`
+	Results    []BulkUnitStatusResult `json:"results"`
+}
+
+// Summarize counts the outcomes of the results
+func (b *BulkUnitStatusResponse) Summarize() {
+	b.Total = len(b.Results)
+	b.Updated, b.Skipped, b.Failed, b.RolledBack = 0, 0, 0, 0
+	for _, result := range b.Results {
+		switch result.Outcome {
+		case BulkOutcomeUpdated:
+			b.Updated++
+		case BulkOutcomeSkipped:
+			b.Skipped++
+		case BulkOutcomeFailed:
+			b.Failed++
+		case BulkOutcomeRolledBack:
+			b.RolledBack++
+		}
+	}
+}
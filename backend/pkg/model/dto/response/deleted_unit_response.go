@@ -0,0 +1,29 @@
+package response
+
+import (
+	"time"
+	"unit-management-be/pkg/model/domain"
+	"unit-management-be/pkg/model/domain/enum"
+
+	"github.com/google/uuid"
+)
+
+type DeletedUnitResponse struct {
+	ID        uuid.UUID       `json:"id"`
+	Name      string          `json:"name"`
+	Type      enum.UnitType   `json:"type"`
+	Status    enum.UnitStatus `json:"status"`
+	Version   int64           `json:"version"`
+	DeletedAt time.Time       `json:"deletedAt"`
+}
+
+func BuildDeletedUnitResponseFromUnit(unit domain.Units) DeletedUnitResponse {
+	return DeletedUnitResponse{
+		ID:        unit.ID,
+		Name:      unit.Name,
+		Type:      unit.Type,
+		Status:    unit.Status,
+		Version:   unit.Version,
+		DeletedAt: unit.DeletedAt.Time,
+	}
+}
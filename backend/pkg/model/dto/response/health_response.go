@@ -0,0 +1,12 @@
+package response
+
+const (
+	HealthOK      = "ok"
+	HealthFailing = "failing"
+)
+
+type HealthResponse struct {
+	Status string `json:"status"`
+	// Checks holds the outcome of every readiness check, ok or failing
+	Checks map[string]string `json:"checks,omitempty"`
+}
@@ -0,0 +1,21 @@
+package response
+
+import (
+	"time"
+	"unit-management-be/pkg/model/domain/enum"
+
+	"github.com/google/uuid"
+)
+
+type HousekeepingTaskResponse struct {
+	ID          uuid.UUID                   `json:"id"`
+	UnitID      uuid.UUID                   `json:"unitId"`
+	UnitName    string                      `json:"unitName"`
+	UnitType    enum.UnitType               `json:"unitType"`
+	Status      enum.HousekeepingTaskStatus `json:"status"`
+	Assignee    string                      `json:"assignee"`
+	DueAt       time.Time                   `json:"dueAt"`
+	ClaimedAt   *time.Time                  `json:"claimedAt"`
+	CompletedAt *time.Time                  `json:"completedAt"`
+	CreatedAt   time.Time                   `json:"createdAt"`
+}
@@ -0,0 +1,15 @@
+package response
+
+type ImportRowError struct {
+	Row     int    `json:"row"`
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+type ImportUnitsResponse struct {
+	DryRun    bool             `json:"dryRun"`
+	TotalRows int              `json:"totalRows"`
+	ValidRows int              `json:"validRows"`
+	Imported  int              `json:"imported"`
+	Errors    []ImportRowError `json:"errors"`
+}
@@ -0,0 +1,13 @@
+package response
+
+import (
+	"time"
+	"unit-management-be/pkg/model/domain"
+)
+
+type LoginResponse struct {
+	AccessToken string      `json:"accessToken"`
+	TokenType   string      `json:"tokenType"`
+	ExpiresAt   time.Time   `json:"expiresAt"`
+	User        domain.User `json:"user"`
+}
@@ -0,0 +1,6 @@
+package response
+
+type ReplayWebhookDeliveriesResponse struct {
+	// Requeued is the number of dead letters queued to be delivered again
+	Requeued int64 `json:"requeued"`
+}
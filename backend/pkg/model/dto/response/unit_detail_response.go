@@ -1,6 +1,7 @@
 package response
 
 import (
+	"time"
 	"unit-management-be/pkg/model/domain"
 	"unit-management-be/pkg/model/domain/enum"
 
@@ -8,17 +9,21 @@ import (
 )
 
 type UnitDetailResponse struct {
-	ID     uuid.UUID       `json:"id"`
-	Name   string          `json:"name"`
-	Type   enum.UnitType   `json:"type"`
-	Status enum.UnitStatus `json:"status"`
+	ID          uuid.UUID       `json:"id"`
+	Name        string          `json:"name"`
+	Type        enum.UnitType   `json:"type"`
+	Status      enum.UnitStatus `json:"status"`
+	Version     int64           `json:"version"`
+	LastUpdated time.Time       `json:"lastUpdated"`
 }
 
 func BuildUnitDetailResponseFromUnit(unit domain.Units) UnitDetailResponse {
 	return UnitDetailResponse{
-		ID:     unit.ID,
-		Name:   unit.Name,
-		Type:   unit.Type,
-		Status: unit.Status,
+		ID:          unit.ID,
+		Name:        unit.Name,
+		Type:        unit.Type,
+		Status:      unit.Status,
+		Version:     unit.Version,
+		LastUpdated: unit.LastUpdated,
 	}
 }
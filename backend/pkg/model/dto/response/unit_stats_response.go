@@ -0,0 +1,68 @@
+package response
+
+import (
+	"time"
+	"unit-management-be/pkg/model/domain/enum"
+)
+
+type UnitTypeStats struct {
+	Type                enum.UnitType             `json:"type"`
+	Total               int64                     `json:"total"`
+	Counts              map[enum.UnitStatus]int64 `json:"counts"`
+	OccupancyPercentage float64                   `json:"occupancyPercentage"`
+}
+
+type UnitStatusStats struct {
+	Status            enum.UnitStatus `json:"status"`
+	Total             int64           `json:"total"`
+	OldestLastUpdated *time.Time      `json:"oldestLastUpdated"`
+}
+
+type UnitStatsResponse struct {
+	Total    int64             `json:"total"`
+	Types    []UnitTypeStats   `json:"types"`
+	Statuses []UnitStatusStats `json:"statuses"`
+}
+
+// NewUnitStatsResponse returns the statistics of an empty inventory, every unit type and status is listed
+// in declaration order with zero counts so the aggregates only have to fill in the groups they found
+func NewUnitStatsResponse() UnitStatsResponse {
+	stats := UnitStatsResponse{
+		Types:    make([]UnitTypeStats, 0, len(enum.UnitTypes())),
+		Statuses: make([]UnitStatusStats, 0, len(enum.UnitStatuses())),
+	}
+
+	for _, unitType := range enum.UnitTypes() {
+		counts := make(map[enum.UnitStatus]int64, len(enum.UnitStatuses()))
+		for _, status := range enum.UnitStatuses() {
+			counts[status] = 0
+		}
+		stats.Types = append(stats.Types, UnitTypeStats{Type: unitType, Counts: counts})
+	}
+
+	for _, status := range enum.UnitStatuses() {
+		stats.Statuses = append(stats.Statuses, UnitStatusStats{Status: status})
+	}
+
+	return stats
+}
+
+// TypeStats returns the entry of the unit type, nil for an unknown type
+func (s *UnitStatsResponse) TypeStats(unitType enum.UnitType) *UnitTypeStats {
+	for i := range s.Types {
+		if s.Types[i].Type == unitType {
+			return &s.Types[i]
+		}
+	}
+	return nil
+}
+
+// StatusStats returns the entry of the unit status, nil for an unknown status
+func (s *UnitStatsResponse) StatusStats(status enum.UnitStatus) *UnitStatusStats {
+	for i := range s.Statuses {
+		if s.Statuses[i].Status == status {
+			return &s.Statuses[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,28 @@
+package response
+
+import (
+	"unit-management-be/pkg/model/domain"
+	"unit-management-be/pkg/model/domain/enum"
+
+	"github.com/google/uuid"
+)
+
+type UnitTransitionsResponse struct {
+	ID                 uuid.UUID         `json:"id"`
+	Status             enum.UnitStatus   `json:"status"`
+	AllowedTransitions []enum.UnitStatus `json:"allowedTransitions"`
+}
+
+type UnitTransitionErrorResponse struct {
+	From    enum.UnitStatus   `json:"from"`
+	To      enum.UnitStatus   `json:"to"`
+	Allowed []enum.UnitStatus `json:"allowed"`
+}
+
+func BuildUnitTransitionsResponseFromUnit(unit domain.Units) UnitTransitionsResponse {
+	return UnitTransitionsResponse{
+		ID:                 unit.ID,
+		Status:             unit.Status,
+		AllowedTransitions: enum.AllowedUnitStatusTransitions(unit.Status),
+	}
+}
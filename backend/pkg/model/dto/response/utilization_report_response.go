@@ -0,0 +1,42 @@
+package response
+
+import (
+	"time"
+	"unit-management-be/pkg/model/domain/enum"
+
+	"github.com/google/uuid"
+)
+
+// UtilizationPeriod holds the utilization of one day or week, times are in seconds. Only the time a unit
+// existed is tracked, a cleaning or maintenance spanning several periods is split between them
+type UtilizationPeriod struct {
+	Start                      time.Time `json:"start"`
+	End                        time.Time `json:"end"`
+	TrackedSeconds             int64     `json:"trackedSeconds"`
+	OccupiedSeconds            int64     `json:"occupiedSeconds"`
+	OccupancyPercentage        float64   `json:"occupancyPercentage"`
+	Cleanings                  int64     `json:"cleanings"`
+	CleaningSeconds            int64     `json:"cleaningSeconds"`
+	AverageCleaningSeconds     int64     `json:"averageCleaningSeconds"`
+	MaintenanceDowntimeSeconds int64     `json:"maintenanceDowntimeSeconds"`
+}
+
+type UnitUtilization struct {
+	UnitID  uuid.UUID           `json:"unitId"`
+	Name    string              `json:"name"`
+	Type    enum.UnitType       `json:"type"`
+	Periods []UtilizationPeriod `json:"periods"`
+}
+
+type UnitTypeUtilization struct {
+	Type    enum.UnitType       `json:"type"`
+	Periods []UtilizationPeriod `json:"periods"`
+}
+
+type UtilizationReportResponse struct {
+	From        time.Time              `json:"from"`
+	To          time.Time              `json:"to"`
+	Granularity enum.ReportGranularity `json:"granularity"`
+	Types       []UnitTypeUtilization  `json:"types"`
+	Units       []UnitUtilization      `json:"units"`
+}
@@ -0,0 +1,26 @@
+package response
+
+import (
+	"time"
+	"unit-management-be/pkg/model/domain/enum"
+
+	"github.com/google/uuid"
+)
+
+// WebhookPayload is the body posted to a webhook, the id is the same on every attempt
+// of a delivery so receivers can drop duplicates
+type WebhookPayload struct {
+	ID         uuid.UUID         `json:"id"`
+	Event      enum.WebhookEvent `json:"event"`
+	OccurredAt time.Time         `json:"occurredAt"`
+	Data       WebhookUnitData   `json:"data"`
+}
+
+// WebhookUnitData carries the unit after the change, the status fields are only set for unit.status_changed
+type WebhookUnitData struct {
+	Unit       UnitDetailResponse `json:"unit"`
+	FromStatus *enum.UnitStatus   `json:"fromStatus,omitempty"`
+	ToStatus   *enum.UnitStatus   `json:"toStatus,omitempty"`
+	Actor      string             `json:"actor,omitempty"`
+	Reason     string             `json:"reason,omitempty"`
+}
@@ -0,0 +1,32 @@
+package response
+
+import (
+	"time"
+	"unit-management-be/pkg/model/domain"
+	"unit-management-be/pkg/model/domain/enum"
+
+	"github.com/google/uuid"
+)
+
+// WebhookResponse is a webhook subscription without its secret
+type WebhookResponse struct {
+	ID        uuid.UUID           `json:"id"`
+	URL       string              `json:"url"`
+	Events    []enum.WebhookEvent `json:"events"`
+	Active    bool                `json:"active"`
+	CreatedBy string              `json:"createdBy"`
+	CreatedAt time.Time           `json:"createdAt"`
+	UpdatedAt time.Time           `json:"updatedAt"`
+}
+
+func BuildWebhookResponse(subscription domain.WebhookSubscription) WebhookResponse {
+	return WebhookResponse{
+		ID:        subscription.ID,
+		URL:       subscription.URL,
+		Events:    subscription.EventList(),
+		Active:    subscription.Active,
+		CreatedBy: subscription.CreatedBy,
+		CreatedAt: subscription.CreatedAt,
+		UpdatedAt: subscription.UpdatedAt,
+	}
+}
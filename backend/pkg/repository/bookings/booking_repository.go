@@ -0,0 +1,20 @@
+package bookings
+
+import (
+	"context"
+	"errors"
+	"time"
+	"unit-management-be/pkg/model/domain"
+)
+
+// ErrBookingOverlap is returned when the booked period collides with another active booking of the same unit
+var ErrBookingOverlap = errors.New("booking overlaps with another active booking of the unit")
+
+type BookingRepository interface {
+	Create(ctx context.Context, booking domain.Booking) (domain.Booking, error)
+	GetByID(ctx context.Context, id string) (domain.Booking, error)
+	FindAll(ctx context.Context, unitID, status string, page, size int) ([]domain.Booking, int64, error)
+	HasOverlap(ctx context.Context, unitID string, startAt, endAt time.Time, excludeID string) (bool, error)
+	Update(ctx context.Context, booking domain.Booking) error
+	Transaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
@@ -0,0 +1,130 @@
+package bookings
+
+import (
+	"context"
+	"time"
+	"unit-management-be/pkg/logging"
+	"unit-management-be/pkg/model/domain"
+	"unit-management-be/pkg/model/domain/enum"
+	"unit-management-be/pkg/transaction"
+	"unit-management-be/pkg/utils"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type BookingRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewBookingRepository(db *gorm.DB) BookingRepository {
+	return &BookingRepositoryImpl{db: db}
+}
+
+// Create inserts the booking while holding a lock on the unit row, so two concurrent
+// bookings of the same unit cannot both pass the overlap check
+func (b *BookingRepositoryImpl) Create(ctx context.Context, booking domain.Booking) (domain.Booking, error) {
+	err := transaction.DB(ctx, b.db).Transaction(func(tx *gorm.DB) error {
+		var unit domain.Units
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ? AND deleted_at IS NULL", booking.UnitID).First(&unit).Error; err != nil {
+			return err
+		}
+
+		overlap, err := hasOverlap(tx, booking.UnitID.String(), booking.StartAt, booking.EndAt, "")
+		if err != nil {
+			return err
+		}
+		if overlap {
+			return ErrBookingOverlap
+		}
+
+		return tx.Create(&booking).Error
+	})
+	if err != nil {
+		logging.Error(ctx, "failed to create new booking", err)
+		return booking, err
+	}
+
+	return booking, nil
+}
+
+func (b *BookingRepositoryImpl) GetByID(ctx context.Context, id string) (domain.Booking, error) {
+	response := domain.Booking{}
+	if err := transaction.DB(ctx, b.db).Where("id = ?", id).First(&response).Error; err != nil {
+		logging.Error(ctx, "failed to get booking by id", err)
+		return response, err
+	}
+	return response, nil
+}
+
+func (b *BookingRepositoryImpl) FindAll(ctx context.Context, unitID, status string, page, size int) ([]domain.Booking, int64, error) {
+	bookings := make([]domain.Booking, 0)
+	baseQuery := transaction.DB(ctx, b.db).Model(&domain.Booking{})
+
+	if !utils.IsEmptyString(unitID) {
+		baseQuery = baseQuery.Where("unit_id = ?", unitID)
+	}
+
+	if !utils.IsEmptyString(status) {
+		baseQuery = baseQuery.Where("status = ?", status)
+	}
+
+	var total int64
+	if err := baseQuery.Count(&total).Error; err != nil {
+		logging.Error(ctx, "failed to count bookings", err)
+		return bookings, total, err
+	}
+
+	offset := (page - 1) * size
+	paginateQuery := baseQuery.Limit(size).Offset(offset).Order("start_at DESC")
+	if err := paginateQuery.Find(&bookings).Error; err != nil {
+		logging.Error(ctx, "failed to find bookings", err)
+		return bookings, total, err
+	}
+
+	return bookings, total, nil
+}
+
+func (b *BookingRepositoryImpl) HasOverlap(ctx context.Context, unitID string, startAt, endAt time.Time, excludeID string) (bool, error) {
+	overlap, err := hasOverlap(transaction.DB(ctx, b.db), unitID, startAt, endAt, excludeID)
+	if err != nil {
+		logging.Error(ctx, "failed to check booking overlap", err)
+		return false, err
+	}
+
+	return overlap, nil
+}
+
+func (b *BookingRepositoryImpl) Update(ctx context.Context, booking domain.Booking) error {
+	if err := transaction.DB(ctx, b.db).Save(&booking).Error; err != nil {
+		logging.Error(ctx, "failed to save booking", err)
+		return err
+	}
+
+	return nil
+}
+
+// hasOverlap checks active bookings of the unit whose period intersects [startAt, endAt)
+func hasOverlap(db *gorm.DB, unitID string, startAt, endAt time.Time, excludeID string) (bool, error) {
+	query := db.Model(&domain.Booking{}).
+		Where("unit_id = ?", unitID).
+		Where("status IN ?", []enum.BookingStatus{enum.BookingReserved, enum.BookingCheckedIn}).
+		Where("start_at < ? AND end_at > ?", endAt, startAt)
+
+	if !utils.IsEmptyString(excludeID) {
+		query = query.Where("id <> ?", excludeID)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return false, err
+	}
+
+	return total > 0, nil
+}
+
+// Transaction runs fn in a single database transaction, the repositories called with the context
+// fn receives join it
+func (b *BookingRepositoryImpl) Transaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return transaction.Run(ctx, b.db, fn)
+}
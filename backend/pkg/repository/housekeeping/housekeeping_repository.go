@@ -0,0 +1,19 @@
+package housekeeping
+
+import (
+	"context"
+	"time"
+	"unit-management-be/pkg/model/domain"
+	"unit-management-be/pkg/model/dto/response"
+)
+
+type HousekeepingRepository interface {
+	Create(ctx context.Context, task domain.HousekeepingTask) (domain.HousekeepingTask, error)
+	GetByID(ctx context.Context, id string) (domain.HousekeepingTask, error)
+	FindAll(ctx context.Context, status, assignee string, page, size int) ([]response.HousekeepingTaskResponse, int64, error)
+	FindPending(ctx context.Context) ([]response.HousekeepingTaskResponse, error)
+	FindOpenByUnitID(ctx context.Context, unitID string) ([]domain.HousekeepingTask, error)
+	Claim(ctx context.Context, id, assignee string, claimedAt time.Time) (bool, error)
+	Update(ctx context.Context, task domain.HousekeepingTask) error
+	Transaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
@@ -0,0 +1,139 @@
+package housekeeping
+
+import (
+	"context"
+	"time"
+	"unit-management-be/pkg/logging"
+	"unit-management-be/pkg/model/domain"
+	"unit-management-be/pkg/model/domain/enum"
+	"unit-management-be/pkg/model/dto/response"
+	"unit-management-be/pkg/transaction"
+	"unit-management-be/pkg/utils"
+
+	"gorm.io/gorm"
+)
+
+const selectTaskStatement = "housekeeping_tasks.id AS id, housekeeping_tasks.unit_id AS unit_id, units.name AS unit_name, units.type AS unit_type, " +
+	"housekeeping_tasks.status AS status, housekeeping_tasks.assignee AS assignee, housekeeping_tasks.due_at AS due_at, " +
+	"housekeeping_tasks.claimed_at AS claimed_at, housekeeping_tasks.completed_at AS completed_at, housekeeping_tasks.created_at AS created_at"
+
+type HousekeepingRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewHousekeepingRepository(db *gorm.DB) HousekeepingRepository {
+	return &HousekeepingRepositoryImpl{db: db}
+}
+
+func (h *HousekeepingRepositoryImpl) Create(ctx context.Context, task domain.HousekeepingTask) (domain.HousekeepingTask, error) {
+	if err := transaction.DB(ctx, h.db).Create(&task).Error; err != nil {
+		logging.Error(ctx, "failed to create housekeeping task", err)
+		return task, err
+	}
+
+	return task, nil
+}
+
+func (h *HousekeepingRepositoryImpl) GetByID(ctx context.Context, id string) (domain.HousekeepingTask, error) {
+	response := domain.HousekeepingTask{}
+	if err := transaction.DB(ctx, h.db).Where("id = ?", id).First(&response).Error; err != nil {
+		logging.Error(ctx, "failed to get housekeeping task by id", err)
+		return response, err
+	}
+	return response, nil
+}
+
+func (h *HousekeepingRepositoryImpl) FindAll(ctx context.Context, status, assignee string, page, size int) ([]response.HousekeepingTaskResponse, int64, error) {
+	tasks := make([]response.HousekeepingTaskResponse, 0)
+	baseQuery := h.baseTaskQuery(ctx)
+
+	if !utils.IsEmptyString(status) {
+		baseQuery = baseQuery.Where("housekeeping_tasks.status = ?", status)
+	}
+
+	if !utils.IsEmptyString(assignee) {
+		baseQuery = baseQuery.Where("housekeeping_tasks.assignee = ?", assignee)
+	}
+
+	var total int64
+	if err := baseQuery.Count(&total).Error; err != nil {
+		logging.Error(ctx, "failed to count housekeeping tasks", err)
+		return tasks, total, err
+	}
+
+	offset := (page - 1) * size
+	paginateQuery := baseQuery.Limit(size).Offset(offset).Order("housekeeping_tasks.created_at ASC")
+	if err := paginateQuery.Scan(&tasks).Error; err != nil {
+		logging.Error(ctx, "failed to scan housekeeping tasks", err)
+		return tasks, total, err
+	}
+
+	return tasks, total, nil
+}
+
+func (h *HousekeepingRepositoryImpl) FindPending(ctx context.Context) ([]response.HousekeepingTaskResponse, error) {
+	tasks := make([]response.HousekeepingTaskResponse, 0)
+	query := h.baseTaskQuery(ctx).
+		Where("housekeeping_tasks.status = ?", enum.HousekeepingPending).
+		Order("housekeeping_tasks.created_at ASC")
+
+	if err := query.Scan(&tasks).Error; err != nil {
+		logging.Error(ctx, "failed to scan pending housekeeping tasks", err)
+		return tasks, err
+	}
+
+	return tasks, nil
+}
+
+func (h *HousekeepingRepositoryImpl) FindOpenByUnitID(ctx context.Context, unitID string) ([]domain.HousekeepingTask, error) {
+	tasks := make([]domain.HousekeepingTask, 0)
+	query := transaction.DB(ctx, h.db).Where("unit_id = ?", unitID).
+		Where("status IN ?", []enum.HousekeepingTaskStatus{enum.HousekeepingPending, enum.HousekeepingInProgress})
+
+	if err := query.Find(&tasks).Error; err != nil {
+		logging.Error(ctx, "failed to find open housekeeping tasks", err)
+		return tasks, err
+	}
+
+	return tasks, nil
+}
+
+// Claim assigns a pending task with a conditional update, false is returned when
+// another staff member claimed the task first
+func (h *HousekeepingRepositoryImpl) Claim(ctx context.Context, id, assignee string, claimedAt time.Time) (bool, error) {
+	result := transaction.DB(ctx, h.db).Model(&domain.HousekeepingTask{}).
+		Where("id = ? AND status = ?", id, enum.HousekeepingPending).
+		Updates(map[string]interface{}{
+			"status":     enum.HousekeepingInProgress,
+			"assignee":   assignee,
+			"claimed_at": claimedAt,
+		})
+	if result.Error != nil {
+		logging.Error(ctx, "failed to claim housekeeping task", result.Error)
+		return false, result.Error
+	}
+
+	return result.RowsAffected > 0, nil
+}
+
+func (h *HousekeepingRepositoryImpl) Update(ctx context.Context, task domain.HousekeepingTask) error {
+	if err := transaction.DB(ctx, h.db).Save(&task).Error; err != nil {
+		logging.Error(ctx, "failed to save housekeeping task", err)
+		return err
+	}
+
+	return nil
+}
+
+func (h *HousekeepingRepositoryImpl) baseTaskQuery(ctx context.Context) *gorm.DB {
+	return transaction.DB(ctx, h.db).Table("housekeeping_tasks").
+		Select(selectTaskStatement).
+		Joins("JOIN units ON units.id = housekeeping_tasks.unit_id").
+		Where("units.deleted_at IS NULL")
+}
+
+// Transaction runs fn in a single database transaction, the repositories called with the context
+// fn receives join it
+func (h *HousekeepingRepositoryImpl) Transaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return transaction.Run(ctx, h.db, fn)
+}
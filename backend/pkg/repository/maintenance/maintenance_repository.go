@@ -0,0 +1,20 @@
+package maintenance
+
+import (
+	"context"
+	"errors"
+	"unit-management-be/pkg/model/domain"
+)
+
+// ErrTicketResolved is returned when updating a ticket that was resolved since it was read
+var ErrTicketResolved = errors.New("maintenance ticket has already been resolved")
+
+type MaintenanceRepository interface {
+	Create(ctx context.Context, ticket domain.MaintenanceTicket) (domain.MaintenanceTicket, error)
+	GetByID(ctx context.Context, id string) (domain.MaintenanceTicket, error)
+	FindAll(ctx context.Context, unitID, status, severity string, page, size int) ([]domain.MaintenanceTicket, int64, error)
+	FindOpenByUnitID(ctx context.Context, unitID string) ([]domain.MaintenanceTicket, error)
+	// Update saves the ticket unless it has been resolved in the meantime, ErrTicketResolved is returned then
+	Update(ctx context.Context, ticket domain.MaintenanceTicket) error
+	Transaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
@@ -0,0 +1,116 @@
+package maintenance
+
+import (
+	"context"
+	"unit-management-be/pkg/logging"
+	"unit-management-be/pkg/model/domain"
+	"unit-management-be/pkg/model/domain/enum"
+	"unit-management-be/pkg/transaction"
+	"unit-management-be/pkg/utils"
+
+	"gorm.io/gorm"
+)
+
+type MaintenanceRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewMaintenanceRepository(db *gorm.DB) MaintenanceRepository {
+	return &MaintenanceRepositoryImpl{db: db}
+}
+
+func (m *MaintenanceRepositoryImpl) Create(ctx context.Context, ticket domain.MaintenanceTicket) (domain.MaintenanceTicket, error) {
+	if err := transaction.DB(ctx, m.db).Create(&ticket).Error; err != nil {
+		logging.Error(ctx, "failed to create maintenance ticket", err)
+		return ticket, err
+	}
+
+	return ticket, nil
+}
+
+func (m *MaintenanceRepositoryImpl) GetByID(ctx context.Context, id string) (domain.MaintenanceTicket, error) {
+	response := domain.MaintenanceTicket{}
+	if err := transaction.DB(ctx, m.db).Where("id = ?", id).First(&response).Error; err != nil {
+		logging.Error(ctx, "failed to get maintenance ticket by id", err)
+		return response, err
+	}
+	return response, nil
+}
+
+func (m *MaintenanceRepositoryImpl) FindAll(ctx context.Context, unitID, status, severity string, page, size int) ([]domain.MaintenanceTicket, int64, error) {
+	tickets := make([]domain.MaintenanceTicket, 0)
+	baseQuery := transaction.DB(ctx, m.db).Model(&domain.MaintenanceTicket{})
+
+	if !utils.IsEmptyString(unitID) {
+		baseQuery = baseQuery.Where("unit_id = ?", unitID)
+	}
+
+	if !utils.IsEmptyString(status) {
+		baseQuery = baseQuery.Where("status = ?", status)
+	}
+
+	if !utils.IsEmptyString(severity) {
+		baseQuery = baseQuery.Where("severity = ?", severity)
+	}
+
+	var total int64
+	if err := baseQuery.Count(&total).Error; err != nil {
+		logging.Error(ctx, "failed to count maintenance tickets", err)
+		return tickets, total, err
+	}
+
+	offset := (page - 1) * size
+	paginateQuery := baseQuery.Limit(size).Offset(offset).Order("created_at DESC")
+	if err := paginateQuery.Find(&tickets).Error; err != nil {
+		logging.Error(ctx, "failed to find maintenance tickets", err)
+		return tickets, total, err
+	}
+
+	return tickets, total, nil
+}
+
+func (m *MaintenanceRepositoryImpl) FindOpenByUnitID(ctx context.Context, unitID string) ([]domain.MaintenanceTicket, error) {
+	tickets := make([]domain.MaintenanceTicket, 0)
+	query := transaction.DB(ctx, m.db).Where("unit_id = ?", unitID).
+		Where("status IN ?", []enum.TicketStatus{enum.TicketOpen, enum.TicketInProgress}).
+		Order("created_at ASC")
+
+	if err := query.Find(&tickets).Error; err != nil {
+		logging.Error(ctx, "failed to find open maintenance tickets", err)
+		return tickets, err
+	}
+
+	return tickets, nil
+}
+
+// Update writes the whole ticket in a single statement that only matches a ticket not resolved yet,
+// so a stale update cannot reopen a resolved ticket and a ticket is resolved once
+func (m *MaintenanceRepositoryImpl) Update(ctx context.Context, ticket domain.MaintenanceTicket) error {
+	result := transaction.DB(ctx, m.db).Model(&ticket).
+		Where("status <> ?", enum.TicketResolved).
+		Select("*").Omit("id", "created_at").
+		Updates(&ticket)
+	if result.Error != nil {
+		logging.Error(ctx, "failed to save maintenance ticket", result.Error)
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		// MySQL only counts the rows it changed, the ticket is read again to tell an unchanged ticket from a resolved one
+		current, err := m.GetByID(ctx, ticket.ID.String())
+		if err != nil {
+			return err
+		}
+		if current.Status == enum.TicketResolved {
+			return ErrTicketResolved
+		}
+	}
+
+	return nil
+}
+
+// Transaction runs fn in a single database transaction, the repositories called with the context
+// fn receives join it
+func (m *MaintenanceRepositoryImpl) Transaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return transaction.Run(ctx, m.db, fn)
+}
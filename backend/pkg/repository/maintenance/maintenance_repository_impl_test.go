@@ -0,0 +1,72 @@
+package maintenance_test
+
+import (
+	"context"
+	"testing"
+	"unit-management-be/internal/db"
+	"unit-management-be/pkg/model/domain"
+	"unit-management-be/pkg/model/domain/enum"
+	"unit-management-be/pkg/repository/maintenance"
+	"unit-management-be/pkg/repository/units"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupTest(t *testing.T) (maintenance.MaintenanceRepository, domain.MaintenanceTicket) {
+	database, err := db.Open(db.DriverSQLite, "file::memory:?_pragma=foreign_keys(1)&_time_format=sqlite", db.DefaultPool)
+	require.NoError(t, err)
+	sqlDB, err := database.DB()
+	require.NoError(t, err)
+	t.Cleanup(func() { sqlDB.Close() })
+
+	migrations, err := db.MigrationsFS(db.DriverSQLite, "")
+	require.NoError(t, err)
+	require.NoError(t, db.Migrate(database, db.DriverSQLite, migrations))
+
+	unit, err := units.NewUnitRepository(database).Create(context.Background(), domain.Units{Name: "Cabin 1", Type: enum.Cabin, Status: enum.MaintenanceNeeded})
+	require.NoError(t, err)
+	repository := maintenance.NewMaintenanceRepository(database)
+	ticket, err := repository.Create(context.Background(), domain.MaintenanceTicket{UnitID: unit.ID, Title: "Leak", Severity: enum.SeverityHigh, Status: enum.TicketOpen})
+	require.NoError(t, err)
+	return repository, ticket
+}
+
+func TestUpdate(t *testing.T) {
+	t.Run("Positive Case: Unchanged open ticket is saved", func(t *testing.T) {
+		repository, ticket := setupTest(t)
+
+		assert.NoError(t, repository.Update(context.Background(), ticket))
+	})
+
+	t.Run("Negative Case: Stale update does not reopen a resolved ticket", func(t *testing.T) {
+		repository, ticket := setupTest(t)
+		stale := ticket
+
+		resolved := ticket
+		resolved.Status, resolved.ResolutionNotes = enum.TicketResolved, "fixed the pipe"
+		require.NoError(t, repository.Update(context.Background(), resolved))
+
+		stale.Status, stale.Assignee = enum.TicketInProgress, "tech"
+		assert.ErrorIs(t, repository.Update(context.Background(), stale), maintenance.ErrTicketResolved)
+
+		current, err := repository.GetByID(context.Background(), ticket.ID.String())
+		require.NoError(t, err)
+		assert.Equal(t, enum.TicketResolved, current.Status)
+		assert.Equal(t, "fixed the pipe", current.ResolutionNotes)
+	})
+
+	t.Run("Negative Case: Ticket is resolved once", func(t *testing.T) {
+		repository, ticket := setupTest(t)
+
+		first, second := ticket, ticket
+		first.Status, first.ResolutionNotes = enum.TicketResolved, "first"
+		second.Status, second.ResolutionNotes = enum.TicketResolved, "second"
+		require.NoError(t, repository.Update(context.Background(), first))
+		assert.ErrorIs(t, repository.Update(context.Background(), second), maintenance.ErrTicketResolved)
+
+		current, err := repository.GetByID(context.Background(), ticket.ID.String())
+		require.NoError(t, err)
+		assert.Equal(t, "first", current.ResolutionNotes)
+	})
+}
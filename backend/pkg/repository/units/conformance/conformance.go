@@ -0,0 +1,726 @@
+// Package conformance holds the behaviour every UnitRepository implementation must share,
+// run it from the tests of an implementation through Run
+package conformance
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+	"unit-management-be/pkg/model/domain"
+	"unit-management-be/pkg/model/domain/enum"
+	"unit-management-be/pkg/model/dto/request"
+	"unit-management-be/pkg/model/dto/response"
+	"unit-management-be/pkg/repository/units"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// Factory returns an empty repository, it is called once per test case
+type Factory func(t *testing.T) units.UnitRepository
+
+// Run executes the conformance suite against the repositories created by newRepository
+func Run(t *testing.T, newRepository Factory) {
+	t.Run("CreateAndGetByID", func(t *testing.T) { testCreateAndGetByID(t, newRepository(t)) })
+	t.Run("GetByIDNotFound", func(t *testing.T) { testGetByIDNotFound(t, newRepository(t)) })
+	t.Run("Delete", func(t *testing.T) { testDelete(t, newRepository(t)) })
+	t.Run("FindAllFilters", func(t *testing.T) { testFindAllFilters(t, newRepository(t)) })
+	t.Run("FindAllUpdatedRange", func(t *testing.T) { testFindAllUpdatedRange(t, newRepository(t)) })
+	t.Run("FindAllSort", func(t *testing.T) { testFindAllSort(t, newRepository(t)) })
+	t.Run("FindAllNameWildcards", func(t *testing.T) { testFindAllNameWildcards(t, newRepository(t)) })
+	t.Run("FindAllPagination", func(t *testing.T) { testFindAllPagination(t, newRepository(t)) })
+	t.Run("FindAllByCursor", func(t *testing.T) { testFindAllByCursor(t, newRepository(t)) })
+	t.Run("FindAllForExport", func(t *testing.T) { testFindAllForExport(t, newRepository(t)) })
+	t.Run("FindAllForReport", func(t *testing.T) { testFindAllForReport(t, newRepository(t)) })
+	t.Run("GetStats", func(t *testing.T) { testGetStats(t, newRepository(t)) })
+	t.Run("UpdateVersion", func(t *testing.T) { testUpdateVersion(t, newRepository(t)) })
+	t.Run("StatusHistory", func(t *testing.T) { testStatusHistory(t, newRepository(t)) })
+	t.Run("StatusHistoryBetween", func(t *testing.T) { testStatusHistoryBetween(t, newRepository(t)) })
+	t.Run("FindDeleted", func(t *testing.T) { testFindDeleted(t, newRepository(t)) })
+	t.Run("Restore", func(t *testing.T) { testRestore(t, newRepository(t)) })
+	t.Run("Purge", func(t *testing.T) { testPurge(t, newRepository(t)) })
+	t.Run("PurgeDeletedBefore", func(t *testing.T) { testPurgeDeletedBefore(t, newRepository(t)) })
+	t.Run("TransactionCommit", func(t *testing.T) { testTransactionCommit(t, newRepository(t)) })
+	t.Run("TransactionRollback", func(t *testing.T) { testTransactionRollback(t, newRepository(t)) })
+	t.Run("LockByID", func(t *testing.T) { testLockByID(t, newRepository(t)) })
+}
+
+func createUnit(t *testing.T, repository units.UnitRepository, name string, unitType enum.UnitType, status enum.UnitStatus) domain.Units {
+	t.Helper()
+
+	unit, err := repository.Create(context.Background(), domain.Units{
+		Name:        name,
+		Type:        unitType,
+		Status:      status,
+		LastUpdated: time.Now(),
+		Version:     1,
+	})
+	require.NoError(t, err)
+	return unit
+}
+
+func createUnitUpdatedAt(t *testing.T, repository units.UnitRepository, name string, lastUpdated time.Time) domain.Units {
+	t.Helper()
+
+	unit, err := repository.Create(context.Background(), domain.Units{
+		Name:        name,
+		Type:        enum.Cabin,
+		Status:      enum.Available,
+		LastUpdated: lastUpdated,
+		Version:     1,
+	})
+	require.NoError(t, err)
+	return unit
+}
+
+func unitNames(t *testing.T, repository units.UnitRepository, filter request.UnitFilterDto) []string {
+	t.Helper()
+
+	found, total, err := repository.FindAll(context.Background(), filter, 1, 100)
+	require.NoError(t, err)
+	assert.Equal(t, int64(len(found)), total)
+
+	names := make([]string, 0, len(found))
+	for _, unit := range found {
+		names = append(names, unit.Name)
+	}
+	return names
+}
+
+func testCreateAndGetByID(t *testing.T, repository units.UnitRepository) {
+	created := createUnit(t, repository, "Capsule A1", enum.Capsule, enum.Available)
+	assert.NotEqual(t, uuid.Nil, created.ID)
+
+	unit, err := repository.GetByID(context.Background(), created.ID.String())
+	require.NoError(t, err)
+	assert.Equal(t, created.ID, unit.ID)
+	assert.Equal(t, "Capsule A1", unit.Name)
+	assert.Equal(t, enum.Capsule, unit.Type)
+	assert.Equal(t, enum.Available, unit.Status)
+	assert.Equal(t, int64(1), unit.Version)
+	assert.False(t, unit.LastUpdated.IsZero())
+}
+
+func testGetByIDNotFound(t *testing.T, repository units.UnitRepository) {
+	_, err := repository.GetByID(context.Background(), uuid.New().String())
+	assert.True(t, errors.Is(err, gorm.ErrRecordNotFound))
+
+	_, err = repository.GetByID(context.Background(), "not-a-uuid")
+	assert.True(t, errors.Is(err, gorm.ErrRecordNotFound))
+}
+
+func testDelete(t *testing.T, repository units.UnitRepository) {
+	deleted := createUnit(t, repository, "Cabin B1", enum.Cabin, enum.Available)
+	kept := createUnit(t, repository, "Cabin B2", enum.Cabin, enum.Available)
+
+	require.NoError(t, repository.Delete(context.Background(), deleted))
+
+	_, err := repository.GetByID(context.Background(), deleted.ID.String())
+	assert.True(t, errors.Is(err, gorm.ErrRecordNotFound))
+	assert.Equal(t, []string{"Cabin B2"}, unitNames(t, repository, request.UnitFilterDto{}))
+
+	exported, err := repository.FindAllForExport(context.Background(), request.UnitFilterDto{})
+	require.NoError(t, err)
+	require.Len(t, exported, 1)
+	assert.Equal(t, kept.ID, exported[0].ID)
+
+	deleted.Name = "Cabin B1 renamed"
+	assert.True(t, errors.Is(repository.Update(context.Background(), deleted), units.ErrVersionConflict))
+}
+
+func testFindAllFilters(t *testing.T, repository units.UnitRepository) {
+	createUnit(t, repository, "Capsule Beta", enum.Capsule, enum.Occupied)
+	createUnit(t, repository, "Cabin Alpha", enum.Cabin, enum.Available)
+	createUnit(t, repository, "capsule gamma", enum.Capsule, enum.Available)
+	createUnit(t, repository, "Cabin Delta", enum.Cabin, enum.CleaningInProgress)
+
+	available := []enum.UnitStatus{enum.Available}
+	capsule := []enum.UnitType{enum.Capsule}
+
+	assert.Equal(t, []string{"Cabin Alpha", "Cabin Delta", "Capsule Beta", "capsule gamma"}, unitNames(t, repository, request.UnitFilterDto{}))
+	assert.Equal(t, []string{"Cabin Alpha", "capsule gamma"}, unitNames(t, repository, request.UnitFilterDto{Statuses: available}))
+	assert.Equal(t, []string{"Capsule Beta", "capsule gamma"}, unitNames(t, repository, request.UnitFilterDto{Types: capsule}))
+	assert.Equal(t, []string{"Capsule Beta", "capsule gamma"}, unitNames(t, repository, request.UnitFilterDto{Name: "CAPSULE"}))
+	assert.Equal(t, []string{"capsule gamma"}, unitNames(t, repository, request.UnitFilterDto{Statuses: available, Types: capsule, Name: "amm"}))
+	assert.Empty(t, unitNames(t, repository, request.UnitFilterDto{Statuses: []enum.UnitStatus{enum.MaintenanceNeeded}}))
+
+	// several values of a filter match any of them
+	assert.Equal(t, []string{"Cabin Delta", "Capsule Beta"}, unitNames(t, repository, request.UnitFilterDto{
+		Statuses: []enum.UnitStatus{enum.Occupied, enum.CleaningInProgress},
+	}))
+	assert.Equal(t, []string{"Cabin Alpha", "Cabin Delta", "Capsule Beta", "capsule gamma"}, unitNames(t, repository, request.UnitFilterDto{
+		Types: []enum.UnitType{enum.Capsule, enum.Cabin},
+	}))
+}
+
+func testFindAllUpdatedRange(t *testing.T, repository units.UnitRepository) {
+	base := time.Date(2025, time.March, 10, 12, 0, 0, 0, time.Local)
+	for i, name := range []string{"Unit 1", "Unit 2", "Unit 3", "Unit 4"} {
+		createUnitUpdatedAt(t, repository, name, base.Add(time.Duration(i)*time.Hour))
+	}
+
+	from := base.Add(time.Hour)
+	to := base.Add(2 * time.Hour)
+
+	// both ends of the range are inclusive
+	assert.Equal(t, []string{"Unit 2", "Unit 3", "Unit 4"}, unitNames(t, repository, request.UnitFilterDto{UpdatedFrom: &from}))
+	assert.Equal(t, []string{"Unit 1", "Unit 2", "Unit 3"}, unitNames(t, repository, request.UnitFilterDto{UpdatedTo: &to}))
+	assert.Equal(t, []string{"Unit 2", "Unit 3"}, unitNames(t, repository, request.UnitFilterDto{UpdatedFrom: &from, UpdatedTo: &to}))
+
+	exported, err := repository.FindAllForExport(context.Background(), request.UnitFilterDto{UpdatedFrom: &to})
+	require.NoError(t, err)
+	assert.Len(t, exported, 2)
+}
+
+func testFindAllSort(t *testing.T, repository units.UnitRepository) {
+	base := time.Date(2025, time.March, 10, 12, 0, 0, 0, time.Local)
+	units := []struct {
+		name     string
+		unitType enum.UnitType
+		status   enum.UnitStatus
+	}{
+		{"Unit A", enum.Cabin, enum.MaintenanceNeeded},
+		{"Unit B", enum.Capsule, enum.Occupied},
+		{"Unit C", enum.Cabin, enum.Available},
+		{"Unit D", enum.Capsule, enum.Available},
+	}
+	for i, unit := range units {
+		created, err := repository.Create(context.Background(), domain.Units{
+			Name:        unit.name,
+			Type:        unit.unitType,
+			Status:      unit.status,
+			LastUpdated: base.Add(time.Duration(len(units)-i) * time.Minute),
+			Version:     1,
+		})
+		require.NoError(t, err)
+		require.NotEqual(t, uuid.Nil, created.ID)
+	}
+
+	sortBy := func(sort ...request.UnitSortDto) []string {
+		return unitNames(t, repository, request.UnitFilterDto{Sort: sort})
+	}
+
+	assert.Equal(t, []string{"Unit D", "Unit C", "Unit B", "Unit A"}, sortBy(request.UnitSortDto{Field: enum.SortUnitName, Descending: true}))
+	assert.Equal(t, []string{"Unit D", "Unit C", "Unit B", "Unit A"}, sortBy(request.UnitSortDto{Field: enum.SortUnitLastUpdated}))
+	assert.Equal(t, []string{"Unit A", "Unit B", "Unit C", "Unit D"}, sortBy(request.UnitSortDto{Field: enum.SortUnitLastUpdated, Descending: true}))
+
+	// status and type sort in declaration order, later fields break ties
+	assert.Equal(t, []string{"Unit C", "Unit D", "Unit B", "Unit A"}, sortBy(
+		request.UnitSortDto{Field: enum.SortUnitStatus},
+		request.UnitSortDto{Field: enum.SortUnitName},
+	))
+	assert.Equal(t, []string{"Unit D", "Unit B", "Unit C", "Unit A"}, sortBy(
+		request.UnitSortDto{Field: enum.SortUnitType},
+		request.UnitSortDto{Field: enum.SortUnitName, Descending: true},
+	))
+
+	exported, err := repository.FindAllForExport(context.Background(), request.UnitFilterDto{Sort: []request.UnitSortDto{{Field: enum.SortUnitStatus, Descending: true}}})
+	require.NoError(t, err)
+	require.Len(t, exported, 4)
+	assert.Equal(t, "Unit A", exported[0].Name)
+}
+
+func testFindAllNameWildcards(t *testing.T, repository units.UnitRepository) {
+	createUnit(t, repository, "Cabin 100%", enum.Cabin, enum.Available)
+	createUnit(t, repository, "Cabin_1", enum.Cabin, enum.Available)
+	createUnit(t, repository, "CabinX1", enum.Cabin, enum.Available)
+	createUnit(t, repository, "Cabin!", enum.Cabin, enum.Available)
+
+	// LIKE wildcards in the search must be matched literally
+	assert.Equal(t, []string{"Cabin 100%"}, unitNames(t, repository, request.UnitFilterDto{Name: "%"}))
+	assert.Equal(t, []string{"Cabin_1"}, unitNames(t, repository, request.UnitFilterDto{Name: "_1"}))
+	assert.Equal(t, []string{"Cabin!"}, unitNames(t, repository, request.UnitFilterDto{Name: "!"}))
+
+	exported, err := repository.FindAllForExport(context.Background(), request.UnitFilterDto{Name: "n_"})
+	require.NoError(t, err)
+	require.Len(t, exported, 1)
+	assert.Equal(t, "Cabin_1", exported[0].Name)
+}
+
+func testFindAllPagination(t *testing.T, repository units.UnitRepository) {
+	for _, name := range []string{"Unit 05", "Unit 01", "Unit 04", "Unit 02", "Unit 03"} {
+		createUnit(t, repository, name, enum.Cabin, enum.Available)
+	}
+
+	found, total, err := repository.FindAll(context.Background(), request.UnitFilterDto{}, 2, 2)
+	require.NoError(t, err)
+	assert.Equal(t, int64(5), total)
+	require.Len(t, found, 2)
+	assert.Equal(t, "Unit 03", found[0].Name)
+	assert.Equal(t, "Unit 04", found[1].Name)
+	assert.Equal(t, int64(1), found[0].Version)
+	assert.False(t, found[0].LastUpdated.IsZero())
+
+	found, total, err = repository.FindAll(context.Background(), request.UnitFilterDto{}, 3, 2)
+	require.NoError(t, err)
+	assert.Equal(t, int64(5), total)
+	require.Len(t, found, 1)
+	assert.Equal(t, "Unit 05", found[0].Name)
+
+	found, total, err = repository.FindAll(context.Background(), request.UnitFilterDto{}, 4, 2)
+	require.NoError(t, err)
+	assert.Equal(t, int64(5), total)
+	assert.NotNil(t, found)
+	assert.Empty(t, found)
+}
+
+func testFindAllByCursor(t *testing.T, repository units.UnitRepository) {
+	base := time.Date(2025, time.March, 10, 12, 0, 0, 0, time.Local)
+	created := make(map[string]domain.Units)
+	for i, name := range []string{"Unit 1", "Unit 2", "Unit 3", "Unit 4", "Unit 5"} {
+		// units 1 and 5, and 2 and 4, share their last update so the id has to break ties
+		created[name] = createUnitUpdatedAt(t, repository, name, base.Add(time.Duration(min(i, 4-i))*time.Hour))
+	}
+
+	filter := request.UnitFilterDto{Sort: []request.UnitSortDto{{Field: enum.SortUnitLastUpdated, Descending: true}}}
+	page := func(cursor *request.UnitCursorDto, limit int) []response.UnitDetailResponse {
+		found, err := repository.FindAllByCursor(context.Background(), filter, cursor, limit)
+		require.NoError(t, err)
+		return found
+	}
+	cursorOf := func(unit response.UnitDetailResponse, backward bool) *request.UnitCursorDto {
+		return &request.UnitCursorDto{ID: unit.ID, Name: unit.Name, Type: unit.Type, Status: unit.Status, LastUpdated: unit.LastUpdated, Backward: backward}
+	}
+
+	// walking forward visits every unit exactly once in sort order
+	all := page(nil, 10)
+	require.Len(t, all, 5)
+	visited := make([]uuid.UUID, 0)
+	var cursor *request.UnitCursorDto
+	for {
+		found := page(cursor, 2)
+		if len(found) == 0 {
+			break
+		}
+		for _, unit := range found {
+			visited = append(visited, unit.ID)
+		}
+		cursor = cursorOf(found[len(found)-1], false)
+	}
+	expected := make([]uuid.UUID, 0)
+	for _, unit := range all {
+		expected = append(expected, unit.ID)
+	}
+	assert.Equal(t, expected, visited)
+
+	// reading backward returns the units right before the cursor in sort order
+	before := page(cursorOf(all[3], true), 2)
+	require.Len(t, before, 2)
+	assert.Equal(t, all[1].ID, before[0].ID)
+	assert.Equal(t, all[2].ID, before[1].ID)
+	assert.Empty(t, page(cursorOf(all[0], true), 2))
+
+	// the cursor unit does not have to exist anymore
+	require.NoError(t, repository.Delete(context.Background(), created[all[1].Name]))
+	after := page(cursorOf(all[1], false), 10)
+	require.Len(t, after, 3)
+	assert.Equal(t, all[2].ID, after[0].ID)
+
+	// filters still apply to cursor pages
+	filter.Name = "Unit 5"
+	found := page(cursorOf(all[0], false), 10)
+	require.Len(t, found, 1)
+	assert.Equal(t, "Unit 5", found[0].Name)
+}
+
+func testFindAllForExport(t *testing.T, repository units.UnitRepository) {
+	createUnit(t, repository, "Cabin 2", enum.Cabin, enum.Occupied)
+	createUnit(t, repository, "Cabin 1", enum.Cabin, enum.Available)
+	createUnit(t, repository, "Capsule 1", enum.Capsule, enum.Available)
+
+	exported, err := repository.FindAllForExport(context.Background(), request.UnitFilterDto{Types: []enum.UnitType{enum.Cabin}})
+	require.NoError(t, err)
+	require.Len(t, exported, 2)
+	assert.Equal(t, "Cabin 1", exported[0].Name)
+	assert.Equal(t, "Cabin 2", exported[1].Name)
+	assert.Equal(t, enum.Occupied, exported[1].Status)
+	assert.Equal(t, int64(1), exported[1].Version)
+
+	exported, err = repository.FindAllForExport(context.Background(), request.UnitFilterDto{Statuses: []enum.UnitStatus{enum.Available}, Name: "1"})
+	require.NoError(t, err)
+	assert.Len(t, exported, 2)
+}
+
+func testFindAllForReport(t *testing.T, repository units.UnitRepository) {
+	createUnit(t, repository, "Cabin R2", enum.Cabin, enum.Available)
+	trashed := createUnit(t, repository, "Cabin R1", enum.Cabin, enum.Occupied)
+	require.NoError(t, repository.Delete(context.Background(), trashed))
+	require.NoError(t, repository.Delete(context.Background(), createUnit(t, repository, "Cabin R3", enum.Cabin, enum.Available)))
+
+	found, err := repository.FindAllForReport(context.Background(), nil)
+	require.NoError(t, err)
+	require.Len(t, found, 1)
+	assert.Equal(t, "Cabin R2", found[0].Name)
+
+	found, err = repository.FindAllForReport(context.Background(), []uuid.UUID{trashed.ID, uuid.New()})
+	require.NoError(t, err)
+	require.Len(t, found, 2)
+	assert.Equal(t, "Cabin R1", found[0].Name)
+	assert.True(t, found[0].DeletedAt.Valid)
+	assert.Equal(t, "Cabin R2", found[1].Name)
+}
+
+func testGetStats(t *testing.T, repository units.UnitRepository) {
+	empty, err := repository.GetStats(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, response.NewUnitStatsResponse(), empty)
+
+	base := time.Date(2025, time.March, 10, 12, 0, 0, 0, time.Local)
+	seeded := []struct {
+		unitType    enum.UnitType
+		status      enum.UnitStatus
+		lastUpdated time.Time
+	}{
+		{enum.Capsule, enum.Available, base.Add(2 * time.Hour)},
+		{enum.Capsule, enum.Available, base.Add(time.Hour)},
+		{enum.Cabin, enum.Available, base.Add(3 * time.Hour)},
+		{enum.Cabin, enum.Occupied, base},
+		{enum.Cabin, enum.MaintenanceNeeded, base.Add(4 * time.Hour)},
+	}
+	for i, unit := range seeded {
+		created, err := repository.Create(context.Background(), domain.Units{
+			Name:        fmt.Sprintf("Unit K%d", i+1),
+			Type:        unit.unitType,
+			Status:      unit.status,
+			LastUpdated: unit.lastUpdated,
+			Version:     1,
+		})
+		require.NoError(t, err)
+		require.NotEqual(t, uuid.Nil, created.ID)
+	}
+
+	// units in the trash are not counted
+	trashed := createUnitUpdatedAt(t, repository, "Unit K6", base.Add(-time.Hour))
+	require.NoError(t, repository.Delete(context.Background(), trashed))
+
+	stats, err := repository.GetStats(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, int64(5), stats.Total)
+
+	capsule := stats.TypeStats(enum.Capsule)
+	require.NotNil(t, capsule)
+	assert.Equal(t, int64(2), capsule.Total)
+	assert.Equal(t, map[enum.UnitStatus]int64{enum.Available: 2, enum.Occupied: 0, enum.CleaningInProgress: 0, enum.MaintenanceNeeded: 0}, capsule.Counts)
+	assert.Equal(t, 0.0, capsule.OccupancyPercentage)
+
+	cabin := stats.TypeStats(enum.Cabin)
+	require.NotNil(t, cabin)
+	assert.Equal(t, int64(3), cabin.Total)
+	assert.Equal(t, map[enum.UnitStatus]int64{enum.Available: 1, enum.Occupied: 1, enum.CleaningInProgress: 0, enum.MaintenanceNeeded: 1}, cabin.Counts)
+	assert.Equal(t, 33.33, cabin.OccupancyPercentage)
+
+	expected := map[enum.UnitStatus]struct {
+		total  int64
+		oldest *time.Time
+	}{
+		enum.Available:          {3, &seeded[1].lastUpdated},
+		enum.Occupied:           {1, &seeded[3].lastUpdated},
+		enum.CleaningInProgress: {0, nil},
+		enum.MaintenanceNeeded:  {1, &seeded[4].lastUpdated},
+	}
+	require.Len(t, stats.Statuses, len(enum.UnitStatuses()))
+	for i, status := range enum.UnitStatuses() {
+		statusStats := stats.Statuses[i]
+		assert.Equal(t, status, statusStats.Status)
+		assert.Equal(t, expected[status].total, statusStats.Total, status)
+		if expected[status].oldest == nil {
+			assert.Nil(t, statusStats.OldestLastUpdated, status)
+			continue
+		}
+		require.NotNil(t, statusStats.OldestLastUpdated, status)
+		assert.True(t, expected[status].oldest.Equal(*statusStats.OldestLastUpdated), "%s: %v", status, statusStats.OldestLastUpdated)
+	}
+}
+
+func testUpdateVersion(t *testing.T, repository units.UnitRepository) {
+	unit := createUnit(t, repository, "Capsule C1", enum.Capsule, enum.Available)
+
+	unit.Name = "Capsule C1 renamed"
+	unit.Status = enum.Occupied
+	require.NoError(t, repository.Update(context.Background(), unit))
+
+	stored, err := repository.GetByID(context.Background(), unit.ID.String())
+	require.NoError(t, err)
+	assert.Equal(t, "Capsule C1 renamed", stored.Name)
+	assert.Equal(t, enum.Occupied, stored.Status)
+	assert.Equal(t, int64(2), stored.Version)
+
+	// unit still carries version 1, so the second write must be rejected
+	unit.Name = "stale write"
+	assert.True(t, errors.Is(repository.Update(context.Background(), unit), units.ErrVersionConflict))
+
+	stored, err = repository.GetByID(context.Background(), unit.ID.String())
+	require.NoError(t, err)
+	assert.Equal(t, "Capsule C1 renamed", stored.Name)
+	assert.Equal(t, int64(2), stored.Version)
+
+	missing := domain.Units{ID: uuid.New(), Name: "missing", Version: 1}
+	assert.True(t, errors.Is(repository.Update(context.Background(), missing), units.ErrVersionConflict))
+}
+
+func testStatusHistory(t *testing.T, repository units.UnitRepository) {
+	unit := createUnit(t, repository, "Cabin D1", enum.Cabin, enum.Available)
+	other := createUnit(t, repository, "Cabin D2", enum.Cabin, enum.Available)
+
+	changedAt := time.Date(2025, 10, 1, 8, 0, 0, 0, time.UTC)
+	statuses := []enum.UnitStatus{enum.Occupied, enum.CleaningInProgress, enum.Available}
+	fromStatus := enum.Available
+	for i, status := range statuses {
+		from := fromStatus
+		require.NoError(t, repository.CreateStatusHistory(context.Background(), domain.UnitStatusHistory{
+			UnitID:     unit.ID,
+			FromStatus: &from,
+			ToStatus:   status,
+			Actor:      "tester",
+			Reason:     "conformance",
+			ChangedAt:  changedAt.Add(time.Duration(i) * time.Hour),
+		}))
+		fromStatus = status
+	}
+	require.NoError(t, repository.CreateStatusHistory(context.Background(), domain.UnitStatusHistory{
+		UnitID:    other.ID,
+		ToStatus:  enum.Available,
+		Actor:     "tester",
+		ChangedAt: changedAt,
+	}))
+
+	histories, total, err := repository.FindStatusHistory(context.Background(), unit.ID.String(), 1, 2)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), total)
+	require.Len(t, histories, 2)
+	assert.Equal(t, enum.Available, histories[0].ToStatus)
+	require.NotNil(t, histories[0].FromStatus)
+	assert.Equal(t, enum.CleaningInProgress, *histories[0].FromStatus)
+	assert.Equal(t, enum.CleaningInProgress, histories[1].ToStatus)
+	assert.True(t, changedAt.Add(2*time.Hour).Equal(histories[0].ChangedAt))
+	assert.Equal(t, "tester", histories[0].Actor)
+	assert.Equal(t, "conformance", histories[0].Reason)
+	assert.NotEqual(t, uuid.Nil, histories[0].ID)
+
+	histories, total, err = repository.FindStatusHistory(context.Background(), unit.ID.String(), 2, 2)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), total)
+	require.Len(t, histories, 1)
+	assert.Equal(t, enum.Occupied, histories[0].ToStatus)
+
+	histories, total, err = repository.FindStatusHistory(context.Background(), other.ID.String(), 1, 10)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+	require.Len(t, histories, 1)
+	assert.Nil(t, histories[0].FromStatus)
+}
+
+func testStatusHistoryBetween(t *testing.T, repository units.UnitRepository) {
+	base := time.Date(2025, 10, 1, 0, 0, 0, 0, time.UTC)
+	changes := map[string][]int{
+		"Cabin L1": {6, 9, 11, 14, 15},
+		"Cabin L2": {5, 7},
+		"Cabin L3": {13, 16},
+	}
+	namesByID := make(map[uuid.UUID]string)
+	for name, hours := range changes {
+		unit := createUnit(t, repository, name, enum.Cabin, enum.Available)
+		namesByID[unit.ID] = name
+		for _, hour := range hours {
+			require.NoError(t, repository.CreateStatusHistory(context.Background(), domain.UnitStatusHistory{
+				UnitID:    unit.ID,
+				ToStatus:  enum.Available,
+				Actor:     "tester",
+				ChangedAt: base.Add(time.Duration(hour) * time.Hour),
+			}))
+		}
+	}
+
+	histories, err := repository.FindStatusHistoryBetween(context.Background(), base.Add(8*time.Hour), base.Add(12*time.Hour))
+	require.NoError(t, err)
+
+	found := make([]string, 0, len(histories))
+	for _, history := range histories {
+		found = append(found, fmt.Sprintf("%s %d", namesByID[history.UnitID], history.ChangedAt.Sub(base)/time.Hour))
+	}
+	// the last change before the range and the first after it are kept for every unit
+	assert.Equal(t, []string{"Cabin L1 6", "Cabin L2 7", "Cabin L1 9", "Cabin L1 11", "Cabin L3 13", "Cabin L1 14"}, found)
+}
+
+func testFindDeleted(t *testing.T, repository units.UnitRepository) {
+	createUnit(t, repository, "Cabin G1", enum.Cabin, enum.Available)
+	for _, name := range []string{"Cabin G2", "Capsule G3", "Cabin G4"} {
+		require.NoError(t, repository.Delete(context.Background(), createUnit(t, repository, name, enum.Cabin, enum.Available)))
+	}
+
+	deleted, total, err := repository.FindDeleted(context.Background(), "", 1, 10)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), total)
+	require.Len(t, deleted, 3)
+	names := make([]string, 0, len(deleted))
+	for _, unit := range deleted {
+		names = append(names, unit.Name)
+		assert.False(t, unit.DeletedAt.IsZero())
+	}
+	assert.ElementsMatch(t, []string{"Cabin G2", "Capsule G3", "Cabin G4"}, names)
+
+	deleted, total, err = repository.FindDeleted(context.Background(), "cabin", 1, 1)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), total)
+	assert.Len(t, deleted, 1)
+}
+
+func testRestore(t *testing.T, repository units.UnitRepository) {
+	unit := createUnit(t, repository, "Cabin H1", enum.Cabin, enum.Occupied)
+	live := createUnit(t, repository, "Cabin H2", enum.Cabin, enum.Available)
+	require.NoError(t, repository.Delete(context.Background(), unit))
+
+	trashed, err := repository.GetDeletedByID(context.Background(), unit.ID.String())
+	require.NoError(t, err)
+	assert.Equal(t, "Cabin H1", trashed.Name)
+	assert.True(t, trashed.DeletedAt.Valid)
+
+	require.NoError(t, repository.Restore(context.Background(), trashed))
+
+	restored, err := repository.GetByID(context.Background(), unit.ID.String())
+	require.NoError(t, err)
+	assert.Equal(t, enum.Occupied, restored.Status)
+	assert.Equal(t, int64(2), restored.Version)
+
+	_, err = repository.GetDeletedByID(context.Background(), unit.ID.String())
+	assert.True(t, errors.Is(err, gorm.ErrRecordNotFound))
+	assert.True(t, errors.Is(repository.Restore(context.Background(), restored), gorm.ErrRecordNotFound))
+	assert.True(t, errors.Is(repository.Restore(context.Background(), live), gorm.ErrRecordNotFound))
+
+	_, total, err := repository.FindDeleted(context.Background(), "", 1, 10)
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), total)
+}
+
+func testPurge(t *testing.T, repository units.UnitRepository) {
+	unit := createUnit(t, repository, "Cabin I1", enum.Cabin, enum.Available)
+	live := createUnit(t, repository, "Cabin I2", enum.Cabin, enum.Available)
+	require.NoError(t, repository.CreateStatusHistory(context.Background(), domain.UnitStatusHistory{UnitID: unit.ID, ToStatus: enum.Available, Actor: "tester", ChangedAt: time.Now()}))
+	require.NoError(t, repository.Delete(context.Background(), unit))
+
+	assert.True(t, errors.Is(repository.Purge(context.Background(), live), gorm.ErrRecordNotFound))
+	require.NoError(t, repository.Purge(context.Background(), unit))
+	assert.True(t, errors.Is(repository.Purge(context.Background(), unit), gorm.ErrRecordNotFound))
+
+	_, err := repository.GetDeletedByID(context.Background(), unit.ID.String())
+	assert.True(t, errors.Is(err, gorm.ErrRecordNotFound))
+
+	_, total, err := repository.FindStatusHistory(context.Background(), unit.ID.String(), 1, 10)
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), total)
+
+	_, err = repository.GetByID(context.Background(), live.ID.String())
+	assert.NoError(t, err)
+}
+
+func testPurgeDeletedBefore(t *testing.T, repository units.UnitRepository) {
+	createUnit(t, repository, "Cabin J1", enum.Cabin, enum.Available)
+	require.NoError(t, repository.Delete(context.Background(), createUnit(t, repository, "Cabin J2", enum.Cabin, enum.Available)))
+	require.NoError(t, repository.Delete(context.Background(), createUnit(t, repository, "Cabin J3", enum.Cabin, enum.Available)))
+
+	purged, err := repository.PurgeDeletedBefore(context.Background(), time.Now().Add(-time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), purged)
+
+	purged, err = repository.PurgeDeletedBefore(context.Background(), time.Now().Add(time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), purged)
+
+	_, total, err := repository.FindDeleted(context.Background(), "", 1, 10)
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), total)
+	assert.Equal(t, []string{"Cabin J1"}, unitNames(t, repository, request.UnitFilterDto{}))
+}
+
+func testTransactionCommit(t *testing.T, repository units.UnitRepository) {
+	existing := createUnit(t, repository, "Cabin E1", enum.Cabin, enum.Available)
+
+	var created domain.Units
+	err := repository.Transaction(context.Background(), func(ctx context.Context, tx units.UnitRepository) error {
+		var err error
+		created, err = tx.Create(ctx, domain.Units{Name: "Cabin E2", Type: enum.Cabin, Status: enum.Available, LastUpdated: time.Now(), Version: 1})
+		if err != nil {
+			return err
+		}
+
+		existing.Status = enum.Occupied
+		if err := tx.Update(ctx, existing); err != nil {
+			return err
+		}
+
+		return tx.CreateStatusHistory(ctx, domain.UnitStatusHistory{UnitID: existing.ID, ToStatus: enum.Occupied, Actor: "tester", ChangedAt: time.Now()})
+	})
+	require.NoError(t, err)
+
+	_, err = repository.GetByID(context.Background(), created.ID.String())
+	assert.NoError(t, err)
+
+	stored, err := repository.GetByID(context.Background(), existing.ID.String())
+	require.NoError(t, err)
+	assert.Equal(t, enum.Occupied, stored.Status)
+	assert.Equal(t, int64(2), stored.Version)
+
+	_, total, err := repository.FindStatusHistory(context.Background(), existing.ID.String(), 1, 10)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+}
+
+func testTransactionRollback(t *testing.T, repository units.UnitRepository) {
+	existing := createUnit(t, repository, "Cabin F1", enum.Cabin, enum.Available)
+	errAbort := errors.New("abort")
+
+	var created domain.Units
+	err := repository.Transaction(context.Background(), func(ctx context.Context, tx units.UnitRepository) error {
+		var err error
+		created, err = tx.Create(ctx, domain.Units{Name: "Cabin F2", Type: enum.Cabin, Status: enum.Available, LastUpdated: time.Now(), Version: 1})
+		if err != nil {
+			return err
+		}
+
+		existing.Status = enum.Occupied
+		if err := tx.Update(ctx, existing); err != nil {
+			return err
+		}
+
+		if err := tx.CreateStatusHistory(ctx, domain.UnitStatusHistory{UnitID: existing.ID, ToStatus: enum.Occupied, Actor: "tester", ChangedAt: time.Now()}); err != nil {
+			return err
+		}
+		return errAbort
+	})
+	assert.True(t, errors.Is(err, errAbort))
+
+	_, err = repository.GetByID(context.Background(), created.ID.String())
+	assert.True(t, errors.Is(err, gorm.ErrRecordNotFound))
+
+	stored, err := repository.GetByID(context.Background(), existing.ID.String())
+	require.NoError(t, err)
+	assert.Equal(t, enum.Available, stored.Status)
+	assert.Equal(t, int64(1), stored.Version)
+
+	_, total, err := repository.FindStatusHistory(context.Background(), existing.ID.String(), 1, 10)
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), total)
+	assert.Equal(t, []string{"Cabin F1"}, unitNames(t, repository, request.UnitFilterDto{}))
+}
+
+func testLockByID(t *testing.T, repository units.UnitRepository) {
+	existing := createUnit(t, repository, "Cabin G3", enum.Cabin, enum.Available)
+	deleted := createUnit(t, repository, "Cabin G4", enum.Cabin, enum.Available)
+	require.NoError(t, repository.Delete(context.Background(), deleted))
+
+	err := repository.Transaction(context.Background(), func(ctx context.Context, tx units.UnitRepository) error {
+		locked, err := tx.LockByID(ctx, existing.ID.String())
+		require.NoError(t, err)
+		assert.Equal(t, existing.ID, locked.ID)
+		assert.Equal(t, enum.Available, locked.Status)
+
+		_, err = tx.LockByID(ctx, deleted.ID.String())
+		assert.True(t, errors.Is(err, gorm.ErrRecordNotFound))
+		return nil
+	})
+	require.NoError(t, err)
+}
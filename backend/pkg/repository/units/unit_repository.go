@@ -1,14 +1,43 @@
 package units
 
 import (
+	"context"
+	"errors"
+	"time"
 	"unit-management-be/pkg/model/domain"
+	"unit-management-be/pkg/model/dto/request"
 	"unit-management-be/pkg/model/dto/response"
+
+	"github.com/google/uuid"
 )
 
+// ErrVersionConflict is returned when the unit was updated by someone else since it was read
+var ErrVersionConflict = errors.New("unit has been modified by another request")
+
+// ErrUnitInUse is returned when purging a unit that bookings, housekeeping tasks or maintenance tickets still reference
+var ErrUnitInUse = errors.New("unit is still referenced by bookings, housekeeping tasks or maintenance tickets")
+
+// ErrUnitBooked is returned when deleting a unit that has a reserved or checked in booking
+var ErrUnitBooked = errors.New("unit has a reserved or checked in booking")
+
 type UnitRepository interface {
-	Create(unit domain.Units) (domain.Units, error)
-	GetByID(id string) (domain.Units, error)
-	Delete(unit domain.Units) error
-	FindAll(status, unitType, name string, page, size int) ([]response.UnitDetailResponse, int64, error)
-	Update(unit domain.Units) error
+	Create(ctx context.Context, unit domain.Units) (domain.Units, error)
+	GetByID(ctx context.Context, id string) (domain.Units, error)
+	LockByID(ctx context.Context, id string) (domain.Units, error)
+	Delete(ctx context.Context, unit domain.Units) error
+	FindAll(ctx context.Context, filter request.UnitFilterDto, page, size int) ([]response.UnitDetailResponse, int64, error)
+	FindAllForExport(ctx context.Context, filter request.UnitFilterDto) ([]domain.Units, error)
+	FindAllForReport(ctx context.Context, deletedIDs []uuid.UUID) ([]domain.Units, error)
+	FindAllByCursor(ctx context.Context, filter request.UnitFilterDto, cursor *request.UnitCursorDto, limit int) ([]response.UnitDetailResponse, error)
+	GetStats(ctx context.Context) (response.UnitStatsResponse, error)
+	Update(ctx context.Context, unit domain.Units) error
+	FindDeleted(ctx context.Context, name string, page, size int) ([]response.DeletedUnitResponse, int64, error)
+	GetDeletedByID(ctx context.Context, id string) (domain.Units, error)
+	Restore(ctx context.Context, unit domain.Units) error
+	Purge(ctx context.Context, unit domain.Units) error
+	PurgeDeletedBefore(ctx context.Context, cutoff time.Time) (int64, error)
+	CreateStatusHistory(ctx context.Context, history domain.UnitStatusHistory) error
+	FindStatusHistory(ctx context.Context, unitID string, page, size int) ([]domain.UnitStatusHistory, int64, error)
+	FindStatusHistoryBetween(ctx context.Context, from, to time.Time) ([]domain.UnitStatusHistory, error)
+	Transaction(ctx context.Context, fn func(ctx context.Context, repository UnitRepository) error) error
 }
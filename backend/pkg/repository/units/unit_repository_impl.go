@@ -1,12 +1,23 @@
 package units
 
 import (
+	"context"
+	"database/sql/driver"
 	"fmt"
+	"slices"
+	"strings"
+	"time"
+	"unit-management-be/pkg/logging"
 	"unit-management-be/pkg/model/domain"
+	"unit-management-be/pkg/model/domain/enum"
+	"unit-management-be/pkg/model/dto/request"
 	"unit-management-be/pkg/model/dto/response"
+	"unit-management-be/pkg/transaction"
 	"unit-management-be/pkg/utils"
 
+	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type UnitRepositoryImpl struct {
@@ -17,73 +28,623 @@ func NewUnitRepository(db *gorm.DB) UnitRepository {
 	return &UnitRepositoryImpl{db: db}
 }
 
-func (u *UnitRepositoryImpl) Create(unit domain.Units) (domain.Units, error) {
-	err := u.db.Create(&unit).Error
+func (u *UnitRepositoryImpl) Create(ctx context.Context, unit domain.Units) (domain.Units, error) {
+	err := transaction.DB(ctx, u.db).Create(&unit).Error
 	if err != nil {
-		fmt.Printf("failed to create new unit: %v", err)
+		logging.Error(ctx, "failed to create new unit", err)
 		return unit, err
 	}
 
 	return unit, nil
 }
 
-func (u *UnitRepositoryImpl) GetByID(id string) (domain.Units, error) {
+func (u *UnitRepositoryImpl) GetByID(ctx context.Context, id string) (domain.Units, error) {
 	response := domain.Units{}
-	if err := u.db.Table("units").Where("id = ? AND deleted_at IS NULL", id).First(&response).Error; err != nil {
-		fmt.Printf("failed to get unit by id: %v", err)
+	if err := transaction.DB(ctx, u.db).Table("units").Where("id = ? AND deleted_at IS NULL", id).First(&response).Error; err != nil {
+		logging.Error(ctx, "failed to get unit by id", err)
 		return response, err
 	}
 	return response, nil
 }
 
-func (u *UnitRepositoryImpl) Delete(unit domain.Units) error {
-	if err := u.db.Delete(&unit).Error; err != nil {
-		fmt.Printf("failed to delete unit by id: %v", err)
-		return err
+// LockByID reads the unit and locks its row until the surrounding transaction ends,
+// writers of the same unit wait for that transaction
+func (u *UnitRepositoryImpl) LockByID(ctx context.Context, id string) (domain.Units, error) {
+	response := domain.Units{}
+	if err := transaction.DB(ctx, u.db).Table("units").Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ? AND deleted_at IS NULL", id).First(&response).Error; err != nil {
+		logging.Error(ctx, "failed to lock unit by id", err)
+		return response, err
 	}
+	return response, nil
+}
 
-	return nil
+// Delete moves a unit to the trash, a unit with a reserved or checked in booking is kept and ErrUnitBooked
+// returned since the booking could no longer be checked in, checked out or cancelled
+func (u *UnitRepositoryImpl) Delete(ctx context.Context, unit domain.Units) error {
+	return transaction.Run(ctx, u.db, func(ctx context.Context) error {
+		var booked int64
+		err := transaction.DB(ctx, u.db).Model(&domain.Units{}).
+			Where("id = ? AND "+unitBooked, unit.ID, []enum.BookingStatus{enum.BookingReserved, enum.BookingCheckedIn}).
+			Count(&booked).Error
+		if err == nil && booked == 0 {
+			err = transaction.DB(ctx, u.db).Delete(&unit).Error
+		}
+		if err != nil {
+			logging.Error(ctx, "failed to delete unit by id", err)
+			return err
+		}
+
+		if booked > 0 {
+			return ErrUnitBooked
+		}
+		return nil
+	})
 }
 
-func (u *UnitRepositoryImpl) FindAll(status, unitType, name string, page, size int) ([]response.UnitDetailResponse, int64, error) {
+func (u *UnitRepositoryImpl) FindAll(ctx context.Context, filter request.UnitFilterDto, page, size int) ([]response.UnitDetailResponse, int64, error) {
 	units := make([]response.UnitDetailResponse, 0)
 
-	selectStatement := "units.id AS ID, units.name AS Name, units.type AS Type, units.status AS Status"
-	baseQuery := u.db.Table("units").Select(selectStatement).Where("units.deleted_at IS NULL")
+	selectStatement := "units.id AS id, units.name AS name, units.type AS type, units.status AS status, units.version AS version, units.last_updated AS last_updated"
+	baseQuery := filterUnits(transaction.DB(ctx, u.db).Table("units").Select(selectStatement), filter)
+
+	var total int64
+	if err := baseQuery.Count(&total).Error; err != nil {
+		logging.Error(ctx, "failed to count units", err)
+		return units, total, err
+	}
+
+	offset := (page - 1) * size
+	paginateQuery := orderUnits(baseQuery.Limit(size).Offset(offset), filter.Sort)
+	if err := paginateQuery.Scan(&units).Error; err != nil {
+		logging.Error(ctx, "failed to scan units", err)
+		return units, total, err
+	}
+
+	return units, total, nil
+}
+
+func (u *UnitRepositoryImpl) FindAllForExport(ctx context.Context, filter request.UnitFilterDto) ([]domain.Units, error) {
+	units := make([]domain.Units, 0)
+
+	query := orderUnits(filterUnits(transaction.DB(ctx, u.db).Table("units"), filter), filter.Sort)
+	if err := query.Find(&units).Error; err != nil {
+		logging.Error(ctx, "failed to find units for export", err)
+		return units, err
+	}
+
+	return units, nil
+}
+
+// FindAllForReport returns the units that are not in the trash together with the deleted units among deletedIDs,
+// in the default unit order
+func (u *UnitRepositoryImpl) FindAllForReport(ctx context.Context, deletedIDs []uuid.UUID) ([]domain.Units, error) {
+	units := make([]domain.Units, 0)
+
+	query := transaction.DB(ctx, u.db).Unscoped().Table("units").Where("units.deleted_at IS NULL")
+	if len(deletedIDs) > 0 {
+		query = query.Or("units.id IN ?", deletedIDs)
+	}
+	if err := orderUnits(query, nil).Find(&units).Error; err != nil {
+		logging.Error(ctx, "failed to find units for report", err)
+		return units, err
+	}
+
+	return units, nil
+}
+
+// FindAllByCursor returns up to limit units following the cursor in the sort order of the filter,
+// without counting the matches. A nil cursor starts at the first unit
+func (u *UnitRepositoryImpl) FindAllByCursor(ctx context.Context, filter request.UnitFilterDto, cursor *request.UnitCursorDto, limit int) ([]response.UnitDetailResponse, error) {
+	units := make([]response.UnitDetailResponse, 0)
+
+	selectStatement := "units.id AS id, units.name AS name, units.type AS type, units.status AS status, units.version AS version, units.last_updated AS last_updated"
+	query := filterUnits(transaction.DB(ctx, u.db).Table("units").Select(selectStatement), filter)
+
+	backward := false
+	if cursor != nil {
+		backward = cursor.Backward
+		query = whereAfterCursor(query, filter.Sort, *cursor)
+	}
+
+	if err := orderUnitsBy(query.Limit(limit), filter.Sort, backward).Scan(&units).Error; err != nil {
+		logging.Error(ctx, "failed to scan units by cursor", err)
+		return units, err
+	}
+
+	// a backward page is read in reverse, give it back in the requested order
+	if backward {
+		slices.Reverse(units)
+	}
+
+	return units, nil
+}
+
+// GetStats counts the units in the inventory by type and status, the occupancy percentage of every type
+// and the oldest last update of every status, all aggregated by the database in a single transaction
+func (u *UnitRepositoryImpl) GetStats(ctx context.Context) (response.UnitStatsResponse, error) {
+	stats := response.NewUnitStatsResponse()
+
+	var counts []struct {
+		Type   enum.UnitType
+		Status enum.UnitStatus
+		Total  int64
+	}
+	var types []struct {
+		Type                enum.UnitType
+		Total               int64
+		OccupancyPercentage float64
+	}
+	var statuses []struct {
+		Status            enum.UnitStatus
+		Total             int64
+		OldestLastUpdated aggregateTime
+	}
+
+	err := transaction.DB(ctx, u.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&domain.Units{}).
+			Select("units.type AS type, units.status AS status, COUNT(*) AS total").
+			Group("units.type, units.status").
+			Scan(&counts).Error; err != nil {
+			logging.Error(ctx, "failed to count units by type and status", err)
+			return err
+		}
+
+		occupancy := fmt.Sprintf("ROUND(100.0 * SUM(CASE WHEN units.status = '%s' THEN 1 ELSE 0 END) / COUNT(*), 2)", enum.Occupied)
+		if err := tx.Model(&domain.Units{}).
+			Select("units.type AS type, COUNT(*) AS total, " + occupancy + " AS occupancy_percentage").
+			Group("units.type").
+			Scan(&types).Error; err != nil {
+			logging.Error(ctx, "failed to compute unit occupancy by type", err)
+			return err
+		}
+
+		if err := tx.Model(&domain.Units{}).
+			Select("units.status AS status, COUNT(*) AS total, MIN(units.last_updated) AS oldest_last_updated").
+			Group("units.status").
+			Scan(&statuses).Error; err != nil {
+			logging.Error(ctx, "failed to find oldest unit update by status", err)
+			return err
+		}
+
+		return nil
+	})
+	if err != nil {
+		return stats, err
+	}
+
+	for _, count := range counts {
+		if typeStats := stats.TypeStats(count.Type); typeStats != nil {
+			typeStats.Counts[count.Status] = count.Total
+		}
+	}
+
+	for _, row := range types {
+		if typeStats := stats.TypeStats(row.Type); typeStats != nil {
+			typeStats.Total = row.Total
+			typeStats.OccupancyPercentage = row.OccupancyPercentage
+		}
+		stats.Total += row.Total
+	}
+
+	for _, row := range statuses {
+		if statusStats := stats.StatusStats(row.Status); statusStats != nil {
+			statusStats.Total = row.Total
+			statusStats.OldestLastUpdated = row.OldestLastUpdated.Time
+		}
+	}
+
+	return stats, nil
+}
+
+// aggregateTime scans the result of MIN or MAX over a timestamp column. SQLite does not keep the
+// column type on aggregates and hands back the stored text, which is parsed here
+type aggregateTime struct {
+	Time *time.Time
+}
+
+// aggregateTimeLayouts are the formats SQLite timestamps are written in
+var aggregateTimeLayouts = []string{
+	"2006-01-02 15:04:05.999999999-07:00",
+	"2006-01-02 15:04:05.999999999",
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05.999999999",
+}
+
+func (a *aggregateTime) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case nil:
+		a.Time = nil
+		return nil
+	case time.Time:
+		a.Time = &v
+		return nil
+	case []byte:
+		return a.parse(string(v))
+	case string:
+		return a.parse(v)
+	default:
+		return fmt.Errorf("cannot scan %T into a timestamp", value)
+	}
+}
+
+// Value is only there so gorm treats aggregateTime as a column rather than a relation
+func (a aggregateTime) Value() (driver.Value, error) {
+	if a.Time == nil {
+		return nil, nil
+	}
+	return *a.Time, nil
+}
+
+func (a *aggregateTime) parse(value string) error {
+	for _, layout := range aggregateTimeLayouts {
+		if parsed, err := time.Parse(layout, value); err == nil {
+			a.Time = &parsed
+			return nil
+		}
+	}
+	return fmt.Errorf("cannot parse %q as a timestamp", value)
+}
+
+// filterUnits applies the filters shared by the unit listing and export
+func filterUnits(query *gorm.DB, filter request.UnitFilterDto) *gorm.DB {
+	query = query.Where("units.deleted_at IS NULL")
+
+	if len(filter.Statuses) > 0 {
+		query = query.Where("units.status IN ?", filter.Statuses)
+	}
+
+	if len(filter.Types) > 0 {
+		query = query.Where("units.type IN ?", filter.Types)
+	}
+
+	if !utils.IsEmptyString(filter.Name) {
+		query = whereNameContains(query, filter.Name)
+	}
+
+	if filter.UpdatedFrom != nil {
+		query = query.Where("units.last_updated >= ?", *filter.UpdatedFrom)
+	}
+
+	if filter.UpdatedTo != nil {
+		query = query.Where("units.last_updated <= ?", *filter.UpdatedTo)
+	}
+
+	return query
+}
+
+// orderUnits sorts by the requested fields, or by name when none are given, with the id as
+// tie breaker so pages stay stable
+func orderUnits(query *gorm.DB, sort []request.UnitSortDto) *gorm.DB {
+	return orderUnitsBy(query, sort, false)
+}
+
+// orderUnitsBy sorts like orderUnits, reversed flips every direction to read a cursor page backward
+func orderUnitsBy(query *gorm.DB, sort []request.UnitSortDto, reversed bool) *gorm.DB {
+	if len(sort) == 0 {
+		sort = request.DefaultUnitSort()
+	}
+
+	for _, field := range sort {
+		query = query.Order(unitSortExpression(field.Field) + sortDirection(field.Descending != reversed))
+	}
+
+	return query.Order("units.id" + sortDirection(reversed))
+}
+
+func sortDirection(descending bool) string {
+	if descending {
+		return " DESC"
+	}
+	return " ASC"
+}
+
+// whereAfterCursor keeps the units sorted after the cursor, or before it when the cursor reads backward.
+// Row value comparison cannot mix directions, so (a, b, id) > (x, y, z) is expanded to
+// a > x OR (a = x AND b > y) OR (a = x AND b = y AND id > z)
+func whereAfterCursor(query *gorm.DB, sort []request.UnitSortDto, cursor request.UnitCursorDto) *gorm.DB {
+	type sortKey struct {
+		expression string
+		value      interface{}
+		descending bool
+	}
 
-	if !utils.IsEmptyString(status) {
-		baseQuery = baseQuery.Where("units.status = ?", status)
+	if len(sort) == 0 {
+		sort = request.DefaultUnitSort()
 	}
 
-	if !utils.IsEmptyString(unitType) {
-		baseQuery = baseQuery.Where("units.type = ?", unitType)
+	keys := make([]sortKey, 0, len(sort)+1)
+	for _, field := range sort {
+		keys = append(keys, sortKey{unitSortExpression(field.Field), unitSortValue(field.Field, cursor), field.Descending != cursor.Backward})
 	}
+	keys = append(keys, sortKey{"units.id", cursor.ID.String(), cursor.Backward})
+
+	conditions := make([]string, 0, len(keys))
+	values := make([]interface{}, 0)
+	for i, key := range keys {
+		parts := make([]string, 0, i+1)
+		for _, previous := range keys[:i] {
+			parts = append(parts, previous.expression+" = ?")
+			values = append(values, previous.value)
+		}
+
+		operator := " > ?"
+		if key.descending {
+			operator = " < ?"
+		}
+		parts = append(parts, key.expression+operator)
+		values = append(values, key.value)
+
+		conditions = append(conditions, "("+strings.Join(parts, " AND ")+")")
+	}
+
+	return query.Where("("+strings.Join(conditions, " OR ")+")", values...)
+}
+
+// unitSortValue returns the value of the cursor compared against unitSortExpression
+func unitSortValue(field enum.UnitSortField, cursor request.UnitCursorDto) interface{} {
+	switch field {
+	case enum.SortUnitType:
+		return slices.Index(enum.UnitTypes(), cursor.Type)
+	case enum.SortUnitStatus:
+		return slices.Index(enum.UnitStatuses(), cursor.Status)
+	case enum.SortUnitLastUpdated:
+		return cursor.LastUpdated
+	default:
+		return cursor.Name
+	}
+}
+
+// unitSortExpression returns the expression a field is sorted by, status and type are ranked in
+// declaration order on every database, as MySQL does for its ENUM columns
+func unitSortExpression(field enum.UnitSortField) string {
+	switch field {
+	case enum.SortUnitType:
+		types := make([]string, 0)
+		for _, unitType := range enum.UnitTypes() {
+			types = append(types, string(unitType))
+		}
+		return rankExpression("units.type", types)
+	case enum.SortUnitStatus:
+		statuses := make([]string, 0)
+		for _, status := range enum.UnitStatuses() {
+			statuses = append(statuses, string(status))
+		}
+		return rankExpression("units.status", statuses)
+	case enum.SortUnitLastUpdated:
+		return "units.last_updated"
+	default:
+		return "units.name"
+	}
+}
 
+// rankExpression maps the values of column to their position, the values are enum constants
+func rankExpression(column string, values []string) string {
+	var expression strings.Builder
+	expression.WriteString("CASE " + column)
+	for rank, value := range values {
+		fmt.Fprintf(&expression, " WHEN '%s' THEN %d", value, rank)
+	}
+	expression.WriteString(" END")
+
+	return expression.String()
+}
+
+// whereNameContains matches units whose name contains name, ignoring case and LIKE wildcards
+func whereNameContains(query *gorm.DB, name string) *gorm.DB {
+	return query.Where("LOWER(units.name) LIKE LOWER(?) ESCAPE '"+utils.LikeEscape+"'", "%"+utils.EscapeLike(name)+"%")
+}
+
+// Update saves the unit only when its version still matches the stored one and bumps the version,
+// so concurrent writers cannot silently overwrite each other
+func (u *UnitRepositoryImpl) Update(ctx context.Context, unit domain.Units) error {
+	result := transaction.DB(ctx, u.db).Model(&domain.Units{}).
+		Where("id = ? AND version = ?", unit.ID, unit.Version).
+		Updates(map[string]interface{}{
+			"name":         unit.Name,
+			"type":         unit.Type,
+			"status":       unit.Status,
+			"last_updated": unit.LastUpdated,
+			"version":      gorm.Expr("version + 1"),
+		})
+	if result.Error != nil {
+		logging.Error(ctx, "failed to save unit", result.Error)
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return ErrVersionConflict
+	}
+
+	return nil
+}
+
+// FindDeleted lists the units in the trash, the most recently deleted first
+func (u *UnitRepositoryImpl) FindDeleted(ctx context.Context, name string, page, size int) ([]response.DeletedUnitResponse, int64, error) {
+	units := make([]response.DeletedUnitResponse, 0)
+	baseQuery := transaction.DB(ctx, u.db).Unscoped().Model(&domain.Units{}).Where("units.deleted_at IS NOT NULL")
 	if !utils.IsEmptyString(name) {
-		baseQuery = baseQuery.Where("LOWER(units.name) LIKE LOWER(?)", "%"+name+"%")
+		baseQuery = whereNameContains(baseQuery, name)
 	}
 
 	var total int64
 	if err := baseQuery.Count(&total).Error; err != nil {
-		fmt.Printf("failed to count units: %v", err)
+		logging.Error(ctx, "failed to count deleted units", err)
 		return units, total, err
 	}
 
+	deleted := make([]domain.Units, 0)
 	offset := (page - 1) * size
-	paginateQuery := baseQuery.Limit(size).Offset(offset).Order("units.name ASC")
-	if err := paginateQuery.Scan(&units).Error; err != nil {
-		fmt.Printf("failed to scan units: %v", err)
+	paginateQuery := baseQuery.Limit(size).Offset(offset).Order("units.deleted_at DESC").Order("units.name ASC")
+	if err := paginateQuery.Find(&deleted).Error; err != nil {
+		logging.Error(ctx, "failed to find deleted units", err)
 		return units, total, err
 	}
 
+	for _, unit := range deleted {
+		units = append(units, response.BuildDeletedUnitResponseFromUnit(unit))
+	}
 	return units, total, nil
 }
 
-func (u *UnitRepositoryImpl) Update(unit domain.Units) error {
-	if err := u.db.Save(unit).Error; err != nil {
-		fmt.Printf("failed to save unit: %v", err)
+func (u *UnitRepositoryImpl) GetDeletedByID(ctx context.Context, id string) (domain.Units, error) {
+	response := domain.Units{}
+	if err := transaction.DB(ctx, u.db).Unscoped().Where("id = ? AND deleted_at IS NOT NULL", id).First(&response).Error; err != nil {
+		logging.Error(ctx, "failed to get deleted unit by id", err)
+		return response, err
+	}
+	return response, nil
+}
+
+// Restore takes the unit out of the trash and bumps its version,
+// gorm.ErrRecordNotFound is returned when the unit is not in the trash
+func (u *UnitRepositoryImpl) Restore(ctx context.Context, unit domain.Units) error {
+	result := transaction.DB(ctx, u.db).Unscoped().Model(&domain.Units{}).
+		Where("id = ? AND deleted_at IS NOT NULL", unit.ID).
+		Updates(map[string]interface{}{
+			"deleted_at": nil,
+			"version":    gorm.Expr("version + 1"),
+		})
+	if result.Error != nil {
+		logging.Error(ctx, "failed to restore unit", result.Error)
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	return nil
+}
+
+// unitInUse matches the units still referenced by bookings, housekeeping tasks or maintenance tickets,
+// the foreign keys restrict deleting them so those records are never lost with a purge
+const unitInUse = "EXISTS (SELECT 1 FROM bookings WHERE bookings.unit_id = units.id)" +
+	" OR EXISTS (SELECT 1 FROM housekeeping_tasks WHERE housekeeping_tasks.unit_id = units.id)" +
+	" OR EXISTS (SELECT 1 FROM maintenance_tickets WHERE maintenance_tickets.unit_id = units.id)"
+
+// unitBooked matches the units with a booking in one of the given statuses
+const unitBooked = "EXISTS (SELECT 1 FROM bookings WHERE bookings.unit_id = units.id AND bookings.status IN ?)"
+
+// Purge permanently deletes a unit from the trash together with its status history, a unit still
+// referenced by bookings, housekeeping tasks or maintenance tickets is kept and ErrUnitInUse returned
+func (u *UnitRepositoryImpl) Purge(ctx context.Context, unit domain.Units) error {
+	return transaction.Run(ctx, u.db, func(ctx context.Context) error {
+		var trashed, inUse int64
+		err := transaction.DB(ctx, u.db).Unscoped().Model(&domain.Units{}).
+			Where("id = ? AND deleted_at IS NOT NULL", unit.ID).
+			Count(&trashed).Error
+		if err == nil && trashed > 0 {
+			err = transaction.DB(ctx, u.db).Unscoped().Model(&domain.Units{}).
+				Where("id = ? AND ("+unitInUse+")", unit.ID).
+				Count(&inUse).Error
+		}
+		if err != nil {
+			logging.Error(ctx, "failed to purge unit", err)
+			return err
+		}
+
+		if trashed == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		if inUse > 0 {
+			return ErrUnitInUse
+		}
+
+		return u.purgeUnits(ctx, []string{unit.ID.String()})
+	})
+}
+
+// PurgeDeletedBefore permanently deletes the units moved to the trash before cutoff together with their status
+// history, the units still referenced by bookings, housekeeping tasks or maintenance tickets stay in the trash
+func (u *UnitRepositoryImpl) PurgeDeletedBefore(ctx context.Context, cutoff time.Time) (int64, error) {
+	var ids []string
+	err := transaction.Run(ctx, u.db, func(ctx context.Context) error {
+		err := transaction.DB(ctx, u.db).Unscoped().Model(&domain.Units{}).
+			Where("deleted_at IS NOT NULL AND deleted_at < ? AND NOT ("+unitInUse+")", cutoff).
+			Pluck("id", &ids).Error
+		if err != nil {
+			logging.Error(ctx, "failed to find expired units", err)
+			return err
+		}
+
+		if len(ids) == 0 {
+			return nil
+		}
+		return u.purgeUnits(ctx, ids)
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return int64(len(ids)), nil
+}
+
+// purgeUnits deletes the status history first, the foreign key restricts deleting a unit that still has one
+func (u *UnitRepositoryImpl) purgeUnits(ctx context.Context, ids []string) error {
+	if err := transaction.DB(ctx, u.db).Where("unit_id IN ?", ids).Delete(&domain.UnitStatusHistory{}).Error; err != nil {
+		logging.Error(ctx, "failed to purge unit status history", err)
+		return err
+	}
+
+	if err := transaction.DB(ctx, u.db).Unscoped().Where("id IN ?", ids).Delete(&domain.Units{}).Error; err != nil {
+		logging.Error(ctx, "failed to purge units", err)
+		return err
+	}
+
+	return nil
+}
+
+func (u *UnitRepositoryImpl) CreateStatusHistory(ctx context.Context, history domain.UnitStatusHistory) error {
+	if err := transaction.DB(ctx, u.db).Create(&history).Error; err != nil {
+		logging.Error(ctx, "failed to create unit status history", err)
 		return err
 	}
 
 	return nil
 }
+
+func (u *UnitRepositoryImpl) FindStatusHistory(ctx context.Context, unitID string, page, size int) ([]domain.UnitStatusHistory, int64, error) {
+	histories := make([]domain.UnitStatusHistory, 0)
+	baseQuery := transaction.DB(ctx, u.db).Model(&domain.UnitStatusHistory{}).Where("unit_id = ?", unitID)
+
+	var total int64
+	if err := baseQuery.Count(&total).Error; err != nil {
+		logging.Error(ctx, "failed to count unit status history", err)
+		return histories, total, err
+	}
+
+	offset := (page - 1) * size
+	paginateQuery := baseQuery.Limit(size).Offset(offset).Order("changed_at DESC")
+	if err := paginateQuery.Find(&histories).Error; err != nil {
+		logging.Error(ctx, "failed to find unit status history", err)
+		return histories, total, err
+	}
+
+	return histories, total, nil
+}
+
+// FindStatusHistoryBetween returns the status changes of every unit from from up to to, together with the last
+// change of each unit before from and its first change at or after to, so the status of a unit is known over
+// the whole range even when it did not change in it. The changes are ordered by time
+func (u *UnitRepositoryImpl) FindStatusHistoryBetween(ctx context.Context, from, to time.Time) ([]domain.UnitStatusHistory, error) {
+	histories := make([]domain.UnitStatusHistory, 0)
+
+	query := transaction.DB(ctx, u.db).Model(&domain.UnitStatusHistory{}).
+		Where("unit_status_history.changed_at >= ? AND unit_status_history.changed_at < ?", from, to).
+		Or("unit_status_history.changed_at = (SELECT MAX(previous.changed_at) FROM unit_status_history previous WHERE previous.unit_id = unit_status_history.unit_id AND previous.changed_at < ?)", from).
+		Or("unit_status_history.changed_at = (SELECT MIN(next.changed_at) FROM unit_status_history next WHERE next.unit_id = unit_status_history.unit_id AND next.changed_at >= ?)", to)
+	if err := query.Order("unit_status_history.changed_at ASC").Find(&histories).Error; err != nil {
+		logging.Error(ctx, "failed to find unit status history", err, "from", from, "to", to)
+		return histories, err
+	}
+
+	return histories, nil
+}
+
+// Transaction runs fn in a single database transaction, joining the one carried by ctx if any,
+// the transaction is rolled back when fn returns an error
+func (u *UnitRepositoryImpl) Transaction(ctx context.Context, fn func(ctx context.Context, repository UnitRepository) error) error {
+	return transaction.Run(ctx, u.db, func(ctx context.Context) error {
+		return fn(ctx, u)
+	})
+}
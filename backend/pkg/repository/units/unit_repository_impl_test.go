@@ -0,0 +1,148 @@
+package units_test
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+	"unit-management-be/internal/db"
+	"unit-management-be/pkg/model/domain"
+	"unit-management-be/pkg/model/domain/enum"
+	"unit-management-be/pkg/repository/units"
+	"unit-management-be/pkg/repository/units/conformance"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// runDatabaseConformance migrates the database once and empties the unit tables before every case,
+// so dsn must point to a throwaway database
+func runDatabaseConformance(t *testing.T, driver, dsn string) {
+	database, err := db.Open(driver, dsn, db.DefaultPool)
+	require.NoError(t, err)
+	migrations, err := db.MigrationsFS(driver, "")
+	require.NoError(t, err)
+	require.NoError(t, db.Migrate(database, driver, migrations))
+
+	sqlDB, err := database.DB()
+	require.NoError(t, err)
+	t.Cleanup(func() { sqlDB.Close() })
+
+	conformance.Run(t, func(t *testing.T) units.UnitRepository {
+		require.NoError(t, database.Exec("DELETE FROM unit_status_history").Error)
+		require.NoError(t, database.Exec("DELETE FROM units").Error)
+		return units.NewUnitRepository(database)
+	})
+}
+
+func TestUnitRepositorySQLiteConformance(t *testing.T) {
+	runDatabaseConformance(t, db.DriverSQLite, "file::memory:?_pragma=foreign_keys(1)&_time_format=sqlite")
+}
+
+func TestUnitRepositoryPurgeKeepsReferencedUnits(t *testing.T) {
+	database, err := db.Open(db.DriverSQLite, "file::memory:?_pragma=foreign_keys(1)&_time_format=sqlite", db.DefaultPool)
+	require.NoError(t, err)
+	migrations, err := db.MigrationsFS(db.DriverSQLite, "")
+	require.NoError(t, err)
+	require.NoError(t, db.Migrate(database, db.DriverSQLite, migrations))
+	sqlDB, err := database.DB()
+	require.NoError(t, err)
+	t.Cleanup(func() { sqlDB.Close() })
+
+	ctx := context.Background()
+	repository := units.NewUnitRepository(database)
+	booked, err := repository.Create(ctx, domain.Units{ID: uuid.New(), Name: "Cabin P1", Type: enum.Cabin, Status: enum.Available, LastUpdated: time.Now()})
+	require.NoError(t, err)
+	unused, err := repository.Create(ctx, domain.Units{ID: uuid.New(), Name: "Cabin P2", Type: enum.Cabin, Status: enum.Available, LastUpdated: time.Now()})
+	require.NoError(t, err)
+	for _, unit := range []domain.Units{booked, unused} {
+		require.NoError(t, repository.CreateStatusHistory(ctx, domain.UnitStatusHistory{ID: uuid.New(), UnitID: unit.ID, ToStatus: enum.Available, Actor: "tester", ChangedAt: time.Now()}))
+		require.NoError(t, repository.Delete(ctx, unit))
+	}
+	require.NoError(t, database.Exec(
+		"INSERT INTO bookings (id, unit_id, guest_name, status, start_at, end_at) VALUES (?, ?, ?, ?, ?, ?)",
+		uuid.NewString(), booked.ID.String(), "Guest", "Checked Out", time.Now().Add(-48*time.Hour), time.Now().Add(-24*time.Hour),
+	).Error)
+
+	t.Run("Negative Case: Unit with a booking is not purged", func(t *testing.T) {
+		assert.ErrorIs(t, repository.Purge(ctx, booked), units.ErrUnitInUse)
+
+		_, err := repository.GetDeletedByID(ctx, booked.ID.String())
+		assert.NoError(t, err)
+		_, total, err := repository.FindStatusHistory(ctx, booked.ID.String(), 1, 10)
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), total)
+	})
+
+	t.Run("Positive Case: Expired purge skips the unit with a booking", func(t *testing.T) {
+		purged, err := repository.PurgeDeletedBefore(ctx, time.Now().Add(time.Hour))
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), purged)
+
+		_, err = repository.GetDeletedByID(ctx, booked.ID.String())
+		assert.NoError(t, err)
+		_, err = repository.GetDeletedByID(ctx, unused.ID.String())
+		assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+	})
+}
+
+func TestUnitRepositoryDeleteKeepsBookedUnits(t *testing.T) {
+	database, err := db.Open(db.DriverSQLite, "file::memory:?_pragma=foreign_keys(1)&_time_format=sqlite", db.DefaultPool)
+	require.NoError(t, err)
+	migrations, err := db.MigrationsFS(db.DriverSQLite, "")
+	require.NoError(t, err)
+	require.NoError(t, db.Migrate(database, db.DriverSQLite, migrations))
+	sqlDB, err := database.DB()
+	require.NoError(t, err)
+	t.Cleanup(func() { sqlDB.Close() })
+
+	ctx := context.Background()
+	repository := units.NewUnitRepository(database)
+	createBookedUnit := func(t *testing.T, status enum.BookingStatus) domain.Units {
+		unit, err := repository.Create(ctx, domain.Units{ID: uuid.New(), Name: "Cabin " + string(status), Type: enum.Cabin, Status: enum.Occupied, LastUpdated: time.Now()})
+		require.NoError(t, err)
+		require.NoError(t, database.Exec(
+			"INSERT INTO bookings (id, unit_id, guest_name, status, start_at, end_at) VALUES (?, ?, ?, ?, ?, ?)",
+			uuid.NewString(), unit.ID.String(), "Guest", string(status), time.Now().Add(-24*time.Hour), time.Now().Add(24*time.Hour),
+		).Error)
+		return unit
+	}
+
+	t.Run("Negative Case: Unit with a reserved or checked in booking is not deleted", func(t *testing.T) {
+		for _, status := range []enum.BookingStatus{enum.BookingReserved, enum.BookingCheckedIn} {
+			unit := createBookedUnit(t, status)
+
+			assert.ErrorIs(t, repository.Delete(ctx, unit), units.ErrUnitBooked, status)
+			_, err := repository.LockByID(ctx, unit.ID.String())
+			assert.NoError(t, err, status)
+		}
+	})
+
+	t.Run("Positive Case: Unit with finished bookings is deleted", func(t *testing.T) {
+		for _, status := range []enum.BookingStatus{enum.BookingCheckedOut, enum.BookingCancelled} {
+			unit := createBookedUnit(t, status)
+
+			assert.NoError(t, repository.Delete(ctx, unit), status)
+			_, err := repository.GetDeletedByID(ctx, unit.ID.String())
+			assert.NoError(t, err, status)
+		}
+	})
+}
+
+func TestUnitRepositoryMySQLConformance(t *testing.T) {
+	dsn := os.Getenv("TEST_MYSQL_DSN")
+	if dsn == "" {
+		t.Skip("TEST_MYSQL_DSN is not set")
+	}
+	runDatabaseConformance(t, db.DriverMySQL, dsn)
+}
+
+func TestUnitRepositoryPostgresConformance(t *testing.T) {
+	dsn := os.Getenv("TEST_POSTGRES_DSN")
+	if dsn == "" {
+		t.Skip("TEST_POSTGRES_DSN is not set")
+	}
+	runDatabaseConformance(t, db.DriverPostgres, dsn)
+}
@@ -0,0 +1,543 @@
+package units
+
+import (
+	"cmp"
+	"context"
+	"math"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"unit-management-be/pkg/model/domain"
+	"unit-management-be/pkg/model/domain/enum"
+	"unit-management-be/pkg/model/dto/request"
+	"unit-management-be/pkg/model/dto/response"
+	"unit-management-be/pkg/transaction"
+	"unit-management-be/pkg/utils"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// memoryUnitState is the data held by MemoryUnitRepository, it is cloned for every transaction
+type memoryUnitState struct {
+	units     map[uuid.UUID]domain.Units
+	histories []domain.UnitStatusHistory
+}
+
+func (s *memoryUnitState) clone() *memoryUnitState {
+	units := make(map[uuid.UUID]domain.Units, len(s.units))
+	for id, unit := range s.units {
+		units[id] = unit
+	}
+
+	histories := make([]domain.UnitStatusHistory, len(s.histories))
+	copy(histories, s.histories)
+
+	return &memoryUnitState{units: units, histories: histories}
+}
+
+// MemoryUnitRepository keeps units in process memory and behaves like UnitRepositoryImpl,
+// it is meant for local runs and tests where no database is available
+type MemoryUnitRepository struct {
+	mu    sync.Mutex
+	state *memoryUnitState
+	now   func() time.Time
+	// db is joined by the transactions when set
+	db *gorm.DB
+}
+
+// NewMemoryUnitRepository keeps units in memory. When database is set every transaction also runs in a
+// database transaction, so what other repositories write in it, such as queued webhook deliveries,
+// is committed or rolled back together with the units
+func NewMemoryUnitRepository(database *gorm.DB) UnitRepository {
+	return &MemoryUnitRepository{
+		state: &memoryUnitState{units: make(map[uuid.UUID]domain.Units)},
+		now:   time.Now,
+		db:    database,
+	}
+}
+
+func (m *MemoryUnitRepository) Create(ctx context.Context, unit domain.Units) (domain.Units, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	unit.ID = uuid.New()
+	if unit.LastUpdated.IsZero() {
+		unit.LastUpdated = m.now()
+	}
+	if unit.Version == 0 {
+		unit.Version = 1
+	}
+	m.state.units[unit.ID] = unit
+
+	return unit, nil
+}
+
+func (m *MemoryUnitRepository) GetByID(ctx context.Context, id string) (domain.Units, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	unit, ok := m.lookup(id)
+	if !ok {
+		return domain.Units{}, gorm.ErrRecordNotFound
+	}
+	return unit, nil
+}
+
+// LockByID reads the unit, the lock of a transaction already keeps other callers out
+func (m *MemoryUnitRepository) LockByID(ctx context.Context, id string) (domain.Units, error) {
+	return m.GetByID(ctx, id)
+}
+
+func (m *MemoryUnitRepository) Delete(ctx context.Context, unit domain.Units) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	stored, ok := m.lookup(unit.ID.String())
+	if !ok {
+		return nil
+	}
+
+	stored.DeletedAt = gorm.DeletedAt{Time: m.now(), Valid: true}
+	m.state.units[stored.ID] = stored
+	return nil
+}
+
+func (m *MemoryUnitRepository) FindAll(ctx context.Context, filter request.UnitFilterDto, page, size int) ([]response.UnitDetailResponse, int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	filtered := m.filter(filter)
+	total := int64(len(filtered))
+
+	units := make([]response.UnitDetailResponse, 0)
+	for _, unit := range paginate(filtered, page, size) {
+		units = append(units, response.BuildUnitDetailResponseFromUnit(unit))
+	}
+
+	return units, total, nil
+}
+
+func (m *MemoryUnitRepository) FindAllForExport(ctx context.Context, filter request.UnitFilterDto) ([]domain.Units, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.filter(filter), nil
+}
+
+func (m *MemoryUnitRepository) FindAllForReport(ctx context.Context, deletedIDs []uuid.UUID) ([]domain.Units, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	units := m.filter(request.UnitFilterDto{})
+	for _, id := range deletedIDs {
+		if unit, ok := m.state.units[id]; ok && unit.DeletedAt.Valid {
+			units = append(units, unit)
+		}
+	}
+
+	sort.Slice(units, func(i, j int) bool {
+		return compareUnitsBy(units[i], units[j], nil) < 0
+	})
+	return units, nil
+}
+
+func (m *MemoryUnitRepository) FindAllByCursor(ctx context.Context, filter request.UnitFilterDto, cursor *request.UnitCursorDto, limit int) ([]response.UnitDetailResponse, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	filtered := m.filter(filter)
+	if cursor != nil {
+		position := domain.Units{
+			ID:          cursor.ID,
+			Name:        cursor.Name,
+			Type:        cursor.Type,
+			Status:      cursor.Status,
+			LastUpdated: cursor.LastUpdated,
+		}
+		// index of the first unit sorted after the cursor position
+		start, _ := slices.BinarySearchFunc(filtered, position, func(unit, position domain.Units) int {
+			if compareUnitsBy(unit, position, filter.Sort) <= 0 {
+				return -1
+			}
+			return 1
+		})
+
+		if cursor.Backward {
+			end := start
+			if end > 0 && compareUnitsBy(filtered[end-1], position, filter.Sort) == 0 {
+				end--
+			}
+			filtered = filtered[max(end-limit, 0):end]
+		} else {
+			filtered = filtered[start:]
+		}
+	}
+
+	units := make([]response.UnitDetailResponse, 0)
+	for _, unit := range paginate(filtered, 1, limit) {
+		units = append(units, response.BuildUnitDetailResponseFromUnit(unit))
+	}
+
+	return units, nil
+}
+
+func (m *MemoryUnitRepository) GetStats(ctx context.Context) (response.UnitStatsResponse, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	stats := response.NewUnitStatsResponse()
+	for _, unit := range m.filter(request.UnitFilterDto{}) {
+		stats.Total++
+
+		if typeStats := stats.TypeStats(unit.Type); typeStats != nil {
+			typeStats.Total++
+			typeStats.Counts[unit.Status]++
+		}
+
+		if statusStats := stats.StatusStats(unit.Status); statusStats != nil {
+			statusStats.Total++
+			if statusStats.OldestLastUpdated == nil || unit.LastUpdated.Before(*statusStats.OldestLastUpdated) {
+				lastUpdated := unit.LastUpdated
+				statusStats.OldestLastUpdated = &lastUpdated
+			}
+		}
+	}
+
+	// rounded to two decimals like the ROUND of UnitRepositoryImpl
+	for i, typeStats := range stats.Types {
+		if typeStats.Total > 0 {
+			stats.Types[i].OccupancyPercentage = math.Round(10000*float64(typeStats.Counts[enum.Occupied])/float64(typeStats.Total)) / 100
+		}
+	}
+
+	return stats, nil
+}
+
+func (m *MemoryUnitRepository) Update(ctx context.Context, unit domain.Units) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	stored, ok := m.lookup(unit.ID.String())
+	if !ok || stored.Version != unit.Version {
+		return ErrVersionConflict
+	}
+
+	stored.Name = unit.Name
+	stored.Type = unit.Type
+	stored.Status = unit.Status
+	stored.LastUpdated = unit.LastUpdated
+	stored.Version++
+	m.state.units[stored.ID] = stored
+
+	return nil
+}
+
+func (m *MemoryUnitRepository) FindDeleted(ctx context.Context, name string, page, size int) ([]response.DeletedUnitResponse, int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	deleted := make([]domain.Units, 0)
+	for _, unit := range m.state.units {
+		if unit.DeletedAt.Valid && containsName(unit, name) {
+			deleted = append(deleted, unit)
+		}
+	}
+	sort.Slice(deleted, func(i, j int) bool {
+		if !deleted[i].DeletedAt.Time.Equal(deleted[j].DeletedAt.Time) {
+			return deleted[i].DeletedAt.Time.After(deleted[j].DeletedAt.Time)
+		}
+		return deleted[i].Name < deleted[j].Name
+	})
+
+	units := make([]response.DeletedUnitResponse, 0)
+	for _, unit := range paginate(deleted, page, size) {
+		units = append(units, response.BuildDeletedUnitResponseFromUnit(unit))
+	}
+
+	return units, int64(len(deleted)), nil
+}
+
+func (m *MemoryUnitRepository) GetDeletedByID(ctx context.Context, id string) (domain.Units, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	unit, ok := m.lookupDeleted(id)
+	if !ok {
+		return domain.Units{}, gorm.ErrRecordNotFound
+	}
+	return unit, nil
+}
+
+func (m *MemoryUnitRepository) Restore(ctx context.Context, unit domain.Units) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	stored, ok := m.lookupDeleted(unit.ID.String())
+	if !ok {
+		return gorm.ErrRecordNotFound
+	}
+
+	stored.DeletedAt = gorm.DeletedAt{}
+	stored.LastUpdated = m.now()
+	stored.Version++
+	m.state.units[stored.ID] = stored
+
+	return nil
+}
+
+func (m *MemoryUnitRepository) Purge(ctx context.Context, unit domain.Units) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.lookupDeleted(unit.ID.String()); !ok {
+		return gorm.ErrRecordNotFound
+	}
+
+	m.remove(unit.ID)
+	return nil
+}
+
+func (m *MemoryUnitRepository) PurgeDeletedBefore(ctx context.Context, cutoff time.Time) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var purged int64
+	for id, unit := range m.state.units {
+		if unit.DeletedAt.Valid && unit.DeletedAt.Time.Before(cutoff) {
+			m.remove(id)
+			purged++
+		}
+	}
+
+	return purged, nil
+}
+
+func (m *MemoryUnitRepository) CreateStatusHistory(ctx context.Context, history domain.UnitStatusHistory) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	history.ID = uuid.New()
+	if history.FromStatus != nil {
+		fromStatus := *history.FromStatus
+		history.FromStatus = &fromStatus
+	}
+	m.state.histories = append(m.state.histories, history)
+
+	return nil
+}
+
+func (m *MemoryUnitRepository) FindStatusHistory(ctx context.Context, unitID string, page, size int) ([]domain.UnitStatusHistory, int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	histories := make([]domain.UnitStatusHistory, 0)
+	for _, history := range m.state.histories {
+		if history.UnitID.String() == unitID {
+			histories = append(histories, history)
+		}
+	}
+	sort.SliceStable(histories, func(i, j int) bool {
+		return histories[i].ChangedAt.After(histories[j].ChangedAt)
+	})
+
+	return paginate(histories, page, size), int64(len(histories)), nil
+}
+
+func (m *MemoryUnitRepository) FindStatusHistoryBetween(ctx context.Context, from, to time.Time) ([]domain.UnitStatusHistory, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	previous := make(map[uuid.UUID]domain.UnitStatusHistory)
+	next := make(map[uuid.UUID]domain.UnitStatusHistory)
+	histories := make([]domain.UnitStatusHistory, 0)
+	for _, history := range m.state.histories {
+		switch {
+		case history.ChangedAt.Before(from):
+			if last, ok := previous[history.UnitID]; !ok || !history.ChangedAt.Before(last.ChangedAt) {
+				previous[history.UnitID] = history
+			}
+		case !history.ChangedAt.Before(to):
+			if first, ok := next[history.UnitID]; !ok || history.ChangedAt.Before(first.ChangedAt) {
+				next[history.UnitID] = history
+			}
+		default:
+			histories = append(histories, history)
+		}
+	}
+
+	for _, history := range previous {
+		histories = append(histories, history)
+	}
+	for _, history := range next {
+		histories = append(histories, history)
+	}
+	sort.SliceStable(histories, func(i, j int) bool {
+		return histories[i].ChangedAt.Before(histories[j].ChangedAt)
+	})
+
+	return histories, nil
+}
+
+// Transaction runs fn against a copy of the data and keeps the copy only when fn succeeds and the
+// database transaction, if any, is committed. Other callers wait until the transaction is finished
+func (m *MemoryUnitRepository) Transaction(ctx context.Context, fn func(ctx context.Context, repository UnitRepository) error) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	previous := m.state
+	tx := &MemoryUnitRepository{state: m.state.clone(), now: m.now, db: m.db}
+	run := func(ctx context.Context) error {
+		if err := fn(ctx, tx); err != nil {
+			return err
+		}
+		// swapped before the commit, the previous data is put back when the commit fails
+		m.state = tx.state
+		return nil
+	}
+
+	var err error
+	if m.db != nil {
+		err = transaction.Run(ctx, m.db, run)
+	} else {
+		err = run(ctx)
+	}
+	if err != nil {
+		m.state = previous
+		return err
+	}
+	return nil
+}
+
+// lookup returns the unit with the given id unless it is missing or deleted
+func (m *MemoryUnitRepository) lookup(id string) (domain.Units, bool) {
+	parsedID, err := uuid.Parse(id)
+	if err != nil {
+		return domain.Units{}, false
+	}
+
+	unit, ok := m.state.units[parsedID]
+	if !ok || unit.DeletedAt.Valid {
+		return domain.Units{}, false
+	}
+	return unit, true
+}
+
+// lookupDeleted returns the unit with the given id when it is in the trash
+func (m *MemoryUnitRepository) lookupDeleted(id string) (domain.Units, bool) {
+	parsedID, err := uuid.Parse(id)
+	if err != nil {
+		return domain.Units{}, false
+	}
+
+	unit, ok := m.state.units[parsedID]
+	if !ok || !unit.DeletedAt.Valid {
+		return domain.Units{}, false
+	}
+	return unit, true
+}
+
+// remove drops the unit together with its status history, like the database purge does
+func (m *MemoryUnitRepository) remove(id uuid.UUID) {
+	delete(m.state.units, id)
+
+	histories := m.state.histories[:0]
+	for _, history := range m.state.histories {
+		if history.UnitID != id {
+			histories = append(histories, history)
+		}
+	}
+	m.state.histories = histories
+}
+
+// filter applies the same filters and ordering as filterUnits and orderUnits
+func (m *MemoryUnitRepository) filter(filter request.UnitFilterDto) []domain.Units {
+	units := make([]domain.Units, 0)
+	for _, unit := range m.state.units {
+		if unit.DeletedAt.Valid {
+			continue
+		}
+		if len(filter.Statuses) > 0 && !slices.Contains(filter.Statuses, unit.Status) {
+			continue
+		}
+		if len(filter.Types) > 0 && !slices.Contains(filter.Types, unit.Type) {
+			continue
+		}
+		if !containsName(unit, filter.Name) {
+			continue
+		}
+		if filter.UpdatedFrom != nil && unit.LastUpdated.Before(*filter.UpdatedFrom) {
+			continue
+		}
+		if filter.UpdatedTo != nil && unit.LastUpdated.After(*filter.UpdatedTo) {
+			continue
+		}
+		units = append(units, unit)
+	}
+
+	sort.Slice(units, func(i, j int) bool {
+		return compareUnitsBy(units[i], units[j], filter.Sort) < 0
+	})
+
+	return units
+}
+
+// compareUnitsBy orders two units by the sort fields and then by id, like orderUnits
+func compareUnitsBy(a, b domain.Units, sortFields []request.UnitSortDto) int {
+	if len(sortFields) == 0 {
+		sortFields = request.DefaultUnitSort()
+	}
+
+	for _, field := range sortFields {
+		compared := compareUnits(a, b, field.Field)
+		if field.Descending {
+			compared = -compared
+		}
+		if compared != 0 {
+			return compared
+		}
+	}
+
+	return strings.Compare(a.ID.String(), b.ID.String())
+}
+
+// compareUnits compares a field of two units like unitSortExpression orders it
+func compareUnits(a, b domain.Units, field enum.UnitSortField) int {
+	switch field {
+	case enum.SortUnitType:
+		return cmp.Compare(slices.Index(enum.UnitTypes(), a.Type), slices.Index(enum.UnitTypes(), b.Type))
+	case enum.SortUnitStatus:
+		return cmp.Compare(slices.Index(enum.UnitStatuses(), a.Status), slices.Index(enum.UnitStatuses(), b.Status))
+	case enum.SortUnitLastUpdated:
+		return a.LastUpdated.Compare(b.LastUpdated)
+	default:
+		return strings.Compare(a.Name, b.Name)
+	}
+}
+
+// containsName matches like whereNameContains, an empty name matches every unit
+func containsName(unit domain.Units, name string) bool {
+	if utils.IsEmptyString(name) {
+		return true
+	}
+	return strings.Contains(strings.ToLower(unit.Name), strings.ToLower(name))
+}
+
+// paginate returns the items of the page the same way LIMIT and OFFSET would
+func paginate[T any](items []T, page, size int) []T {
+	offset := (page - 1) * size
+	if offset < 0 {
+		offset = 0
+	}
+	if offset >= len(items) {
+		return make([]T, 0)
+	}
+
+	end := len(items)
+	if size >= 0 && offset+size < end {
+		end = offset + size
+	}
+	return items[offset:end]
+}
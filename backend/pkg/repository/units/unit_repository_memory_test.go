@@ -0,0 +1,13 @@
+package units_test
+
+import (
+	"testing"
+	"unit-management-be/pkg/repository/units"
+	"unit-management-be/pkg/repository/units/conformance"
+)
+
+func TestMemoryUnitRepositoryConformance(t *testing.T) {
+	conformance.Run(t, func(t *testing.T) units.UnitRepository {
+		return units.NewMemoryUnitRepository(nil)
+	})
+}
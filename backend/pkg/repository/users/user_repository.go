@@ -0,0 +1,14 @@
+package users
+
+import (
+	"context"
+	"unit-management-be/pkg/model/domain"
+)
+
+type UserRepository interface {
+	Create(ctx context.Context, user domain.User) (domain.User, error)
+	GetByID(ctx context.Context, id string) (domain.User, error)
+	GetByUsername(ctx context.Context, username string) (domain.User, error)
+	FindAll(ctx context.Context, role string, page, size int) ([]domain.User, int64, error)
+	CountByRole(ctx context.Context, role string) (int64, error)
+}
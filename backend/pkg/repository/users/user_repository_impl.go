@@ -0,0 +1,80 @@
+package users
+
+import (
+	"context"
+	"unit-management-be/pkg/logging"
+	"unit-management-be/pkg/model/domain"
+	"unit-management-be/pkg/transaction"
+	"unit-management-be/pkg/utils"
+
+	"gorm.io/gorm"
+)
+
+type UserRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewUserRepository(db *gorm.DB) UserRepository {
+	return &UserRepositoryImpl{db: db}
+}
+
+func (u *UserRepositoryImpl) Create(ctx context.Context, user domain.User) (domain.User, error) {
+	if err := transaction.DB(ctx, u.db).Create(&user).Error; err != nil {
+		logging.Error(ctx, "failed to create user", err)
+		return user, err
+	}
+
+	return user, nil
+}
+
+func (u *UserRepositoryImpl) GetByID(ctx context.Context, id string) (domain.User, error) {
+	response := domain.User{}
+	if err := transaction.DB(ctx, u.db).Where("id = ?", id).First(&response).Error; err != nil {
+		logging.Error(ctx, "failed to get user by id", err)
+		return response, err
+	}
+	return response, nil
+}
+
+func (u *UserRepositoryImpl) GetByUsername(ctx context.Context, username string) (domain.User, error) {
+	response := domain.User{}
+	if err := transaction.DB(ctx, u.db).Where("username = ?", username).First(&response).Error; err != nil {
+		logging.Error(ctx, "failed to get user by username", err)
+		return response, err
+	}
+	return response, nil
+}
+
+func (u *UserRepositoryImpl) FindAll(ctx context.Context, role string, page, size int) ([]domain.User, int64, error) {
+	users := make([]domain.User, 0)
+	baseQuery := transaction.DB(ctx, u.db).Model(&domain.User{})
+
+	if !utils.IsEmptyString(role) {
+		baseQuery = baseQuery.Where("role = ?", role)
+	}
+
+	var total int64
+	if err := baseQuery.Count(&total).Error; err != nil {
+		logging.Error(ctx, "failed to count users", err)
+		return users, total, err
+	}
+
+	offset := (page - 1) * size
+	paginateQuery := baseQuery.Limit(size).Offset(offset).Order("username ASC")
+	if err := paginateQuery.Find(&users).Error; err != nil {
+		logging.Error(ctx, "failed to find users", err)
+		return users, total, err
+	}
+
+	return users, total, nil
+}
+
+func (u *UserRepositoryImpl) CountByRole(ctx context.Context, role string) (int64, error) {
+	var total int64
+	if err := transaction.DB(ctx, u.db).Model(&domain.User{}).Where("role = ?", role).Count(&total).Error; err != nil {
+		logging.Error(ctx, "failed to count users by role", err)
+		return total, err
+	}
+
+	return total, nil
+}
@@ -0,0 +1,24 @@
+package webhooks
+
+import (
+	"context"
+	"time"
+	"unit-management-be/pkg/model/domain"
+	"unit-management-be/pkg/model/domain/enum"
+)
+
+type WebhookRepository interface {
+	Create(ctx context.Context, subscription domain.WebhookSubscription) (domain.WebhookSubscription, error)
+	GetByID(ctx context.Context, id string) (domain.WebhookSubscription, error)
+	FindAll(ctx context.Context, page, size int) ([]domain.WebhookSubscription, int64, error)
+	FindActive(ctx context.Context) ([]domain.WebhookSubscription, error)
+	Update(ctx context.Context, subscription domain.WebhookSubscription) error
+	Delete(ctx context.Context, subscription domain.WebhookSubscription) error
+	CreateDeliveries(ctx context.Context, deliveries []domain.WebhookDelivery) error
+	GetDeliveryByID(ctx context.Context, id string) (domain.WebhookDelivery, error)
+	FindDeliveries(ctx context.Context, subscriptionID, status string, page, size int) ([]domain.WebhookDelivery, int64, error)
+	FindDueDeliveries(ctx context.Context, now time.Time, limit int) ([]domain.WebhookDelivery, error)
+	UpdateDelivery(ctx context.Context, delivery domain.WebhookDelivery) error
+	// RequeueDeliveries moves the deliveries of the subscription with the status back to pending
+	RequeueDeliveries(ctx context.Context, subscriptionID string, status enum.WebhookDeliveryStatus, now time.Time) (int64, error)
+}
@@ -0,0 +1,198 @@
+package webhooks
+
+import (
+	"context"
+	"time"
+	"unit-management-be/pkg/logging"
+	"unit-management-be/pkg/model/domain"
+	"unit-management-be/pkg/model/domain/enum"
+	"unit-management-be/pkg/transaction"
+	"unit-management-be/pkg/utils"
+
+	"gorm.io/gorm"
+)
+
+type WebhookRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewWebhookRepository(db *gorm.DB) WebhookRepository {
+	return &WebhookRepositoryImpl{db: db}
+}
+
+func (w *WebhookRepositoryImpl) Create(ctx context.Context, subscription domain.WebhookSubscription) (domain.WebhookSubscription, error) {
+	if err := transaction.DB(ctx, w.db).Create(&subscription).Error; err != nil {
+		logging.Error(ctx, "failed to create webhook subscription", err)
+		return subscription, err
+	}
+
+	return subscription, nil
+}
+
+func (w *WebhookRepositoryImpl) GetByID(ctx context.Context, id string) (domain.WebhookSubscription, error) {
+	response := domain.WebhookSubscription{}
+	if err := transaction.DB(ctx, w.db).Where("id = ?", id).First(&response).Error; err != nil {
+		logging.Error(ctx, "failed to get webhook subscription by id", err)
+		return response, err
+	}
+	return response, nil
+}
+
+func (w *WebhookRepositoryImpl) FindAll(ctx context.Context, page, size int) ([]domain.WebhookSubscription, int64, error) {
+	subscriptions := make([]domain.WebhookSubscription, 0)
+	baseQuery := transaction.DB(ctx, w.db).Model(&domain.WebhookSubscription{})
+
+	var total int64
+	if err := baseQuery.Count(&total).Error; err != nil {
+		logging.Error(ctx, "failed to count webhook subscriptions", err)
+		return subscriptions, total, err
+	}
+
+	offset := (page - 1) * size
+	paginateQuery := baseQuery.Limit(size).Offset(offset).Order("created_at DESC")
+	if err := paginateQuery.Find(&subscriptions).Error; err != nil {
+		logging.Error(ctx, "failed to find webhook subscriptions", err)
+		return subscriptions, total, err
+	}
+
+	return subscriptions, total, nil
+}
+
+func (w *WebhookRepositoryImpl) FindActive(ctx context.Context) ([]domain.WebhookSubscription, error) {
+	subscriptions := make([]domain.WebhookSubscription, 0)
+	if err := transaction.DB(ctx, w.db).Where("active = ?", true).Find(&subscriptions).Error; err != nil {
+		logging.Error(ctx, "failed to find active webhook subscriptions", err)
+		return subscriptions, err
+	}
+
+	return subscriptions, nil
+}
+
+func (w *WebhookRepositoryImpl) Update(ctx context.Context, subscription domain.WebhookSubscription) error {
+	if err := transaction.DB(ctx, w.db).Save(&subscription).Error; err != nil {
+		logging.Error(ctx, "failed to save webhook subscription", err)
+		return err
+	}
+
+	return nil
+}
+
+func (w *WebhookRepositoryImpl) Delete(ctx context.Context, subscription domain.WebhookSubscription) error {
+	err := transaction.DB(ctx, w.db).Transaction(func(tx *gorm.DB) error {
+		// deleted explicitly so a SQLite connection without foreign keys enabled leaves none behind
+		if err := tx.Where("subscription_id = ?", subscription.ID).Delete(&domain.WebhookDelivery{}).Error; err != nil {
+			return err
+		}
+
+		return tx.Delete(&subscription).Error
+	})
+	if err != nil {
+		logging.Error(ctx, "failed to delete webhook subscription", err)
+		return err
+	}
+
+	return nil
+}
+
+func (w *WebhookRepositoryImpl) CreateDeliveries(ctx context.Context, deliveries []domain.WebhookDelivery) error {
+	if len(deliveries) == 0 {
+		return nil
+	}
+
+	if err := transaction.DB(ctx, w.db).Create(&deliveries).Error; err != nil {
+		logging.Error(ctx, "failed to create webhook deliveries", err)
+		return err
+	}
+
+	return nil
+}
+
+func (w *WebhookRepositoryImpl) GetDeliveryByID(ctx context.Context, id string) (domain.WebhookDelivery, error) {
+	response := domain.WebhookDelivery{}
+	if err := transaction.DB(ctx, w.db).Where("id = ?", id).First(&response).Error; err != nil {
+		logging.Error(ctx, "failed to get webhook delivery by id", err)
+		return response, err
+	}
+	return response, nil
+}
+
+func (w *WebhookRepositoryImpl) FindDeliveries(ctx context.Context, subscriptionID, status string, page, size int) ([]domain.WebhookDelivery, int64, error) {
+	deliveries := make([]domain.WebhookDelivery, 0)
+	baseQuery := transaction.DB(ctx, w.db).Model(&domain.WebhookDelivery{})
+
+	if !utils.IsEmptyString(subscriptionID) {
+		baseQuery = baseQuery.Where("subscription_id = ?", subscriptionID)
+	}
+
+	if !utils.IsEmptyString(status) {
+		baseQuery = baseQuery.Where("status = ?", status)
+	}
+
+	var total int64
+	if err := baseQuery.Count(&total).Error; err != nil {
+		logging.Error(ctx, "failed to count webhook deliveries", err)
+		return deliveries, total, err
+	}
+
+	offset := (page - 1) * size
+	paginateQuery := baseQuery.Limit(size).Offset(offset).Order("created_at DESC").Order("id ASC")
+	if err := paginateQuery.Find(&deliveries).Error; err != nil {
+		logging.Error(ctx, "failed to find webhook deliveries", err)
+		return deliveries, total, err
+	}
+
+	return deliveries, total, nil
+}
+
+// heldBackDelivery matches a due delivery queued after a pending delivery of the same subscription that waits
+// for its retry, so a receiver never gets an event before the ones queued ahead of it
+const heldBackDelivery = `EXISTS (SELECT 1 FROM webhook_deliveries AS older
+	WHERE older.subscription_id = webhook_deliveries.subscription_id AND older.status = ? AND older.next_attempt_at > ?
+	AND (older.created_at < webhook_deliveries.created_at
+		OR (older.created_at = webhook_deliveries.created_at AND older.id < webhook_deliveries.id)))`
+
+// FindDueDeliveries returns the pending deliveries due at now in the order they were queued,
+// leaving out the ones held back by an older delivery of their subscription
+func (w *WebhookRepositoryImpl) FindDueDeliveries(ctx context.Context, now time.Time, limit int) ([]domain.WebhookDelivery, error) {
+	deliveries := make([]domain.WebhookDelivery, 0)
+	query := transaction.DB(ctx, w.db).Where("status = ?", enum.DeliveryPending).
+		Where("next_attempt_at <= ?", now).
+		Where("NOT "+heldBackDelivery, enum.DeliveryPending, now).
+		Order("created_at ASC").
+		Order("id ASC").
+		Limit(limit)
+
+	if err := query.Find(&deliveries).Error; err != nil {
+		logging.Error(ctx, "failed to find due webhook deliveries", err)
+		return deliveries, err
+	}
+
+	return deliveries, nil
+}
+
+func (w *WebhookRepositoryImpl) UpdateDelivery(ctx context.Context, delivery domain.WebhookDelivery) error {
+	if err := transaction.DB(ctx, w.db).Save(&delivery).Error; err != nil {
+		logging.Error(ctx, "failed to save webhook delivery", err)
+		return err
+	}
+
+	return nil
+}
+
+func (w *WebhookRepositoryImpl) RequeueDeliveries(ctx context.Context, subscriptionID string, status enum.WebhookDeliveryStatus, now time.Time) (int64, error) {
+	result := transaction.DB(ctx, w.db).Model(&domain.WebhookDelivery{}).
+		Where("subscription_id = ?", subscriptionID).
+		Where("status = ?", status).
+		Updates(map[string]interface{}{
+			"status":          enum.DeliveryPending,
+			"attempts":        0,
+			"next_attempt_at": now,
+			"updated_at":      now,
+		})
+	if result.Error != nil {
+		logging.Error(ctx, "failed to requeue webhook deliveries", result.Error)
+		return 0, result.Error
+	}
+
+	return result.RowsAffected, nil
+}
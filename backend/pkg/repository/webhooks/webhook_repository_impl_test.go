@@ -0,0 +1,73 @@
+package webhooks_test
+
+import (
+	"context"
+	"testing"
+	"time"
+	"unit-management-be/internal/db"
+	"unit-management-be/pkg/model/domain"
+	"unit-management-be/pkg/model/domain/enum"
+	"unit-management-be/pkg/repository/webhooks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupTest(t *testing.T) webhooks.WebhookRepository {
+	database, err := db.Open(db.DriverSQLite, "file::memory:?_pragma=foreign_keys(1)&_time_format=sqlite", db.DefaultPool)
+	require.NoError(t, err)
+	sqlDB, err := database.DB()
+	require.NoError(t, err)
+	t.Cleanup(func() { sqlDB.Close() })
+
+	migrations, err := db.MigrationsFS(db.DriverSQLite, "")
+	require.NoError(t, err)
+	require.NoError(t, db.Migrate(database, db.DriverSQLite, migrations))
+	return webhooks.NewWebhookRepository(database)
+}
+
+func TestFindDueDeliveries(t *testing.T) {
+	t.Run("Negative Case: Delivery waiting for its retry holds back the newer ones of its webhook", func(t *testing.T) {
+		repository := setupTest(t)
+		now := time.Date(2025, time.October, 21, 9, 0, 0, 0, time.UTC)
+
+		failing, err := repository.Create(context.Background(), domain.WebhookSubscription{URL: "http://localhost:9001", Active: true})
+		require.NoError(t, err)
+		healthy, err := repository.Create(context.Background(), domain.WebhookSubscription{URL: "http://localhost:9002", Active: true})
+		require.NoError(t, err)
+
+		delivery := func(subscription domain.WebhookSubscription, event enum.WebhookEvent, queuedAt time.Time) domain.WebhookDelivery {
+			return domain.WebhookDelivery{SubscriptionID: subscription.ID, Event: event, Payload: "{}", Status: enum.DeliveryPending,
+				NextAttemptAt: &queuedAt, CreatedAt: queuedAt, UpdatedAt: queuedAt}
+		}
+		deliveries := []domain.WebhookDelivery{
+			delivery(failing, enum.WebhookUnitStatusChanged, now.Add(-2*time.Minute)),
+			delivery(failing, enum.WebhookUnitUpdated, now.Add(-time.Minute)),
+			delivery(healthy, enum.WebhookUnitCreated, now),
+		}
+		require.NoError(t, repository.CreateDeliveries(context.Background(), deliveries))
+
+		due, err := repository.FindDueDeliveries(context.Background(), now, 10)
+		require.NoError(t, err)
+		require.Len(t, due, 3)
+		assert.Equal(t, deliveries[0].ID, due[0].ID)
+		assert.Equal(t, deliveries[1].ID, due[1].ID)
+
+		// the first delivery failed and is retried later, the one queued after it has to wait
+		retryAt := now.Add(30 * time.Second)
+		first := due[0]
+		first.Attempts, first.NextAttemptAt = 1, &retryAt
+		require.NoError(t, repository.UpdateDelivery(context.Background(), first))
+
+		due, err = repository.FindDueDeliveries(context.Background(), now, 10)
+		require.NoError(t, err)
+		require.Len(t, due, 1)
+		assert.Equal(t, deliveries[2].ID, due[0].ID)
+
+		due, err = repository.FindDueDeliveries(context.Background(), retryAt, 10)
+		require.NoError(t, err)
+		require.Len(t, due, 3)
+		assert.Equal(t, deliveries[0].ID, due[0].ID)
+		assert.Equal(t, deliveries[1].ID, due[1].ID)
+	})
+}
@@ -0,0 +1,18 @@
+package bookings
+
+import (
+	"context"
+	"unit-management-be/pkg/handler"
+	"unit-management-be/pkg/model/domain"
+	"unit-management-be/pkg/model/dto"
+	"unit-management-be/pkg/model/dto/request"
+)
+
+type BookingService interface {
+	CreateBooking(ctx context.Context, request request.CreateBookingDto) (*domain.Booking, *handler.CustomError)
+	FindByID(ctx context.Context, id string) (domain.Booking, *handler.CustomError)
+	FindBookings(ctx context.Context, unitID, status string, page, size int) (*dto.PaginationResponse, *handler.CustomError)
+	CheckIn(ctx context.Context, id, actor string) (*domain.Booking, *handler.CustomError)
+	CheckOut(ctx context.Context, id, actor string) (*domain.Booking, *handler.CustomError)
+	Cancel(ctx context.Context, id string) (*domain.Booking, *handler.CustomError)
+}
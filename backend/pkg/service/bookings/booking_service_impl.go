@@ -0,0 +1,232 @@
+package bookings
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+	"unit-management-be/pkg/handler"
+	"unit-management-be/pkg/model/domain"
+	"unit-management-be/pkg/model/domain/enum"
+	"unit-management-be/pkg/model/dto"
+	"unit-management-be/pkg/model/dto/request"
+	bookingrepository "unit-management-be/pkg/repository/bookings"
+	unitservice "unit-management-be/pkg/service/units"
+	"unit-management-be/pkg/utils"
+
+	"gorm.io/gorm"
+)
+
+type BookingServiceImpl struct {
+	bookingRepository bookingrepository.BookingRepository
+	unitService       unitservice.UnitService
+	now               func() time.Time
+}
+
+func NewBookingService(bookingRepository bookingrepository.BookingRepository, unitService unitservice.UnitService) BookingService {
+	return &BookingServiceImpl{
+		bookingRepository: bookingRepository,
+		unitService:       unitService,
+		now:               time.Now,
+	}
+}
+
+func (b *BookingServiceImpl) CreateBooking(ctx context.Context, request request.CreateBookingDto) (*domain.Booking, *handler.CustomError) {
+	if !request.EndAt.After(request.StartAt) {
+		return nil, handler.NewError(http.StatusBadRequest, "booking end time must be after start time")
+	}
+
+	if !request.EndAt.After(b.now()) {
+		return nil, handler.NewError(http.StatusBadRequest, "booking end time must be in the future")
+	}
+
+	unit, err := b.unitService.FindByID(ctx, request.UnitID)
+	if err != nil {
+		return nil, handler.NewError(err.Code, err.Message)
+	}
+
+	booking := domain.Booking{
+		UnitID:       unit.ID,
+		GuestName:    request.GuestName,
+		GuestContact: request.GuestContact,
+		Status:       enum.BookingReserved,
+		StartAt:      request.StartAt,
+		EndAt:        request.EndAt,
+	}
+
+	createdBooking, errSave := b.bookingRepository.Create(ctx, booking)
+	if errSave != nil {
+		if errSave == bookingrepository.ErrBookingOverlap {
+			return nil, handler.NewError(http.StatusConflict, "unit is already booked for that period")
+		}
+		return nil, handler.NewError(http.StatusInternalServerError, errSave.Error())
+	}
+
+	return &createdBooking, nil
+}
+
+func (b *BookingServiceImpl) FindByID(ctx context.Context, id string) (domain.Booking, *handler.CustomError) {
+	booking, err := b.bookingRepository.GetByID(ctx, id)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return booking, handler.NewError(http.StatusNotFound, "booking with that id was not found")
+		}
+		return booking, handler.NewError(http.StatusInternalServerError, err.Error())
+	}
+
+	return booking, nil
+}
+
+func (b *BookingServiceImpl) FindBookings(ctx context.Context, unitID, status string, page, size int) (*dto.PaginationResponse, *handler.CustomError) {
+	if !utils.IsEmptyString(status) {
+		if _, isValidStatus := enum.ParseBookingStatus(status); !isValidStatus {
+			return nil, handler.NewError(http.StatusBadRequest, "invalid booking status, must be one of 'Reserved', 'Checked In', 'Checked Out', 'Cancelled'")
+		}
+	}
+
+	bookings, total, err := b.bookingRepository.FindAll(ctx, unitID, status, page, size)
+	if err != nil {
+		return nil, handler.NewError(http.StatusInternalServerError, err.Error())
+	}
+
+	return dto.NewPaginationResponse(page, size, int(total), bookings), nil
+}
+
+// CheckIn occupies the unit and checks the guest in within one transaction. The unit row stays locked
+// until the commit, so the booking and the overlap are checked again against a stable unit
+func (b *BookingServiceImpl) CheckIn(ctx context.Context, id, actor string) (*domain.Booking, *handler.CustomError) {
+	booking, err := b.FindByID(ctx, id)
+	if err != nil {
+		return nil, handler.NewError(err.Code, err.Message)
+	}
+
+	errTransaction := b.bookingRepository.Transaction(ctx, func(ctx context.Context) error {
+		if _, errLock := b.unitService.LockByID(ctx, booking.UnitID.String()); errLock != nil {
+			return errLock
+		}
+
+		current, errBooking := b.FindByID(ctx, id)
+		if errBooking != nil {
+			return errBooking
+		}
+		booking = current
+
+		if booking.Status != enum.BookingReserved {
+			return handler.NewError(http.StatusBadRequest, fmt.Sprintf("booking with status '%s' cannot be checked in", booking.Status))
+		}
+
+		now := b.now()
+		if !booking.EndAt.After(now) {
+			return handler.NewError(http.StatusBadRequest, "booking period has already ended")
+		}
+
+		// an early check-in extends the stay backwards, it must not collide with the previous guest
+		if now.Before(booking.StartAt) {
+			overlap, errOverlap := b.bookingRepository.HasOverlap(ctx, booking.UnitID.String(), now, booking.EndAt, booking.ID.String())
+			if errOverlap != nil {
+				return errOverlap
+			}
+			if overlap {
+				return handler.NewError(http.StatusConflict, "unit is booked by another guest before this booking starts")
+			}
+		}
+
+		_, errUnit := b.unitService.ChangeStatus(ctx, booking.UnitID.String(), request.ChangeUnitStatusDto{
+			Status: string(enum.Occupied),
+			Reason: fmt.Sprintf("check-in of booking %s", booking.ID),
+			Actor:  actor,
+		})
+		if errUnit != nil {
+			return errUnit
+		}
+
+		booking.Status = enum.BookingCheckedIn
+		booking.CheckedInAt = &now
+		return b.bookingRepository.Update(ctx, booking)
+	})
+	if errTransaction != nil {
+		return nil, handler.AsCustomError(errTransaction)
+	}
+
+	return &booking, nil
+}
+
+// CheckOut frees the unit for cleaning and checks the guest out within one transaction
+func (b *BookingServiceImpl) CheckOut(ctx context.Context, id, actor string) (*domain.Booking, *handler.CustomError) {
+	booking, err := b.FindByID(ctx, id)
+	if err != nil {
+		return nil, handler.NewError(err.Code, err.Message)
+	}
+
+	errTransaction := b.bookingRepository.Transaction(ctx, func(ctx context.Context) error {
+		unit, errLock := b.unitService.LockByID(ctx, booking.UnitID.String())
+		if errLock != nil {
+			return errLock
+		}
+
+		current, errBooking := b.FindByID(ctx, id)
+		if errBooking != nil {
+			return errBooking
+		}
+		booking = current
+
+		if booking.Status != enum.BookingCheckedIn {
+			return handler.NewError(http.StatusBadRequest, fmt.Sprintf("booking with status '%s' cannot be checked out", booking.Status))
+		}
+
+		// a unit moved to maintenance during the stay keeps that status after the guest leaves
+		if unit.Status == enum.Occupied {
+			_, errUnit := b.unitService.ChangeStatus(ctx, booking.UnitID.String(), request.ChangeUnitStatusDto{
+				Status: string(enum.CleaningInProgress),
+				Reason: fmt.Sprintf("check-out of booking %s", booking.ID),
+				Actor:  actor,
+			})
+			if errUnit != nil {
+				return errUnit
+			}
+		}
+
+		now := b.now()
+		booking.Status = enum.BookingCheckedOut
+		booking.CheckedOutAt = &now
+		return b.bookingRepository.Update(ctx, booking)
+	})
+	if errTransaction != nil {
+		return nil, handler.AsCustomError(errTransaction)
+	}
+
+	return &booking, nil
+}
+
+// Cancel cancels a reservation with the unit locked like CheckIn, so a check-in running at the same time
+// either sees the cancelled booking or is seen as checked in here
+func (b *BookingServiceImpl) Cancel(ctx context.Context, id string) (*domain.Booking, *handler.CustomError) {
+	booking, err := b.FindByID(ctx, id)
+	if err != nil {
+		return nil, handler.NewError(err.Code, err.Message)
+	}
+
+	errTransaction := b.bookingRepository.Transaction(ctx, func(ctx context.Context) error {
+		if _, errLock := b.unitService.LockByID(ctx, booking.UnitID.String()); errLock != nil {
+			return errLock
+		}
+
+		current, errBooking := b.FindByID(ctx, id)
+		if errBooking != nil {
+			return errBooking
+		}
+		booking = current
+
+		if booking.Status != enum.BookingReserved {
+			return handler.NewError(http.StatusBadRequest, fmt.Sprintf("booking with status '%s' cannot be cancelled", booking.Status))
+		}
+
+		booking.Status = enum.BookingCancelled
+		return b.bookingRepository.Update(ctx, booking)
+	})
+	if errTransaction != nil {
+		return nil, handler.AsCustomError(errTransaction)
+	}
+
+	return &booking, nil
+}
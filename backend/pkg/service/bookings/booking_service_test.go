@@ -0,0 +1,354 @@
+package bookings
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"unit-management-be/pkg/handler"
+	"unit-management-be/pkg/model/domain"
+	"unit-management-be/pkg/model/domain/enum"
+	"unit-management-be/pkg/model/dto/request"
+	bookingrepository "unit-management-be/pkg/repository/bookings"
+	unitservice "unit-management-be/pkg/service/units"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+// MockBookingRepository of booking repository
+type MockBookingRepository struct {
+	mock.Mock
+}
+
+func (m *MockBookingRepository) Create(ctx context.Context, booking domain.Booking) (domain.Booking, error) {
+	args := m.Called(ctx, booking)
+	return args.Get(0).(domain.Booking), args.Error(1)
+}
+
+func (m *MockBookingRepository) GetByID(ctx context.Context, id string) (domain.Booking, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(domain.Booking), args.Error(1)
+}
+
+func (m *MockBookingRepository) FindAll(ctx context.Context, unitID, status string, page, size int) ([]domain.Booking, int64, error) {
+	args := m.Called(ctx, unitID, status, page, size)
+	return args.Get(0).([]domain.Booking), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockBookingRepository) HasOverlap(ctx context.Context, unitID string, startAt, endAt time.Time, excludeID string) (bool, error) {
+	args := m.Called(ctx, unitID, startAt, endAt, excludeID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockBookingRepository) Update(ctx context.Context, booking domain.Booking) error {
+	args := m.Called(ctx, booking)
+	return args.Error(0)
+}
+
+func (m *MockBookingRepository) Transaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+var _ bookingrepository.BookingRepository = &MockBookingRepository{}
+
+// MockUnitService of unit service, only the methods used by booking service are mocked
+type MockUnitService struct {
+	mock.Mock
+	unitservice.UnitService
+}
+
+func (m *MockUnitService) FindByID(ctx context.Context, id string) (domain.Units, *handler.CustomError) {
+	args := m.Called(ctx, id)
+	err, _ := args.Get(1).(*handler.CustomError)
+	return args.Get(0).(domain.Units), err
+}
+
+func (m *MockUnitService) LockByID(ctx context.Context, id string) (domain.Units, *handler.CustomError) {
+	args := m.Called(ctx, id)
+	err, _ := args.Get(1).(*handler.CustomError)
+	return args.Get(0).(domain.Units), err
+}
+
+func (m *MockUnitService) ChangeStatus(ctx context.Context, id string, request request.ChangeUnitStatusDto) (*domain.Units, *handler.CustomError) {
+	args := m.Called(ctx, id, request)
+	unit, _ := args.Get(0).(*domain.Units)
+	err, _ := args.Get(1).(*handler.CustomError)
+	return unit, err
+}
+
+var now = time.Date(2025, 10, 8, 14, 0, 0, 0, time.UTC)
+
+// initialization service, booking repository and unit service
+func setupTest(t *testing.T) (*MockBookingRepository, *MockUnitService, BookingService) {
+	mockRepo := new(MockBookingRepository)
+	mockUnitService := new(MockUnitService)
+	bookingService := &BookingServiceImpl{
+		bookingRepository: mockRepo,
+		unitService:       mockUnitService,
+		now:               func() time.Time { return now },
+	}
+	return mockRepo, mockUnitService, bookingService
+}
+
+func TestCreateBooking(t *testing.T) {
+	unitID := uuid.New()
+	req := request.CreateBookingDto{
+		UnitID:    unitID.String(),
+		GuestName: "Guest",
+		StartAt:   now.Add(time.Hour),
+		EndAt:     now.Add(24 * time.Hour),
+	}
+
+	t.Run("Positive Case: Create booking successfully", func(t *testing.T) {
+		mockRepo, mockUnitService, bookingService := setupTest(t)
+
+		mockUnitService.On("FindByID", mock.Anything, req.UnitID).Return(domain.Units{ID: unitID}, nil).Once()
+		mockRepo.On("Create", mock.Anything, mock.AnythingOfType("domain.Booking")).Return(domain.Booking{ID: uuid.New(), UnitID: unitID, Status: enum.BookingReserved}, nil).Run(func(args mock.Arguments) {
+			booking := args.Get(1).(domain.Booking)
+			assert.Equal(t, unitID, booking.UnitID)
+			assert.Equal(t, enum.BookingReserved, booking.Status)
+			assert.Equal(t, req.StartAt, booking.StartAt)
+		}).Once()
+
+		result, err := bookingService.CreateBooking(context.Background(), req)
+		assert.Nil(t, err)
+		assert.Equal(t, enum.BookingReserved, result.Status)
+		mockRepo.AssertExpectations(t)
+		mockUnitService.AssertExpectations(t)
+	})
+
+	t.Run("Negative Case: End time before start time", func(t *testing.T) {
+		_, _, bookingService := setupTest(t)
+		invalidReq := req
+		invalidReq.EndAt = req.StartAt.Add(-time.Minute)
+
+		result, err := bookingService.CreateBooking(context.Background(), invalidReq)
+		assert.Nil(t, result)
+		assert.Equal(t, http.StatusBadRequest, err.Code)
+	})
+
+	t.Run("Negative Case: Period overlaps another booking", func(t *testing.T) {
+		mockRepo, mockUnitService, bookingService := setupTest(t)
+
+		mockUnitService.On("FindByID", mock.Anything, req.UnitID).Return(domain.Units{ID: unitID}, nil).Once()
+		mockRepo.On("Create", mock.Anything, mock.Anything).Return(domain.Booking{}, bookingrepository.ErrBookingOverlap).Once()
+
+		result, err := bookingService.CreateBooking(context.Background(), req)
+		assert.Nil(t, result)
+		assert.Equal(t, http.StatusConflict, err.Code)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Negative Case: Unit not found", func(t *testing.T) {
+		mockRepo, mockUnitService, bookingService := setupTest(t)
+
+		mockUnitService.On("FindByID", mock.Anything, req.UnitID).Return(domain.Units{}, handler.NewError(http.StatusNotFound, "unit with that id was not found")).Once()
+
+		result, err := bookingService.CreateBooking(context.Background(), req)
+		assert.Nil(t, result)
+		assert.Equal(t, http.StatusNotFound, err.Code)
+		mockRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+}
+
+func TestCheckIn(t *testing.T) {
+	t.Run("Positive Case: Check in moves unit to occupied", func(t *testing.T) {
+		mockRepo, mockUnitService, bookingService := setupTest(t)
+		booking := domain.Booking{ID: uuid.New(), UnitID: uuid.New(), Status: enum.BookingReserved, StartAt: now.Add(-time.Hour), EndAt: now.Add(time.Hour)}
+
+		mockRepo.On("GetByID", mock.Anything, booking.ID.String()).Return(booking, nil).Twice()
+		mockUnitService.On("LockByID", mock.Anything, booking.UnitID.String()).Return(domain.Units{ID: booking.UnitID, Status: enum.Available}, nil).Once()
+		mockUnitService.On("ChangeStatus", mock.Anything, booking.UnitID.String(), mock.MatchedBy(func(req request.ChangeUnitStatusDto) bool {
+			return req.Status == string(enum.Occupied) && req.Actor == "front desk"
+		})).Return(&domain.Units{ID: booking.UnitID, Status: enum.Occupied}, nil).Once()
+		mockRepo.On("Update", mock.Anything, mock.MatchedBy(func(b domain.Booking) bool {
+			return b.Status == enum.BookingCheckedIn && b.CheckedInAt != nil
+		})).Return(nil).Once()
+
+		result, err := bookingService.CheckIn(context.Background(), booking.ID.String(), "front desk")
+		assert.Nil(t, err)
+		assert.Equal(t, enum.BookingCheckedIn, result.Status)
+		mockRepo.AssertExpectations(t)
+		mockUnitService.AssertExpectations(t)
+	})
+
+	t.Run("Negative Case: Early check in collides with previous guest", func(t *testing.T) {
+		mockRepo, mockUnitService, bookingService := setupTest(t)
+		booking := domain.Booking{ID: uuid.New(), UnitID: uuid.New(), Status: enum.BookingReserved, StartAt: now.Add(time.Hour), EndAt: now.Add(3 * time.Hour)}
+
+		mockRepo.On("GetByID", mock.Anything, booking.ID.String()).Return(booking, nil).Twice()
+		mockUnitService.On("LockByID", mock.Anything, booking.UnitID.String()).Return(domain.Units{ID: booking.UnitID, Status: enum.Available}, nil).Once()
+		mockRepo.On("HasOverlap", mock.Anything, booking.UnitID.String(), now, booking.EndAt, booking.ID.String()).Return(true, nil).Once()
+
+		result, err := bookingService.CheckIn(context.Background(), booking.ID.String(), "front desk")
+		assert.Nil(t, result)
+		assert.Equal(t, http.StatusConflict, err.Code)
+		mockUnitService.AssertNotCalled(t, "ChangeStatus", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Negative Case: Unit cannot become occupied", func(t *testing.T) {
+		mockRepo, mockUnitService, bookingService := setupTest(t)
+		booking := domain.Booking{ID: uuid.New(), UnitID: uuid.New(), Status: enum.BookingReserved, StartAt: now.Add(-time.Hour), EndAt: now.Add(time.Hour)}
+
+		mockRepo.On("GetByID", mock.Anything, booking.ID.String()).Return(booking, nil).Twice()
+		mockUnitService.On("LockByID", mock.Anything, booking.UnitID.String()).Return(domain.Units{ID: booking.UnitID, Status: enum.MaintenanceNeeded}, nil).Once()
+		mockUnitService.On("ChangeStatus", mock.Anything, booking.UnitID.String(), mock.Anything).Return(nil, handler.NewError(http.StatusBadRequest, "unit cannot transition")).Once()
+
+		result, err := bookingService.CheckIn(context.Background(), booking.ID.String(), "front desk")
+		assert.Nil(t, result)
+		assert.Equal(t, http.StatusBadRequest, err.Code)
+		mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Negative Case: Booking checked in by another request while waiting for the unit", func(t *testing.T) {
+		mockRepo, mockUnitService, bookingService := setupTest(t)
+		booking := domain.Booking{ID: uuid.New(), UnitID: uuid.New(), Status: enum.BookingReserved, StartAt: now.Add(-time.Hour), EndAt: now.Add(time.Hour)}
+		checkedIn := booking
+		checkedIn.Status = enum.BookingCheckedIn
+
+		mockRepo.On("GetByID", mock.Anything, booking.ID.String()).Return(booking, nil).Once()
+		mockUnitService.On("LockByID", mock.Anything, booking.UnitID.String()).Return(domain.Units{ID: booking.UnitID, Status: enum.Occupied}, nil).Once()
+		mockRepo.On("GetByID", mock.Anything, booking.ID.String()).Return(checkedIn, nil).Once()
+
+		result, err := bookingService.CheckIn(context.Background(), booking.ID.String(), "front desk")
+		assert.Nil(t, result)
+		assert.Equal(t, http.StatusBadRequest, err.Code)
+		mockUnitService.AssertNotCalled(t, "ChangeStatus", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Negative Case: Failed booking update fails the check in", func(t *testing.T) {
+		mockRepo, mockUnitService, bookingService := setupTest(t)
+		booking := domain.Booking{ID: uuid.New(), UnitID: uuid.New(), Status: enum.BookingReserved, StartAt: now.Add(-time.Hour), EndAt: now.Add(time.Hour)}
+
+		mockRepo.On("GetByID", mock.Anything, booking.ID.String()).Return(booking, nil).Twice()
+		mockUnitService.On("LockByID", mock.Anything, booking.UnitID.String()).Return(domain.Units{ID: booking.UnitID, Status: enum.Available}, nil).Once()
+		mockUnitService.On("ChangeStatus", mock.Anything, booking.UnitID.String(), mock.Anything).Return(&domain.Units{ID: booking.UnitID, Status: enum.Occupied}, nil).Once()
+		mockRepo.On("Update", mock.Anything, mock.Anything).Return(gorm.ErrInvalidTransaction).Once()
+
+		result, err := bookingService.CheckIn(context.Background(), booking.ID.String(), "front desk")
+		assert.Nil(t, result)
+		assert.Equal(t, http.StatusInternalServerError, err.Code)
+	})
+
+	t.Run("Negative Case: Booking already checked out", func(t *testing.T) {
+		mockRepo, mockUnitService, bookingService := setupTest(t)
+		booking := domain.Booking{ID: uuid.New(), UnitID: uuid.New(), Status: enum.BookingCheckedOut}
+
+		mockRepo.On("GetByID", mock.Anything, booking.ID.String()).Return(booking, nil).Twice()
+		mockUnitService.On("LockByID", mock.Anything, booking.UnitID.String()).Return(domain.Units{ID: booking.UnitID, Status: enum.CleaningInProgress}, nil).Once()
+
+		result, err := bookingService.CheckIn(context.Background(), booking.ID.String(), "front desk")
+		assert.Nil(t, result)
+		assert.Equal(t, http.StatusBadRequest, err.Code)
+	})
+
+	t.Run("Negative Case: Booking not found", func(t *testing.T) {
+		mockRepo, _, bookingService := setupTest(t)
+		id := uuid.New().String()
+
+		mockRepo.On("GetByID", mock.Anything, id).Return(domain.Booking{}, gorm.ErrRecordNotFound).Once()
+
+		result, err := bookingService.CheckIn(context.Background(), id, "front desk")
+		assert.Nil(t, result)
+		assert.Equal(t, http.StatusNotFound, err.Code)
+	})
+}
+
+func TestCheckOut(t *testing.T) {
+	t.Run("Positive Case: Check out moves unit to cleaning", func(t *testing.T) {
+		mockRepo, mockUnitService, bookingService := setupTest(t)
+		booking := domain.Booking{ID: uuid.New(), UnitID: uuid.New(), Status: enum.BookingCheckedIn}
+
+		mockRepo.On("GetByID", mock.Anything, booking.ID.String()).Return(booking, nil).Twice()
+		mockUnitService.On("LockByID", mock.Anything, booking.UnitID.String()).Return(domain.Units{ID: booking.UnitID, Status: enum.Occupied}, nil).Once()
+		mockUnitService.On("ChangeStatus", mock.Anything, booking.UnitID.String(), mock.MatchedBy(func(req request.ChangeUnitStatusDto) bool {
+			return req.Status == string(enum.CleaningInProgress)
+		})).Return(&domain.Units{ID: booking.UnitID, Status: enum.CleaningInProgress}, nil).Once()
+		mockRepo.On("Update", mock.Anything, mock.Anything).Return(nil).Once()
+
+		result, err := bookingService.CheckOut(context.Background(), booking.ID.String(), "front desk")
+		assert.Nil(t, err)
+		assert.Equal(t, enum.BookingCheckedOut, result.Status)
+		assert.NotNil(t, result.CheckedOutAt)
+		mockRepo.AssertExpectations(t)
+		mockUnitService.AssertExpectations(t)
+	})
+
+	t.Run("Positive Case: Unit under maintenance keeps its status", func(t *testing.T) {
+		mockRepo, mockUnitService, bookingService := setupTest(t)
+		booking := domain.Booking{ID: uuid.New(), UnitID: uuid.New(), Status: enum.BookingCheckedIn}
+
+		mockRepo.On("GetByID", mock.Anything, booking.ID.String()).Return(booking, nil).Twice()
+		mockUnitService.On("LockByID", mock.Anything, booking.UnitID.String()).Return(domain.Units{ID: booking.UnitID, Status: enum.MaintenanceNeeded}, nil).Once()
+		mockRepo.On("Update", mock.Anything, mock.Anything).Return(nil).Once()
+
+		result, err := bookingService.CheckOut(context.Background(), booking.ID.String(), "front desk")
+		assert.Nil(t, err)
+		assert.Equal(t, enum.BookingCheckedOut, result.Status)
+		mockUnitService.AssertNotCalled(t, "ChangeStatus", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Negative Case: Booking not checked in", func(t *testing.T) {
+		mockRepo, mockUnitService, bookingService := setupTest(t)
+		booking := domain.Booking{ID: uuid.New(), UnitID: uuid.New(), Status: enum.BookingReserved}
+
+		mockRepo.On("GetByID", mock.Anything, booking.ID.String()).Return(booking, nil).Twice()
+		mockUnitService.On("LockByID", mock.Anything, booking.UnitID.String()).Return(domain.Units{ID: booking.UnitID, Status: enum.Available}, nil).Once()
+
+		result, err := bookingService.CheckOut(context.Background(), booking.ID.String(), "front desk")
+		assert.Nil(t, result)
+		assert.Equal(t, http.StatusBadRequest, err.Code)
+		mockUnitService.AssertNotCalled(t, "ChangeStatus", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestCancel(t *testing.T) {
+	t.Run("Positive Case: Cancel reservation", func(t *testing.T) {
+		mockRepo, mockUnitService, bookingService := setupTest(t)
+		booking := domain.Booking{ID: uuid.New(), UnitID: uuid.New(), Status: enum.BookingReserved}
+
+		mockRepo.On("GetByID", mock.Anything, booking.ID.String()).Return(booking, nil).Twice()
+		mockUnitService.On("LockByID", mock.Anything, booking.UnitID.String()).Return(domain.Units{ID: booking.UnitID, Status: enum.Available}, nil).Once()
+		mockRepo.On("Update", mock.Anything, mock.MatchedBy(func(updated domain.Booking) bool {
+			return updated.Status == enum.BookingCancelled
+		})).Return(nil).Once()
+
+		result, err := bookingService.Cancel(context.Background(), booking.ID.String())
+		assert.Nil(t, err)
+		assert.Equal(t, enum.BookingCancelled, result.Status)
+		mockRepo.AssertExpectations(t)
+		mockUnitService.AssertExpectations(t)
+	})
+
+	t.Run("Negative Case: Booking checked in by another request while waiting for the unit", func(t *testing.T) {
+		mockRepo, mockUnitService, bookingService := setupTest(t)
+		booking := domain.Booking{ID: uuid.New(), UnitID: uuid.New(), Status: enum.BookingReserved}
+		checkedIn := booking
+		checkedIn.Status = enum.BookingCheckedIn
+
+		mockRepo.On("GetByID", mock.Anything, booking.ID.String()).Return(booking, nil).Once()
+		mockUnitService.On("LockByID", mock.Anything, booking.UnitID.String()).Return(domain.Units{ID: booking.UnitID, Status: enum.Occupied}, nil).Once()
+		mockRepo.On("GetByID", mock.Anything, booking.ID.String()).Return(checkedIn, nil).Once()
+
+		result, err := bookingService.Cancel(context.Background(), booking.ID.String())
+		assert.Nil(t, result)
+		assert.Equal(t, http.StatusBadRequest, err.Code)
+		mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Negative Case: Cannot cancel checked in booking", func(t *testing.T) {
+		mockRepo, mockUnitService, bookingService := setupTest(t)
+		booking := domain.Booking{ID: uuid.New(), UnitID: uuid.New(), Status: enum.BookingCheckedIn}
+
+		mockRepo.On("GetByID", mock.Anything, booking.ID.String()).Return(booking, nil).Twice()
+		mockUnitService.On("LockByID", mock.Anything, booking.UnitID.String()).Return(domain.Units{ID: booking.UnitID, Status: enum.Occupied}, nil).Once()
+
+		result, err := bookingService.Cancel(context.Background(), booking.ID.String())
+		assert.Nil(t, result)
+		assert.Equal(t, http.StatusBadRequest, err.Code)
+	})
+}
@@ -0,0 +1,18 @@
+package housekeeping
+
+import (
+	"context"
+	"unit-management-be/pkg/handler"
+	"unit-management-be/pkg/model/domain"
+	"unit-management-be/pkg/model/dto"
+	"unit-management-be/pkg/model/dto/response"
+)
+
+type HousekeepingService interface {
+	FindByID(ctx context.Context, id string) (domain.HousekeepingTask, *handler.CustomError)
+	FindTasks(ctx context.Context, status, assignee string, page, size int) (*dto.PaginationResponse, *handler.CustomError)
+	NextTask(ctx context.Context) (*response.HousekeepingTaskResponse, *handler.CustomError)
+	Claim(ctx context.Context, id, actor string) (*domain.HousekeepingTask, *handler.CustomError)
+	Complete(ctx context.Context, id, actor string) (*domain.HousekeepingTask, *handler.CustomError)
+	OnUnitStatusChanged(ctx context.Context, unit domain.Units, history domain.UnitStatusHistory) error
+}
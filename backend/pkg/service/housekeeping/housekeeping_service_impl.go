@@ -0,0 +1,217 @@
+package housekeeping
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+	"unit-management-be/pkg/handler"
+	"unit-management-be/pkg/model/domain"
+	"unit-management-be/pkg/model/domain/enum"
+	"unit-management-be/pkg/model/dto"
+	"unit-management-be/pkg/model/dto/request"
+	"unit-management-be/pkg/model/dto/response"
+	housekeepingrepository "unit-management-be/pkg/repository/housekeeping"
+	unitservice "unit-management-be/pkg/service/units"
+	"unit-management-be/pkg/utils"
+
+	"gorm.io/gorm"
+)
+
+// taskDueIn is how long housekeeping has to clean a unit once it enters cleaning
+const taskDueIn = time.Hour
+
+type HousekeepingServiceImpl struct {
+	housekeepingRepository housekeepingrepository.HousekeepingRepository
+	unitService            unitservice.UnitService
+	peakHours              PeakHours
+	now                    func() time.Time
+}
+
+func NewHousekeepingService(housekeepingRepository housekeepingrepository.HousekeepingRepository, unitService unitservice.UnitService, peakHours PeakHours) HousekeepingService {
+	return &HousekeepingServiceImpl{
+		housekeepingRepository: housekeepingRepository,
+		unitService:            unitService,
+		peakHours:              peakHours,
+		now:                    time.Now,
+	}
+}
+
+func (h *HousekeepingServiceImpl) FindByID(ctx context.Context, id string) (domain.HousekeepingTask, *handler.CustomError) {
+	task, err := h.housekeepingRepository.GetByID(ctx, id)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return task, handler.NewError(http.StatusNotFound, "housekeeping task with that id was not found")
+		}
+		return task, handler.NewError(http.StatusInternalServerError, err.Error())
+	}
+
+	return task, nil
+}
+
+func (h *HousekeepingServiceImpl) FindTasks(ctx context.Context, status, assignee string, page, size int) (*dto.PaginationResponse, *handler.CustomError) {
+	if !utils.IsEmptyString(status) {
+		if _, isValidStatus := enum.ParseHousekeepingTaskStatus(status); !isValidStatus {
+			return nil, handler.NewError(http.StatusBadRequest, "invalid housekeeping task status, must be one of 'Pending', 'In Progress', 'Completed', 'Cancelled'")
+		}
+	}
+
+	tasks, total, err := h.housekeepingRepository.FindAll(ctx, status, assignee, page, size)
+	if err != nil {
+		return nil, handler.NewError(http.StatusInternalServerError, err.Error())
+	}
+
+	return dto.NewPaginationResponse(page, size, int(total), tasks), nil
+}
+
+func (h *HousekeepingServiceImpl) NextTask(ctx context.Context) (*response.HousekeepingTaskResponse, *handler.CustomError) {
+	tasks, err := h.housekeepingRepository.FindPending(ctx)
+	if err != nil {
+		return nil, handler.NewError(http.StatusInternalServerError, err.Error())
+	}
+
+	if len(tasks) == 0 {
+		return nil, handler.NewError(http.StatusNotFound, "there is no pending housekeeping task")
+	}
+
+	prioritizeTasks(tasks, h.peakHours.Contains(h.now()))
+	return &tasks[0], nil
+}
+
+func (h *HousekeepingServiceImpl) Claim(ctx context.Context, id, actor string) (*domain.HousekeepingTask, *handler.CustomError) {
+	task, err := h.FindByID(ctx, id)
+	if err != nil {
+		return nil, handler.NewError(err.Code, err.Message)
+	}
+
+	if task.Status != enum.HousekeepingPending {
+		return nil, handler.NewError(http.StatusConflict, fmt.Sprintf("housekeeping task with status '%s' cannot be claimed", task.Status))
+	}
+
+	now := h.now()
+	claimed, errClaim := h.housekeepingRepository.Claim(ctx, id, actor, now)
+	if errClaim != nil {
+		return nil, handler.NewError(http.StatusInternalServerError, errClaim.Error())
+	}
+	if !claimed {
+		return nil, handler.NewError(http.StatusConflict, "housekeeping task has already been claimed")
+	}
+
+	task.Status = enum.HousekeepingInProgress
+	task.Assignee = actor
+	task.ClaimedAt = &now
+	return &task, nil
+}
+
+// Complete finishes the task and makes the unit available again in one transaction
+func (h *HousekeepingServiceImpl) Complete(ctx context.Context, id, actor string) (*domain.HousekeepingTask, *handler.CustomError) {
+	task, err := h.FindByID(ctx, id)
+	if err != nil {
+		return nil, handler.NewError(err.Code, err.Message)
+	}
+
+	errTransaction := h.housekeepingRepository.Transaction(ctx, func(ctx context.Context) error {
+		if _, errLock := h.unitService.LockByID(ctx, task.UnitID.String()); errLock != nil {
+			return errLock
+		}
+
+		current, errTask := h.FindByID(ctx, id)
+		if errTask != nil {
+			return errTask
+		}
+		task = current
+
+		if !task.IsOpen() {
+			return handler.NewError(http.StatusConflict, fmt.Sprintf("housekeeping task with status '%s' cannot be completed", task.Status))
+		}
+
+		now := h.now()
+		if utils.IsEmptyString(task.Assignee) {
+			task.Assignee = actor
+			task.ClaimedAt = &now
+		}
+		task.Status = enum.HousekeepingCompleted
+		task.CompletedAt = &now
+
+		// the task is completed before the unit leaves cleaning, so the status hook does not cancel it
+		if errUpdate := h.housekeepingRepository.Update(ctx, task); errUpdate != nil {
+			return errUpdate
+		}
+
+		_, errUnit := h.unitService.ChangeStatus(ctx, task.UnitID.String(), request.ChangeUnitStatusDto{
+			Status: string(enum.Available),
+			Reason: fmt.Sprintf("housekeeping task %s completed", task.ID),
+			Actor:  actor,
+		})
+		if errUnit != nil {
+			return errUnit
+		}
+		return nil
+	})
+	if errTransaction != nil {
+		return nil, handler.AsCustomError(errTransaction)
+	}
+
+	return &task, nil
+}
+
+// OnUnitStatusChanged opens a task when a unit enters cleaning and cancels the open tasks when the
+// unit leaves cleaning without the task being completed. It runs in the transaction of the status
+// change, so the change is rolled back when the tasks cannot be written
+func (h *HousekeepingServiceImpl) OnUnitStatusChanged(ctx context.Context, unit domain.Units, history domain.UnitStatusHistory) error {
+	if history.ToStatus == enum.CleaningInProgress {
+		return h.openTask(ctx, unit, history.ChangedAt)
+	}
+
+	if history.FromStatus != nil && *history.FromStatus == enum.CleaningInProgress {
+		return h.cancelOpenTasks(ctx, unit)
+	}
+	return nil
+}
+
+func (h *HousekeepingServiceImpl) openTask(ctx context.Context, unit domain.Units, since time.Time) error {
+	openTasks, err := h.housekeepingRepository.FindOpenByUnitID(ctx, unit.ID.String())
+	if err != nil {
+		return err
+	}
+	if len(openTasks) > 0 {
+		return nil
+	}
+
+	task := domain.HousekeepingTask{
+		UnitID:    unit.ID,
+		Status:    enum.HousekeepingPending,
+		DueAt:     since.Add(taskDueIn),
+		CreatedAt: since,
+	}
+	_, err = h.housekeepingRepository.Create(ctx, task)
+	return err
+}
+
+func (h *HousekeepingServiceImpl) cancelOpenTasks(ctx context.Context, unit domain.Units) error {
+	openTasks, err := h.housekeepingRepository.FindOpenByUnitID(ctx, unit.ID.String())
+	if err != nil {
+		return err
+	}
+
+	for _, task := range openTasks {
+		task.Status = enum.HousekeepingCancelled
+		if err := h.housekeepingRepository.Update(ctx, task); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// prioritizeTasks orders tasks by how long the unit has been waiting, during peak hours
+// cabins are cleaned before capsules
+func prioritizeTasks(tasks []response.HousekeepingTaskResponse, isPeak bool) {
+	sort.SliceStable(tasks, func(i, j int) bool {
+		if isPeak && tasks[i].UnitType != tasks[j].UnitType {
+			return tasks[i].UnitType == enum.Cabin
+		}
+
+		return tasks[i].CreatedAt.Before(tasks[j].CreatedAt)
+	})
+}
@@ -0,0 +1,309 @@
+package housekeeping
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"unit-management-be/pkg/handler"
+	"unit-management-be/pkg/model/domain"
+	"unit-management-be/pkg/model/domain/enum"
+	"unit-management-be/pkg/model/dto/request"
+	"unit-management-be/pkg/model/dto/response"
+	housekeepingrepository "unit-management-be/pkg/repository/housekeeping"
+	unitservice "unit-management-be/pkg/service/units"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+// MockHousekeepingRepository of housekeeping repository
+type MockHousekeepingRepository struct {
+	mock.Mock
+}
+
+func (m *MockHousekeepingRepository) Create(ctx context.Context, task domain.HousekeepingTask) (domain.HousekeepingTask, error) {
+	args := m.Called(ctx, task)
+	return args.Get(0).(domain.HousekeepingTask), args.Error(1)
+}
+
+func (m *MockHousekeepingRepository) GetByID(ctx context.Context, id string) (domain.HousekeepingTask, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(domain.HousekeepingTask), args.Error(1)
+}
+
+func (m *MockHousekeepingRepository) FindAll(ctx context.Context, status, assignee string, page, size int) ([]response.HousekeepingTaskResponse, int64, error) {
+	args := m.Called(ctx, status, assignee, page, size)
+	return args.Get(0).([]response.HousekeepingTaskResponse), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockHousekeepingRepository) FindPending(ctx context.Context) ([]response.HousekeepingTaskResponse, error) {
+	args := m.Called(ctx)
+	return args.Get(0).([]response.HousekeepingTaskResponse), args.Error(1)
+}
+
+func (m *MockHousekeepingRepository) FindOpenByUnitID(ctx context.Context, unitID string) ([]domain.HousekeepingTask, error) {
+	args := m.Called(ctx, unitID)
+	return args.Get(0).([]domain.HousekeepingTask), args.Error(1)
+}
+
+func (m *MockHousekeepingRepository) Claim(ctx context.Context, id, assignee string, claimedAt time.Time) (bool, error) {
+	args := m.Called(ctx, id, assignee, claimedAt)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockHousekeepingRepository) Update(ctx context.Context, task domain.HousekeepingTask) error {
+	args := m.Called(ctx, task)
+	return args.Error(0)
+}
+
+func (m *MockHousekeepingRepository) Transaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+var _ housekeepingrepository.HousekeepingRepository = &MockHousekeepingRepository{}
+
+// MockUnitService of unit service, only the methods used by housekeeping service are mocked
+type MockUnitService struct {
+	mock.Mock
+	unitservice.UnitService
+}
+
+func (m *MockUnitService) LockByID(ctx context.Context, id string) (domain.Units, *handler.CustomError) {
+	args := m.Called(ctx, id)
+	err, _ := args.Get(1).(*handler.CustomError)
+	return args.Get(0).(domain.Units), err
+}
+
+func (m *MockUnitService) ChangeStatus(ctx context.Context, id string, request request.ChangeUnitStatusDto) (*domain.Units, *handler.CustomError) {
+	args := m.Called(ctx, id, request)
+	unit, _ := args.Get(0).(*domain.Units)
+	err, _ := args.Get(1).(*handler.CustomError)
+	return unit, err
+}
+
+var now = time.Date(2025, 10, 10, 15, 0, 0, 0, time.UTC)
+
+// initialization service, housekeeping repository and unit service
+func setupTest(t *testing.T) (*MockHousekeepingRepository, *MockUnitService, *HousekeepingServiceImpl) {
+	mockRepo := new(MockHousekeepingRepository)
+	mockUnitService := new(MockUnitService)
+	housekeepingService := &HousekeepingServiceImpl{
+		housekeepingRepository: mockRepo,
+		unitService:            mockUnitService,
+		peakHours:              DefaultPeakHours,
+		now:                    func() time.Time { return now },
+	}
+	return mockRepo, mockUnitService, housekeepingService
+}
+
+func TestNextTask(t *testing.T) {
+	oldCapsule := response.HousekeepingTaskResponse{ID: uuid.New(), UnitType: enum.Capsule, CreatedAt: now.Add(-2 * time.Hour)}
+	newCabin := response.HousekeepingTaskResponse{ID: uuid.New(), UnitType: enum.Cabin, CreatedAt: now.Add(-10 * time.Minute)}
+	oldCabin := response.HousekeepingTaskResponse{ID: uuid.New(), UnitType: enum.Cabin, CreatedAt: now.Add(-time.Hour)}
+
+	t.Run("Positive Case: Cabins first during peak hours", func(t *testing.T) {
+		mockRepo, _, housekeepingService := setupTest(t)
+
+		mockRepo.On("FindPending", mock.Anything).Return([]response.HousekeepingTaskResponse{oldCapsule, newCabin, oldCabin}, nil).Once()
+
+		result, err := housekeepingService.NextTask(context.Background())
+		assert.Nil(t, err)
+		assert.Equal(t, oldCabin.ID, result.ID)
+	})
+
+	t.Run("Positive Case: Longest waiting first outside peak hours", func(t *testing.T) {
+		mockRepo, _, housekeepingService := setupTest(t)
+		housekeepingService.now = func() time.Time { return now.Add(-6 * time.Hour) }
+
+		mockRepo.On("FindPending", mock.Anything).Return([]response.HousekeepingTaskResponse{newCabin, oldCabin, oldCapsule}, nil).Once()
+
+		result, err := housekeepingService.NextTask(context.Background())
+		assert.Nil(t, err)
+		assert.Equal(t, oldCapsule.ID, result.ID)
+	})
+
+	t.Run("Negative Case: No pending task", func(t *testing.T) {
+		mockRepo, _, housekeepingService := setupTest(t)
+
+		mockRepo.On("FindPending", mock.Anything).Return([]response.HousekeepingTaskResponse{}, nil).Once()
+
+		result, err := housekeepingService.NextTask(context.Background())
+		assert.Nil(t, result)
+		assert.Equal(t, http.StatusNotFound, err.Code)
+	})
+}
+
+func TestClaim(t *testing.T) {
+	t.Run("Positive Case: Claim pending task", func(t *testing.T) {
+		mockRepo, _, housekeepingService := setupTest(t)
+		task := domain.HousekeepingTask{ID: uuid.New(), Status: enum.HousekeepingPending}
+
+		mockRepo.On("GetByID", mock.Anything, task.ID.String()).Return(task, nil).Once()
+		mockRepo.On("Claim", mock.Anything, task.ID.String(), "maria", now).Return(true, nil).Once()
+
+		result, err := housekeepingService.Claim(context.Background(), task.ID.String(), "maria")
+		assert.Nil(t, err)
+		assert.Equal(t, enum.HousekeepingInProgress, result.Status)
+		assert.Equal(t, "maria", result.Assignee)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Negative Case: Task claimed concurrently", func(t *testing.T) {
+		mockRepo, _, housekeepingService := setupTest(t)
+		task := domain.HousekeepingTask{ID: uuid.New(), Status: enum.HousekeepingPending}
+
+		mockRepo.On("GetByID", mock.Anything, task.ID.String()).Return(task, nil).Once()
+		mockRepo.On("Claim", mock.Anything, task.ID.String(), "maria", now).Return(false, nil).Once()
+
+		result, err := housekeepingService.Claim(context.Background(), task.ID.String(), "maria")
+		assert.Nil(t, result)
+		assert.Equal(t, http.StatusConflict, err.Code)
+	})
+
+	t.Run("Negative Case: Task not found", func(t *testing.T) {
+		mockRepo, _, housekeepingService := setupTest(t)
+		id := uuid.New().String()
+
+		mockRepo.On("GetByID", mock.Anything, id).Return(domain.HousekeepingTask{}, gorm.ErrRecordNotFound).Once()
+
+		result, err := housekeepingService.Claim(context.Background(), id, "maria")
+		assert.Nil(t, result)
+		assert.Equal(t, http.StatusNotFound, err.Code)
+	})
+}
+
+func TestComplete(t *testing.T) {
+	t.Run("Positive Case: Complete task moves unit to available", func(t *testing.T) {
+		mockRepo, mockUnitService, housekeepingService := setupTest(t)
+		task := domain.HousekeepingTask{ID: uuid.New(), UnitID: uuid.New(), Status: enum.HousekeepingInProgress, Assignee: "maria"}
+
+		mockRepo.On("GetByID", mock.Anything, task.ID.String()).Return(task, nil).Twice()
+		mockUnitService.On("LockByID", mock.Anything, task.UnitID.String()).Return(domain.Units{ID: task.UnitID, Status: enum.CleaningInProgress}, nil).Once()
+		mockRepo.On("Update", mock.Anything, mock.MatchedBy(func(updated domain.HousekeepingTask) bool {
+			return updated.Status == enum.HousekeepingCompleted && updated.CompletedAt != nil
+		})).Return(nil).Once()
+		mockUnitService.On("ChangeStatus", mock.Anything, task.UnitID.String(), mock.MatchedBy(func(req request.ChangeUnitStatusDto) bool {
+			return req.Status == string(enum.Available) && req.Actor == "maria"
+		})).Return(&domain.Units{ID: task.UnitID, Status: enum.Available}, nil).Once()
+
+		result, err := housekeepingService.Complete(context.Background(), task.ID.String(), "maria")
+		assert.Nil(t, err)
+		assert.Equal(t, enum.HousekeepingCompleted, result.Status)
+		mockRepo.AssertExpectations(t)
+		mockUnitService.AssertExpectations(t)
+	})
+
+	t.Run("Negative Case: Unit cannot become available fails the whole completion", func(t *testing.T) {
+		mockRepo, mockUnitService, housekeepingService := setupTest(t)
+		task := domain.HousekeepingTask{ID: uuid.New(), UnitID: uuid.New(), Status: enum.HousekeepingInProgress, Assignee: "maria"}
+
+		mockRepo.On("GetByID", mock.Anything, task.ID.String()).Return(task, nil).Twice()
+		mockUnitService.On("LockByID", mock.Anything, task.UnitID.String()).Return(domain.Units{ID: task.UnitID, Status: enum.MaintenanceNeeded}, nil).Once()
+		mockRepo.On("Update", mock.Anything, mock.MatchedBy(func(updated domain.HousekeepingTask) bool {
+			return updated.Status == enum.HousekeepingCompleted
+		})).Return(nil).Once()
+		mockUnitService.On("ChangeStatus", mock.Anything, task.UnitID.String(), mock.Anything).Return(nil, handler.NewError(http.StatusBadRequest, "unit cannot transition")).Once()
+
+		result, err := housekeepingService.Complete(context.Background(), task.ID.String(), "maria")
+		assert.Nil(t, result)
+		assert.Equal(t, http.StatusBadRequest, err.Code)
+		mockRepo.AssertExpectations(t)
+		mockRepo.AssertNumberOfCalls(t, "Update", 1)
+	})
+
+	t.Run("Negative Case: Task completed by another request while waiting for the unit", func(t *testing.T) {
+		mockRepo, mockUnitService, housekeepingService := setupTest(t)
+		task := domain.HousekeepingTask{ID: uuid.New(), UnitID: uuid.New(), Status: enum.HousekeepingInProgress, Assignee: "maria"}
+		completed := task
+		completed.Status = enum.HousekeepingCompleted
+
+		mockRepo.On("GetByID", mock.Anything, task.ID.String()).Return(task, nil).Once()
+		mockUnitService.On("LockByID", mock.Anything, task.UnitID.String()).Return(domain.Units{ID: task.UnitID, Status: enum.Available}, nil).Once()
+		mockRepo.On("GetByID", mock.Anything, task.ID.String()).Return(completed, nil).Once()
+
+		result, err := housekeepingService.Complete(context.Background(), task.ID.String(), "maria")
+		assert.Nil(t, result)
+		assert.Equal(t, http.StatusConflict, err.Code)
+		mockUnitService.AssertNotCalled(t, "ChangeStatus", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Negative Case: Task already completed", func(t *testing.T) {
+		mockRepo, mockUnitService, housekeepingService := setupTest(t)
+		task := domain.HousekeepingTask{ID: uuid.New(), UnitID: uuid.New(), Status: enum.HousekeepingCompleted}
+
+		mockRepo.On("GetByID", mock.Anything, task.ID.String()).Return(task, nil).Twice()
+		mockUnitService.On("LockByID", mock.Anything, task.UnitID.String()).Return(domain.Units{ID: task.UnitID, Status: enum.Available}, nil).Once()
+
+		result, err := housekeepingService.Complete(context.Background(), task.ID.String(), "maria")
+		assert.Nil(t, result)
+		assert.Equal(t, http.StatusConflict, err.Code)
+	})
+}
+
+func TestOnUnitStatusChanged(t *testing.T) {
+	t.Run("Positive Case: Unit entering cleaning opens a task", func(t *testing.T) {
+		mockRepo, _, housekeepingService := setupTest(t)
+		unit := domain.Units{ID: uuid.New(), Status: enum.CleaningInProgress}
+		from := enum.Occupied
+
+		mockRepo.On("FindOpenByUnitID", mock.Anything, unit.ID.String()).Return([]domain.HousekeepingTask{}, nil).Once()
+		mockRepo.On("Create", mock.Anything, mock.MatchedBy(func(task domain.HousekeepingTask) bool {
+			return task.UnitID == unit.ID && task.Status == enum.HousekeepingPending && task.DueAt.Equal(now.Add(taskDueIn))
+		})).Return(domain.HousekeepingTask{}, nil).Once()
+
+		err := housekeepingService.OnUnitStatusChanged(context.Background(), unit, domain.UnitStatusHistory{UnitID: unit.ID, FromStatus: &from, ToStatus: enum.CleaningInProgress, ChangedAt: now})
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Positive Case: Unit leaving cleaning cancels open tasks", func(t *testing.T) {
+		mockRepo, _, housekeepingService := setupTest(t)
+		unit := domain.Units{ID: uuid.New(), Status: enum.MaintenanceNeeded}
+		from := enum.CleaningInProgress
+		openTask := domain.HousekeepingTask{ID: uuid.New(), UnitID: unit.ID, Status: enum.HousekeepingPending}
+
+		mockRepo.On("FindOpenByUnitID", mock.Anything, unit.ID.String()).Return([]domain.HousekeepingTask{openTask}, nil).Once()
+		mockRepo.On("Update", mock.Anything, mock.MatchedBy(func(task domain.HousekeepingTask) bool {
+			return task.ID == openTask.ID && task.Status == enum.HousekeepingCancelled
+		})).Return(nil).Once()
+
+		err := housekeepingService.OnUnitStatusChanged(context.Background(), unit, domain.UnitStatusHistory{UnitID: unit.ID, FromStatus: &from, ToStatus: enum.MaintenanceNeeded, ChangedAt: now})
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Negative Case: Failed task creation fails the status change", func(t *testing.T) {
+		mockRepo, _, housekeepingService := setupTest(t)
+		unit := domain.Units{ID: uuid.New(), Status: enum.CleaningInProgress}
+		from := enum.Occupied
+
+		mockRepo.On("FindOpenByUnitID", mock.Anything, unit.ID.String()).Return([]domain.HousekeepingTask{}, nil).Once()
+		mockRepo.On("Create", mock.Anything, mock.Anything).Return(domain.HousekeepingTask{}, gorm.ErrInvalidDB).Once()
+
+		err := housekeepingService.OnUnitStatusChanged(context.Background(), unit, domain.UnitStatusHistory{UnitID: unit.ID, FromStatus: &from, ToStatus: enum.CleaningInProgress, ChangedAt: now})
+		assert.ErrorIs(t, err, gorm.ErrInvalidDB)
+	})
+}
+
+func TestParsePeakHours(t *testing.T) {
+	t.Run("Positive Case", func(t *testing.T) {
+		peakHours, err := ParsePeakHours("22-2")
+		assert.NoError(t, err)
+		assert.True(t, peakHours.Contains(time.Date(2025, 10, 10, 23, 0, 0, 0, time.UTC)))
+		assert.True(t, peakHours.Contains(time.Date(2025, 10, 10, 1, 0, 0, 0, time.UTC)))
+		assert.False(t, peakHours.Contains(time.Date(2025, 10, 10, 2, 0, 0, 0, time.UTC)))
+	})
+
+	t.Run("Negative Case", func(t *testing.T) {
+		_, err := ParsePeakHours("afternoon")
+		assert.Error(t, err)
+		_, err = ParsePeakHours("14-14")
+		assert.Error(t, err)
+		_, err = ParsePeakHours("25-3")
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,64 @@
+package housekeeping
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// PeakHours is the daily window, in local hours, where cabins are cleaned before capsules
+type PeakHours struct {
+	Start int
+	End   int
+}
+
+// DefaultPeakHours covers the afternoon check-in rush
+var DefaultPeakHours = PeakHours{Start: 14, End: 18}
+
+// ParsePeakHours parses a "start-end" hour range such as "14-18", the range may wrap past midnight
+func ParsePeakHours(value string) (PeakHours, error) {
+	parts := strings.Split(strings.TrimSpace(value), "-")
+	if len(parts) != 2 {
+		return PeakHours{}, fmt.Errorf("invalid peak hours %q, must be formatted as start-end", value)
+	}
+
+	start, errStart := strconv.Atoi(strings.TrimSpace(parts[0]))
+	end, errEnd := strconv.Atoi(strings.TrimSpace(parts[1]))
+	if errStart != nil || errEnd != nil || start < 0 || start > 23 || end < 0 || end > 24 || start == end {
+		return PeakHours{}, fmt.Errorf("invalid peak hours %q, hours must be between 0 and 24", value)
+	}
+
+	return PeakHours{Start: start, End: end}, nil
+}
+
+// Contains reports whether the time falls inside the peak window
+func (p PeakHours) Contains(t time.Time) bool {
+	hour := t.Hour()
+	if p.Start < p.End {
+		return hour >= p.Start && hour < p.End
+	}
+
+	return hour >= p.Start || hour < p.End
+}
+
+// String formats the window the way ParsePeakHours reads it
+func (p PeakHours) String() string {
+	return fmt.Sprintf("%d-%d", p.Start, p.End)
+}
+
+// MarshalText lets the window be written to configuration files as "start-end"
+func (p PeakHours) MarshalText() ([]byte, error) {
+	return []byte(p.String()), nil
+}
+
+// UnmarshalText lets the window be read from configuration files as "start-end"
+func (p *PeakHours) UnmarshalText(text []byte) error {
+	parsed, err := ParsePeakHours(string(text))
+	if err != nil {
+		return err
+	}
+
+	*p = parsed
+	return nil
+}
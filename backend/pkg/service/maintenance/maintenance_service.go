@@ -0,0 +1,20 @@
+package maintenance
+
+import (
+	"context"
+	"unit-management-be/pkg/handler"
+	"unit-management-be/pkg/model/domain"
+	"unit-management-be/pkg/model/domain/enum"
+	"unit-management-be/pkg/model/dto"
+	"unit-management-be/pkg/model/dto/request"
+)
+
+type MaintenanceService interface {
+	CreateTicket(ctx context.Context, request request.CreateMaintenanceTicketDto) (*domain.MaintenanceTicket, *handler.CustomError)
+	FindByID(ctx context.Context, id string) (domain.MaintenanceTicket, *handler.CustomError)
+	FindTickets(ctx context.Context, unitID, status, severity string, page, size int) (*dto.PaginationResponse, *handler.CustomError)
+	FindUnitTickets(ctx context.Context, unitID, status string, page, size int) (*dto.PaginationResponse, *handler.CustomError)
+	UpdateTicket(ctx context.Context, id string, request request.UpdateMaintenanceTicketDto) (*domain.MaintenanceTicket, *handler.CustomError)
+	ResolveTicket(ctx context.Context, id string, request request.ResolveMaintenanceTicketDto) (*domain.MaintenanceTicket, *handler.CustomError)
+	GuardUnitStatusChange(ctx context.Context, unit domain.Units, to enum.UnitStatus) *handler.CustomError
+}
@@ -0,0 +1,220 @@
+package maintenance
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+	"unit-management-be/pkg/handler"
+	"unit-management-be/pkg/model/domain"
+	"unit-management-be/pkg/model/domain/enum"
+	"unit-management-be/pkg/model/dto"
+	"unit-management-be/pkg/model/dto/request"
+	maintenancerepository "unit-management-be/pkg/repository/maintenance"
+	unitservice "unit-management-be/pkg/service/units"
+	"unit-management-be/pkg/utils"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	invalidSeverityMessage = "invalid ticket severity, must be one of 'low', 'medium', 'high', 'critical'"
+	invalidStatusMessage   = "invalid ticket status, must be one of 'Open', 'In Progress', 'Resolved'"
+)
+
+type MaintenanceServiceImpl struct {
+	maintenanceRepository maintenancerepository.MaintenanceRepository
+	unitService           unitservice.UnitService
+	now                   func() time.Time
+}
+
+func NewMaintenanceService(maintenanceRepository maintenancerepository.MaintenanceRepository, unitService unitservice.UnitService) MaintenanceService {
+	return &MaintenanceServiceImpl{
+		maintenanceRepository: maintenanceRepository,
+		unitService:           unitService,
+		now:                   time.Now,
+	}
+}
+
+// CreateTicket records the ticket and takes the unit out of service in one transaction. The unit row is
+// locked first, so a concurrent status change either sees the ticket in its guard or happens before it
+func (m *MaintenanceServiceImpl) CreateTicket(ctx context.Context, ticketRequest request.CreateMaintenanceTicketDto) (*domain.MaintenanceTicket, *handler.CustomError) {
+	severity, isValidSeverity := enum.ParseTicketSeverity(ticketRequest.Severity)
+	if !isValidSeverity {
+		return nil, handler.NewError(http.StatusBadRequest, invalidSeverityMessage)
+	}
+
+	var createdTicket domain.MaintenanceTicket
+	errTransaction := m.maintenanceRepository.Transaction(ctx, func(ctx context.Context) error {
+		unit, err := m.unitService.LockByID(ctx, ticketRequest.UnitID)
+		if err != nil {
+			return err
+		}
+
+		// reporting a problem takes the unit out of service until every ticket is resolved
+		if unit.Status != enum.MaintenanceNeeded {
+			_, errUnit := m.unitService.ChangeStatus(ctx, unit.ID.String(), request.ChangeUnitStatusDto{
+				Status: string(enum.MaintenanceNeeded),
+				Reason: fmt.Sprintf("maintenance reported: %s", ticketRequest.Title),
+				Actor:  ticketRequest.Reporter,
+			})
+			if errUnit != nil {
+				return errUnit
+			}
+		}
+
+		ticket := domain.MaintenanceTicket{
+			UnitID:      unit.ID,
+			Title:       ticketRequest.Title,
+			Description: ticketRequest.Description,
+			Severity:    severity,
+			Reporter:    ticketRequest.Reporter,
+			Assignee:    ticketRequest.Assignee,
+			Status:      enum.TicketOpen,
+		}
+
+		var errSave error
+		createdTicket, errSave = m.maintenanceRepository.Create(ctx, ticket)
+		return errSave
+	})
+	if errTransaction != nil {
+		return nil, handler.AsCustomError(errTransaction)
+	}
+
+	return &createdTicket, nil
+}
+
+func (m *MaintenanceServiceImpl) FindByID(ctx context.Context, id string) (domain.MaintenanceTicket, *handler.CustomError) {
+	ticket, err := m.maintenanceRepository.GetByID(ctx, id)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return ticket, handler.NewError(http.StatusNotFound, "maintenance ticket with that id was not found")
+		}
+		return ticket, handler.NewError(http.StatusInternalServerError, err.Error())
+	}
+
+	return ticket, nil
+}
+
+func (m *MaintenanceServiceImpl) FindTickets(ctx context.Context, unitID, status, severity string, page, size int) (*dto.PaginationResponse, *handler.CustomError) {
+	if !utils.IsEmptyString(status) {
+		if _, isValidStatus := enum.ParseTicketStatus(status); !isValidStatus {
+			return nil, handler.NewError(http.StatusBadRequest, invalidStatusMessage)
+		}
+	}
+
+	if !utils.IsEmptyString(severity) {
+		if _, isValidSeverity := enum.ParseTicketSeverity(severity); !isValidSeverity {
+			return nil, handler.NewError(http.StatusBadRequest, invalidSeverityMessage)
+		}
+	}
+
+	tickets, total, err := m.maintenanceRepository.FindAll(ctx, unitID, status, severity, page, size)
+	if err != nil {
+		return nil, handler.NewError(http.StatusInternalServerError, err.Error())
+	}
+
+	return dto.NewPaginationResponse(page, size, int(total), tickets), nil
+}
+
+func (m *MaintenanceServiceImpl) FindUnitTickets(ctx context.Context, unitID, status string, page, size int) (*dto.PaginationResponse, *handler.CustomError) {
+	unit, err := m.unitService.FindByID(ctx, unitID)
+	if err != nil {
+		return nil, handler.NewError(err.Code, err.Message)
+	}
+
+	return m.FindTickets(ctx, unit.ID.String(), status, "", page, size)
+}
+
+func (m *MaintenanceServiceImpl) UpdateTicket(ctx context.Context, id string, request request.UpdateMaintenanceTicketDto) (*domain.MaintenanceTicket, *handler.CustomError) {
+	ticket, err := m.FindByID(ctx, id)
+	if err != nil {
+		return nil, handler.NewError(err.Code, err.Message)
+	}
+
+	if !ticket.IsOpen() {
+		return nil, handler.NewError(http.StatusConflict, "resolved maintenance ticket cannot be updated")
+	}
+
+	severity, isValidSeverity := enum.ParseTicketSeverity(request.Severity)
+	if !isValidSeverity {
+		return nil, handler.NewError(http.StatusBadRequest, invalidSeverityMessage)
+	}
+
+	status, isValidStatus := enum.ParseTicketStatus(request.Status)
+	if !isValidStatus {
+		return nil, handler.NewError(http.StatusBadRequest, invalidStatusMessage)
+	}
+
+	if status == enum.TicketResolved {
+		return nil, handler.NewError(http.StatusBadRequest, "maintenance ticket must be resolved with resolution notes")
+	}
+
+	ticket.Title = request.Title
+	ticket.Description = request.Description
+	ticket.Severity = severity
+	ticket.Assignee = request.Assignee
+	ticket.Status = status
+
+	if errUpdate := m.maintenanceRepository.Update(ctx, ticket); errUpdate != nil {
+		if errUpdate == maintenancerepository.ErrTicketResolved {
+			return nil, handler.NewError(http.StatusConflict, "resolved maintenance ticket cannot be updated")
+		}
+		return nil, handler.NewError(http.StatusInternalServerError, errUpdate.Error())
+	}
+
+	return &ticket, nil
+}
+
+func (m *MaintenanceServiceImpl) ResolveTicket(ctx context.Context, id string, request request.ResolveMaintenanceTicketDto) (*domain.MaintenanceTicket, *handler.CustomError) {
+	ticket, err := m.FindByID(ctx, id)
+	if err != nil {
+		return nil, handler.NewError(err.Code, err.Message)
+	}
+
+	if !ticket.IsOpen() {
+		return nil, handler.NewError(http.StatusConflict, "maintenance ticket has already been resolved")
+	}
+
+	now := m.now()
+	if utils.IsEmptyString(ticket.Assignee) {
+		ticket.Assignee = request.Actor
+	}
+	ticket.Status = enum.TicketResolved
+	ticket.ResolutionNotes = request.ResolutionNotes
+	ticket.ResolvedAt = &now
+
+	if errUpdate := m.maintenanceRepository.Update(ctx, ticket); errUpdate != nil {
+		if errUpdate == maintenancerepository.ErrTicketResolved {
+			return nil, handler.NewError(http.StatusConflict, "maintenance ticket has already been resolved")
+		}
+		return nil, handler.NewError(http.StatusInternalServerError, errUpdate.Error())
+	}
+
+	return &ticket, nil
+}
+
+// GuardUnitStatusChange keeps a unit in maintenance while it still has open tickets, it runs in the
+// transaction of the status change while the unit is locked
+func (m *MaintenanceServiceImpl) GuardUnitStatusChange(ctx context.Context, unit domain.Units, to enum.UnitStatus) *handler.CustomError {
+	if unit.Status != enum.MaintenanceNeeded || to == enum.MaintenanceNeeded {
+		return nil
+	}
+
+	openTickets, err := m.maintenanceRepository.FindOpenByUnitID(ctx, unit.ID.String())
+	if err != nil {
+		return handler.NewError(http.StatusInternalServerError, err.Error())
+	}
+
+	if len(openTickets) == 0 {
+		return nil
+	}
+
+	ticketIDs := make([]uuid.UUID, 0, len(openTickets))
+	for _, ticket := range openTickets {
+		ticketIDs = append(ticketIDs, ticket.ID)
+	}
+
+	return handler.NewErrorWithData(http.StatusConflict, fmt.Sprintf("unit cannot leave '%s' while it has %d open maintenance ticket(s)", enum.MaintenanceNeeded, len(openTickets)), ticketIDs)
+}
@@ -0,0 +1,302 @@
+package maintenance
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"unit-management-be/pkg/handler"
+	"unit-management-be/pkg/model/domain"
+	"unit-management-be/pkg/model/domain/enum"
+	"unit-management-be/pkg/model/dto/request"
+	maintenancerepository "unit-management-be/pkg/repository/maintenance"
+	unitservice "unit-management-be/pkg/service/units"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+// MockMaintenanceRepository of maintenance repository
+type MockMaintenanceRepository struct {
+	mock.Mock
+}
+
+func (m *MockMaintenanceRepository) Create(ctx context.Context, ticket domain.MaintenanceTicket) (domain.MaintenanceTicket, error) {
+	args := m.Called(ctx, ticket)
+	return args.Get(0).(domain.MaintenanceTicket), args.Error(1)
+}
+
+func (m *MockMaintenanceRepository) GetByID(ctx context.Context, id string) (domain.MaintenanceTicket, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(domain.MaintenanceTicket), args.Error(1)
+}
+
+func (m *MockMaintenanceRepository) FindAll(ctx context.Context, unitID, status, severity string, page, size int) ([]domain.MaintenanceTicket, int64, error) {
+	args := m.Called(ctx, unitID, status, severity, page, size)
+	return args.Get(0).([]domain.MaintenanceTicket), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockMaintenanceRepository) FindOpenByUnitID(ctx context.Context, unitID string) ([]domain.MaintenanceTicket, error) {
+	args := m.Called(ctx, unitID)
+	return args.Get(0).([]domain.MaintenanceTicket), args.Error(1)
+}
+
+func (m *MockMaintenanceRepository) Update(ctx context.Context, ticket domain.MaintenanceTicket) error {
+	args := m.Called(ctx, ticket)
+	return args.Error(0)
+}
+
+func (m *MockMaintenanceRepository) Transaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+var _ maintenancerepository.MaintenanceRepository = &MockMaintenanceRepository{}
+
+// MockUnitService of unit service, only the methods used by maintenance service are mocked
+type MockUnitService struct {
+	mock.Mock
+	unitservice.UnitService
+}
+
+func (m *MockUnitService) FindByID(ctx context.Context, id string) (domain.Units, *handler.CustomError) {
+	args := m.Called(ctx, id)
+	err, _ := args.Get(1).(*handler.CustomError)
+	return args.Get(0).(domain.Units), err
+}
+
+func (m *MockUnitService) LockByID(ctx context.Context, id string) (domain.Units, *handler.CustomError) {
+	args := m.Called(ctx, id)
+	err, _ := args.Get(1).(*handler.CustomError)
+	return args.Get(0).(domain.Units), err
+}
+
+func (m *MockUnitService) ChangeStatus(ctx context.Context, id string, request request.ChangeUnitStatusDto) (*domain.Units, *handler.CustomError) {
+	args := m.Called(ctx, id, request)
+	unit, _ := args.Get(0).(*domain.Units)
+	err, _ := args.Get(1).(*handler.CustomError)
+	return unit, err
+}
+
+// initialization service, maintenance repository and unit service
+func setupTest(t *testing.T) (*MockMaintenanceRepository, *MockUnitService, MaintenanceService) {
+	mockRepo := new(MockMaintenanceRepository)
+	mockUnitService := new(MockUnitService)
+	maintenanceService := &MaintenanceServiceImpl{
+		maintenanceRepository: mockRepo,
+		unitService:           mockUnitService,
+		now:                   func() time.Time { return time.Date(2025, 10, 13, 9, 0, 0, 0, time.UTC) },
+	}
+	return mockRepo, mockUnitService, maintenanceService
+}
+
+func TestCreateTicket(t *testing.T) {
+	unit := domain.Units{ID: uuid.New(), Name: "C-12", Status: enum.Available}
+	req := request.CreateMaintenanceTicketDto{
+		UnitID:   unit.ID.String(),
+		Title:    "Broken reading light",
+		Severity: "medium",
+		Reporter: "front desk",
+	}
+
+	t.Run("Positive Case: Create ticket moves unit to maintenance", func(t *testing.T) {
+		mockRepo, mockUnitService, maintenanceService := setupTest(t)
+
+		mockUnitService.On("LockByID", mock.Anything, req.UnitID).Return(unit, nil).Once()
+		mockUnitService.On("ChangeStatus", mock.Anything, req.UnitID, mock.MatchedBy(func(change request.ChangeUnitStatusDto) bool {
+			return change.Status == string(enum.MaintenanceNeeded) && change.Actor == "front desk"
+		})).Return(&domain.Units{ID: unit.ID, Status: enum.MaintenanceNeeded}, nil).Once()
+		mockRepo.On("Create", mock.Anything, mock.MatchedBy(func(ticket domain.MaintenanceTicket) bool {
+			return ticket.UnitID == unit.ID && ticket.Status == enum.TicketOpen && ticket.Severity == enum.SeverityMedium && ticket.Reporter == "front desk"
+		})).Return(domain.MaintenanceTicket{ID: uuid.New(), Status: enum.TicketOpen}, nil).Once()
+
+		result, err := maintenanceService.CreateTicket(context.Background(), req)
+		assert.Nil(t, err)
+		assert.Equal(t, enum.TicketOpen, result.Status)
+		mockRepo.AssertExpectations(t)
+		mockUnitService.AssertExpectations(t)
+	})
+
+	t.Run("Positive Case: Unit already in maintenance keeps its status", func(t *testing.T) {
+		mockRepo, mockUnitService, maintenanceService := setupTest(t)
+		unitInMaintenance := unit
+		unitInMaintenance.Status = enum.MaintenanceNeeded
+
+		mockUnitService.On("LockByID", mock.Anything, req.UnitID).Return(unitInMaintenance, nil).Once()
+		mockRepo.On("Create", mock.Anything, mock.Anything).Return(domain.MaintenanceTicket{ID: uuid.New()}, nil).Once()
+
+		_, err := maintenanceService.CreateTicket(context.Background(), req)
+		assert.Nil(t, err)
+		mockUnitService.AssertNotCalled(t, "ChangeStatus", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Negative Case: Ticket is not created when the unit cannot enter maintenance", func(t *testing.T) {
+		mockRepo, mockUnitService, maintenanceService := setupTest(t)
+
+		mockUnitService.On("LockByID", mock.Anything, req.UnitID).Return(unit, nil).Once()
+		mockUnitService.On("ChangeStatus", mock.Anything, req.UnitID, mock.Anything).Return(nil, handler.NewError(http.StatusConflict, "unit has been modified by another request, please retry")).Once()
+
+		result, err := maintenanceService.CreateTicket(context.Background(), req)
+		assert.Nil(t, result)
+		assert.Equal(t, http.StatusConflict, err.Code)
+		mockRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Negative Case: Failed ticket insert fails the whole report", func(t *testing.T) {
+		mockRepo, mockUnitService, maintenanceService := setupTest(t)
+
+		mockUnitService.On("LockByID", mock.Anything, req.UnitID).Return(unit, nil).Once()
+		mockUnitService.On("ChangeStatus", mock.Anything, req.UnitID, mock.Anything).Return(&domain.Units{ID: unit.ID, Status: enum.MaintenanceNeeded}, nil).Once()
+		mockRepo.On("Create", mock.Anything, mock.Anything).Return(domain.MaintenanceTicket{}, gorm.ErrInvalidDB).Once()
+
+		result, err := maintenanceService.CreateTicket(context.Background(), req)
+		assert.Nil(t, result)
+		assert.Equal(t, http.StatusInternalServerError, err.Code)
+	})
+
+	t.Run("Negative Case: Unit not found", func(t *testing.T) {
+		mockRepo, mockUnitService, maintenanceService := setupTest(t)
+
+		mockUnitService.On("LockByID", mock.Anything, req.UnitID).Return(domain.Units{}, handler.NewError(http.StatusNotFound, "unit with that id was not found")).Once()
+
+		result, err := maintenanceService.CreateTicket(context.Background(), req)
+		assert.Nil(t, result)
+		assert.Equal(t, http.StatusNotFound, err.Code)
+		mockRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Negative Case: Invalid severity", func(t *testing.T) {
+		_, _, maintenanceService := setupTest(t)
+		invalidReq := req
+		invalidReq.Severity = "urgent"
+
+		result, err := maintenanceService.CreateTicket(context.Background(), invalidReq)
+		assert.Nil(t, result)
+		assert.Equal(t, http.StatusBadRequest, err.Code)
+	})
+}
+
+func TestResolveTicket(t *testing.T) {
+	t.Run("Positive Case: Resolve open ticket", func(t *testing.T) {
+		mockRepo, _, maintenanceService := setupTest(t)
+		ticket := domain.MaintenanceTicket{ID: uuid.New(), Status: enum.TicketInProgress}
+
+		mockRepo.On("GetByID", mock.Anything, ticket.ID.String()).Return(ticket, nil).Once()
+		mockRepo.On("Update", mock.Anything, mock.MatchedBy(func(updated domain.MaintenanceTicket) bool {
+			return updated.Status == enum.TicketResolved && updated.ResolutionNotes == "replaced bulb" && updated.Assignee == "tech" && updated.ResolvedAt != nil
+		})).Return(nil).Once()
+
+		result, err := maintenanceService.ResolveTicket(context.Background(), ticket.ID.String(), request.ResolveMaintenanceTicketDto{ResolutionNotes: "replaced bulb", Actor: "tech"})
+		assert.Nil(t, err)
+		assert.Equal(t, enum.TicketResolved, result.Status)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Negative Case: Ticket already resolved", func(t *testing.T) {
+		mockRepo, _, maintenanceService := setupTest(t)
+		ticket := domain.MaintenanceTicket{ID: uuid.New(), Status: enum.TicketResolved}
+
+		mockRepo.On("GetByID", mock.Anything, ticket.ID.String()).Return(ticket, nil).Once()
+
+		result, err := maintenanceService.ResolveTicket(context.Background(), ticket.ID.String(), request.ResolveMaintenanceTicketDto{ResolutionNotes: "again"})
+		assert.Nil(t, result)
+		assert.Equal(t, http.StatusConflict, err.Code)
+	})
+
+	t.Run("Negative Case: Ticket resolved by a concurrent request", func(t *testing.T) {
+		mockRepo, _, maintenanceService := setupTest(t)
+		ticket := domain.MaintenanceTicket{ID: uuid.New(), Status: enum.TicketOpen}
+
+		mockRepo.On("GetByID", mock.Anything, ticket.ID.String()).Return(ticket, nil).Once()
+		mockRepo.On("Update", mock.Anything, mock.Anything).Return(maintenancerepository.ErrTicketResolved).Once()
+
+		result, err := maintenanceService.ResolveTicket(context.Background(), ticket.ID.String(), request.ResolveMaintenanceTicketDto{ResolutionNotes: "again"})
+		assert.Nil(t, result)
+		assert.Equal(t, http.StatusConflict, err.Code)
+	})
+
+	t.Run("Negative Case: Ticket not found", func(t *testing.T) {
+		mockRepo, _, maintenanceService := setupTest(t)
+		id := uuid.New().String()
+
+		mockRepo.On("GetByID", mock.Anything, id).Return(domain.MaintenanceTicket{}, gorm.ErrRecordNotFound).Once()
+
+		result, err := maintenanceService.ResolveTicket(context.Background(), id, request.ResolveMaintenanceTicketDto{ResolutionNotes: "done"})
+		assert.Nil(t, result)
+		assert.Equal(t, http.StatusNotFound, err.Code)
+	})
+}
+
+func TestUpdateTicket(t *testing.T) {
+	t.Run("Positive Case: Assign ticket", func(t *testing.T) {
+		mockRepo, _, maintenanceService := setupTest(t)
+		ticket := domain.MaintenanceTicket{ID: uuid.New(), Status: enum.TicketOpen, Severity: enum.SeverityLow}
+
+		mockRepo.On("GetByID", mock.Anything, ticket.ID.String()).Return(ticket, nil).Once()
+		mockRepo.On("Update", mock.Anything, mock.Anything).Return(nil).Once()
+
+		result, err := maintenanceService.UpdateTicket(context.Background(), ticket.ID.String(), request.UpdateMaintenanceTicketDto{Title: "Leak", Severity: "high", Assignee: "tech", Status: "In Progress"})
+		assert.Nil(t, err)
+		assert.Equal(t, "tech", result.Assignee)
+		assert.Equal(t, enum.SeverityHigh, result.Severity)
+		assert.Equal(t, enum.TicketInProgress, result.Status)
+	})
+
+	t.Run("Negative Case: Cannot resolve without notes", func(t *testing.T) {
+		mockRepo, _, maintenanceService := setupTest(t)
+		ticket := domain.MaintenanceTicket{ID: uuid.New(), Status: enum.TicketOpen}
+
+		mockRepo.On("GetByID", mock.Anything, ticket.ID.String()).Return(ticket, nil).Once()
+
+		result, err := maintenanceService.UpdateTicket(context.Background(), ticket.ID.String(), request.UpdateMaintenanceTicketDto{Title: "Leak", Severity: "high", Status: "Resolved"})
+		assert.Nil(t, result)
+		assert.Equal(t, http.StatusBadRequest, err.Code)
+		mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Negative Case: Late update does not reopen a ticket resolved in the meantime", func(t *testing.T) {
+		mockRepo, _, maintenanceService := setupTest(t)
+		ticket := domain.MaintenanceTicket{ID: uuid.New(), Status: enum.TicketOpen}
+
+		mockRepo.On("GetByID", mock.Anything, ticket.ID.String()).Return(ticket, nil).Once()
+		mockRepo.On("Update", mock.Anything, mock.Anything).Return(maintenancerepository.ErrTicketResolved).Once()
+
+		result, err := maintenanceService.UpdateTicket(context.Background(), ticket.ID.String(), request.UpdateMaintenanceTicketDto{Title: "Leak", Severity: "high", Status: "In Progress"})
+		assert.Nil(t, result)
+		assert.Equal(t, http.StatusConflict, err.Code)
+	})
+}
+
+func TestGuardUnitStatusChange(t *testing.T) {
+	t.Run("Positive Case: Unit without open tickets can leave maintenance", func(t *testing.T) {
+		mockRepo, _, maintenanceService := setupTest(t)
+		unit := domain.Units{ID: uuid.New(), Status: enum.MaintenanceNeeded}
+
+		mockRepo.On("FindOpenByUnitID", mock.Anything, unit.ID.String()).Return([]domain.MaintenanceTicket{}, nil).Once()
+
+		assert.Nil(t, maintenanceService.GuardUnitStatusChange(context.Background(), unit, enum.Available))
+	})
+
+	t.Run("Positive Case: Units outside maintenance are not checked", func(t *testing.T) {
+		mockRepo, _, maintenanceService := setupTest(t)
+		unit := domain.Units{ID: uuid.New(), Status: enum.Available}
+
+		assert.Nil(t, maintenanceService.GuardUnitStatusChange(context.Background(), unit, enum.Occupied))
+		mockRepo.AssertNotCalled(t, "FindOpenByUnitID", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Negative Case: Unit with open tickets cannot leave maintenance", func(t *testing.T) {
+		mockRepo, _, maintenanceService := setupTest(t)
+		unit := domain.Units{ID: uuid.New(), Status: enum.MaintenanceNeeded}
+		openTicket := domain.MaintenanceTicket{ID: uuid.New(), UnitID: unit.ID, Status: enum.TicketOpen}
+
+		mockRepo.On("FindOpenByUnitID", mock.Anything, unit.ID.String()).Return([]domain.MaintenanceTicket{openTicket}, nil).Once()
+
+		err := maintenanceService.GuardUnitStatusChange(context.Background(), unit, enum.Available)
+		assert.NotNil(t, err)
+		assert.Equal(t, http.StatusConflict, err.Code)
+		assert.Equal(t, []uuid.UUID{openTicket.ID}, err.Data)
+	})
+}
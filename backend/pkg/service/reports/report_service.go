@@ -0,0 +1,12 @@
+package reports
+
+import (
+	"context"
+	"unit-management-be/pkg/handler"
+	"unit-management-be/pkg/model/dto/request"
+	"unit-management-be/pkg/model/dto/response"
+)
+
+type ReportService interface {
+	GetUtilization(ctx context.Context, request request.UtilizationReportDto) (*response.UtilizationReportResponse, *handler.CustomError)
+}
@@ -0,0 +1,279 @@
+package reports
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"time"
+	"unit-management-be/pkg/handler"
+	"unit-management-be/pkg/model/domain"
+	"unit-management-be/pkg/model/domain/enum"
+	"unit-management-be/pkg/model/dto/request"
+	"unit-management-be/pkg/model/dto/response"
+	unitrepository "unit-management-be/pkg/repository/units"
+
+	"github.com/google/uuid"
+)
+
+// maxReportPeriods keeps a report to about a year of days, or seven years of weeks
+const maxReportPeriods = 366
+
+type ReportServiceImpl struct {
+	unitRepository unitrepository.UnitRepository
+	now            func() time.Time
+}
+
+func NewReportService(unitRepository unitrepository.UnitRepository) ReportService {
+	return &ReportServiceImpl{unitRepository: unitRepository, now: time.Now}
+}
+
+// reportPeriod is one day or week of a report, the first and last periods are cut to the report range
+type reportPeriod struct {
+	start time.Time
+	end   time.Time
+}
+
+// statusSpan is a stretch of time a unit spent in one status
+type statusSpan struct {
+	status enum.UnitStatus
+	start  time.Time
+	end    time.Time
+}
+
+// utilization adds up the time spent in each status over a period
+type utilization struct {
+	tracked     time.Duration
+	occupied    time.Duration
+	cleaning    time.Duration
+	maintenance time.Duration
+	cleanings   int64
+}
+
+// GetUtilization derives the occupancy, cleaning time and maintenance downtime of every unit in the inventory
+// and of every unit type from the recorded status changes, per day or week of the range
+func (r *ReportServiceImpl) GetUtilization(ctx context.Context, reportRequest request.UtilizationReportDto) (*response.UtilizationReportResponse, *handler.CustomError) {
+	if !reportRequest.From.Before(reportRequest.To) {
+		return nil, handler.NewError(http.StatusBadRequest, "report range is empty, from must be before to")
+	}
+
+	granularity := reportRequest.Granularity
+	if granularity == "" {
+		granularity = enum.GranularityDay
+	}
+	if _, isValidGranularity := enum.ParseReportGranularity(string(granularity)); !isValidGranularity {
+		return nil, handler.NewError(http.StatusBadRequest, "invalid report granularity, must be 'day' or 'week'")
+	}
+
+	periods := reportPeriods(reportRequest.From, reportRequest.To, granularity)
+	if len(periods) > maxReportPeriods {
+		return nil, handler.NewError(http.StatusBadRequest, fmt.Sprintf("report range is too long, it can cover at most %d %ss", maxReportPeriods, granularity))
+	}
+
+	histories, err := r.unitRepository.FindStatusHistoryBetween(ctx, reportRequest.From, reportRequest.To)
+	if err != nil {
+		return nil, handler.NewError(http.StatusInternalServerError, err.Error())
+	}
+
+	changes := make(map[uuid.UUID][]domain.UnitStatusHistory)
+	changedIDs := make([]uuid.UUID, 0)
+	for _, history := range histories {
+		if _, seen := changes[history.UnitID]; !seen {
+			changedIDs = append(changedIDs, history.UnitID)
+		}
+		changes[history.UnitID] = append(changes[history.UnitID], history)
+	}
+
+	// units moved to the trash still count up to the time they were deleted
+	units, err := r.unitRepository.FindAllForReport(ctx, changedIDs)
+	if err != nil {
+		return nil, handler.NewError(http.StatusInternalServerError, err.Error())
+	}
+
+	report := &response.UtilizationReportResponse{
+		From:        reportRequest.From,
+		To:          reportRequest.To,
+		Granularity: granularity,
+		Types:       make([]response.UnitTypeUtilization, 0, len(enum.UnitTypes())),
+		Units:       make([]response.UnitUtilization, 0, len(units)),
+	}
+
+	// the statuses are only known up to now, the rest of a range ending in the future is not tracked
+	trackedUntil := earliest(reportRequest.To, r.now())
+
+	typeUsage := make(map[enum.UnitType][]utilization)
+	for _, unitType := range enum.UnitTypes() {
+		typeUsage[unitType] = make([]utilization, len(periods))
+	}
+
+	for _, unit := range units {
+		unitTrackedUntil := trackedUntil
+		if unit.DeletedAt.Valid {
+			unitTrackedUntil = earliest(unitTrackedUntil, unit.DeletedAt.Time)
+		}
+		spans := statusSpans(unit, changes[unit.ID], reportRequest.From, unitTrackedUntil)
+		// the unit was created after the report range, or deleted before it
+		if len(spans) == 0 {
+			continue
+		}
+
+		unitReport := response.UnitUtilization{
+			UnitID:  unit.ID,
+			Name:    unit.Name,
+			Type:    unit.Type,
+			Periods: make([]response.UtilizationPeriod, 0, len(periods)),
+		}
+		for i, period := range periods {
+			var usage utilization
+			for _, span := range spans {
+				usage.add(span, period)
+			}
+
+			if totals, ok := typeUsage[unit.Type]; ok {
+				totals[i].merge(usage)
+			}
+			unitReport.Periods = append(unitReport.Periods, usage.report(period))
+		}
+		report.Units = append(report.Units, unitReport)
+	}
+
+	for _, unitType := range enum.UnitTypes() {
+		typeReport := response.UnitTypeUtilization{Type: unitType, Periods: make([]response.UtilizationPeriod, 0, len(periods))}
+		for i, period := range periods {
+			typeReport.Periods = append(typeReport.Periods, typeUsage[unitType][i].report(period))
+		}
+		report.Types = append(report.Types, typeReport)
+	}
+
+	return report, nil
+}
+
+// reportPeriods splits the range into calendar days, or weeks starting on Monday, in the time zone of from.
+// At most one period more than maxReportPeriods is returned so a long range is cheap to reject
+func reportPeriods(from, to time.Time, granularity enum.ReportGranularity) []reportPeriod {
+	boundary := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, from.Location())
+	days := 1
+	if granularity == enum.GranularityWeek {
+		days = 7
+		boundary = boundary.AddDate(0, 0, -((int(boundary.Weekday()) + 6) % 7))
+	}
+
+	periods := make([]reportPeriod, 0)
+	for boundary.Before(to) && len(periods) <= maxReportPeriods {
+		next := boundary.AddDate(0, 0, days)
+		periods = append(periods, reportPeriod{start: latest(boundary, from), end: earliest(next, to)})
+		boundary = next
+	}
+
+	return periods
+}
+
+// statusSpans rebuilds the statuses of a unit between from and to out of its changes, which hold its last change
+// before from and its first change after to. A unit is not tracked before the change that created it and a unit
+// without any recorded change is assumed to have kept its current status over the whole range
+func statusSpans(unit domain.Units, changes []domain.UnitStatusHistory, from, to time.Time) []statusSpan {
+	var status enum.UnitStatus
+	tracked := false
+	switch {
+	case len(changes) == 0:
+		status, tracked = unit.Status, true
+	case changes[0].ChangedAt.Before(from):
+		status, tracked = changes[0].ToStatus, true
+	case changes[0].FromStatus != nil:
+		status, tracked = *changes[0].FromStatus, true
+	}
+
+	spans := make([]statusSpan, 0)
+	start := from
+	for _, change := range changes {
+		if change.ChangedAt.Before(from) {
+			continue
+		}
+		if !change.ChangedAt.Before(to) {
+			break
+		}
+		if tracked && change.ToStatus == status {
+			continue
+		}
+
+		if tracked && change.ChangedAt.After(start) {
+			spans = append(spans, statusSpan{status: status, start: start, end: change.ChangedAt})
+		}
+		status, tracked, start = change.ToStatus, true, change.ChangedAt
+	}
+
+	if tracked && start.Before(to) {
+		spans = append(spans, statusSpan{status: status, start: start, end: to})
+	}
+
+	return spans
+}
+
+// add counts the part of the span that falls in the period, a cleaning is counted in every period it overlaps
+func (u *utilization) add(span statusSpan, period reportPeriod) {
+	start := latest(span.start, period.start)
+	end := earliest(span.end, period.end)
+	if !start.Before(end) {
+		return
+	}
+
+	duration := end.Sub(start)
+	u.tracked += duration
+	switch span.status {
+	case enum.Occupied:
+		u.occupied += duration
+	case enum.CleaningInProgress:
+		u.cleaning += duration
+		u.cleanings++
+	case enum.MaintenanceNeeded:
+		u.maintenance += duration
+	}
+}
+
+func (u *utilization) merge(other utilization) {
+	u.tracked += other.tracked
+	u.occupied += other.occupied
+	u.cleaning += other.cleaning
+	u.maintenance += other.maintenance
+	u.cleanings += other.cleanings
+}
+
+// report rounds the durations to seconds and the occupancy to two decimals
+func (u utilization) report(period reportPeriod) response.UtilizationPeriod {
+	result := response.UtilizationPeriod{
+		Start:                      period.start,
+		End:                        period.end,
+		TrackedSeconds:             seconds(u.tracked),
+		OccupiedSeconds:            seconds(u.occupied),
+		Cleanings:                  u.cleanings,
+		CleaningSeconds:            seconds(u.cleaning),
+		MaintenanceDowntimeSeconds: seconds(u.maintenance),
+	}
+
+	if u.tracked > 0 {
+		result.OccupancyPercentage = math.Round(10000*u.occupied.Seconds()/u.tracked.Seconds()) / 100
+	}
+	if u.cleanings > 0 {
+		result.AverageCleaningSeconds = int64(math.Round(u.cleaning.Seconds() / float64(u.cleanings)))
+	}
+
+	return result
+}
+
+func seconds(duration time.Duration) int64 {
+	return int64(duration.Round(time.Second) / time.Second)
+}
+
+func latest(a, b time.Time) time.Time {
+	if a.After(b) {
+		return a
+	}
+	return b
+}
+
+func earliest(a, b time.Time) time.Time {
+	if a.Before(b) {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,187 @@
+package reports
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+	"unit-management-be/pkg/model/domain"
+	"unit-management-be/pkg/model/domain/enum"
+	"unit-management-be/pkg/model/dto/request"
+	"unit-management-be/pkg/model/dto/response"
+	unitrepository "unit-management-be/pkg/repository/units"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// MockUnitRepository of unit repository, only the methods used by report service are mocked
+type MockUnitRepository struct {
+	mock.Mock
+	unitrepository.UnitRepository
+}
+
+func (m *MockUnitRepository) FindAllForReport(ctx context.Context, deletedIDs []uuid.UUID) ([]domain.Units, error) {
+	args := m.Called(ctx, deletedIDs)
+	return args.Get(0).([]domain.Units), args.Error(1)
+}
+
+func (m *MockUnitRepository) FindStatusHistoryBetween(ctx context.Context, from, to time.Time) ([]domain.UnitStatusHistory, error) {
+	args := m.Called(ctx, from, to)
+	return args.Get(0).([]domain.UnitStatusHistory), args.Error(1)
+}
+
+var reportDay = time.Date(2025, time.October, 6, 0, 0, 0, 0, time.UTC)
+
+// at returns the time hours after the start of the first report day
+func at(hours int) time.Time {
+	return reportDay.Add(time.Duration(hours) * time.Hour)
+}
+
+// seedUnit creates a unit with its status changes, a change without from status is the creation of the unit
+func seedUnit(t *testing.T, repository unitrepository.UnitRepository, name string, unitType enum.UnitType, status enum.UnitStatus, changes ...domain.UnitStatusHistory) domain.Units {
+	unit, err := repository.Create(context.Background(), domain.Units{Name: name, Type: unitType, Status: status})
+	require.NoError(t, err)
+
+	for _, change := range changes {
+		change.UnitID = unit.ID
+		require.NoError(t, repository.CreateStatusHistory(context.Background(), change))
+	}
+	return unit
+}
+
+func change(from *enum.UnitStatus, to enum.UnitStatus, changedAt time.Time) domain.UnitStatusHistory {
+	return domain.UnitStatusHistory{FromStatus: from, ToStatus: to, Actor: "tester", ChangedAt: changedAt}
+}
+
+func status(value enum.UnitStatus) *enum.UnitStatus {
+	return &value
+}
+
+func TestGetUtilization(t *testing.T) {
+	t.Run("Positive Case: Utilization is derived from the status changes", func(t *testing.T) {
+		repository := unitrepository.NewMemoryUnitRepository(nil)
+		cabin := seedUnit(t, repository, "Cabin 1", enum.Cabin, enum.Available,
+			change(nil, enum.Available, at(-120)),
+			change(status(enum.Available), enum.Occupied, at(12)),
+			change(status(enum.Occupied), enum.CleaningInProgress, at(30)),
+			change(status(enum.CleaningInProgress), enum.Available, at(32)),
+			change(status(enum.Available), enum.MaintenanceNeeded, at(42)),
+			change(status(enum.MaintenanceNeeded), enum.Available, at(60)),
+		)
+		seedUnit(t, repository, "Capsule 1", enum.Capsule, enum.Available, change(nil, enum.Available, at(36)))
+		// units without history keep their current status, units created after the range are left out
+		seedUnit(t, repository, "Capsule 2", enum.Capsule, enum.Occupied)
+		seedUnit(t, repository, "Capsule 3", enum.Capsule, enum.Available, change(nil, enum.Available, at(96)))
+
+		reportService := NewReportService(repository)
+		report, err := reportService.GetUtilization(context.Background(), request.UtilizationReportDto{From: at(0), To: at(48)})
+		require.Nil(t, err)
+		assert.Equal(t, enum.GranularityDay, report.Granularity)
+
+		require.Len(t, report.Units, 3)
+		assert.Equal(t, cabin.ID, report.Units[0].UnitID)
+		assert.Equal(t, []response.UtilizationPeriod{
+			{Start: at(0), End: at(24), TrackedSeconds: 86400, OccupiedSeconds: 43200, OccupancyPercentage: 50},
+			{Start: at(24), End: at(48), TrackedSeconds: 86400, OccupiedSeconds: 21600, OccupancyPercentage: 25,
+				Cleanings: 1, CleaningSeconds: 7200, AverageCleaningSeconds: 7200, MaintenanceDowntimeSeconds: 21600},
+		}, report.Units[0].Periods)
+
+		assert.Equal(t, "Capsule 1", report.Units[1].Name)
+		assert.Equal(t, int64(0), report.Units[1].Periods[0].TrackedSeconds)
+		assert.Equal(t, int64(43200), report.Units[1].Periods[1].TrackedSeconds)
+		assert.Equal(t, "Capsule 2", report.Units[2].Name)
+		assert.Equal(t, 100.0, report.Units[2].Periods[0].OccupancyPercentage)
+
+		require.Len(t, report.Types, 2)
+		assert.Equal(t, enum.Capsule, report.Types[0].Type)
+		assert.Equal(t, int64(129600), report.Types[0].Periods[1].TrackedSeconds)
+		assert.Equal(t, 66.67, report.Types[0].Periods[1].OccupancyPercentage)
+		assert.Equal(t, enum.Cabin, report.Types[1].Type)
+		assert.Equal(t, report.Units[0].Periods, report.Types[1].Periods)
+	})
+
+	t.Run("Positive Case: Time after now is not tracked", func(t *testing.T) {
+		repository := unitrepository.NewMemoryUnitRepository(nil)
+		seedUnit(t, repository, "Cabin 1", enum.Cabin, enum.Occupied, change(nil, enum.Occupied, at(-24)))
+		seedUnit(t, repository, "Cabin 2", enum.Cabin, enum.Available, change(nil, enum.Available, at(20)))
+
+		reportService := &ReportServiceImpl{unitRepository: repository, now: func() time.Time { return at(12) }}
+		report, err := reportService.GetUtilization(context.Background(), request.UtilizationReportDto{From: at(0), To: at(48)})
+		require.Nil(t, err)
+
+		require.Len(t, report.Units, 1)
+		assert.Equal(t, "Cabin 1", report.Units[0].Name)
+		assert.Equal(t, int64(43200), report.Units[0].Periods[0].TrackedSeconds)
+		assert.Equal(t, 100.0, report.Units[0].Periods[0].OccupancyPercentage)
+		assert.Equal(t, int64(0), report.Units[0].Periods[1].TrackedSeconds)
+	})
+
+	t.Run("Positive Case: Units in the trash are tracked until they were deleted", func(t *testing.T) {
+		trashed := domain.Units{ID: uuid.New(), Name: "Cabin 1", Type: enum.Cabin, Status: enum.Occupied,
+			DeletedAt: gorm.DeletedAt{Time: at(30), Valid: true}}
+		live := domain.Units{ID: uuid.New(), Name: "Cabin 2", Type: enum.Cabin, Status: enum.Available}
+		histories := []domain.UnitStatusHistory{
+			change(nil, enum.Available, at(-24)),
+			change(status(enum.Available), enum.Occupied, at(12)),
+		}
+		for i := range histories {
+			histories[i].UnitID = trashed.ID
+		}
+
+		mockRepo := &MockUnitRepository{}
+		mockRepo.On("FindStatusHistoryBetween", mock.Anything, at(0), at(48)).Return(histories, nil).Once()
+		mockRepo.On("FindAllForReport", mock.Anything, []uuid.UUID{trashed.ID}).Return([]domain.Units{trashed, live}, nil).Once()
+
+		reportService := &ReportServiceImpl{unitRepository: mockRepo, now: func() time.Time { return at(72) }}
+		report, err := reportService.GetUtilization(context.Background(), request.UtilizationReportDto{From: at(0), To: at(48)})
+		require.Nil(t, err)
+
+		require.Len(t, report.Units, 2)
+		assert.Equal(t, trashed.ID, report.Units[0].UnitID)
+		assert.Equal(t, []response.UtilizationPeriod{
+			{Start: at(0), End: at(24), TrackedSeconds: 86400, OccupiedSeconds: 43200, OccupancyPercentage: 50},
+			{Start: at(24), End: at(48), TrackedSeconds: 21600, OccupiedSeconds: 21600, OccupancyPercentage: 100},
+		}, report.Units[0].Periods)
+		assert.Equal(t, int64(86400), report.Units[1].Periods[1].TrackedSeconds)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Positive Case: Weeks start on Monday and are cut to the range", func(t *testing.T) {
+		periods := reportPeriods(at(48), at(9*24), enum.GranularityWeek)
+		assert.Equal(t, []reportPeriod{
+			{start: at(48), end: at(7 * 24)},
+			{start: at(7 * 24), end: at(9 * 24)},
+		}, periods)
+	})
+
+	t.Run("Negative Case: Invalid report range", func(t *testing.T) {
+		reportService := NewReportService(&MockUnitRepository{})
+
+		_, err := reportService.GetUtilization(context.Background(), request.UtilizationReportDto{From: at(24), To: at(24)})
+		require.NotNil(t, err)
+		assert.Equal(t, http.StatusBadRequest, err.Code)
+
+		_, err = reportService.GetUtilization(context.Background(), request.UtilizationReportDto{From: at(0), To: at(24), Granularity: "month"})
+		require.NotNil(t, err)
+		assert.Equal(t, http.StatusBadRequest, err.Code)
+
+		_, err = reportService.GetUtilization(context.Background(), request.UtilizationReportDto{From: at(0), To: at(367 * 24)})
+		require.NotNil(t, err)
+		assert.Equal(t, http.StatusBadRequest, err.Code)
+	})
+
+	t.Run("Negative Case: Repository error", func(t *testing.T) {
+		mockRepo := &MockUnitRepository{}
+		mockRepo.On("FindStatusHistoryBetween", mock.Anything, at(0), at(24)).Return([]domain.UnitStatusHistory{}, errors.New("database error")).Once()
+
+		_, err := NewReportService(mockRepo).GetUtilization(context.Background(), request.UtilizationReportDto{From: at(0), To: at(24)})
+		require.NotNil(t, err)
+		assert.Equal(t, http.StatusInternalServerError, err.Code)
+		mockRepo.AssertExpectations(t)
+	})
+}
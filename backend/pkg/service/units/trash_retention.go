@@ -0,0 +1,36 @@
+package units
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+// RunTrashRetention purges the units deleted longer than retention ago right away and then every interval,
+// it returns once ctx is done
+func RunTrashRetention(ctx context.Context, unitService UnitService, retention, interval time.Duration) {
+	purge := func() {
+		purged, err := unitService.PurgeExpired(ctx, retention)
+		if err != nil {
+			slog.ErrorContext(ctx, "failed to purge expired units from the trash", "error", err.Message)
+			return
+		}
+
+		if purged > 0 {
+			slog.InfoContext(ctx, "purged expired units from the trash", "purged", purged, "retention", retention.String())
+		}
+	}
+
+	purge()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			purge()
+		}
+	}
+}
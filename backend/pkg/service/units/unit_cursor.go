@@ -0,0 +1,94 @@
+package units
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"slices"
+	"time"
+	"unit-management-be/pkg/model/domain/enum"
+	"unit-management-be/pkg/model/dto/request"
+	"unit-management-be/pkg/model/dto/response"
+
+	"github.com/google/uuid"
+)
+
+var errInvalidCursor = errors.New("invalid cursor")
+
+// unitCursor is the content of the opaque cursor handed to clients, it keeps the sort order
+// so the next pages continue in the order the first one was read
+type unitCursor struct {
+	Sort        []unitCursorSort `json:"s"`
+	ID          uuid.UUID        `json:"i"`
+	Name        string           `json:"n"`
+	Type        enum.UnitType    `json:"t"`
+	Status      enum.UnitStatus  `json:"st"`
+	LastUpdated time.Time        `json:"u"`
+	Backward    bool             `json:"b,omitempty"`
+}
+
+type unitCursorSort struct {
+	Field      enum.UnitSortField `json:"f"`
+	Descending bool               `json:"d,omitempty"`
+}
+
+// encodeUnitCursor returns the cursor of the page after the unit, or before it when backward
+func encodeUnitCursor(sort []request.UnitSortDto, unit response.UnitDetailResponse, backward bool) string {
+	cursor := unitCursor{
+		ID:          unit.ID,
+		Name:        unit.Name,
+		Type:        unit.Type,
+		Status:      unit.Status,
+		LastUpdated: unit.LastUpdated,
+		Backward:    backward,
+	}
+	for _, field := range sort {
+		cursor.Sort = append(cursor.Sort, unitCursorSort{Field: field.Field, Descending: field.Descending})
+	}
+
+	// marshalling plain values cannot fail
+	content, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(content)
+}
+
+// decodeUnitCursor reads a cursor made by encodeUnitCursor and returns its sort order and position
+func decodeUnitCursor(value string) ([]request.UnitSortDto, request.UnitCursorDto, error) {
+	content, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, request.UnitCursorDto{}, errInvalidCursor
+	}
+
+	var cursor unitCursor
+	if err := json.Unmarshal(content, &cursor); err != nil || cursor.ID == uuid.Nil {
+		return nil, request.UnitCursorDto{}, errInvalidCursor
+	}
+
+	sort := make([]request.UnitSortDto, 0, len(cursor.Sort))
+	for _, field := range cursor.Sort {
+		if _, isValidField := enum.ParseUnitSortField(string(field.Field)); !isValidField {
+			return nil, request.UnitCursorDto{}, errInvalidCursor
+		}
+		sort = append(sort, request.UnitSortDto{Field: field.Field, Descending: field.Descending})
+	}
+
+	return sort, request.UnitCursorDto{
+		ID:          cursor.ID,
+		Name:        cursor.Name,
+		Type:        cursor.Type,
+		Status:      cursor.Status,
+		LastUpdated: cursor.LastUpdated,
+		Backward:    cursor.Backward,
+	}, nil
+}
+
+// sameUnitSort reports whether both sort orders list the same units in the same order
+func sameUnitSort(a, b []request.UnitSortDto) bool {
+	if len(a) == 0 {
+		a = request.DefaultUnitSort()
+	}
+	if len(b) == 0 {
+		b = request.DefaultUnitSort()
+	}
+
+	return slices.Equal(a, b)
+}
@@ -0,0 +1,20 @@
+package units
+
+import (
+	"context"
+	"log/slog"
+	"unit-management-be/pkg/events"
+	"unit-management-be/pkg/model/domain"
+	"unit-management-be/pkg/model/domain/enum"
+	"unit-management-be/pkg/model/dto/response"
+)
+
+// PublishChanges returns a change listener that publishes every unit change to the broker,
+// the event carries the unit as returned by the detail endpoint
+func PublishChanges(broker *events.Broker) ChangeListener {
+	return func(ctx context.Context, eventType enum.UnitEventType, unit domain.Units) {
+		if _, err := broker.Publish(string(eventType), response.BuildUnitDetailResponseFromUnit(unit)); err != nil {
+			slog.ErrorContext(ctx, "failed to publish unit event", "event", eventType, "unit_id", unit.ID, "error", err)
+		}
+	}
+}
@@ -0,0 +1,58 @@
+package units
+
+import (
+	"context"
+	"unit-management-be/pkg/handler"
+	"unit-management-be/pkg/metrics"
+	"unit-management-be/pkg/model/domain"
+	"unit-management-be/pkg/model/domain/enum"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// UnitMetrics exposes the number of units in each status, counted by the database on every scrape,
+// and the status changes refused by the state machine or a status guard
+type UnitMetrics struct {
+	unitService UnitService
+	units       *prometheus.Desc
+	rejected    *prometheus.CounterVec
+}
+
+func NewUnitMetrics(unitService UnitService) *UnitMetrics {
+	return &UnitMetrics{
+		unitService: unitService,
+		units: prometheus.NewDesc(
+			prometheus.BuildFQName(metrics.Namespace, "", "units"),
+			"Units in the inventory by status, units in the trash are not counted.",
+			[]string{"status"}, nil,
+		),
+		rejected: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metrics.Namespace,
+			Name:      "unit_status_transitions_rejected_total",
+			Help:      "Status changes refused by the state machine or a status guard, by current and requested status.",
+		}, []string{"from", "to"}),
+	}
+}
+
+func (m *UnitMetrics) Describe(ch chan<- *prometheus.Desc) {
+	ch <- m.units
+	m.rejected.Describe(ch)
+}
+
+func (m *UnitMetrics) Collect(ch chan<- prometheus.Metric) {
+	stats, err := m.unitService.GetStats(context.Background())
+	if err != nil {
+		ch <- prometheus.NewInvalidMetric(m.units, err)
+	} else {
+		for _, statusStats := range stats.Statuses {
+			ch <- prometheus.MustNewConstMetric(m.units, prometheus.GaugeValue, float64(statusStats.Total), string(statusStats.Status))
+		}
+	}
+
+	m.rejected.Collect(ch)
+}
+
+// OnStatusChangeRejected counts a refused status change, register it as a rejection listener
+func (m *UnitMetrics) OnStatusChangeRejected(ctx context.Context, unit domain.Units, to enum.UnitStatus, err *handler.CustomError) {
+	m.rejected.WithLabelValues(string(unit.Status), string(to)).Inc()
+}
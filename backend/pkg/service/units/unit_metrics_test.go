@@ -0,0 +1,57 @@
+package units
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"unit-management-be/pkg/model/domain"
+	"unit-management-be/pkg/model/domain/enum"
+	"unit-management-be/pkg/model/dto/request"
+	"unit-management-be/pkg/model/dto/response"
+
+	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestUnitMetrics(t *testing.T) {
+	t.Run("Positive Case: Units per status and rejected changes are exposed", func(t *testing.T) {
+		mockRepo, unitService := setupTest(t)
+		unitMetrics := NewUnitMetrics(unitService)
+		unitService.RegisterRejectionListener(unitMetrics.OnStatusChangeRejected)
+
+		stats := response.NewUnitStatsResponse()
+		stats.StatusStats(enum.Available).Total = 3
+		stats.StatusStats(enum.Occupied).Total = 1
+		mockRepo.On("GetStats", mock.Anything).Return(stats, nil).Once()
+
+		id := uuid.New().String()
+		mockRepo.On("GetByID", mock.Anything, id).Return(domain.Units{ID: uuid.MustParse(id), Status: enum.Occupied, Type: enum.Cabin}, nil).Once()
+		_, err := unitService.ChangeStatus(context.Background(), id, request.ChangeUnitStatusDto{Status: "Available"})
+		assert.NotNil(t, err)
+
+		expected := `
+# HELP unit_management_unit_status_transitions_rejected_total Status changes refused by the state machine or a status guard, by current and requested status.
+# TYPE unit_management_unit_status_transitions_rejected_total counter
+unit_management_unit_status_transitions_rejected_total{from="Occupied",to="Available"} 1
+# HELP unit_management_units Units in the inventory by status, units in the trash are not counted.
+# TYPE unit_management_units gauge
+unit_management_units{status="Available"} 3
+unit_management_units{status="Cleaning In Progress"} 0
+unit_management_units{status="Maintenance Needed"} 0
+unit_management_units{status="Occupied"} 1
+`
+		assert.NoError(t, testutil.CollectAndCompare(unitMetrics, strings.NewReader(expected)))
+	})
+
+	t.Run("Negative Case: Units are left out when they cannot be counted", func(t *testing.T) {
+		mockRepo, unitService := setupTest(t)
+		unitMetrics := NewUnitMetrics(unitService)
+
+		mockRepo.On("GetStats", mock.Anything).Return(response.UnitStatsResponse{}, errors.New("database error")).Once()
+		_, err := testutil.CollectAndLint(unitMetrics)
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,130 @@
+package units
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"unit-management-be/pkg/handler"
+	"unit-management-be/pkg/model/domain"
+	"unit-management-be/pkg/model/domain/enum"
+	"unit-management-be/pkg/model/dto/request"
+	"unit-management-be/pkg/utils"
+
+	jsonpatch "github.com/evanphx/json-patch/v5"
+)
+
+// maxPatchAttempts bounds how often a patch sent without If-Match is reapplied
+// when another request changed the unit in the meantime
+const maxPatchAttempts = 3
+
+// patchDocument is the view of a unit a patch is applied to, id and version can be
+// tested by a JSON Patch but not changed, reason is only recorded to the status history
+type patchDocument struct {
+	ID      string `json:"id"`
+	Name    string `json:"name"`
+	Type    string `json:"type"`
+	Status  string `json:"status"`
+	Version int64  `json:"version"`
+	Reason  string `json:"reason"`
+}
+
+// applyUnitPatch applies the patch of the request to the unit and returns the full update it results in
+func applyUnitPatch(unit domain.Units, patchRequest request.PatchUnitDto) (request.UpdateUnitDto, *handler.CustomError) {
+	format, isValidFormat := enum.ParsePatchFormat(patchRequest.Format)
+	if !isValidFormat {
+		return request.UpdateUnitDto{}, handler.NewError(http.StatusUnsupportedMediaType,
+			fmt.Sprintf("unsupported patch format, content type must be '%s' or '%s'", enum.MergePatch, enum.JSONPatch))
+	}
+
+	original := patchDocument{
+		ID:      unit.ID.String(),
+		Name:    unit.Name,
+		Type:    string(unit.Type),
+		Status:  string(unit.Status),
+		Version: unit.Version,
+	}
+	document, err := json.Marshal(original)
+	if err != nil {
+		return request.UpdateUnitDto{}, handler.NewError(http.StatusInternalServerError, err.Error())
+	}
+
+	var patched []byte
+	switch format {
+	case enum.JSONPatch:
+		patch, errDecode := jsonpatch.DecodePatch(patchRequest.Patch)
+		if errDecode != nil {
+			return request.UpdateUnitDto{}, handler.NewError(http.StatusBadRequest, "invalid json patch document: "+errDecode.Error())
+		}
+
+		patched, err = patch.Apply(document)
+		if errors.Is(err, jsonpatch.ErrTestFailed) {
+			return request.UpdateUnitDto{}, handler.NewError(http.StatusConflict, "json patch test operation failed: "+err.Error())
+		}
+		if err != nil {
+			return request.UpdateUnitDto{}, handler.NewError(http.StatusUnprocessableEntity, "json patch cannot be applied to the unit: "+err.Error())
+		}
+	default:
+		if !json.Valid(patchRequest.Patch) {
+			return request.UpdateUnitDto{}, handler.NewError(http.StatusBadRequest, "invalid merge patch document, must be a json object")
+		}
+
+		patched, err = jsonpatch.MergePatch(document, patchRequest.Patch)
+		if err != nil {
+			return request.UpdateUnitDto{}, handler.NewError(http.StatusBadRequest, "invalid merge patch document: "+err.Error())
+		}
+	}
+
+	result, errResult := decodePatchDocument(patched)
+	if errResult != nil {
+		return request.UpdateUnitDto{}, errResult
+	}
+
+	if result.ID != original.ID || result.Version != original.Version {
+		return request.UpdateUnitDto{}, handler.NewError(http.StatusUnprocessableEntity, "unit id and version cannot be patched")
+	}
+
+	if patchRequest.StatusOnly && (result.Name != original.Name || result.Type != original.Type) {
+		return request.UpdateUnitDto{}, handler.NewError(http.StatusForbidden, "your role is only allowed to patch the unit status")
+	}
+
+	if utils.IsEmptyString(result.Name) {
+		return request.UpdateUnitDto{}, handler.NewError(http.StatusBadRequest, "unit name is required")
+	}
+
+	return request.UpdateUnitDto{
+		CreateUnitDto: request.CreateUnitDto{
+			Name:   result.Name,
+			Type:   result.Type,
+			Status: result.Status,
+			Actor:  patchRequest.Actor,
+		},
+		Reason:          result.Reason,
+		ExpectedVersion: patchRequest.ExpectedVersion,
+	}, nil
+}
+
+// decodePatchDocument reads the patched unit back, fields cannot be removed or added
+func decodePatchDocument(patched []byte) (patchDocument, *handler.CustomError) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(patched, &fields); err != nil {
+		return patchDocument{}, handler.NewError(http.StatusUnprocessableEntity, "patched unit must be a json object")
+	}
+
+	for _, field := range []string{"id", "name", "type", "status", "version"} {
+		if _, ok := fields[field]; !ok {
+			return patchDocument{}, handler.NewError(http.StatusUnprocessableEntity, fmt.Sprintf("unit %s cannot be removed", field))
+		}
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(patched))
+	decoder.DisallowUnknownFields()
+
+	var result patchDocument
+	if err := decoder.Decode(&result); err != nil {
+		return patchDocument{}, handler.NewError(http.StatusUnprocessableEntity, "patched unit is invalid: "+err.Error())
+	}
+
+	return result, nil
+}
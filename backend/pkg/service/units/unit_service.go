@@ -1,18 +1,62 @@
 package units
 
 import (
+	"context"
+	"time"
 	"unit-management-be/pkg/handler"
 	"unit-management-be/pkg/model/domain"
+	"unit-management-be/pkg/model/domain/enum"
 	"unit-management-be/pkg/model/dto"
 	"unit-management-be/pkg/model/dto/request"
 	"unit-management-be/pkg/model/dto/response"
 )
 
+// StatusListener is notified after a unit status change, and the transaction it belongs to, has been committed
+type StatusListener func(ctx context.Context, unit domain.Units, history domain.UnitStatusHistory)
+
+// StatusHook runs in the transaction of a unit status change, so its writes are committed with the
+// change and an error rolls the change back
+type StatusHook func(ctx context.Context, unit domain.Units, history domain.UnitStatusHistory) error
+
+// ChangeListener is notified after a unit has been created, updated, deleted or restored and the change committed
+type ChangeListener func(ctx context.Context, eventType enum.UnitEventType, unit domain.Units)
+
+// ChangeHook runs in the transaction of a unit creation, update, deletion or restore, so its writes are
+// committed with the change and an error rolls the change back
+type ChangeHook func(ctx context.Context, eventType enum.UnitEventType, unit domain.Units) error
+
+// RejectionListener is notified when a status change is refused by the state machine or a status guard
+type RejectionListener func(ctx context.Context, unit domain.Units, to enum.UnitStatus, err *handler.CustomError)
+
+// StatusGuard can veto a status change that is allowed by the state machine, for example
+// while another subsystem still holds the unit
+type StatusGuard func(ctx context.Context, unit domain.Units, to enum.UnitStatus) *handler.CustomError
+
 type UnitService interface {
-	CreateUnit(request request.CreateUnitDto) (*domain.Units, *handler.CustomError)
-	GetDetailByID(id string) (response.UnitDetailResponse, *handler.CustomError)
-	DeleteByID(id string) *handler.CustomError
-	FindByID(id string) (domain.Units, *handler.CustomError)
-	FindUnits(status, unitType, name string, page, size int) (*dto.PaginationResponse, *handler.CustomError)
-	Update(id string, request request.UpdateUnitDto) (*domain.Units, *handler.CustomError)
+	CreateUnit(ctx context.Context, request request.CreateUnitDto) (*domain.Units, *handler.CustomError)
+	GetDetailByID(ctx context.Context, id string) (response.UnitDetailResponse, *handler.CustomError)
+	DeleteByID(ctx context.Context, id string) *handler.CustomError
+	FindTrash(ctx context.Context, name string, page, size int) (*dto.PaginationResponse, *handler.CustomError)
+	RestoreByID(ctx context.Context, id string) (*domain.Units, *handler.CustomError)
+	PurgeByID(ctx context.Context, id string) *handler.CustomError
+	PurgeExpired(ctx context.Context, retention time.Duration) (int64, *handler.CustomError)
+	FindByID(ctx context.Context, id string) (domain.Units, *handler.CustomError)
+	LockByID(ctx context.Context, id string) (domain.Units, *handler.CustomError)
+	FindUnits(ctx context.Context, filter request.UnitFilterDto, page, size int) (*dto.PaginationResponse, *handler.CustomError)
+	FindUnitsByCursor(ctx context.Context, filter request.UnitFilterDto, cursor string, limit int) (*dto.CursorPaginationResponse, *handler.CustomError)
+	ExportUnits(ctx context.Context, filter request.UnitFilterDto) ([]domain.Units, *handler.CustomError)
+	GetStats(ctx context.Context) (response.UnitStatsResponse, *handler.CustomError)
+	ImportUnits(ctx context.Context, rows []request.ImportUnitRowDto, dryRun bool, actor string) (*response.ImportUnitsResponse, *handler.CustomError)
+	Update(ctx context.Context, id string, request request.UpdateUnitDto) (*domain.Units, *handler.CustomError)
+	Patch(ctx context.Context, id string, request request.PatchUnitDto) (*domain.Units, *handler.CustomError)
+	ChangeStatus(ctx context.Context, id string, request request.ChangeUnitStatusDto) (*domain.Units, *handler.CustomError)
+	BulkChangeStatus(ctx context.Context, request request.BulkChangeUnitStatusDto) (*response.BulkUnitStatusResponse, *handler.CustomError)
+	GetTransitionsByID(ctx context.Context, id string) (response.UnitTransitionsResponse, *handler.CustomError)
+	FindStatusHistory(ctx context.Context, id string, page, size int) (*dto.PaginationResponse, *handler.CustomError)
+	RegisterStatusListener(listener StatusListener)
+	RegisterStatusHook(hook StatusHook)
+	RegisterStatusGuard(guard StatusGuard)
+	RegisterChangeListener(listener ChangeListener)
+	RegisterChangeHook(hook ChangeHook)
+	RegisterRejectionListener(listener RejectionListener)
 }
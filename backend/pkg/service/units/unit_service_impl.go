@@ -1,7 +1,11 @@
 package units
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"net/http"
+	"strings"
 	"time"
 	"unit-management-be/pkg/handler"
 	"unit-management-be/pkg/model/domain"
@@ -10,18 +14,36 @@ import (
 	"unit-management-be/pkg/model/dto/request"
 	"unit-management-be/pkg/model/dto/response"
 	unitrepository "unit-management-be/pkg/repository/units"
+	"unit-management-be/pkg/transaction"
+	"unit-management-be/pkg/utils"
 
 	"gorm.io/gorm"
 )
 
+// maxUnitNameLength follows the size of the units.name column
+const maxUnitNameLength = 255
+
+// maxBulkUnits caps a bulk status change, a whole floor fits comfortably
+const maxBulkUnits = 200
+
+// errBulkRolledBack rolls back an atomic bulk change when one of its units failed
+var errBulkRolledBack = errors.New("bulk status change rolled back")
+
 type UnitServiceImpl struct {
-	unitRepository unitrepository.UnitRepository
+	unitRepository  unitrepository.UnitRepository
+	statusListeners []StatusListener
+	statusHooks     []StatusHook
+	statusGuards    []StatusGuard
+	changeListeners []ChangeListener
+	changeHooks     []ChangeHook
+	rejectListeners []RejectionListener
+	now             func() time.Time
 }
 
 func NewUnitService(unitRepository unitrepository.UnitRepository) UnitService {
-	return &UnitServiceImpl{unitRepository: unitRepository}
+	return &UnitServiceImpl{unitRepository: unitRepository, now: time.Now}
 }
-func (u *UnitServiceImpl) CreateUnit(request request.CreateUnitDto) (*domain.Units, *handler.CustomError) {
+func (u *UnitServiceImpl) CreateUnit(ctx context.Context, request request.CreateUnitDto) (*domain.Units, *handler.CustomError) {
 	status, isValidStatus := enum.ParseUnitStatus(request.Status)
 	if !isValidStatus {
 		return nil, handler.NewError(http.StatusBadRequest, "invalid unit status, must be one of 'Available', 'Occupied', 'Cleaning In Progress', 'Maintenance Needed'")
@@ -32,24 +54,46 @@ func (u *UnitServiceImpl) CreateUnit(request request.CreateUnitDto) (*domain.Uni
 		return nil, handler.NewError(http.StatusBadRequest, "invalid unit type, must be 'cabin' or 'capsule'")
 	}
 
+	if errTransition := validateStatusTransition("", status); errTransition != nil {
+		return nil, errTransition
+	}
+
 	unit := domain.Units{
-		Name:   request.Name,
-		Status: status,
-		Type:   unitType,
+		Name:    request.Name,
+		Status:  status,
+		Type:    unitType,
+		Version: 1,
 	}
 
-	createdUnit, errSave := u.unitRepository.Create(unit)
+	var createdUnit domain.Units
+	var history domain.UnitStatusHistory
+	errSave := u.unitRepository.Transaction(ctx, func(ctx context.Context, repository unitrepository.UnitRepository) error {
+		var err error
+		createdUnit, err = repository.Create(ctx, unit)
+		if err != nil {
+			return err
+		}
+
+		history = buildStatusHistory(createdUnit, nil, request.Actor, "")
+		if err := u.recordStatusChange(ctx, repository, createdUnit, history); err != nil {
+			return err
+		}
+
+		return u.recordChange(ctx, enum.UnitCreated, createdUnit)
+	})
 	if errSave != nil {
 		return nil, handler.NewError(http.StatusInternalServerError, errSave.Error())
 	}
 
+	u.notifyStatusChanged(ctx, createdUnit, history)
+	u.notifyChanged(ctx, enum.UnitCreated, createdUnit)
 	return &createdUnit, nil
 }
 
-func (u *UnitServiceImpl) FindByID(id string) (domain.Units, *handler.CustomError) {
+func (u *UnitServiceImpl) FindByID(ctx context.Context, id string) (domain.Units, *handler.CustomError) {
 	var unit domain.Units
 
-	unit, err := u.unitRepository.GetByID(id)
+	unit, err := u.unitRepository.GetByID(ctx, id)
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return unit, handler.NewError(http.StatusNotFound, "unit with that id was not found")
@@ -60,10 +104,20 @@ func (u *UnitServiceImpl) FindByID(id string) (domain.Units, *handler.CustomErro
 	return unit, nil
 }
 
-func (u *UnitServiceImpl) GetDetailByID(id string) (response.UnitDetailResponse, *handler.CustomError) {
+// LockByID reads the unit and keeps other writers of it waiting until the transaction carried by ctx ends
+func (u *UnitServiceImpl) LockByID(ctx context.Context, id string) (domain.Units, *handler.CustomError) {
+	unit, err := u.unitRepository.LockByID(ctx, id)
+	if err != nil {
+		return unit, lookupUnitError(err)
+	}
+
+	return unit, nil
+}
+
+func (u *UnitServiceImpl) GetDetailByID(ctx context.Context, id string) (response.UnitDetailResponse, *handler.CustomError) {
 	var responseUnit response.UnitDetailResponse
 
-	unit, err := u.FindByID(id)
+	unit, err := u.FindByID(ctx, id)
 	if err != nil {
 		return responseUnit, handler.NewError(err.Code, err.Message)
 	}
@@ -71,22 +125,102 @@ func (u *UnitServiceImpl) GetDetailByID(id string) (response.UnitDetailResponse,
 	return response.BuildUnitDetailResponseFromUnit(unit), nil
 }
 
-func (u *UnitServiceImpl) DeleteByID(id string) *handler.CustomError {
-	unit, err := u.FindByID(id)
+func (u *UnitServiceImpl) DeleteByID(ctx context.Context, id string) *handler.CustomError {
+	unit, err := u.FindByID(ctx, id)
 	if err != nil {
 		return handler.NewError(err.Code, err.Message)
 	}
 
-	errDelete := u.unitRepository.Delete(unit)
+	errDelete := u.unitRepository.Transaction(ctx, func(ctx context.Context, repository unitrepository.UnitRepository) error {
+		// the lock waits for a booking being reserved or checked in on the unit, so it is seen by Delete
+		locked, err := repository.LockByID(ctx, id)
+		if err != nil {
+			return lookupUnitError(err)
+		}
+		unit = locked
+
+		if err := repository.Delete(ctx, unit); err != nil {
+			if err == unitrepository.ErrUnitBooked {
+				return handler.NewError(http.StatusConflict, "unit has a reserved or checked in booking, check it out or cancel it before deleting the unit")
+			}
+			return err
+		}
+
+		return u.recordChange(ctx, enum.UnitDeleted, unit)
+	})
 	if errDelete != nil {
-		return handler.NewError(http.StatusInternalServerError, errDelete.Error())
+		return handler.AsCustomError(errDelete)
 	}
 
+	u.notifyChanged(ctx, enum.UnitDeleted, unit)
 	return nil
 }
 
-func (u *UnitServiceImpl) FindUnits(status, unitType, name string, page, size int) (*dto.PaginationResponse, *handler.CustomError) {
-	units, totalUnit, err := u.unitRepository.FindAll(status, unitType, name, page, size)
+func (u *UnitServiceImpl) FindTrash(ctx context.Context, name string, page, size int) (*dto.PaginationResponse, *handler.CustomError) {
+	units, total, err := u.unitRepository.FindDeleted(ctx, name, page, size)
+	if err != nil {
+		return nil, handler.NewError(http.StatusInternalServerError, err.Error())
+	}
+
+	return dto.NewPaginationResponse(page, size, int(total), units), nil
+}
+
+// RestoreByID takes a unit out of the trash with the status it had when it was deleted
+func (u *UnitServiceImpl) RestoreByID(ctx context.Context, id string) (*domain.Units, *handler.CustomError) {
+	unit, err := u.unitRepository.GetDeletedByID(ctx, id)
+	if err != nil {
+		return nil, trashUnitError(err)
+	}
+
+	var restored domain.Units
+	errRestore := u.unitRepository.Transaction(ctx, func(ctx context.Context, repository unitrepository.UnitRepository) error {
+		if err := repository.Restore(ctx, unit); err != nil {
+			return trashUnitError(err)
+		}
+
+		var err error
+		restored, err = repository.GetByID(ctx, id)
+		if err != nil {
+			return lookupUnitError(err)
+		}
+
+		return u.recordChange(ctx, enum.UnitRestored, restored)
+	})
+	if errRestore != nil {
+		return nil, handler.AsCustomError(errRestore)
+	}
+
+	u.notifyChanged(ctx, enum.UnitRestored, restored)
+	return &restored, nil
+}
+
+// PurgeByID permanently deletes a unit, only units in the trash that no other record references can be purged
+func (u *UnitServiceImpl) PurgeByID(ctx context.Context, id string) *handler.CustomError {
+	unit, err := u.unitRepository.GetDeletedByID(ctx, id)
+	if err != nil {
+		return trashUnitError(err)
+	}
+
+	if errPurge := u.unitRepository.Purge(ctx, unit); errPurge != nil {
+		return trashUnitError(errPurge)
+	}
+
+	return nil
+}
+
+// PurgeExpired permanently deletes the units that have been in the trash longer than retention, the ones
+// still referenced by other records are skipped
+func (u *UnitServiceImpl) PurgeExpired(ctx context.Context, retention time.Duration) (int64, *handler.CustomError) {
+	purged, err := u.unitRepository.PurgeDeletedBefore(ctx, u.now().Add(-retention))
+	if err != nil {
+		return 0, handler.NewError(http.StatusInternalServerError, err.Error())
+	}
+
+	return purged, nil
+}
+
+func (u *UnitServiceImpl) FindUnits(ctx context.Context, filter request.UnitFilterDto, page, size int) (*dto.PaginationResponse, *handler.CustomError) {
+	units, totalUnit, err := u.unitRepository.FindAll(ctx, filter, page, size)
 	if err != nil {
 		return nil, handler.NewError(http.StatusInternalServerError, err.Error())
 	}
@@ -94,12 +228,161 @@ func (u *UnitServiceImpl) FindUnits(status, unitType, name string, page, size in
 	return dto.NewPaginationResponse(page, size, int(totalUnit), units), nil
 }
 
-func (u *UnitServiceImpl) Update(id string, request request.UpdateUnitDto) (*domain.Units, *handler.CustomError) {
-	unit, err := u.FindByID(id)
+// FindUnitsByCursor returns a page of units after or before the cursor position without counting the
+// matching units, an empty cursor starts at the first unit. The sort order is carried by the cursor
+func (u *UnitServiceImpl) FindUnitsByCursor(ctx context.Context, filter request.UnitFilterDto, cursor string, limit int) (*dto.CursorPaginationResponse, *handler.CustomError) {
+	var position *request.UnitCursorDto
+	if !utils.IsEmptyString(cursor) {
+		cursorSort, decoded, err := decodeUnitCursor(cursor)
+		if err != nil {
+			return nil, handler.NewError(http.StatusBadRequest, "invalid cursor parameter, use a cursor returned by the API")
+		}
+
+		if len(filter.Sort) > 0 && !sameUnitSort(filter.Sort, cursorSort) {
+			return nil, handler.NewError(http.StatusBadRequest, "cursor was issued for another sort order, send the same sort or start again without a cursor")
+		}
+		filter.Sort = cursorSort
+		position = &decoded
+	}
+
+	// one extra unit tells whether there is a page beyond this one
+	units, err := u.unitRepository.FindAllByCursor(ctx, filter, position, limit+1)
+	if err != nil {
+		return nil, handler.NewError(http.StatusInternalServerError, err.Error())
+	}
+
+	backward := position != nil && position.Backward
+	hasMore := len(units) > limit
+	if hasMore && backward {
+		units = units[1:]
+	} else if hasMore {
+		units = units[:limit]
+	}
+
+	var nextCursor, prevCursor string
+	if len(units) > 0 {
+		if hasMore || backward {
+			nextCursor = encodeUnitCursor(filter.Sort, units[len(units)-1], false)
+		}
+		if (hasMore && backward) || (position != nil && !backward) {
+			prevCursor = encodeUnitCursor(filter.Sort, units[0], true)
+		}
+	}
+
+	return dto.NewCursorPaginationResponse(limit, nextCursor, prevCursor, units), nil
+}
+
+func (u *UnitServiceImpl) ExportUnits(ctx context.Context, filter request.UnitFilterDto) ([]domain.Units, *handler.CustomError) {
+	units, err := u.unitRepository.FindAllForExport(ctx, filter)
+	if err != nil {
+		return nil, handler.NewError(http.StatusInternalServerError, err.Error())
+	}
+
+	return units, nil
+}
+
+func (u *UnitServiceImpl) GetStats(ctx context.Context) (response.UnitStatsResponse, *handler.CustomError) {
+	stats, err := u.unitRepository.GetStats(ctx)
+	if err != nil {
+		return stats, handler.NewError(http.StatusInternalServerError, err.Error())
+	}
+
+	return stats, nil
+}
+
+// ImportUnits validates every row and creates the valid ones in a single transaction,
+// in dry run mode nothing is created and only the validation report is returned
+func (u *UnitServiceImpl) ImportUnits(ctx context.Context, rows []request.ImportUnitRowDto, dryRun bool, actor string) (*response.ImportUnitsResponse, *handler.CustomError) {
+	importResponse := &response.ImportUnitsResponse{
+		DryRun:    dryRun,
+		TotalRows: len(rows),
+		Errors:    make([]response.ImportRowError, 0),
+	}
+
+	units := make([]domain.Units, 0, len(rows))
+	for _, row := range rows {
+		unit, rowErrors := validateImportRow(row)
+		if len(rowErrors) > 0 {
+			importResponse.Errors = append(importResponse.Errors, rowErrors...)
+			continue
+		}
+		units = append(units, unit)
+	}
+	importResponse.ValidRows = len(units)
+
+	if dryRun || len(units) == 0 {
+		return importResponse, nil
+	}
+
+	createdUnits := make([]domain.Units, 0, len(units))
+	histories := make([]domain.UnitStatusHistory, 0, len(units))
+	errSave := u.unitRepository.Transaction(ctx, func(ctx context.Context, repository unitrepository.UnitRepository) error {
+		for _, unit := range units {
+			createdUnit, err := repository.Create(ctx, unit)
+			if err != nil {
+				return err
+			}
+
+			history := buildStatusHistory(createdUnit, nil, actor, "imported from csv")
+			if err := u.recordStatusChange(ctx, repository, createdUnit, history); err != nil {
+				return err
+			}
+			if err := u.recordChange(ctx, enum.UnitCreated, createdUnit); err != nil {
+				return err
+			}
+
+			createdUnits = append(createdUnits, createdUnit)
+			histories = append(histories, history)
+		}
+		return nil
+	})
+	if errSave != nil {
+		return nil, handler.NewError(http.StatusInternalServerError, errSave.Error())
+	}
+
+	for i := range createdUnits {
+		u.notifyStatusChanged(ctx, createdUnits[i], histories[i])
+		u.notifyChanged(ctx, enum.UnitCreated, createdUnits[i])
+	}
+
+	importResponse.Imported = len(createdUnits)
+	return importResponse, nil
+}
+
+func (u *UnitServiceImpl) Update(ctx context.Context, id string, request request.UpdateUnitDto) (*domain.Units, *handler.CustomError) {
+	unit, err := u.FindByID(ctx, id)
 	if err != nil {
 		return nil, handler.NewError(err.Code, err.Message)
 	}
 
+	return u.update(ctx, unit, request)
+}
+
+// Patch applies a JSON Merge Patch or JSON Patch to the unit and saves the result like Update.
+// Without an expected version the patch is reapplied when another request changed the unit first
+func (u *UnitServiceImpl) Patch(ctx context.Context, id string, patchRequest request.PatchUnitDto) (*domain.Units, *handler.CustomError) {
+	for attempt := 1; ; attempt++ {
+		unit, err := u.FindByID(ctx, id)
+		if err != nil {
+			return nil, handler.NewError(err.Code, err.Message)
+		}
+
+		updateRequest, errPatch := applyUnitPatch(unit, patchRequest)
+		if errPatch != nil {
+			return nil, errPatch
+		}
+
+		updated, errUpdate := u.update(ctx, unit, updateRequest)
+		if errUpdate != nil && errUpdate.Code == http.StatusPreconditionFailed &&
+			patchRequest.ExpectedVersion == nil && attempt < maxPatchAttempts {
+			continue
+		}
+		return updated, errUpdate
+	}
+}
+
+// update validates the requested fields against the loaded unit and saves them
+func (u *UnitServiceImpl) update(ctx context.Context, unit domain.Units, request request.UpdateUnitDto) (*domain.Units, *handler.CustomError) {
 	unitType, isValidUnitType := enum.ParseUnitType(request.Type)
 	if !isValidUnitType {
 		return nil, handler.NewError(http.StatusBadRequest, "invalid unit type, must be 'cabin' or 'capsule'")
@@ -110,19 +393,548 @@ func (u *UnitServiceImpl) Update(id string, request request.UpdateUnitDto) (*dom
 		return nil, handler.NewError(http.StatusBadRequest, "invalid unit status, must be one of 'Available', 'Occupied', 'Cleaning In Progress', 'Maintenance Needed'")
 	}
 
-	if unit.Status == enum.Occupied && newStatus == enum.Available {
-		return nil, handler.NewError(http.StatusBadRequest, "unit cannot go directly from occupied to available")
+	if request.ExpectedVersion != nil && *request.ExpectedVersion != unit.Version {
+		return nil, preconditionFailedError(unit)
 	}
 
+	if unit.Status != newStatus {
+		if errTransition := u.checkTransition(ctx, unit, newStatus); errTransition != nil {
+			return nil, errTransition
+		}
+	}
+
+	previousStatus := unit.Status
 	unit.Name = request.Name
 	unit.Type = unitType
 	unit.Status = newStatus
-	unit.LastUpdated = time.Now()
+	unit.LastUpdated = u.now()
 
-	errUpdate := u.unitRepository.Update(unit)
+	errUpdate := u.save(ctx, &unit, previousStatus, request.Actor, request.Reason)
 	if errUpdate != nil {
-		return nil, handler.NewError(http.StatusInternalServerError, errUpdate.Error())
+		if errUpdate == unitrepository.ErrVersionConflict {
+			current, errCurrent := u.FindByID(ctx, unit.ID.String())
+			if errCurrent != nil {
+				return nil, handler.NewError(errCurrent.Code, errCurrent.Message)
+			}
+			return nil, preconditionFailedError(current)
+		}
+		return nil, handler.AsCustomError(errUpdate)
 	}
 
 	return &unit, nil
 }
+
+func (u *UnitServiceImpl) ChangeStatus(ctx context.Context, id string, request request.ChangeUnitStatusDto) (*domain.Units, *handler.CustomError) {
+	unit, err := u.FindByID(ctx, id)
+	if err != nil {
+		return nil, handler.NewError(err.Code, err.Message)
+	}
+
+	newStatus, isValidStatus := enum.ParseUnitStatus(request.Status)
+	if !isValidStatus {
+		return nil, handler.NewError(http.StatusBadRequest, "invalid unit status, must be one of 'Available', 'Occupied', 'Cleaning In Progress', 'Maintenance Needed'")
+	}
+
+	// unlike Update, an explicit status change must move the unit to a different status
+	if unit.Status == newStatus {
+		return nil, handler.NewError(http.StatusConflict, fmt.Sprintf("unit status is already '%s'", newStatus))
+	}
+
+	if errChange := u.changeStatus(ctx, &unit, newStatus, request.Actor, request.Reason); errChange != nil {
+		return nil, errChange
+	}
+
+	return &unit, nil
+}
+
+// changeStatus validates and saves the move of the unit to another status
+func (u *UnitServiceImpl) changeStatus(ctx context.Context, unit *domain.Units, newStatus enum.UnitStatus, actor, reason string) *handler.CustomError {
+	if errTransition := u.checkTransition(ctx, *unit, newStatus); errTransition != nil {
+		return errTransition
+	}
+
+	previousStatus := unit.Status
+	unit.Status = newStatus
+	unit.LastUpdated = u.now()
+
+	errUpdate := u.save(ctx, unit, previousStatus, actor, reason)
+	if errUpdate != nil {
+		unit.Status = previousStatus
+		return saveStatusError(errUpdate)
+	}
+
+	return nil
+}
+
+func (u *UnitServiceImpl) BulkChangeStatus(ctx context.Context, bulkRequest request.BulkChangeUnitStatusDto) (*response.BulkUnitStatusResponse, *handler.CustomError) {
+	newStatus, isValidStatus := enum.ParseUnitStatus(bulkRequest.Status)
+	if !isValidStatus {
+		return nil, handler.NewError(http.StatusBadRequest, "invalid unit status, must be one of 'Available', 'Occupied', 'Cleaning In Progress', 'Maintenance Needed'")
+	}
+
+	mode := enum.BulkAtomic
+	if !utils.IsEmptyString(bulkRequest.Mode) {
+		parsedMode, isValidMode := enum.ParseBulkMode(bulkRequest.Mode)
+		if !isValidMode {
+			return nil, handler.NewError(http.StatusBadRequest, "invalid bulk mode, must be 'atomic' or 'best_effort'")
+		}
+		mode = parsedMode
+	}
+
+	unitIDs := uniqueUnitIDs(bulkRequest.UnitIDs)
+	if len(unitIDs) == 0 {
+		return nil, handler.NewError(http.StatusBadRequest, "unit ids are required")
+	}
+
+	if len(unitIDs) > maxBulkUnits {
+		return nil, handler.NewError(http.StatusBadRequest, fmt.Sprintf("at most %d units can be changed at once", maxBulkUnits))
+	}
+
+	bulkResponse := &response.BulkUnitStatusResponse{Mode: mode, Status: newStatus}
+	if mode == enum.BulkBestEffort {
+		bulkResponse.Results = u.bulkChangeStatusBestEffort(ctx, unitIDs, newStatus, bulkRequest.Actor, bulkRequest.Reason)
+		bulkResponse.Summarize()
+		return bulkResponse, nil
+	}
+
+	results, errBulk := u.bulkChangeStatusAtomic(ctx, unitIDs, newStatus, bulkRequest.Actor, bulkRequest.Reason)
+	bulkResponse.Results = results
+	bulkResponse.Summarize()
+	if errBulk == errBulkRolledBack {
+		message := fmt.Sprintf("no unit was updated, %d of %d units cannot change status", bulkResponse.Failed, bulkResponse.Total)
+		return nil, handler.NewErrorWithData(http.StatusConflict, message, bulkResponse)
+	}
+	if errBulk != nil {
+		return nil, handler.NewError(http.StatusInternalServerError, errBulk.Error())
+	}
+
+	return bulkResponse, nil
+}
+
+// bulkChangeStatusBestEffort changes every unit on its own, a failing unit does not stop the others
+func (u *UnitServiceImpl) bulkChangeStatusBestEffort(ctx context.Context, unitIDs []string, newStatus enum.UnitStatus, actor, reason string) []response.BulkUnitStatusResult {
+	results := make([]response.BulkUnitStatusResult, 0, len(unitIDs))
+	for _, id := range unitIDs {
+		unit, err := u.FindByID(ctx, id)
+		if err != nil {
+			results = append(results, failedBulkResult(id, nil, err))
+			continue
+		}
+
+		previousStatus := unit.Status
+		if previousStatus == newStatus {
+			results = append(results, response.BulkUnitStatusResult{UnitID: id, Outcome: response.BulkOutcomeSkipped, FromStatus: &previousStatus, Version: unit.Version})
+			continue
+		}
+
+		if errChange := u.changeStatus(ctx, &unit, newStatus, actor, reason); errChange != nil {
+			results = append(results, failedBulkResult(id, &previousStatus, errChange))
+			continue
+		}
+
+		results = append(results, response.BulkUnitStatusResult{UnitID: id, Outcome: response.BulkOutcomeUpdated, FromStatus: &previousStatus, Version: unit.Version})
+	}
+
+	return results
+}
+
+// bulkChangeStatusAtomic changes every unit in a single transaction, when any unit fails the whole batch
+// is rolled back but the remaining units are still validated so the report is complete
+func (u *UnitServiceImpl) bulkChangeStatusAtomic(ctx context.Context, unitIDs []string, newStatus enum.UnitStatus, actor, reason string) ([]response.BulkUnitStatusResult, error) {
+	results := make([]response.BulkUnitStatusResult, 0, len(unitIDs))
+	changedUnits := make([]domain.Units, 0, len(unitIDs))
+	histories := make([]domain.UnitStatusHistory, 0, len(unitIDs))
+
+	errTransaction := u.unitRepository.Transaction(ctx, func(ctx context.Context, repository unitrepository.UnitRepository) error {
+		hasFailure := false
+		for _, id := range unitIDs {
+			unit, err := repository.LockByID(ctx, id)
+			if err != nil {
+				hasFailure = true
+				results = append(results, failedBulkResult(id, nil, lookupUnitError(err)))
+				continue
+			}
+
+			previousStatus := unit.Status
+			if previousStatus == newStatus {
+				results = append(results, response.BulkUnitStatusResult{UnitID: id, Outcome: response.BulkOutcomeSkipped, FromStatus: &previousStatus, Version: unit.Version})
+				continue
+			}
+
+			if errTransition := u.checkStatusChange(ctx, unit, newStatus); errTransition != nil {
+				hasFailure = true
+				results = append(results, failedBulkResult(id, &previousStatus, errTransition))
+				continue
+			}
+
+			// once a unit failed nothing will be committed, the remaining units are only validated
+			if hasFailure {
+				results = append(results, response.BulkUnitStatusResult{UnitID: id, Outcome: response.BulkOutcomeRolledBack, FromStatus: &previousStatus})
+				continue
+			}
+
+			unit.Status = newStatus
+			unit.LastUpdated = u.now()
+			history := buildStatusHistory(unit, &previousStatus, actor, reason)
+			if err := repository.Update(ctx, unit); err != nil {
+				hasFailure = true
+				results = append(results, failedBulkResult(id, &previousStatus, saveStatusError(err)))
+				continue
+			}
+
+			unit.Version++
+			if err := u.recordStatusChange(ctx, repository, unit, history); err != nil {
+				hasFailure = true
+				results = append(results, failedBulkResult(id, &previousStatus, saveStatusError(err)))
+				continue
+			}
+			if err := u.recordChange(ctx, enum.UnitUpdated, unit); err != nil {
+				hasFailure = true
+				results = append(results, failedBulkResult(id, &previousStatus, saveStatusError(err)))
+				continue
+			}
+
+			changedUnits = append(changedUnits, unit)
+			histories = append(histories, history)
+			results = append(results, response.BulkUnitStatusResult{UnitID: id, Outcome: response.BulkOutcomeUpdated, FromStatus: &previousStatus, Version: unit.Version})
+		}
+
+		if hasFailure {
+			return errBulkRolledBack
+		}
+		return nil
+	})
+
+	if errTransaction != nil {
+		for i := range results {
+			if results[i].Outcome == response.BulkOutcomeUpdated {
+				results[i].Outcome = response.BulkOutcomeRolledBack
+				results[i].Version = 0
+			}
+		}
+		return results, errTransaction
+	}
+
+	for i := range changedUnits {
+		u.notifyStatusChanged(ctx, changedUnits[i], histories[i])
+		u.notifyChanged(ctx, enum.UnitUpdated, changedUnits[i])
+	}
+	return results, nil
+}
+
+// save persists the unit and bumps its version, a status change is recorded to the history
+// in the same transaction so the history never diverges from the unit. The status guards run
+// in that transaction on the locked unit, so what they checked cannot change before the commit
+func (u *UnitServiceImpl) save(ctx context.Context, unit *domain.Units, previousStatus enum.UnitStatus, actor, reason string) error {
+	// the hooks get the unit as it is stored, with the version bumped by the update
+	updated := *unit
+	updated.Version++
+
+	if previousStatus == unit.Status {
+		err := u.unitRepository.Transaction(ctx, func(ctx context.Context, repository unitrepository.UnitRepository) error {
+			if err := repository.Update(ctx, *unit); err != nil {
+				return err
+			}
+
+			return u.recordChange(ctx, enum.UnitUpdated, updated)
+		})
+		if err != nil {
+			return err
+		}
+
+		*unit = updated
+		u.notifyChanged(ctx, enum.UnitUpdated, *unit)
+		return nil
+	}
+
+	history := buildStatusHistory(*unit, &previousStatus, actor, reason)
+	err := u.unitRepository.Transaction(ctx, func(ctx context.Context, repository unitrepository.UnitRepository) error {
+		current, err := repository.LockByID(ctx, unit.ID.String())
+		if err != nil {
+			return lookupUnitError(err)
+		}
+		if current.Version != unit.Version {
+			return unitrepository.ErrVersionConflict
+		}
+
+		if errGuard := u.checkGuards(ctx, current, unit.Status); errGuard != nil {
+			return errGuard
+		}
+
+		if err := repository.Update(ctx, *unit); err != nil {
+			return err
+		}
+
+		if err := u.recordStatusChange(ctx, repository, updated, history); err != nil {
+			return err
+		}
+		return u.recordChange(ctx, enum.UnitUpdated, updated)
+	})
+	if err != nil {
+		return err
+	}
+
+	*unit = updated
+	u.notifyStatusChanged(ctx, *unit, history)
+	u.notifyChanged(ctx, enum.UnitUpdated, *unit)
+	return nil
+}
+
+// recordStatusChange writes the history of a status change and runs the status hooks,
+// repository must be bound to the transaction of the change
+func (u *UnitServiceImpl) recordStatusChange(ctx context.Context, repository unitrepository.UnitRepository, unit domain.Units, history domain.UnitStatusHistory) error {
+	if err := repository.CreateStatusHistory(ctx, history); err != nil {
+		return err
+	}
+
+	for _, hook := range u.statusHooks {
+		if err := hook(ctx, unit, history); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// recordChange runs the change hooks, ctx must carry the transaction of the change
+func (u *UnitServiceImpl) recordChange(ctx context.Context, eventType enum.UnitEventType, unit domain.Units) error {
+	for _, hook := range u.changeHooks {
+		if err := hook(ctx, eventType, unit); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (u *UnitServiceImpl) RegisterStatusListener(listener StatusListener) {
+	u.statusListeners = append(u.statusListeners, listener)
+}
+
+func (u *UnitServiceImpl) RegisterStatusHook(hook StatusHook) {
+	u.statusHooks = append(u.statusHooks, hook)
+}
+
+func (u *UnitServiceImpl) RegisterStatusGuard(guard StatusGuard) {
+	u.statusGuards = append(u.statusGuards, guard)
+}
+
+func (u *UnitServiceImpl) RegisterChangeListener(listener ChangeListener) {
+	u.changeListeners = append(u.changeListeners, listener)
+}
+
+func (u *UnitServiceImpl) RegisterChangeHook(hook ChangeHook) {
+	u.changeHooks = append(u.changeHooks, hook)
+}
+
+func (u *UnitServiceImpl) RegisterRejectionListener(listener RejectionListener) {
+	u.rejectListeners = append(u.rejectListeners, listener)
+}
+
+// checkStatusChange validates the move against the state machine and the registered guards
+func (u *UnitServiceImpl) checkStatusChange(ctx context.Context, unit domain.Units, to enum.UnitStatus) *handler.CustomError {
+	if errTransition := u.checkTransition(ctx, unit, to); errTransition != nil {
+		return errTransition
+	}
+
+	return u.checkGuards(ctx, unit, to)
+}
+
+// checkTransition validates the move against the state machine
+func (u *UnitServiceImpl) checkTransition(ctx context.Context, unit domain.Units, to enum.UnitStatus) *handler.CustomError {
+	if errTransition := validateStatusTransition(unit.Status, to); errTransition != nil {
+		u.notifyRejected(ctx, unit, to, errTransition)
+		return errTransition
+	}
+
+	return nil
+}
+
+// checkGuards asks the registered guards, unit should be locked by the transaction carried by ctx
+func (u *UnitServiceImpl) checkGuards(ctx context.Context, unit domain.Units, to enum.UnitStatus) *handler.CustomError {
+	for _, guard := range u.statusGuards {
+		if errGuard := guard(ctx, unit, to); errGuard != nil {
+			u.notifyRejected(ctx, unit, to, errGuard)
+			return errGuard
+		}
+	}
+
+	return nil
+}
+
+// notifyStatusChanged calls the status listeners once the transaction carried by ctx, if any, is committed
+func (u *UnitServiceImpl) notifyStatusChanged(ctx context.Context, unit domain.Units, history domain.UnitStatusHistory) {
+	transaction.AfterCommit(ctx, func(ctx context.Context) {
+		for _, listener := range u.statusListeners {
+			listener(ctx, unit, history)
+		}
+	})
+}
+
+func (u *UnitServiceImpl) notifyRejected(ctx context.Context, unit domain.Units, to enum.UnitStatus, err *handler.CustomError) {
+	for _, listener := range u.rejectListeners {
+		listener(ctx, unit, to, err)
+	}
+}
+
+// notifyChanged calls the change listeners once the transaction carried by ctx, if any, is committed
+func (u *UnitServiceImpl) notifyChanged(ctx context.Context, eventType enum.UnitEventType, unit domain.Units) {
+	transaction.AfterCommit(ctx, func(ctx context.Context) {
+		for _, listener := range u.changeListeners {
+			listener(ctx, eventType, unit)
+		}
+	})
+}
+
+func (u *UnitServiceImpl) GetTransitionsByID(ctx context.Context, id string) (response.UnitTransitionsResponse, *handler.CustomError) {
+	var responseTransitions response.UnitTransitionsResponse
+
+	unit, err := u.FindByID(ctx, id)
+	if err != nil {
+		return responseTransitions, handler.NewError(err.Code, err.Message)
+	}
+
+	return response.BuildUnitTransitionsResponseFromUnit(unit), nil
+}
+
+func (u *UnitServiceImpl) FindStatusHistory(ctx context.Context, id string, page, size int) (*dto.PaginationResponse, *handler.CustomError) {
+	unit, err := u.FindByID(ctx, id)
+	if err != nil {
+		return nil, handler.NewError(err.Code, err.Message)
+	}
+
+	histories, total, errHistory := u.unitRepository.FindStatusHistory(ctx, unit.ID.String(), page, size)
+	if errHistory != nil {
+		return nil, handler.NewError(http.StatusInternalServerError, errHistory.Error())
+	}
+
+	return dto.NewPaginationResponse(page, size, int(total), histories), nil
+}
+
+func buildStatusHistory(unit domain.Units, from *enum.UnitStatus, actor, reason string) domain.UnitStatusHistory {
+	changedAt := unit.LastUpdated
+	if changedAt.IsZero() {
+		changedAt = time.Now()
+	}
+
+	return domain.UnitStatusHistory{
+		UnitID:     unit.ID,
+		FromStatus: from,
+		ToStatus:   unit.Status,
+		Actor:      actor,
+		Reason:     reason,
+		ChangedAt:  changedAt,
+	}
+}
+
+// validateImportRow applies the same rules as CreateUnit to an imported row
+func validateImportRow(row request.ImportUnitRowDto) (domain.Units, []response.ImportRowError) {
+	rowErrors := make([]response.ImportRowError, 0)
+	addError := func(field, message string) {
+		rowErrors = append(rowErrors, response.ImportRowError{Row: row.Row, Field: field, Message: message})
+	}
+
+	name := strings.TrimSpace(row.Name)
+	if utils.IsEmptyString(name) {
+		addError("name", "unit name is required")
+	} else if len(name) > maxUnitNameLength {
+		addError("name", fmt.Sprintf("unit name must be at most %d characters", maxUnitNameLength))
+	}
+
+	unitType, isValidUnitType := enum.ParseUnitType(strings.TrimSpace(row.Type))
+	if !isValidUnitType {
+		addError("type", "invalid unit type, must be 'cabin' or 'capsule'")
+	}
+
+	status, isValidStatus := enum.ParseUnitStatus(strings.TrimSpace(row.Status))
+	if !isValidStatus {
+		addError("status", "invalid unit status, must be one of 'Available', 'Occupied', 'Cleaning In Progress', 'Maintenance Needed'")
+	} else if errTransition := validateStatusTransition("", status); errTransition != nil {
+		addError("status", errTransition.Message)
+	}
+
+	return domain.Units{Name: name, Type: unitType, Status: status, Version: 1}, rowErrors
+}
+
+// saveStatusError maps a failed save of a status change to the error returned to the client
+func saveStatusError(err error) *handler.CustomError {
+	if err == unitrepository.ErrVersionConflict {
+		return handler.NewError(http.StatusConflict, "unit has been modified by another request, please retry")
+	}
+	return handler.AsCustomError(err)
+}
+
+func lookupUnitError(err error) *handler.CustomError {
+	if err == gorm.ErrRecordNotFound {
+		return handler.NewError(http.StatusNotFound, "unit with that id was not found")
+	}
+	return handler.NewError(http.StatusInternalServerError, err.Error())
+}
+
+func trashUnitError(err error) *handler.CustomError {
+	if err == gorm.ErrRecordNotFound {
+		return handler.NewError(http.StatusNotFound, "unit with that id was not found in the trash")
+	}
+	if err == unitrepository.ErrUnitInUse {
+		return handler.NewError(http.StatusConflict, "unit still has bookings, housekeeping tasks or maintenance tickets, it can stay in the trash but cannot be purged")
+	}
+	return handler.NewError(http.StatusInternalServerError, err.Error())
+}
+
+func failedBulkResult(unitID string, from *enum.UnitStatus, err *handler.CustomError) response.BulkUnitStatusResult {
+	return response.BulkUnitStatusResult{
+		UnitID:     unitID,
+		Outcome:    response.BulkOutcomeFailed,
+		FromStatus: from,
+		Code:       err.Code,
+		Error:      err.Message,
+		Detail:     err.Data,
+	}
+}
+
+// uniqueUnitIDs drops blank and repeated ids while keeping the request order
+func uniqueUnitIDs(unitIDs []string) []string {
+	seen := make(map[string]bool, len(unitIDs))
+	result := make([]string, 0, len(unitIDs))
+	for _, id := range unitIDs {
+		id = strings.TrimSpace(id)
+		if utils.IsEmptyString(id) || seen[id] {
+			continue
+		}
+		seen[id] = true
+		result = append(result, id)
+	}
+
+	return result
+}
+
+// preconditionFailedError tells the client its copy of the unit is stale and hands back the current one
+func preconditionFailedError(current domain.Units) *handler.CustomError {
+	return handler.NewErrorWithData(http.StatusPreconditionFailed, "unit has been modified by another request, reload and retry",
+		response.BuildUnitDetailResponseFromUnit(current))
+}
+
+// validateStatusTransition checks the move against the unit status state machine,
+// an empty from status means the unit is being created
+func validateStatusTransition(from, to enum.UnitStatus) *handler.CustomError {
+	if enum.CanTransitionUnitStatus(from, to) {
+		return nil
+	}
+
+	allowed := enum.AllowedUnitStatusTransitions(from)
+	allowedNames := make([]string, 0, len(allowed))
+	for _, status := range allowed {
+		allowedNames = append(allowedNames, fmt.Sprintf("'%s'", status))
+	}
+
+	var message string
+	if from == "" {
+		message = fmt.Sprintf("unit cannot be created with status '%s', allowed: %s", to, strings.Join(allowedNames, ", "))
+	} else {
+		message = fmt.Sprintf("unit cannot transition from '%s' to '%s', allowed: %s", from, to, strings.Join(allowedNames, ", "))
+	}
+
+	return handler.NewErrorWithData(http.StatusBadRequest, message, response.UnitTransitionErrorResponse{
+		From:    from,
+		To:      to,
+		Allowed: allowed,
+	})
+}
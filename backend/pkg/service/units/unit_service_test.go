@@ -1,8 +1,11 @@
 package units
 
 import (
+	"context"
+	"fmt"
 	"net/http"
 	"testing"
+	"time"
 
 	"unit-management-be/pkg/handler"
 	"unit-management-be/pkg/model/domain"
@@ -22,31 +25,101 @@ type MockUnitRepository struct {
 	mock.Mock
 }
 
-func (m *MockUnitRepository) Create(unit domain.Units) (domain.Units, error) {
-	args := m.Called(unit)
+func (m *MockUnitRepository) Create(ctx context.Context, unit domain.Units) (domain.Units, error) {
+	args := m.Called(ctx, unit)
 	return args.Get(0).(domain.Units), args.Error(1)
 }
 
-func (m *MockUnitRepository) GetByID(id string) (domain.Units, error) {
-	args := m.Called(id)
+func (m *MockUnitRepository) GetByID(ctx context.Context, id string) (domain.Units, error) {
+	args := m.Called(ctx, id)
 	return args.Get(0).(domain.Units), args.Error(1)
 }
 
-func (m *MockUnitRepository) Delete(unit domain.Units) error {
-	args := m.Called(unit)
+func (m *MockUnitRepository) LockByID(ctx context.Context, id string) (domain.Units, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(domain.Units), args.Error(1)
+}
+
+func (m *MockUnitRepository) Delete(ctx context.Context, unit domain.Units) error {
+	args := m.Called(ctx, unit)
 	return args.Error(0)
 }
 
-func (m *MockUnitRepository) Update(unit domain.Units) error {
-	args := m.Called(unit)
+func (m *MockUnitRepository) FindAllForExport(ctx context.Context, filter request.UnitFilterDto) ([]domain.Units, error) {
+	args := m.Called(ctx, filter)
+	return args.Get(0).([]domain.Units), args.Error(1)
+}
+
+func (m *MockUnitRepository) FindAllForReport(ctx context.Context, deletedIDs []uuid.UUID) ([]domain.Units, error) {
+	args := m.Called(ctx, deletedIDs)
+	return args.Get(0).([]domain.Units), args.Error(1)
+}
+
+func (m *MockUnitRepository) Update(ctx context.Context, unit domain.Units) error {
+	args := m.Called(ctx, unit)
 	return args.Error(0)
 }
 
-func (m *MockUnitRepository) FindAll(status, unitType, name string, page, size int) ([]response.UnitDetailResponse, int64, error) {
-	args := m.Called(status, unitType, name, page, size)
+func (m *MockUnitRepository) FindAll(ctx context.Context, filter request.UnitFilterDto, page, size int) ([]response.UnitDetailResponse, int64, error) {
+	args := m.Called(ctx, filter, page, size)
 	return args.Get(0).([]response.UnitDetailResponse), args.Get(1).(int64), args.Error(2)
 }
 
+func (m *MockUnitRepository) FindAllByCursor(ctx context.Context, filter request.UnitFilterDto, cursor *request.UnitCursorDto, limit int) ([]response.UnitDetailResponse, error) {
+	args := m.Called(ctx, filter, cursor, limit)
+	return args.Get(0).([]response.UnitDetailResponse), args.Error(1)
+}
+
+func (m *MockUnitRepository) GetStats(ctx context.Context) (response.UnitStatsResponse, error) {
+	args := m.Called(ctx)
+	return args.Get(0).(response.UnitStatsResponse), args.Error(1)
+}
+
+func (m *MockUnitRepository) FindStatusHistoryBetween(ctx context.Context, from, to time.Time) ([]domain.UnitStatusHistory, error) {
+	args := m.Called(ctx, from, to)
+	return args.Get(0).([]domain.UnitStatusHistory), args.Error(1)
+}
+
+func (m *MockUnitRepository) FindDeleted(ctx context.Context, name string, page, size int) ([]response.DeletedUnitResponse, int64, error) {
+	args := m.Called(ctx, name, page, size)
+	return args.Get(0).([]response.DeletedUnitResponse), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockUnitRepository) GetDeletedByID(ctx context.Context, id string) (domain.Units, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(domain.Units), args.Error(1)
+}
+
+func (m *MockUnitRepository) Restore(ctx context.Context, unit domain.Units) error {
+	args := m.Called(ctx, unit)
+	return args.Error(0)
+}
+
+func (m *MockUnitRepository) Purge(ctx context.Context, unit domain.Units) error {
+	args := m.Called(ctx, unit)
+	return args.Error(0)
+}
+
+func (m *MockUnitRepository) PurgeDeletedBefore(ctx context.Context, cutoff time.Time) (int64, error) {
+	args := m.Called(ctx, cutoff)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockUnitRepository) CreateStatusHistory(ctx context.Context, history domain.UnitStatusHistory) error {
+	args := m.Called(ctx, history)
+	return args.Error(0)
+}
+
+func (m *MockUnitRepository) FindStatusHistory(ctx context.Context, unitID string, page, size int) ([]domain.UnitStatusHistory, int64, error) {
+	args := m.Called(ctx, unitID, page, size)
+	return args.Get(0).([]domain.UnitStatusHistory), args.Get(1).(int64), args.Error(2)
+}
+
+// Transaction runs fn against the mock itself so calls inside the transaction keep their expectations
+func (m *MockUnitRepository) Transaction(ctx context.Context, fn func(ctx context.Context, repository unitrepository.UnitRepository) error) error {
+	return fn(ctx, m)
+}
+
 var _ unitrepository.UnitRepository = &MockUnitRepository{}
 
 // initialization service and unit repository
@@ -71,9 +144,15 @@ func TestCreateUnit(t *testing.T) {
 			Type:   enum.Capsule,
 		}
 
-		mockRepo.On("Create", mock.Anything).Return(expectedUnit, nil).Once()
+		mockRepo.On("Create", mock.Anything, mock.Anything).Return(expectedUnit, nil).Once()
+		mockRepo.On("CreateStatusHistory", mock.Anything, mock.AnythingOfType("domain.UnitStatusHistory")).Return(nil).Run(func(args mock.Arguments) {
+			history := args.Get(1).(domain.UnitStatusHistory)
+			assert.Equal(t, expectedUnit.ID, history.UnitID)
+			assert.Nil(t, history.FromStatus)
+			assert.Equal(t, enum.Available, history.ToStatus)
+		}).Once()
 
-		result, err := unitService.CreateUnit(req)
+		result, err := unitService.CreateUnit(context.Background(), req)
 
 		assert.Nil(t, err)
 		assert.NotNil(t, result)
@@ -90,7 +169,7 @@ func TestCreateUnit(t *testing.T) {
 			Status: "InvalidStatus",
 			Type:   "cabin",
 		}
-		result, err := unitService.CreateUnit(req)
+		result, err := unitService.CreateUnit(context.Background(), req)
 		assert.Nil(t, result)
 		assert.NotNil(t, err)
 		assert.Equal(t, http.StatusBadRequest, err.Code)
@@ -104,7 +183,7 @@ func TestCreateUnit(t *testing.T) {
 			Status: "Available",
 			Type:   "invalid_type",
 		}
-		result, err := unitService.CreateUnit(req)
+		result, err := unitService.CreateUnit(context.Background(), req)
 		assert.Nil(t, result)
 		assert.NotNil(t, err)
 		assert.Equal(t, http.StatusBadRequest, err.Code)
@@ -120,8 +199,8 @@ func TestCreateUnit(t *testing.T) {
 		}
 		expectedUnit := domain.Units{}
 		expectedErr := handler.NewError(http.StatusInternalServerError, "database error")
-		mockRepo.On("Create", mock.Anything).Return(expectedUnit, expectedErr).Once()
-		result, err := unitService.CreateUnit(req)
+		mockRepo.On("Create", mock.Anything, mock.Anything).Return(expectedUnit, expectedErr).Once()
+		result, err := unitService.CreateUnit(context.Background(), req)
 		assert.Nil(t, result)
 		assert.NotNil(t, err)
 		assert.Equal(t, http.StatusInternalServerError, err.Code)
@@ -129,15 +208,36 @@ func TestCreateUnit(t *testing.T) {
 	})
 }
 
+func TestCreateUnitStatusTransition(t *testing.T) {
+	t.Run("Negative Case: Cannot create unit as occupied", func(t *testing.T) {
+		mockRepo, unitService := setupTest(t)
+		req := request.CreateUnitDto{
+			Name:   "Unit Test",
+			Status: "Occupied",
+			Type:   "capsule",
+		}
+
+		result, err := unitService.CreateUnit(context.Background(), req)
+		assert.Nil(t, result)
+		assert.NotNil(t, err)
+		assert.Equal(t, http.StatusBadRequest, err.Code)
+		assert.Equal(t, response.UnitTransitionErrorResponse{
+			To:      enum.Occupied,
+			Allowed: []enum.UnitStatus{enum.Available, enum.CleaningInProgress, enum.MaintenanceNeeded},
+		}, err.Data)
+		mockRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+}
+
 func TestFindByID(t *testing.T) {
 	t.Run("Positive Case: Find unit by ID successfully", func(t *testing.T) {
 		mockRepo, unitService := setupTest(t)
 		id := uuid.New().String()
 		expectedUnit := domain.Units{ID: uuid.MustParse(id)}
 
-		mockRepo.On("GetByID", id).Return(expectedUnit, nil).Once()
+		mockRepo.On("GetByID", mock.Anything, id).Return(expectedUnit, nil).Once()
 
-		result, err := unitService.FindByID(id)
+		result, err := unitService.FindByID(context.Background(), id)
 
 		assert.Nil(t, err)
 		assert.Equal(t, expectedUnit.ID, result.ID)
@@ -149,9 +249,9 @@ func TestFindByID(t *testing.T) {
 		id := uuid.New().String()
 		expectedUnit := domain.Units{}
 
-		mockRepo.On("GetByID", id).Return(expectedUnit, gorm.ErrRecordNotFound).Once()
+		mockRepo.On("GetByID", mock.Anything, id).Return(expectedUnit, gorm.ErrRecordNotFound).Once()
 
-		_, err := unitService.FindByID(id)
+		_, err := unitService.FindByID(context.Background(), id)
 
 		assert.NotNil(t, err)
 		assert.Equal(t, http.StatusNotFound, err.Code)
@@ -164,9 +264,9 @@ func TestFindByID(t *testing.T) {
 		expectedUnit := domain.Units{}
 		expectedErr := gorm.ErrInvalidDB
 
-		mockRepo.On("GetByID", id).Return(expectedUnit, expectedErr).Once()
+		mockRepo.On("GetByID", mock.Anything, id).Return(expectedUnit, expectedErr).Once()
 
-		_, err := unitService.FindByID(id)
+		_, err := unitService.FindByID(context.Background(), id)
 
 		assert.NotNil(t, err)
 		assert.Equal(t, http.StatusInternalServerError, err.Code)
@@ -186,9 +286,9 @@ func TestGetDetailByID(t *testing.T) {
 		}
 		expectedResponse := response.BuildUnitDetailResponseFromUnit(unit)
 
-		mockRepo.On("GetByID", id).Return(unit, nil).Once()
+		mockRepo.On("GetByID", mock.Anything, id).Return(unit, nil).Once()
 
-		result, err := unitService.GetDetailByID(id)
+		result, err := unitService.GetDetailByID(context.Background(), id)
 
 		assert.Nil(t, err)
 		assert.Equal(t, expectedResponse.ID, result.ID)
@@ -201,9 +301,9 @@ func TestGetDetailByID(t *testing.T) {
 		id := uuid.New().String()
 		unit := domain.Units{}
 
-		mockRepo.On("GetByID", id).Return(unit, gorm.ErrRecordNotFound).Once()
+		mockRepo.On("GetByID", mock.Anything, id).Return(unit, gorm.ErrRecordNotFound).Once()
 
-		result, err := unitService.GetDetailByID(id)
+		result, err := unitService.GetDetailByID(context.Background(), id)
 
 		assert.Equal(t, response.UnitDetailResponse{}, result)
 		assert.NotNil(t, err)
@@ -218,10 +318,11 @@ func TestDeleteByID(t *testing.T) {
 		id := uuid.New().String()
 		unit := domain.Units{ID: uuid.MustParse(id)}
 
-		mockRepo.On("GetByID", id).Return(unit, nil).Once()
-		mockRepo.On("Delete", unit).Return(nil).Once()
+		mockRepo.On("GetByID", mock.Anything, id).Return(unit, nil).Once()
+		mockRepo.On("LockByID", mock.Anything, id).Return(unit, nil).Once()
+		mockRepo.On("Delete", mock.Anything, unit).Return(nil).Once()
 
-		err := unitService.DeleteByID(id)
+		err := unitService.DeleteByID(context.Background(), id)
 
 		assert.Nil(t, err)
 		mockRepo.AssertExpectations(t)
@@ -232,9 +333,9 @@ func TestDeleteByID(t *testing.T) {
 		id := uuid.New().String()
 		unit := domain.Units{}
 
-		mockRepo.On("GetByID", id).Return(unit, gorm.ErrRecordNotFound).Once()
+		mockRepo.On("GetByID", mock.Anything, id).Return(unit, gorm.ErrRecordNotFound).Once()
 
-		err := unitService.DeleteByID(id)
+		err := unitService.DeleteByID(context.Background(), id)
 
 		assert.NotNil(t, err)
 		assert.Equal(t, http.StatusNotFound, err.Code)
@@ -247,12 +348,225 @@ func TestDeleteByID(t *testing.T) {
 		unit := domain.Units{ID: uuid.MustParse(id)}
 		expectedErr := gorm.ErrInvalidDB
 
-		mockRepo.On("GetByID", id).Return(unit, nil).Once()
-		mockRepo.On("Delete", unit).Return(expectedErr).Once()
+		mockRepo.On("GetByID", mock.Anything, id).Return(unit, nil).Once()
+		mockRepo.On("LockByID", mock.Anything, id).Return(unit, nil).Once()
+		mockRepo.On("Delete", mock.Anything, unit).Return(expectedErr).Once()
+
+		err := unitService.DeleteByID(context.Background(), id)
+
+		assert.NotNil(t, err)
+		assert.Equal(t, http.StatusInternalServerError, err.Code)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Negative Case: Unit with an active booking is not deleted", func(t *testing.T) {
+		mockRepo, unitService := setupTest(t)
+		id := uuid.New().String()
+		unit := domain.Units{ID: uuid.MustParse(id), Status: enum.Occupied}
+
+		notified := 0
+		unitService.RegisterChangeListener(func(ctx context.Context, eventType enum.UnitEventType, unit domain.Units) {
+			notified++
+		})
+
+		mockRepo.On("GetByID", mock.Anything, id).Return(unit, nil).Once()
+		mockRepo.On("LockByID", mock.Anything, id).Return(unit, nil).Once()
+		mockRepo.On("Delete", mock.Anything, unit).Return(unitrepository.ErrUnitBooked).Once()
+
+		err := unitService.DeleteByID(context.Background(), id)
+
+		assert.NotNil(t, err)
+		assert.Equal(t, http.StatusConflict, err.Code)
+		assert.Equal(t, 0, notified)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Negative Case: Unit deleted by a concurrent request", func(t *testing.T) {
+		mockRepo, unitService := setupTest(t)
+		id := uuid.New().String()
+
+		mockRepo.On("GetByID", mock.Anything, id).Return(domain.Units{ID: uuid.MustParse(id)}, nil).Once()
+		mockRepo.On("LockByID", mock.Anything, id).Return(domain.Units{}, gorm.ErrRecordNotFound).Once()
 
-		err := unitService.DeleteByID(id)
+		err := unitService.DeleteByID(context.Background(), id)
 
 		assert.NotNil(t, err)
+		assert.Equal(t, http.StatusNotFound, err.Code)
+		mockRepo.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+	})
+}
+
+func TestFindTrash(t *testing.T) {
+	t.Run("Positive Case: Find units in trash successfully", func(t *testing.T) {
+		mockRepo, unitService := setupTest(t)
+		unitsData := []response.DeletedUnitResponse{{ID: uuid.New()}}
+
+		mockRepo.On("FindDeleted", mock.Anything, "cabin", 1, 10).Return(unitsData, int64(1), nil).Once()
+
+		result, err := unitService.FindTrash(context.Background(), "cabin", 1, 10)
+
+		assert.Nil(t, err)
+		assert.Equal(t, 1, result.Pagination.Total)
+		assert.Len(t, result.Content.([]response.DeletedUnitResponse), 1)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Negative Case: Repository returns error", func(t *testing.T) {
+		mockRepo, unitService := setupTest(t)
+
+		mockRepo.On("FindDeleted", mock.Anything, "", 1, 10).Return([]response.DeletedUnitResponse{}, int64(0), gorm.ErrInvalidDB).Once()
+
+		result, err := unitService.FindTrash(context.Background(), "", 1, 10)
+
+		assert.Nil(t, result)
+		assert.Equal(t, http.StatusInternalServerError, err.Code)
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+func TestRestoreByID(t *testing.T) {
+	t.Run("Positive Case: Restore unit from trash successfully", func(t *testing.T) {
+		mockRepo, unitService := setupTest(t)
+		id := uuid.New().String()
+		unit := domain.Units{ID: uuid.MustParse(id), Status: enum.Occupied, Version: 3}
+		restored := unit
+		restored.Version = 4
+
+		mockRepo.On("GetDeletedByID", mock.Anything, id).Return(unit, nil).Once()
+		mockRepo.On("Restore", mock.Anything, unit).Return(nil).Once()
+		mockRepo.On("GetByID", mock.Anything, id).Return(restored, nil).Once()
+
+		result, err := unitService.RestoreByID(context.Background(), id)
+
+		assert.Nil(t, err)
+		assert.Equal(t, enum.Occupied, result.Status)
+		assert.Equal(t, int64(4), result.Version)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Negative Case: Unit is not in trash", func(t *testing.T) {
+		mockRepo, unitService := setupTest(t)
+		id := uuid.New().String()
+
+		mockRepo.On("GetDeletedByID", mock.Anything, id).Return(domain.Units{}, gorm.ErrRecordNotFound).Once()
+
+		result, err := unitService.RestoreByID(context.Background(), id)
+
+		assert.Nil(t, result)
+		assert.Equal(t, http.StatusNotFound, err.Code)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Negative Case: Unit was restored by another request", func(t *testing.T) {
+		mockRepo, unitService := setupTest(t)
+		id := uuid.New().String()
+		unit := domain.Units{ID: uuid.MustParse(id)}
+
+		mockRepo.On("GetDeletedByID", mock.Anything, id).Return(unit, nil).Once()
+		mockRepo.On("Restore", mock.Anything, unit).Return(gorm.ErrRecordNotFound).Once()
+
+		result, err := unitService.RestoreByID(context.Background(), id)
+
+		assert.Nil(t, result)
+		assert.Equal(t, http.StatusNotFound, err.Code)
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+func TestPurgeByID(t *testing.T) {
+	t.Run("Positive Case: Purge unit from trash successfully", func(t *testing.T) {
+		mockRepo, unitService := setupTest(t)
+		id := uuid.New().String()
+		unit := domain.Units{ID: uuid.MustParse(id)}
+
+		mockRepo.On("GetDeletedByID", mock.Anything, id).Return(unit, nil).Once()
+		mockRepo.On("Purge", mock.Anything, unit).Return(nil).Once()
+
+		err := unitService.PurgeByID(context.Background(), id)
+
+		assert.Nil(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Negative Case: Unit is not in trash", func(t *testing.T) {
+		mockRepo, unitService := setupTest(t)
+		id := uuid.New().String()
+
+		mockRepo.On("GetDeletedByID", mock.Anything, id).Return(domain.Units{}, gorm.ErrRecordNotFound).Once()
+
+		err := unitService.PurgeByID(context.Background(), id)
+
+		assert.Equal(t, http.StatusNotFound, err.Code)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Negative Case: Unit is still referenced by other records", func(t *testing.T) {
+		mockRepo, unitService := setupTest(t)
+		id := uuid.New().String()
+		unit := domain.Units{ID: uuid.MustParse(id)}
+
+		mockRepo.On("GetDeletedByID", mock.Anything, id).Return(unit, nil).Once()
+		mockRepo.On("Purge", mock.Anything, unit).Return(unitrepository.ErrUnitInUse).Once()
+
+		err := unitService.PurgeByID(context.Background(), id)
+
+		assert.Equal(t, http.StatusConflict, err.Code)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Negative Case: Repository returns error on purge", func(t *testing.T) {
+		mockRepo, unitService := setupTest(t)
+		id := uuid.New().String()
+		unit := domain.Units{ID: uuid.MustParse(id)}
+
+		mockRepo.On("GetDeletedByID", mock.Anything, id).Return(unit, nil).Once()
+		mockRepo.On("Purge", mock.Anything, unit).Return(gorm.ErrInvalidDB).Once()
+
+		err := unitService.PurgeByID(context.Background(), id)
+
+		assert.Equal(t, http.StatusInternalServerError, err.Code)
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+func TestPurgeExpired(t *testing.T) {
+	now := time.Date(2025, 10, 20, 9, 0, 0, 0, time.UTC)
+
+	t.Run("Positive Case: Purge units deleted before the retention", func(t *testing.T) {
+		mockRepo := new(MockUnitRepository)
+		unitService := &UnitServiceImpl{unitRepository: mockRepo, now: func() time.Time { return now }}
+
+		mockRepo.On("PurgeDeletedBefore", mock.Anything, now.Add(-30*24*time.Hour)).Return(int64(2), nil).Once()
+
+		purged, err := unitService.PurgeExpired(context.Background(), 30*24*time.Hour)
+
+		assert.Nil(t, err)
+		assert.Equal(t, int64(2), purged)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Positive Case: Retention job purges once before waiting", func(t *testing.T) {
+		mockRepo := new(MockUnitRepository)
+		unitService := &UnitServiceImpl{unitRepository: mockRepo, now: func() time.Time { return now }}
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		mockRepo.On("PurgeDeletedBefore", mock.Anything, now.Add(-time.Hour)).Return(int64(0), nil).Once()
+
+		RunTrashRetention(ctx, unitService, time.Hour, time.Minute)
+
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Negative Case: Repository returns error", func(t *testing.T) {
+		mockRepo := new(MockUnitRepository)
+		unitService := &UnitServiceImpl{unitRepository: mockRepo, now: func() time.Time { return now }}
+
+		mockRepo.On("PurgeDeletedBefore", mock.Anything, mock.Anything).Return(int64(0), gorm.ErrInvalidDB).Once()
+
+		purged, err := unitService.PurgeExpired(context.Background(), time.Hour)
+
+		assert.Equal(t, int64(0), purged)
 		assert.Equal(t, http.StatusInternalServerError, err.Code)
 		mockRepo.AssertExpectations(t)
 	})
@@ -261,17 +575,20 @@ func TestDeleteByID(t *testing.T) {
 func TestFindUnits(t *testing.T) {
 	t.Run("Positive Case: Find units successfully", func(t *testing.T) {
 		mockRepo, unitService := setupTest(t)
-		status := "Available"
-		unitType := "cabin"
-		name := "Unit 1"
+		filter := request.UnitFilterDto{
+			Statuses: []enum.UnitStatus{enum.Available, enum.Occupied},
+			Types:    []enum.UnitType{enum.Cabin},
+			Name:     "Unit 1",
+			Sort:     []request.UnitSortDto{{Field: enum.SortUnitLastUpdated, Descending: true}},
+		}
 		page := 1
 		size := 10
 		unitsData := []response.UnitDetailResponse{{ID: uuid.New()}, {ID: uuid.New()}}
 		totalUnit := int64(2)
 
-		mockRepo.On("FindAll", status, unitType, name, page, size).Return(unitsData, totalUnit, nil).Once()
+		mockRepo.On("FindAll", mock.Anything, filter, page, size).Return(unitsData, totalUnit, nil).Once()
 
-		result, err := unitService.FindUnits(status, unitType, name, page, size)
+		result, err := unitService.FindUnits(context.Background(), filter, page, size)
 
 		assert.Nil(t, err)
 		assert.NotNil(t, result)
@@ -284,16 +601,14 @@ func TestFindUnits(t *testing.T) {
 
 	t.Run("Negative Case: Repository returns error", func(t *testing.T) {
 		mockRepo, unitService := setupTest(t)
-		status := "Available"
-		unitType := "cabin"
-		name := ""
+		filter := request.UnitFilterDto{Statuses: []enum.UnitStatus{enum.Available}}
 		page := 1
 		size := 10
 		expectedErr := gorm.ErrInvalidDB
 
-		mockRepo.On("FindAll", status, unitType, name, page, size).Return([]response.UnitDetailResponse{}, int64(0), expectedErr).Once()
+		mockRepo.On("FindAll", mock.Anything, filter, page, size).Return([]response.UnitDetailResponse{}, int64(0), expectedErr).Once()
 
-		result, err := unitService.FindUnits(status, unitType, name, page, size)
+		result, err := unitService.FindUnits(context.Background(), filter, page, size)
 
 		assert.Nil(t, result)
 		assert.NotNil(t, err)
@@ -313,23 +628,34 @@ func TestUpdate(t *testing.T) {
 			Type:   enum.Capsule,
 		}
 		updateReq := request.UpdateUnitDto{
-			request.CreateUnitDto{
+			CreateUnitDto: request.CreateUnitDto{
 				Name:   "New Name",
 				Status: "Cleaning In Progress",
 				Type:   "cabin",
+				Actor:  "housekeeping",
 			},
+			Reason: "guest checked out",
 		}
 
-		mockRepo.On("GetByID", id).Return(oldUnit, nil).Once()
-		mockRepo.On("Update", mock.AnythingOfType("domain.Units")).Return(nil).Run(func(args mock.Arguments) {
-			argUnit := args.Get(0).(domain.Units)
+		mockRepo.On("GetByID", mock.Anything, id).Return(oldUnit, nil).Once()
+		mockRepo.On("LockByID", mock.Anything, id).Return(oldUnit, nil)
+		mockRepo.On("Update", mock.Anything, mock.AnythingOfType("domain.Units")).Return(nil).Run(func(args mock.Arguments) {
+			argUnit := args.Get(1).(domain.Units)
 			assert.Equal(t, updateReq.Name, argUnit.Name)
 			assert.Equal(t, enum.CleaningInProgress, argUnit.Status)
 			assert.Equal(t, enum.Cabin, argUnit.Type)
 			assert.NotZero(t, argUnit.LastUpdated)
 		}).Once()
+		mockRepo.On("CreateStatusHistory", mock.Anything, mock.AnythingOfType("domain.UnitStatusHistory")).Return(nil).Run(func(args mock.Arguments) {
+			history := args.Get(1).(domain.UnitStatusHistory)
+			assert.Equal(t, oldUnit.ID, history.UnitID)
+			assert.Equal(t, enum.Available, *history.FromStatus)
+			assert.Equal(t, enum.CleaningInProgress, history.ToStatus)
+			assert.Equal(t, "housekeeping", history.Actor)
+			assert.Equal(t, "guest checked out", history.Reason)
+		}).Once()
 
-		result, err := unitService.Update(id, updateReq)
+		result, err := unitService.Update(context.Background(), id, updateReq)
 		assert.Nil(t, err)
 		assert.NotNil(t, result)
 		assert.Equal(t, updateReq.Name, result.Name)
@@ -337,15 +663,37 @@ func TestUpdate(t *testing.T) {
 		mockRepo.AssertExpectations(t)
 	})
 
+	t.Run("Positive Case: Unit and its history are stamped with the service clock", func(t *testing.T) {
+		now := time.Date(2025, 10, 20, 9, 0, 0, 0, time.UTC)
+		mockRepo := new(MockUnitRepository)
+		unitService := &UnitServiceImpl{unitRepository: mockRepo, now: func() time.Time { return now }}
+		unit := domain.Units{ID: uuid.New(), Name: "Unit", Status: enum.Available, Type: enum.Capsule}
+		updateReq := request.UpdateUnitDto{CreateUnitDto: request.CreateUnitDto{Name: "Unit", Status: "Occupied", Type: "capsule"}}
+
+		mockRepo.On("GetByID", mock.Anything, unit.ID.String()).Return(unit, nil).Once()
+		mockRepo.On("LockByID", mock.Anything, unit.ID.String()).Return(unit, nil)
+		mockRepo.On("Update", mock.Anything, mock.MatchedBy(func(updated domain.Units) bool {
+			return updated.LastUpdated.Equal(now)
+		})).Return(nil).Once()
+		mockRepo.On("CreateStatusHistory", mock.Anything, mock.MatchedBy(func(history domain.UnitStatusHistory) bool {
+			return history.ChangedAt.Equal(now)
+		})).Return(nil).Once()
+
+		result, err := unitService.Update(context.Background(), unit.ID.String(), updateReq)
+		assert.Nil(t, err)
+		assert.Equal(t, now, result.LastUpdated)
+		mockRepo.AssertExpectations(t)
+	})
+
 	t.Run("Negative Case: Unit to update not found", func(t *testing.T) {
 		mockRepo, unitService := setupTest(t)
 		id := uuid.New().String()
 		updateReq := request.UpdateUnitDto{}
 		unit := domain.Units{}
 
-		mockRepo.On("GetByID", id).Return(unit, gorm.ErrRecordNotFound).Once()
+		mockRepo.On("GetByID", mock.Anything, id).Return(unit, gorm.ErrRecordNotFound).Once()
 
-		result, err := unitService.Update(id, updateReq)
+		result, err := unitService.Update(context.Background(), id, updateReq)
 		assert.Nil(t, result)
 		assert.NotNil(t, err)
 		assert.Equal(t, http.StatusNotFound, err.Code)
@@ -357,12 +705,12 @@ func TestUpdate(t *testing.T) {
 		id := uuid.New().String()
 		oldUnit := domain.Units{ID: uuid.MustParse(id)}
 		updateReq := request.UpdateUnitDto{
-			request.CreateUnitDto{Status: "InvalidStatus"},
+			CreateUnitDto: request.CreateUnitDto{Status: "InvalidStatus"},
 		}
 
-		mockRepo.On("GetByID", id).Return(oldUnit, nil).Once()
+		mockRepo.On("GetByID", mock.Anything, id).Return(oldUnit, nil).Once()
 
-		result, err := unitService.Update(id, updateReq)
+		result, err := unitService.Update(context.Background(), id, updateReq)
 		assert.Nil(t, result)
 		assert.NotNil(t, err)
 		assert.Equal(t, http.StatusBadRequest, err.Code)
@@ -374,11 +722,11 @@ func TestUpdate(t *testing.T) {
 		id := uuid.New().String()
 		oldUnit := domain.Units{ID: uuid.MustParse(id)}
 		updateReq := request.UpdateUnitDto{
-			request.CreateUnitDto{Status: "Available",
+			CreateUnitDto: request.CreateUnitDto{Status: "Available",
 				Type: "invalid_type"},
 		}
-		mockRepo.On("GetByID", id).Return(oldUnit, nil).Once()
-		result, err := unitService.Update(id, updateReq)
+		mockRepo.On("GetByID", mock.Anything, id).Return(oldUnit, nil).Once()
+		result, err := unitService.Update(context.Background(), id, updateReq)
 		assert.Nil(t, result)
 		assert.NotNil(t, err)
 		assert.Equal(t, http.StatusBadRequest, err.Code)
@@ -394,14 +742,35 @@ func TestUpdate(t *testing.T) {
 			Type:   enum.Cabin,
 		}
 		updateReq := request.UpdateUnitDto{
-			request.CreateUnitDto{Status: "Available", Type: "cabin"},
+			CreateUnitDto: request.CreateUnitDto{Status: "Available", Type: "cabin"},
 		}
-		mockRepo.On("GetByID", id).Return(oldUnit, nil).Once()
-		result, err := unitService.Update(id, updateReq)
+		mockRepo.On("GetByID", mock.Anything, id).Return(oldUnit, nil).Once()
+		result, err := unitService.Update(context.Background(), id, updateReq)
 		assert.Nil(t, result)
 		assert.NotNil(t, err)
 		assert.Equal(t, http.StatusBadRequest, err.Code)
-		assert.Equal(t, "unit cannot go directly from occupied to available", err.Message)
+		assert.Equal(t, "unit cannot transition from 'Occupied' to 'Available', allowed: 'Cleaning In Progress', 'Maintenance Needed'", err.Message)
+		assert.Equal(t, response.UnitTransitionErrorResponse{
+			From:    enum.Occupied,
+			To:      enum.Available,
+			Allowed: []enum.UnitStatus{enum.CleaningInProgress, enum.MaintenanceNeeded},
+		}, err.Data)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Positive Case: Keep same status while renaming unit", func(t *testing.T) {
+		mockRepo, unitService := setupTest(t)
+		id := uuid.New().String()
+		oldUnit := domain.Units{ID: uuid.MustParse(id), Name: "Old Name", Status: enum.Occupied, Type: enum.Cabin}
+		updateReq := request.UpdateUnitDto{CreateUnitDto: request.CreateUnitDto{Name: "New Name", Status: "Occupied", Type: "cabin"}}
+
+		mockRepo.On("GetByID", mock.Anything, id).Return(oldUnit, nil).Once()
+		mockRepo.On("Update", mock.Anything, mock.Anything).Return(nil).Once()
+
+		result, err := unitService.Update(context.Background(), id, updateReq)
+		assert.Nil(t, err)
+		assert.Equal(t, "New Name", result.Name)
+		assert.Equal(t, enum.Occupied, result.Status)
 		mockRepo.AssertExpectations(t)
 	})
 
@@ -409,16 +778,939 @@ func TestUpdate(t *testing.T) {
 		mockRepo, unitService := setupTest(t)
 		id := uuid.New().String()
 		oldUnit := domain.Units{ID: uuid.MustParse(id), Status: enum.Available, Type: enum.Cabin}
-		updateReq := request.UpdateUnitDto{request.CreateUnitDto{Status: "Cleaning In Progress", Type: "cabin"}}
+		updateReq := request.UpdateUnitDto{CreateUnitDto: request.CreateUnitDto{Status: "Cleaning In Progress", Type: "cabin"}}
 		expectedErr := gorm.ErrInvalidDB
 
-		mockRepo.On("GetByID", id).Return(oldUnit, nil).Once()
-		mockRepo.On("Update", mock.Anything).Return(expectedErr).Once()
+		mockRepo.On("GetByID", mock.Anything, id).Return(oldUnit, nil).Once()
+		mockRepo.On("LockByID", mock.Anything, id).Return(oldUnit, nil)
+		mockRepo.On("Update", mock.Anything, mock.Anything).Return(expectedErr).Once()
 
-		result, err := unitService.Update(id, updateReq)
+		result, err := unitService.Update(context.Background(), id, updateReq)
 		assert.Nil(t, result)
 		assert.NotNil(t, err)
 		assert.Equal(t, http.StatusInternalServerError, err.Code)
 		mockRepo.AssertExpectations(t)
 	})
 }
+
+func TestUpdateVersion(t *testing.T) {
+	id := uuid.New().String()
+	updateReq := func(expectedVersion int64) request.UpdateUnitDto {
+		return request.UpdateUnitDto{
+			CreateUnitDto:   request.CreateUnitDto{Name: "Renamed", Status: "Available", Type: "capsule"},
+			ExpectedVersion: &expectedVersion,
+		}
+	}
+
+	t.Run("Positive Case: Matching version is bumped", func(t *testing.T) {
+		mockRepo, unitService := setupTest(t)
+		unit := domain.Units{ID: uuid.MustParse(id), Name: "Unit", Status: enum.Available, Type: enum.Capsule, Version: 3}
+
+		mockRepo.On("GetByID", mock.Anything, id).Return(unit, nil).Once()
+		mockRepo.On("Update", mock.Anything, mock.MatchedBy(func(updated domain.Units) bool {
+			return updated.Version == 3 && updated.Name == "Renamed"
+		})).Return(nil).Once()
+
+		result, err := unitService.Update(context.Background(), id, updateReq(3))
+		assert.Nil(t, err)
+		assert.Equal(t, int64(4), result.Version)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Negative Case: Stale If-Match returns current unit", func(t *testing.T) {
+		mockRepo, unitService := setupTest(t)
+		unit := domain.Units{ID: uuid.MustParse(id), Name: "Unit", Status: enum.Available, Type: enum.Capsule, Version: 5}
+
+		mockRepo.On("GetByID", mock.Anything, id).Return(unit, nil).Once()
+
+		result, err := unitService.Update(context.Background(), id, updateReq(4))
+		assert.Nil(t, result)
+		assert.Equal(t, http.StatusPreconditionFailed, err.Code)
+		assert.Equal(t, response.BuildUnitDetailResponseFromUnit(unit), err.Data)
+		mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Negative Case: Unit changed between read and write", func(t *testing.T) {
+		mockRepo, unitService := setupTest(t)
+		unit := domain.Units{ID: uuid.MustParse(id), Name: "Unit", Status: enum.Available, Type: enum.Capsule, Version: 2}
+		current := unit
+		current.Name = "Changed by someone else"
+		current.Version = 3
+
+		mockRepo.On("GetByID", mock.Anything, id).Return(unit, nil).Once()
+		mockRepo.On("Update", mock.Anything, mock.Anything).Return(unitrepository.ErrVersionConflict).Once()
+		mockRepo.On("GetByID", mock.Anything, id).Return(current, nil).Once()
+
+		result, err := unitService.Update(context.Background(), id, updateReq(2))
+		assert.Nil(t, result)
+		assert.Equal(t, http.StatusPreconditionFailed, err.Code)
+		assert.Equal(t, response.BuildUnitDetailResponseFromUnit(current), err.Data)
+	})
+
+	t.Run("Positive Case: Wildcard If-Match skips the version check", func(t *testing.T) {
+		mockRepo, unitService := setupTest(t)
+		unit := domain.Units{ID: uuid.MustParse(id), Name: "Unit", Status: enum.Available, Type: enum.Capsule, Version: 7}
+
+		mockRepo.On("GetByID", mock.Anything, id).Return(unit, nil).Once()
+		mockRepo.On("Update", mock.Anything, mock.Anything).Return(nil).Once()
+
+		result, err := unitService.Update(context.Background(), id, request.UpdateUnitDto{CreateUnitDto: request.CreateUnitDto{Name: "Renamed", Status: "Available", Type: "capsule"}})
+		assert.Nil(t, err)
+		assert.Equal(t, int64(8), result.Version)
+	})
+
+	t.Run("Negative Case: Concurrent status change conflicts", func(t *testing.T) {
+		mockRepo, unitService := setupTest(t)
+		unit := domain.Units{ID: uuid.MustParse(id), Status: enum.Available, Version: 1}
+
+		mockRepo.On("GetByID", mock.Anything, id).Return(unit, nil).Once()
+		mockRepo.On("LockByID", mock.Anything, id).Return(unit, nil)
+		mockRepo.On("Update", mock.Anything, mock.Anything).Return(unitrepository.ErrVersionConflict).Once()
+
+		result, err := unitService.ChangeStatus(context.Background(), id, request.ChangeUnitStatusDto{Status: "Cleaning In Progress"})
+		assert.Nil(t, result)
+		assert.Equal(t, http.StatusConflict, err.Code)
+		mockRepo.AssertNotCalled(t, "CreateStatusHistory", mock.Anything, mock.Anything)
+	})
+}
+
+func TestPatch(t *testing.T) {
+	id := uuid.New().String()
+	newUnit := func() domain.Units {
+		return domain.Units{ID: uuid.MustParse(id), Name: "Capsule 1", Status: enum.Occupied, Type: enum.Capsule, Version: 4}
+	}
+	mergePatch := func(patch string) request.PatchUnitDto {
+		return request.PatchUnitDto{Format: string(enum.MergePatch), Patch: []byte(patch), Actor: "housekeeper"}
+	}
+	jsonPatch := func(patch string) request.PatchUnitDto {
+		return request.PatchUnitDto{Format: string(enum.JSONPatch), Patch: []byte(patch), Actor: "housekeeper"}
+	}
+
+	t.Run("Positive Case: Merge patch only changes the given fields", func(t *testing.T) {
+		mockRepo, unitService := setupTest(t)
+
+		mockRepo.On("GetByID", mock.Anything, id).Return(newUnit(), nil).Once()
+		mockRepo.On("LockByID", mock.Anything, id).Return(newUnit(), nil)
+		mockRepo.On("Update", mock.Anything, mock.MatchedBy(func(updated domain.Units) bool {
+			return updated.Name == "Capsule 1" && updated.Type == enum.Capsule &&
+				updated.Status == enum.CleaningInProgress && updated.Version == 4
+		})).Return(nil).Once()
+		mockRepo.On("CreateStatusHistory", mock.Anything, mock.MatchedBy(func(history domain.UnitStatusHistory) bool {
+			return history.Actor == "housekeeper" && history.Reason == "guest checked out"
+		})).Return(nil).Once()
+
+		result, err := unitService.Patch(context.Background(), id, mergePatch(`{"status": "Cleaning In Progress", "reason": "guest checked out"}`))
+		assert.Nil(t, err)
+		assert.Equal(t, enum.CleaningInProgress, result.Status)
+		assert.Equal(t, int64(5), result.Version)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Positive Case: JSON patch with a passing test operation", func(t *testing.T) {
+		mockRepo, unitService := setupTest(t)
+
+		mockRepo.On("GetByID", mock.Anything, id).Return(newUnit(), nil).Once()
+		mockRepo.On("Update", mock.Anything, mock.MatchedBy(func(updated domain.Units) bool {
+			return updated.Name == "Capsule 1A" && updated.Status == enum.Occupied
+		})).Return(nil).Once()
+
+		result, err := unitService.Patch(context.Background(), id, jsonPatch(`[
+			{"op": "test", "path": "/version", "value": 4},
+			{"op": "replace", "path": "/name", "value": "Capsule 1A"}
+		]`))
+		assert.Nil(t, err)
+		assert.Equal(t, "Capsule 1A", result.Name)
+		mockRepo.AssertNotCalled(t, "CreateStatusHistory", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Positive Case: Patch is reapplied when the unit changed without If-Match", func(t *testing.T) {
+		mockRepo, unitService := setupTest(t)
+		current := newUnit()
+		current.Name = "Renamed by someone else"
+		current.Version = 5
+
+		// the locked unit is already newer than the one the patch was applied to
+		mockRepo.On("GetByID", mock.Anything, id).Return(newUnit(), nil).Once()
+		mockRepo.On("LockByID", mock.Anything, id).Return(current, nil).Twice()
+		mockRepo.On("GetByID", mock.Anything, id).Return(current, nil).Twice()
+		mockRepo.On("Update", mock.Anything, mock.MatchedBy(func(updated domain.Units) bool {
+			return updated.Version == 5 && updated.Name == "Renamed by someone else" && updated.Status == enum.CleaningInProgress
+		})).Return(nil).Once()
+		mockRepo.On("CreateStatusHistory", mock.Anything, mock.Anything).Return(nil).Once()
+
+		result, err := unitService.Patch(context.Background(), id, mergePatch(`{"status": "Cleaning In Progress"}`))
+		assert.Nil(t, err)
+		assert.Equal(t, int64(6), result.Version)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Negative Case: Stale If-Match returns current unit", func(t *testing.T) {
+		mockRepo, unitService := setupTest(t)
+		expectedVersion := int64(3)
+		patch := mergePatch(`{"status": "Cleaning In Progress"}`)
+		patch.ExpectedVersion = &expectedVersion
+
+		mockRepo.On("GetByID", mock.Anything, id).Return(newUnit(), nil).Once()
+
+		result, err := unitService.Patch(context.Background(), id, patch)
+		assert.Nil(t, result)
+		assert.Equal(t, http.StatusPreconditionFailed, err.Code)
+		assert.Equal(t, response.BuildUnitDetailResponseFromUnit(newUnit()), err.Data)
+		mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Negative Case: Status transition rules still apply", func(t *testing.T) {
+		mockRepo, unitService := setupTest(t)
+
+		mockRepo.On("GetByID", mock.Anything, id).Return(newUnit(), nil).Once()
+
+		result, err := unitService.Patch(context.Background(), id, mergePatch(`{"status": "Available"}`))
+		assert.Nil(t, result)
+		assert.Equal(t, http.StatusBadRequest, err.Code)
+		assert.IsType(t, response.UnitTransitionErrorResponse{}, err.Data)
+		mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Negative Case: Invalid patch documents", func(t *testing.T) {
+		testCases := []struct {
+			name  string
+			patch request.PatchUnitDto
+			code  int
+		}{
+			{"unsupported format", request.PatchUnitDto{Format: "text/plain", Patch: []byte(`{}`)}, http.StatusUnsupportedMediaType},
+			{"malformed merge patch", mergePatch(`{"status": `), http.StatusBadRequest},
+			{"malformed json patch", jsonPatch(`{"op": "replace"}`), http.StatusBadRequest},
+			{"invalid type", mergePatch(`{"type": "suite"}`), http.StatusBadRequest},
+			{"empty name", mergePatch(`{"name": " "}`), http.StatusBadRequest},
+			{"removed name", mergePatch(`{"name": null}`), http.StatusUnprocessableEntity},
+			{"unknown field", mergePatch(`{"floor": 2}`), http.StatusUnprocessableEntity},
+			{"wrong value type", mergePatch(`{"name": 12}`), http.StatusUnprocessableEntity},
+			{"read-only version", mergePatch(`{"version": 9}`), http.StatusUnprocessableEntity},
+			{"missing path", jsonPatch(`[{"op": "replace", "path": "/floor", "value": 2}]`), http.StatusUnprocessableEntity},
+			{"failed test operation", jsonPatch(`[{"op": "test", "path": "/status", "value": "Available"}]`), http.StatusConflict},
+		}
+
+		for _, testCase := range testCases {
+			mockRepo, unitService := setupTest(t)
+			mockRepo.On("GetByID", mock.Anything, id).Return(newUnit(), nil).Once()
+
+			result, err := unitService.Patch(context.Background(), id, testCase.patch)
+			assert.Nil(t, result, testCase.name)
+			if assert.NotNil(t, err, testCase.name) {
+				assert.Equal(t, testCase.code, err.Code, testCase.name)
+			}
+			mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+		}
+	})
+
+	t.Run("Negative Case: Status only patch cannot rename the unit", func(t *testing.T) {
+		mockRepo, unitService := setupTest(t)
+		patch := mergePatch(`{"name": "Capsule 2", "status": "Cleaning In Progress"}`)
+		patch.StatusOnly = true
+
+		mockRepo.On("GetByID", mock.Anything, id).Return(newUnit(), nil).Once()
+
+		result, err := unitService.Patch(context.Background(), id, patch)
+		assert.Nil(t, result)
+		assert.Equal(t, http.StatusForbidden, err.Code)
+		mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+}
+
+func TestGetTransitionsByID(t *testing.T) {
+	t.Run("Positive Case: Get allowed transitions of unit", func(t *testing.T) {
+		mockRepo, unitService := setupTest(t)
+		id := uuid.New().String()
+		unit := domain.Units{ID: uuid.MustParse(id), Status: enum.CleaningInProgress, Type: enum.Capsule}
+
+		mockRepo.On("GetByID", mock.Anything, id).Return(unit, nil).Once()
+
+		result, err := unitService.GetTransitionsByID(context.Background(), id)
+		assert.Nil(t, err)
+		assert.Equal(t, unit.ID, result.ID)
+		assert.Equal(t, enum.CleaningInProgress, result.Status)
+		assert.Equal(t, []enum.UnitStatus{enum.Available, enum.MaintenanceNeeded}, result.AllowedTransitions)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Negative Case: Unit not found", func(t *testing.T) {
+		mockRepo, unitService := setupTest(t)
+		id := uuid.New().String()
+
+		mockRepo.On("GetByID", mock.Anything, id).Return(domain.Units{}, gorm.ErrRecordNotFound).Once()
+
+		_, err := unitService.GetTransitionsByID(context.Background(), id)
+		assert.NotNil(t, err)
+		assert.Equal(t, http.StatusNotFound, err.Code)
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+func TestFindStatusHistory(t *testing.T) {
+	t.Run("Positive Case: Find unit status history successfully", func(t *testing.T) {
+		mockRepo, unitService := setupTest(t)
+		id := uuid.New().String()
+		unit := domain.Units{ID: uuid.MustParse(id), Status: enum.MaintenanceNeeded}
+		from := enum.Available
+		histories := []domain.UnitStatusHistory{{ID: uuid.New(), UnitID: unit.ID, FromStatus: &from, ToStatus: enum.MaintenanceNeeded, Actor: "ops"}}
+
+		mockRepo.On("GetByID", mock.Anything, id).Return(unit, nil).Once()
+		mockRepo.On("FindStatusHistory", mock.Anything, id, 1, 10).Return(histories, int64(1), nil).Once()
+
+		result, err := unitService.FindStatusHistory(context.Background(), id, 1, 10)
+		assert.Nil(t, err)
+		assert.Equal(t, 1, result.Pagination.Total)
+		assert.Equal(t, histories, result.Content)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Negative Case: Unit not found", func(t *testing.T) {
+		mockRepo, unitService := setupTest(t)
+		id := uuid.New().String()
+
+		mockRepo.On("GetByID", mock.Anything, id).Return(domain.Units{}, gorm.ErrRecordNotFound).Once()
+
+		result, err := unitService.FindStatusHistory(context.Background(), id, 1, 10)
+		assert.Nil(t, result)
+		assert.Equal(t, http.StatusNotFound, err.Code)
+		mockRepo.AssertNotCalled(t, "FindStatusHistory", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Negative Case: Status history is not recorded when update fails", func(t *testing.T) {
+		mockRepo, unitService := setupTest(t)
+		id := uuid.New().String()
+		oldUnit := domain.Units{ID: uuid.MustParse(id), Status: enum.Available, Type: enum.Cabin}
+		updateReq := request.UpdateUnitDto{CreateUnitDto: request.CreateUnitDto{Status: "Occupied", Type: "cabin"}}
+
+		mockRepo.On("GetByID", mock.Anything, id).Return(oldUnit, nil).Once()
+		mockRepo.On("LockByID", mock.Anything, id).Return(oldUnit, nil)
+		mockRepo.On("Update", mock.Anything, mock.Anything).Return(gorm.ErrInvalidDB).Once()
+
+		result, err := unitService.Update(context.Background(), id, updateReq)
+		assert.Nil(t, result)
+		assert.Equal(t, http.StatusInternalServerError, err.Code)
+		mockRepo.AssertNotCalled(t, "CreateStatusHistory", mock.Anything, mock.Anything)
+	})
+}
+
+func TestChangeStatus(t *testing.T) {
+	t.Run("Positive Case: Change status and record history", func(t *testing.T) {
+		mockRepo, unitService := setupTest(t)
+		id := uuid.New().String()
+		oldUnit := domain.Units{ID: uuid.MustParse(id), Name: "C-12", Status: enum.Available, Type: enum.Capsule}
+
+		mockRepo.On("GetByID", mock.Anything, id).Return(oldUnit, nil).Once()
+		mockRepo.On("LockByID", mock.Anything, id).Return(oldUnit, nil)
+		mockRepo.On("Update", mock.Anything, mock.MatchedBy(func(unit domain.Units) bool {
+			return unit.Status == enum.Occupied && unit.Name == "C-12"
+		})).Return(nil).Once()
+		mockRepo.On("CreateStatusHistory", mock.Anything, mock.MatchedBy(func(history domain.UnitStatusHistory) bool {
+			return *history.FromStatus == enum.Available && history.ToStatus == enum.Occupied && history.Actor == "front desk"
+		})).Return(nil).Once()
+
+		result, err := unitService.ChangeStatus(context.Background(), id, request.ChangeUnitStatusDto{Status: "Occupied", Actor: "front desk"})
+		assert.Nil(t, err)
+		assert.Equal(t, enum.Occupied, result.Status)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Negative Case: Status is unchanged", func(t *testing.T) {
+		mockRepo, unitService := setupTest(t)
+		id := uuid.New().String()
+		oldUnit := domain.Units{ID: uuid.MustParse(id), Status: enum.Occupied}
+
+		mockRepo.On("GetByID", mock.Anything, id).Return(oldUnit, nil).Once()
+
+		result, err := unitService.ChangeStatus(context.Background(), id, request.ChangeUnitStatusDto{Status: "Occupied"})
+		assert.Nil(t, result)
+		assert.Equal(t, http.StatusConflict, err.Code)
+		mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Negative Case: Transition not allowed", func(t *testing.T) {
+		mockRepo, unitService := setupTest(t)
+		id := uuid.New().String()
+		oldUnit := domain.Units{ID: uuid.MustParse(id), Status: enum.MaintenanceNeeded}
+
+		mockRepo.On("GetByID", mock.Anything, id).Return(oldUnit, nil).Once()
+
+		result, err := unitService.ChangeStatus(context.Background(), id, request.ChangeUnitStatusDto{Status: "Occupied"})
+		assert.Nil(t, result)
+		assert.Equal(t, http.StatusBadRequest, err.Code)
+		mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+}
+
+func TestStatusListener(t *testing.T) {
+	t.Run("Positive Case: Listener notified after status change", func(t *testing.T) {
+		mockRepo, unitService := setupTest(t)
+		id := uuid.New().String()
+		oldUnit := domain.Units{ID: uuid.MustParse(id), Status: enum.Occupied}
+
+		var notified []domain.UnitStatusHistory
+		unitService.RegisterStatusListener(func(ctx context.Context, unit domain.Units, history domain.UnitStatusHistory) {
+			notified = append(notified, history)
+		})
+
+		mockRepo.On("GetByID", mock.Anything, id).Return(oldUnit, nil).Once()
+		mockRepo.On("LockByID", mock.Anything, id).Return(oldUnit, nil)
+		mockRepo.On("Update", mock.Anything, mock.Anything).Return(nil).Once()
+		mockRepo.On("CreateStatusHistory", mock.Anything, mock.Anything).Return(nil).Once()
+
+		_, err := unitService.ChangeStatus(context.Background(), id, request.ChangeUnitStatusDto{Status: "Cleaning In Progress"})
+		assert.Nil(t, err)
+		assert.Len(t, notified, 1)
+		assert.Equal(t, enum.CleaningInProgress, notified[0].ToStatus)
+	})
+
+	t.Run("Negative Case: Listener not notified when status is unchanged or save fails", func(t *testing.T) {
+		mockRepo, unitService := setupTest(t)
+		id := uuid.New().String()
+		oldUnit := domain.Units{ID: uuid.MustParse(id), Status: enum.Occupied, Type: enum.Cabin}
+
+		notified := 0
+		unitService.RegisterStatusListener(func(ctx context.Context, unit domain.Units, history domain.UnitStatusHistory) {
+			notified++
+		})
+
+		mockRepo.On("GetByID", mock.Anything, id).Return(oldUnit, nil).Twice()
+		mockRepo.On("LockByID", mock.Anything, id).Return(oldUnit, nil)
+		mockRepo.On("Update", mock.Anything, mock.Anything).Return(nil).Once()
+		_, err := unitService.Update(context.Background(), id, request.UpdateUnitDto{CreateUnitDto: request.CreateUnitDto{Name: "Renamed", Status: "Occupied", Type: "cabin"}})
+		assert.Nil(t, err)
+
+		mockRepo.On("Update", mock.Anything, mock.Anything).Return(gorm.ErrInvalidDB).Once()
+		_, err = unitService.ChangeStatus(context.Background(), id, request.ChangeUnitStatusDto{Status: "Cleaning In Progress"})
+		assert.NotNil(t, err)
+		assert.Equal(t, 0, notified)
+	})
+}
+
+func TestStatusHook(t *testing.T) {
+	t.Run("Positive Case: Hook runs with the status change", func(t *testing.T) {
+		mockRepo, unitService := setupTest(t)
+		id := uuid.New().String()
+		oldUnit := domain.Units{ID: uuid.MustParse(id), Status: enum.Occupied}
+
+		var hooked []domain.UnitStatusHistory
+		unitService.RegisterStatusHook(func(ctx context.Context, unit domain.Units, history domain.UnitStatusHistory) error {
+			hooked = append(hooked, history)
+			return nil
+		})
+
+		mockRepo.On("GetByID", mock.Anything, id).Return(oldUnit, nil).Once()
+		mockRepo.On("LockByID", mock.Anything, id).Return(oldUnit, nil)
+		mockRepo.On("Update", mock.Anything, mock.Anything).Return(nil).Once()
+		mockRepo.On("CreateStatusHistory", mock.Anything, mock.Anything).Return(nil).Once()
+
+		_, err := unitService.ChangeStatus(context.Background(), id, request.ChangeUnitStatusDto{Status: "Cleaning In Progress"})
+		assert.Nil(t, err)
+		assert.Len(t, hooked, 1)
+		assert.Equal(t, enum.CleaningInProgress, hooked[0].ToStatus)
+	})
+
+	t.Run("Negative Case: Failing hook fails the status change and skips the listeners", func(t *testing.T) {
+		mockRepo, unitService := setupTest(t)
+		id := uuid.New().String()
+		oldUnit := domain.Units{ID: uuid.MustParse(id), Status: enum.Occupied}
+
+		notified := 0
+		unitService.RegisterStatusHook(func(ctx context.Context, unit domain.Units, history domain.UnitStatusHistory) error {
+			return gorm.ErrInvalidDB
+		})
+		unitService.RegisterStatusListener(func(ctx context.Context, unit domain.Units, history domain.UnitStatusHistory) {
+			notified++
+		})
+
+		mockRepo.On("GetByID", mock.Anything, id).Return(oldUnit, nil).Once()
+		mockRepo.On("LockByID", mock.Anything, id).Return(oldUnit, nil)
+		mockRepo.On("Update", mock.Anything, mock.Anything).Return(nil).Once()
+		mockRepo.On("CreateStatusHistory", mock.Anything, mock.Anything).Return(nil).Once()
+
+		result, err := unitService.ChangeStatus(context.Background(), id, request.ChangeUnitStatusDto{Status: "Cleaning In Progress"})
+		assert.Nil(t, result)
+		assert.Equal(t, http.StatusInternalServerError, err.Code)
+		assert.Equal(t, 0, notified)
+	})
+}
+
+func TestChangeHook(t *testing.T) {
+	t.Run("Positive Case: Hook runs in the change with the stored version", func(t *testing.T) {
+		mockRepo, unitService := setupTest(t)
+		id := uuid.New().String()
+		unit := domain.Units{ID: uuid.MustParse(id), Name: "Cabin 1", Status: enum.Available, Type: enum.Cabin, Version: 1}
+
+		var hooked []int64
+		unitService.RegisterChangeHook(func(ctx context.Context, eventType enum.UnitEventType, unit domain.Units) error {
+			hooked = append(hooked, unit.Version)
+			return nil
+		})
+
+		mockRepo.On("GetByID", mock.Anything, id).Return(unit, nil).Twice()
+		mockRepo.On("LockByID", mock.Anything, id).Return(unit, nil)
+		mockRepo.On("Update", mock.Anything, mock.Anything).Return(nil).Twice()
+		mockRepo.On("CreateStatusHistory", mock.Anything, mock.Anything).Return(nil).Once()
+		_, err := unitService.Update(context.Background(), id, request.UpdateUnitDto{CreateUnitDto: request.CreateUnitDto{Name: "Cabin 2", Status: "Available", Type: "cabin"}})
+		assert.Nil(t, err)
+		_, err = unitService.ChangeStatus(context.Background(), id, request.ChangeUnitStatusDto{Status: "Occupied"})
+		assert.Nil(t, err)
+
+		assert.Equal(t, []int64{2, 2}, hooked)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Negative Case: Failing hook fails the change and skips the listeners", func(t *testing.T) {
+		mockRepo, unitService := setupTest(t)
+		id := uuid.New().String()
+		unit := domain.Units{ID: uuid.MustParse(id), Status: enum.Occupied, Type: enum.Cabin}
+
+		notified := 0
+		unitService.RegisterChangeHook(func(ctx context.Context, eventType enum.UnitEventType, unit domain.Units) error {
+			return gorm.ErrInvalidDB
+		})
+		unitService.RegisterChangeListener(func(ctx context.Context, eventType enum.UnitEventType, unit domain.Units) {
+			notified++
+		})
+
+		mockRepo.On("GetByID", mock.Anything, id).Return(unit, nil).Once()
+		mockRepo.On("LockByID", mock.Anything, id).Return(unit, nil).Once()
+		mockRepo.On("Delete", mock.Anything, unit).Return(nil).Once()
+		err := unitService.DeleteByID(context.Background(), id)
+		assert.Equal(t, http.StatusInternalServerError, err.Code)
+
+		assert.Equal(t, 0, notified)
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+func TestChangeListener(t *testing.T) {
+	type change struct {
+		eventType enum.UnitEventType
+		version   int64
+	}
+
+	t.Run("Positive Case: Listener notified after unit changes", func(t *testing.T) {
+		mockRepo, unitService := setupTest(t)
+		id := uuid.New().String()
+		unit := domain.Units{ID: uuid.MustParse(id), Name: "Cabin 1", Status: enum.Available, Type: enum.Cabin, Version: 1}
+
+		var changes []change
+		unitService.RegisterChangeListener(func(ctx context.Context, eventType enum.UnitEventType, unit domain.Units) {
+			changes = append(changes, change{eventType, unit.Version})
+		})
+
+		mockRepo.On("Create", mock.Anything, mock.Anything).Return(unit, nil).Once()
+		mockRepo.On("CreateStatusHistory", mock.Anything, mock.Anything).Return(nil)
+		_, err := unitService.CreateUnit(context.Background(), request.CreateUnitDto{Name: "Cabin 1", Status: "Available", Type: "cabin"})
+		assert.Nil(t, err)
+
+		mockRepo.On("GetByID", mock.Anything, id).Return(unit, nil).Times(3)
+		mockRepo.On("LockByID", mock.Anything, id).Return(unit, nil)
+		mockRepo.On("Update", mock.Anything, mock.Anything).Return(nil).Twice()
+		_, err = unitService.Update(context.Background(), id, request.UpdateUnitDto{CreateUnitDto: request.CreateUnitDto{Name: "Cabin 2", Status: "Available", Type: "cabin"}})
+		assert.Nil(t, err)
+		_, err = unitService.ChangeStatus(context.Background(), id, request.ChangeUnitStatusDto{Status: "Occupied"})
+		assert.Nil(t, err)
+
+		mockRepo.On("Delete", mock.Anything, unit).Return(nil).Once()
+		assert.Nil(t, unitService.DeleteByID(context.Background(), id))
+
+		mockRepo.On("GetDeletedByID", mock.Anything, id).Return(unit, nil).Once()
+		mockRepo.On("Restore", mock.Anything, unit).Return(nil).Once()
+		mockRepo.On("GetByID", mock.Anything, id).Return(unit, nil).Once()
+		_, err = unitService.RestoreByID(context.Background(), id)
+		assert.Nil(t, err)
+
+		assert.Equal(t, []change{
+			{enum.UnitCreated, 1},
+			{enum.UnitUpdated, 2},
+			{enum.UnitUpdated, 2},
+			{enum.UnitDeleted, 1},
+			{enum.UnitRestored, 1},
+		}, changes)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Negative Case: Listener not notified when the change fails", func(t *testing.T) {
+		mockRepo, unitService := setupTest(t)
+		id := uuid.New().String()
+		unit := domain.Units{ID: uuid.MustParse(id), Status: enum.Occupied, Type: enum.Cabin}
+
+		notified := 0
+		unitService.RegisterChangeListener(func(ctx context.Context, eventType enum.UnitEventType, unit domain.Units) {
+			notified++
+		})
+
+		mockRepo.On("GetByID", mock.Anything, id).Return(unit, nil).Twice()
+		mockRepo.On("Update", mock.Anything, mock.Anything).Return(gorm.ErrInvalidDB).Once()
+		_, err := unitService.Update(context.Background(), id, request.UpdateUnitDto{CreateUnitDto: request.CreateUnitDto{Name: "Renamed", Status: "Occupied", Type: "cabin"}})
+		assert.NotNil(t, err)
+
+		mockRepo.On("LockByID", mock.Anything, id).Return(unit, nil).Once()
+		mockRepo.On("Delete", mock.Anything, unit).Return(gorm.ErrInvalidDB).Once()
+		assert.NotNil(t, unitService.DeleteByID(context.Background(), id))
+
+		assert.Equal(t, 0, notified)
+	})
+}
+
+func TestStatusGuard(t *testing.T) {
+	t.Run("Negative Case: Guard rejects status change", func(t *testing.T) {
+		mockRepo, unitService := setupTest(t)
+		id := uuid.New().String()
+		oldUnit := domain.Units{ID: uuid.MustParse(id), Status: enum.MaintenanceNeeded, Type: enum.Cabin}
+
+		unitService.RegisterStatusGuard(func(ctx context.Context, unit domain.Units, to enum.UnitStatus) *handler.CustomError {
+			return handler.NewError(http.StatusConflict, "unit has open maintenance tickets")
+		})
+
+		mockRepo.On("GetByID", mock.Anything, id).Return(oldUnit, nil).Once()
+		mockRepo.On("LockByID", mock.Anything, id).Return(oldUnit, nil)
+
+		result, err := unitService.Update(context.Background(), id, request.UpdateUnitDto{CreateUnitDto: request.CreateUnitDto{Name: "Unit", Status: "Available", Type: "cabin"}})
+		assert.Nil(t, result)
+		assert.Equal(t, http.StatusConflict, err.Code)
+		mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Positive Case: Guard is skipped when status is unchanged", func(t *testing.T) {
+		mockRepo, unitService := setupTest(t)
+		id := uuid.New().String()
+		oldUnit := domain.Units{ID: uuid.MustParse(id), Status: enum.MaintenanceNeeded, Type: enum.Cabin}
+
+		unitService.RegisterStatusGuard(func(ctx context.Context, unit domain.Units, to enum.UnitStatus) *handler.CustomError {
+			return handler.NewError(http.StatusConflict, "unit has open maintenance tickets")
+		})
+
+		mockRepo.On("GetByID", mock.Anything, id).Return(oldUnit, nil).Once()
+		mockRepo.On("Update", mock.Anything, mock.Anything).Return(nil).Once()
+
+		result, err := unitService.Update(context.Background(), id, request.UpdateUnitDto{CreateUnitDto: request.CreateUnitDto{Name: "Renamed", Status: "Maintenance Needed", Type: "cabin"}})
+		assert.Nil(t, err)
+		assert.Equal(t, "Renamed", result.Name)
+	})
+}
+
+func TestRejectionListener(t *testing.T) {
+	type rejection struct {
+		to   enum.UnitStatus
+		code int
+	}
+
+	t.Run("Negative Case: Listener notified when the state machine or a guard refuses the change", func(t *testing.T) {
+		mockRepo, unitService := setupTest(t)
+		id := uuid.New().String()
+		unit := domain.Units{ID: uuid.MustParse(id), Status: enum.Occupied, Type: enum.Cabin}
+
+		var rejections []rejection
+		unitService.RegisterRejectionListener(func(ctx context.Context, unit domain.Units, to enum.UnitStatus, err *handler.CustomError) {
+			rejections = append(rejections, rejection{to, err.Code})
+		})
+		unitService.RegisterStatusGuard(func(ctx context.Context, unit domain.Units, to enum.UnitStatus) *handler.CustomError {
+			if to == enum.CleaningInProgress {
+				return handler.NewError(http.StatusConflict, "guest is still checked in")
+			}
+			return nil
+		})
+
+		mockRepo.On("GetByID", mock.Anything, id).Return(unit, nil).Twice()
+		mockRepo.On("LockByID", mock.Anything, id).Return(unit, nil)
+		_, err := unitService.ChangeStatus(context.Background(), id, request.ChangeUnitStatusDto{Status: "Available"})
+		assert.NotNil(t, err)
+		_, err = unitService.ChangeStatus(context.Background(), id, request.ChangeUnitStatusDto{Status: "Cleaning In Progress"})
+		assert.NotNil(t, err)
+
+		assert.Equal(t, []rejection{
+			{enum.Available, http.StatusBadRequest},
+			{enum.CleaningInProgress, http.StatusConflict},
+		}, rejections)
+		mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+}
+
+func TestBulkChangeStatus(t *testing.T) {
+	firstID, secondID := uuid.New(), uuid.New()
+	bulkReq := func(mode string) request.BulkChangeUnitStatusDto {
+		return request.BulkChangeUnitStatusDto{
+			UnitIDs: []string{firstID.String(), secondID.String(), firstID.String()},
+			Status:  "Available",
+			Reason:  "floor 2 cleaned",
+			Mode:    mode,
+			Actor:   "maria",
+		}
+	}
+
+	t.Run("Positive Case: Atomic change of every unit", func(t *testing.T) {
+		mockRepo, unitService := setupTest(t)
+		notified := 0
+		unitService.RegisterStatusListener(func(ctx context.Context, unit domain.Units, history domain.UnitStatusHistory) { notified++ })
+
+		mockRepo.On("LockByID", mock.Anything, firstID.String()).Return(domain.Units{ID: firstID, Status: enum.CleaningInProgress, Version: 1}, nil).Once()
+		mockRepo.On("LockByID", mock.Anything, secondID.String()).Return(domain.Units{ID: secondID, Status: enum.CleaningInProgress, Version: 4}, nil).Once()
+		mockRepo.On("Update", mock.Anything, mock.MatchedBy(func(unit domain.Units) bool { return unit.Status == enum.Available })).Return(nil).Twice()
+		mockRepo.On("CreateStatusHistory", mock.Anything, mock.MatchedBy(func(history domain.UnitStatusHistory) bool {
+			return history.Actor == "maria" && history.Reason == "floor 2 cleaned"
+		})).Return(nil).Twice()
+
+		result, err := unitService.BulkChangeStatus(context.Background(), bulkReq(""))
+		assert.Nil(t, err)
+		assert.Equal(t, enum.BulkAtomic, result.Mode)
+		assert.Equal(t, 2, result.Total)
+		assert.Equal(t, 2, result.Updated)
+		assert.Equal(t, int64(5), result.Results[1].Version)
+		assert.Equal(t, 2, notified)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Negative Case: Atomic change is rolled back when a unit cannot change", func(t *testing.T) {
+		mockRepo, unitService := setupTest(t)
+		notified := 0
+		unitService.RegisterStatusListener(func(ctx context.Context, unit domain.Units, history domain.UnitStatusHistory) { notified++ })
+
+		mockRepo.On("LockByID", mock.Anything, firstID.String()).Return(domain.Units{ID: firstID, Status: enum.CleaningInProgress}, nil).Once()
+		mockRepo.On("LockByID", mock.Anything, secondID.String()).Return(domain.Units{ID: secondID, Status: enum.Occupied}, nil).Once()
+		mockRepo.On("Update", mock.Anything, mock.Anything).Return(nil).Once()
+		mockRepo.On("CreateStatusHistory", mock.Anything, mock.Anything).Return(nil).Once()
+
+		result, err := unitService.BulkChangeStatus(context.Background(), bulkReq("atomic"))
+		assert.Nil(t, result)
+		assert.Equal(t, http.StatusConflict, err.Code)
+
+		report := err.Data.(*response.BulkUnitStatusResponse)
+		assert.Equal(t, response.BulkOutcomeRolledBack, report.Results[0].Outcome)
+		assert.Equal(t, response.BulkOutcomeFailed, report.Results[1].Outcome)
+		assert.Equal(t, http.StatusBadRequest, report.Results[1].Code)
+		assert.Equal(t, 1, report.Failed)
+		assert.Equal(t, 1, report.RolledBack)
+		assert.Equal(t, 0, notified)
+	})
+
+	t.Run("Positive Case: Best effort changes the valid units", func(t *testing.T) {
+		mockRepo, unitService := setupTest(t)
+
+		mockRepo.On("GetByID", mock.Anything, firstID.String()).Return(domain.Units{ID: firstID, Status: enum.CleaningInProgress}, nil).Once()
+		mockRepo.On("LockByID", mock.Anything, firstID.String()).Return(domain.Units{ID: firstID, Status: enum.CleaningInProgress}, nil)
+		mockRepo.On("GetByID", mock.Anything, secondID.String()).Return(domain.Units{}, gorm.ErrRecordNotFound).Once()
+		mockRepo.On("Update", mock.Anything, mock.Anything).Return(nil).Once()
+		mockRepo.On("CreateStatusHistory", mock.Anything, mock.Anything).Return(nil).Once()
+
+		result, err := unitService.BulkChangeStatus(context.Background(), bulkReq("best_effort"))
+		assert.Nil(t, err)
+		assert.Equal(t, 1, result.Updated)
+		assert.Equal(t, 1, result.Failed)
+		assert.Equal(t, http.StatusNotFound, result.Results[1].Code)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Positive Case: Unit already in target status is skipped", func(t *testing.T) {
+		mockRepo, unitService := setupTest(t)
+
+		mockRepo.On("LockByID", mock.Anything, firstID.String()).Return(domain.Units{ID: firstID, Status: enum.Available}, nil).Once()
+		mockRepo.On("LockByID", mock.Anything, secondID.String()).Return(domain.Units{ID: secondID, Status: enum.Available}, nil).Once()
+
+		result, err := unitService.BulkChangeStatus(context.Background(), bulkReq("atomic"))
+		assert.Nil(t, err)
+		assert.Equal(t, 2, result.Skipped)
+		mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Negative Case: Invalid mode", func(t *testing.T) {
+		_, unitService := setupTest(t)
+
+		result, err := unitService.BulkChangeStatus(context.Background(), bulkReq("sometimes"))
+		assert.Nil(t, result)
+		assert.Equal(t, http.StatusBadRequest, err.Code)
+	})
+
+	t.Run("Negative Case: No unit ids", func(t *testing.T) {
+		_, unitService := setupTest(t)
+
+		result, err := unitService.BulkChangeStatus(context.Background(), request.BulkChangeUnitStatusDto{UnitIDs: []string{" "}, Status: "Available"})
+		assert.Nil(t, result)
+		assert.Equal(t, http.StatusBadRequest, err.Code)
+	})
+}
+
+func TestImportUnits(t *testing.T) {
+	rows := []request.ImportUnitRowDto{
+		{Row: 2, Name: "C-01", Type: "capsule", Status: "Available"},
+		{Row: 3, Name: " ", Type: "suite", Status: "Available"},
+		{Row: 4, Name: "C-03", Type: "capsule", Status: "Occupied"},
+		{Row: 5, Name: "Cabin 1", Type: "cabin", Status: "Cleaning In Progress"},
+	}
+
+	t.Run("Positive Case: Dry run only reports row errors", func(t *testing.T) {
+		mockRepo, unitService := setupTest(t)
+
+		result, err := unitService.ImportUnits(context.Background(), rows, true, "admin")
+		assert.Nil(t, err)
+		assert.True(t, result.DryRun)
+		assert.Equal(t, 4, result.TotalRows)
+		assert.Equal(t, 2, result.ValidRows)
+		assert.Equal(t, 0, result.Imported)
+		assert.Equal(t, []response.ImportRowError{
+			{Row: 3, Field: "name", Message: "unit name is required"},
+			{Row: 3, Field: "type", Message: "invalid unit type, must be 'cabin' or 'capsule'"},
+			{Row: 4, Field: "status", Message: "unit cannot be created with status 'Occupied', allowed: 'Available', 'Cleaning In Progress', 'Maintenance Needed'"},
+		}, result.Errors)
+		mockRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Positive Case: Valid rows are imported with their history", func(t *testing.T) {
+		mockRepo, unitService := setupTest(t)
+
+		mockRepo.On("Create", mock.Anything, mock.MatchedBy(func(unit domain.Units) bool { return unit.Version == 1 })).Return(domain.Units{ID: uuid.New()}, nil).Twice()
+		mockRepo.On("CreateStatusHistory", mock.Anything, mock.MatchedBy(func(history domain.UnitStatusHistory) bool {
+			return history.Actor == "admin" && history.FromStatus == nil
+		})).Return(nil).Twice()
+
+		result, err := unitService.ImportUnits(context.Background(), rows, false, "admin")
+		assert.Nil(t, err)
+		assert.Equal(t, 2, result.Imported)
+		assert.Len(t, result.Errors, 3)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Negative Case: Nothing is reported imported when the transaction fails", func(t *testing.T) {
+		mockRepo, unitService := setupTest(t)
+
+		mockRepo.On("Create", mock.Anything, mock.Anything).Return(domain.Units{ID: uuid.New()}, nil).Once()
+		mockRepo.On("CreateStatusHistory", mock.Anything, mock.Anything).Return(nil).Once()
+		mockRepo.On("Create", mock.Anything, mock.Anything).Return(domain.Units{}, gorm.ErrInvalidDB).Once()
+
+		result, err := unitService.ImportUnits(context.Background(), rows, false, "admin")
+		assert.Nil(t, result)
+		assert.Equal(t, http.StatusInternalServerError, err.Code)
+	})
+}
+
+func TestFindUnitsByCursor(t *testing.T) {
+	sortByUpdate := []request.UnitSortDto{{Field: enum.SortUnitLastUpdated, Descending: true}}
+	newUnits := func(count int) []response.UnitDetailResponse {
+		units := make([]response.UnitDetailResponse, 0, count)
+		for i := 0; i < count; i++ {
+			units = append(units, response.UnitDetailResponse{ID: uuid.New(), Name: fmt.Sprintf("Unit %d", i), LastUpdated: time.Now()})
+		}
+		return units
+	}
+
+	t.Run("Positive Case: First page links to the next page only", func(t *testing.T) {
+		mockRepo, unitService := setupTest(t)
+		filter := request.UnitFilterDto{Sort: sortByUpdate}
+		units := newUnits(3)
+
+		mockRepo.On("FindAllByCursor", mock.Anything, filter, (*request.UnitCursorDto)(nil), 3).Return(units, nil).Once()
+
+		result, err := unitService.FindUnitsByCursor(context.Background(), filter, "", 2)
+		assert.Nil(t, err)
+		assert.Equal(t, units[:2], result.Content)
+		assert.Equal(t, 2, result.Pagination.Limit)
+		assert.Nil(t, result.Pagination.PrevCursor)
+		if assert.NotNil(t, result.Pagination.NextCursor) {
+			sort, cursor, errDecode := decodeUnitCursor(*result.Pagination.NextCursor)
+			assert.NoError(t, errDecode)
+			assert.Equal(t, sortByUpdate, sort)
+			assert.Equal(t, units[1].ID, cursor.ID)
+			assert.True(t, units[1].LastUpdated.Equal(cursor.LastUpdated))
+			assert.False(t, cursor.Backward)
+		}
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Positive Case: Next page keeps the sort of the cursor", func(t *testing.T) {
+		mockRepo, unitService := setupTest(t)
+		units := newUnits(2)
+		cursor := encodeUnitCursor(sortByUpdate, units[0], false)
+
+		mockRepo.On("FindAllByCursor", mock.Anything, request.UnitFilterDto{Name: "Unit", Sort: sortByUpdate}, mock.MatchedBy(func(position *request.UnitCursorDto) bool {
+			return position != nil && position.ID == units[0].ID && !position.Backward
+		}), 3).Return(units[1:], nil).Once()
+
+		result, err := unitService.FindUnitsByCursor(context.Background(), request.UnitFilterDto{Name: "Unit"}, cursor, 2)
+		assert.Nil(t, err)
+		assert.Len(t, result.Content, 1)
+		assert.Nil(t, result.Pagination.NextCursor)
+		if assert.NotNil(t, result.Pagination.PrevCursor) {
+			_, position, errDecode := decodeUnitCursor(*result.Pagination.PrevCursor)
+			assert.NoError(t, errDecode)
+			assert.Equal(t, units[1].ID, position.ID)
+			assert.True(t, position.Backward)
+		}
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Positive Case: Previous page drops the extra unit at its start", func(t *testing.T) {
+		mockRepo, unitService := setupTest(t)
+		units := newUnits(4)
+		cursor := encodeUnitCursor(nil, units[3], true)
+
+		mockRepo.On("FindAllByCursor", mock.Anything, request.UnitFilterDto{Sort: []request.UnitSortDto{}}, mock.Anything, 3).Return(units[:3], nil).Once()
+
+		result, err := unitService.FindUnitsByCursor(context.Background(), request.UnitFilterDto{}, cursor, 2)
+		assert.Nil(t, err)
+		assert.Equal(t, units[1:3], result.Content)
+		assert.NotNil(t, result.Pagination.PrevCursor)
+		assert.NotNil(t, result.Pagination.NextCursor)
+	})
+
+	t.Run("Negative Case: Invalid cursor", func(t *testing.T) {
+		mockRepo, unitService := setupTest(t)
+
+		for _, cursor := range []string{"not a cursor", "bm90LWpzb24", "e30"} {
+			result, err := unitService.FindUnitsByCursor(context.Background(), request.UnitFilterDto{}, cursor, 10)
+			assert.Nil(t, result)
+			assert.Equal(t, http.StatusBadRequest, err.Code, cursor)
+		}
+		mockRepo.AssertNotCalled(t, "FindAllByCursor", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Negative Case: Cursor used with another sort", func(t *testing.T) {
+		mockRepo, unitService := setupTest(t)
+		cursor := encodeUnitCursor(sortByUpdate, newUnits(1)[0], false)
+
+		result, err := unitService.FindUnitsByCursor(context.Background(), request.UnitFilterDto{Sort: []request.UnitSortDto{{Field: enum.SortUnitName}}}, cursor, 10)
+		assert.Nil(t, result)
+		assert.Equal(t, http.StatusBadRequest, err.Code)
+		mockRepo.AssertNotCalled(t, "FindAllByCursor", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestExportUnits(t *testing.T) {
+	t.Run("Positive Case: Export uses the list filters", func(t *testing.T) {
+		mockRepo, unitService := setupTest(t)
+		units := []domain.Units{{ID: uuid.New(), Name: "C-01"}}
+
+		filter := request.UnitFilterDto{Statuses: []enum.UnitStatus{enum.Available}, Types: []enum.UnitType{enum.Capsule}, Name: "C-"}
+
+		mockRepo.On("FindAllForExport", mock.Anything, filter).Return(units, nil).Once()
+
+		result, err := unitService.ExportUnits(context.Background(), filter)
+		assert.Nil(t, err)
+		assert.Equal(t, units, result)
+	})
+}
+
+func TestGetStats(t *testing.T) {
+	t.Run("Positive Case: Stats are returned from the repository", func(t *testing.T) {
+		mockRepo, unitService := setupTest(t)
+		stats := response.NewUnitStatsResponse()
+		stats.Total = 2
+
+		mockRepo.On("GetStats", mock.Anything).Return(stats, nil).Once()
+
+		result, err := unitService.GetStats(context.Background())
+		assert.Nil(t, err)
+		assert.Equal(t, stats, result)
+	})
+
+	t.Run("Negative Case: Repository error", func(t *testing.T) {
+		mockRepo, unitService := setupTest(t)
+
+		expectedErr := handler.NewError(http.StatusInternalServerError, "database error")
+		mockRepo.On("GetStats", mock.Anything).Return(response.UnitStatsResponse{}, expectedErr).Once()
+
+		_, err := unitService.GetStats(context.Background())
+		assert.NotNil(t, err)
+		assert.Equal(t, http.StatusInternalServerError, err.Code)
+	})
+}
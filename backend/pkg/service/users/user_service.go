@@ -0,0 +1,18 @@
+package users
+
+import (
+	"context"
+	"unit-management-be/pkg/handler"
+	"unit-management-be/pkg/model/domain"
+	"unit-management-be/pkg/model/dto"
+	"unit-management-be/pkg/model/dto/request"
+	"unit-management-be/pkg/model/dto/response"
+)
+
+type UserService interface {
+	Login(ctx context.Context, request request.LoginDto) (*response.LoginResponse, *handler.CustomError)
+	CreateUser(ctx context.Context, request request.CreateUserDto) (*domain.User, *handler.CustomError)
+	FindByID(ctx context.Context, id string) (domain.User, *handler.CustomError)
+	FindUsers(ctx context.Context, role string, page, size int) (*dto.PaginationResponse, *handler.CustomError)
+	EnsureAdmin(ctx context.Context, username, password string) *handler.CustomError
+}
@@ -0,0 +1,149 @@
+package users
+
+import (
+	"context"
+	"net/http"
+	"unit-management-be/pkg/auth"
+	"unit-management-be/pkg/handler"
+	"unit-management-be/pkg/model/domain"
+	"unit-management-be/pkg/model/domain/enum"
+	"unit-management-be/pkg/model/dto"
+	"unit-management-be/pkg/model/dto/request"
+	"unit-management-be/pkg/model/dto/response"
+	userrepository "unit-management-be/pkg/repository/users"
+	"unit-management-be/pkg/utils"
+
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+)
+
+const (
+	invalidCredentialsMessage = "invalid username or password"
+	invalidRoleMessage        = "invalid user role, must be one of 'admin', 'front_desk', 'housekeeping', 'read_only'"
+	minPasswordLength         = 8
+)
+
+type UserServiceImpl struct {
+	userRepository userrepository.UserRepository
+	tokenManager   *auth.TokenManager
+}
+
+func NewUserService(userRepository userrepository.UserRepository, tokenManager *auth.TokenManager) UserService {
+	return &UserServiceImpl{
+		userRepository: userRepository,
+		tokenManager:   tokenManager,
+	}
+}
+
+func (u *UserServiceImpl) Login(ctx context.Context, loginRequest request.LoginDto) (*response.LoginResponse, *handler.CustomError) {
+	user, err := u.userRepository.GetByUsername(ctx, loginRequest.Username)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, handler.NewError(http.StatusUnauthorized, invalidCredentialsMessage)
+		}
+		return nil, handler.NewError(http.StatusInternalServerError, err.Error())
+	}
+
+	if errCompare := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(loginRequest.Password)); errCompare != nil {
+		return nil, handler.NewError(http.StatusUnauthorized, invalidCredentialsMessage)
+	}
+
+	token, expiresAt, errToken := u.tokenManager.Generate(user)
+	if errToken != nil {
+		return nil, handler.NewError(http.StatusInternalServerError, errToken.Error())
+	}
+
+	return &response.LoginResponse{
+		AccessToken: token,
+		TokenType:   "Bearer",
+		ExpiresAt:   expiresAt,
+		User:        user,
+	}, nil
+}
+
+func (u *UserServiceImpl) CreateUser(ctx context.Context, userRequest request.CreateUserDto) (*domain.User, *handler.CustomError) {
+	role, isValidRole := enum.ParseUserRole(userRequest.Role)
+	if !isValidRole {
+		return nil, handler.NewError(http.StatusBadRequest, invalidRoleMessage)
+	}
+
+	if len(userRequest.Password) < minPasswordLength {
+		return nil, handler.NewError(http.StatusBadRequest, "password must be at least 8 characters")
+	}
+
+	_, err := u.userRepository.GetByUsername(ctx, userRequest.Username)
+	if err == nil {
+		return nil, handler.NewError(http.StatusConflict, "username is already taken")
+	}
+	if err != gorm.ErrRecordNotFound {
+		return nil, handler.NewError(http.StatusInternalServerError, err.Error())
+	}
+
+	passwordHash, errHash := bcrypt.GenerateFromPassword([]byte(userRequest.Password), bcrypt.DefaultCost)
+	if errHash != nil {
+		return nil, handler.NewError(http.StatusInternalServerError, errHash.Error())
+	}
+
+	user := domain.User{
+		Username:     userRequest.Username,
+		PasswordHash: string(passwordHash),
+		Role:         role,
+	}
+
+	createdUser, errSave := u.userRepository.Create(ctx, user)
+	if errSave != nil {
+		return nil, handler.NewError(http.StatusInternalServerError, errSave.Error())
+	}
+
+	return &createdUser, nil
+}
+
+func (u *UserServiceImpl) FindByID(ctx context.Context, id string) (domain.User, *handler.CustomError) {
+	user, err := u.userRepository.GetByID(ctx, id)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return user, handler.NewError(http.StatusNotFound, "user with that id was not found")
+		}
+		return user, handler.NewError(http.StatusInternalServerError, err.Error())
+	}
+
+	return user, nil
+}
+
+func (u *UserServiceImpl) FindUsers(ctx context.Context, role string, page, size int) (*dto.PaginationResponse, *handler.CustomError) {
+	if !utils.IsEmptyString(role) {
+		if _, isValidRole := enum.ParseUserRole(role); !isValidRole {
+			return nil, handler.NewError(http.StatusBadRequest, invalidRoleMessage)
+		}
+	}
+
+	users, total, err := u.userRepository.FindAll(ctx, role, page, size)
+	if err != nil {
+		return nil, handler.NewError(http.StatusInternalServerError, err.Error())
+	}
+
+	return dto.NewPaginationResponse(page, size, int(total), users), nil
+}
+
+// EnsureAdmin creates the first admin account when no admin exists yet, so a fresh database can be logged in to
+func (u *UserServiceImpl) EnsureAdmin(ctx context.Context, username, password string) *handler.CustomError {
+	total, err := u.userRepository.CountByRole(ctx, string(enum.RoleAdmin))
+	if err != nil {
+		return handler.NewError(http.StatusInternalServerError, err.Error())
+	}
+
+	if total > 0 {
+		return nil
+	}
+
+	if utils.IsEmptyString(username) || utils.IsEmptyString(password) {
+		return handler.NewError(http.StatusInternalServerError, "no admin user exists, ADMIN_USERNAME and ADMIN_PASSWORD must be set")
+	}
+
+	_, errCreate := u.CreateUser(ctx, request.CreateUserDto{
+		Username: username,
+		Password: password,
+		Role:     string(enum.RoleAdmin),
+	})
+	return errCreate
+}
@@ -0,0 +1,176 @@
+package users
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"unit-management-be/pkg/auth"
+	"unit-management-be/pkg/model/domain"
+	"unit-management-be/pkg/model/domain/enum"
+	"unit-management-be/pkg/model/dto/request"
+	userrepository "unit-management-be/pkg/repository/users"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+)
+
+// MockUserRepository of user repository
+type MockUserRepository struct {
+	mock.Mock
+}
+
+func (m *MockUserRepository) Create(ctx context.Context, user domain.User) (domain.User, error) {
+	args := m.Called(ctx, user)
+	return args.Get(0).(domain.User), args.Error(1)
+}
+
+func (m *MockUserRepository) GetByID(ctx context.Context, id string) (domain.User, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(domain.User), args.Error(1)
+}
+
+func (m *MockUserRepository) GetByUsername(ctx context.Context, username string) (domain.User, error) {
+	args := m.Called(ctx, username)
+	return args.Get(0).(domain.User), args.Error(1)
+}
+
+func (m *MockUserRepository) FindAll(ctx context.Context, role string, page, size int) ([]domain.User, int64, error) {
+	args := m.Called(ctx, role, page, size)
+	return args.Get(0).([]domain.User), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockUserRepository) CountByRole(ctx context.Context, role string) (int64, error) {
+	args := m.Called(ctx, role)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+var _ userrepository.UserRepository = &MockUserRepository{}
+
+// initialization service, user repository and token manager
+func setupTest(t *testing.T) (*MockUserRepository, *auth.TokenManager, UserService) {
+	mockRepo := new(MockUserRepository)
+	tokenManager := auth.NewTokenManager("test-secret", time.Hour)
+	userService := NewUserService(mockRepo, tokenManager)
+	return mockRepo, tokenManager, userService
+}
+
+func hashPassword(t *testing.T, password string) string {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.MinCost)
+	assert.NoError(t, err)
+	return string(hash)
+}
+
+func TestLogin(t *testing.T) {
+	t.Run("Positive Case: Valid credentials issue a token", func(t *testing.T) {
+		mockRepo, tokenManager, userService := setupTest(t)
+		user := domain.User{ID: uuid.New(), Username: "desk", PasswordHash: hashPassword(t, "secret123"), Role: enum.RoleFrontDesk}
+
+		mockRepo.On("GetByUsername", mock.Anything, "desk").Return(user, nil).Once()
+
+		result, err := userService.Login(context.Background(), request.LoginDto{Username: "desk", Password: "secret123"})
+		assert.Nil(t, err)
+		assert.Equal(t, "Bearer", result.TokenType)
+
+		claims, errParse := tokenManager.Parse(result.AccessToken)
+		assert.NoError(t, errParse)
+		assert.Equal(t, user.ID.String(), claims.Subject)
+		assert.Equal(t, enum.RoleFrontDesk, claims.Role)
+	})
+
+	t.Run("Negative Case: Wrong password", func(t *testing.T) {
+		mockRepo, _, userService := setupTest(t)
+		user := domain.User{ID: uuid.New(), Username: "desk", PasswordHash: hashPassword(t, "secret123"), Role: enum.RoleFrontDesk}
+
+		mockRepo.On("GetByUsername", mock.Anything, "desk").Return(user, nil).Once()
+
+		result, err := userService.Login(context.Background(), request.LoginDto{Username: "desk", Password: "wrong-password"})
+		assert.Nil(t, result)
+		assert.Equal(t, http.StatusUnauthorized, err.Code)
+	})
+
+	t.Run("Negative Case: Unknown username", func(t *testing.T) {
+		mockRepo, _, userService := setupTest(t)
+
+		mockRepo.On("GetByUsername", mock.Anything, "ghost").Return(domain.User{}, gorm.ErrRecordNotFound).Once()
+
+		result, err := userService.Login(context.Background(), request.LoginDto{Username: "ghost", Password: "secret123"})
+		assert.Nil(t, result)
+		assert.Equal(t, http.StatusUnauthorized, err.Code)
+	})
+}
+
+func TestCreateUser(t *testing.T) {
+	t.Run("Positive Case: Password is stored hashed", func(t *testing.T) {
+		mockRepo, _, userService := setupTest(t)
+
+		mockRepo.On("GetByUsername", mock.Anything, "maria").Return(domain.User{}, gorm.ErrRecordNotFound).Once()
+		mockRepo.On("Create", mock.Anything, mock.MatchedBy(func(user domain.User) bool {
+			return user.Role == enum.RoleHousekeeping &&
+				bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte("secret123")) == nil
+		})).Return(domain.User{ID: uuid.New(), Username: "maria", Role: enum.RoleHousekeeping}, nil).Once()
+
+		result, err := userService.CreateUser(context.Background(), request.CreateUserDto{Username: "maria", Password: "secret123", Role: "housekeeping"})
+		assert.Nil(t, err)
+		assert.Equal(t, "maria", result.Username)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Negative Case: Invalid role", func(t *testing.T) {
+		_, _, userService := setupTest(t)
+
+		result, err := userService.CreateUser(context.Background(), request.CreateUserDto{Username: "maria", Password: "secret123", Role: "owner"})
+		assert.Nil(t, result)
+		assert.Equal(t, http.StatusBadRequest, err.Code)
+	})
+
+	t.Run("Negative Case: Username already taken", func(t *testing.T) {
+		mockRepo, _, userService := setupTest(t)
+
+		mockRepo.On("GetByUsername", mock.Anything, "maria").Return(domain.User{ID: uuid.New()}, nil).Once()
+
+		result, err := userService.CreateUser(context.Background(), request.CreateUserDto{Username: "maria", Password: "secret123", Role: "housekeeping"})
+		assert.Nil(t, result)
+		assert.Equal(t, http.StatusConflict, err.Code)
+		mockRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+}
+
+func TestEnsureAdmin(t *testing.T) {
+	t.Run("Positive Case: Existing admin is kept", func(t *testing.T) {
+		mockRepo, _, userService := setupTest(t)
+
+		mockRepo.On("CountByRole", mock.Anything, "admin").Return(int64(1), nil).Once()
+
+		err := userService.EnsureAdmin(context.Background(), "", "")
+		assert.Nil(t, err)
+		mockRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Positive Case: First admin is created", func(t *testing.T) {
+		mockRepo, _, userService := setupTest(t)
+
+		mockRepo.On("CountByRole", mock.Anything, "admin").Return(int64(0), nil).Once()
+		mockRepo.On("GetByUsername", mock.Anything, "admin").Return(domain.User{}, gorm.ErrRecordNotFound).Once()
+		mockRepo.On("Create", mock.Anything, mock.MatchedBy(func(user domain.User) bool {
+			return user.Username == "admin" && user.Role == enum.RoleAdmin
+		})).Return(domain.User{ID: uuid.New(), Username: "admin", Role: enum.RoleAdmin}, nil).Once()
+
+		err := userService.EnsureAdmin(context.Background(), "admin", "Admin12345!")
+		assert.Nil(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Negative Case: No admin and no credentials configured", func(t *testing.T) {
+		mockRepo, _, userService := setupTest(t)
+
+		mockRepo.On("CountByRole", mock.Anything, "admin").Return(int64(0), nil).Once()
+
+		err := userService.EnsureAdmin(context.Background(), "", "")
+		assert.Equal(t, http.StatusInternalServerError, err.Code)
+	})
+}
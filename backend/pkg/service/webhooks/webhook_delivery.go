@@ -0,0 +1,200 @@
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+	"unit-management-be/pkg/handler"
+	"unit-management-be/pkg/logging"
+	"unit-management-be/pkg/model/domain"
+	"unit-management-be/pkg/model/domain/enum"
+
+	"gorm.io/gorm"
+)
+
+const (
+	// maxDeliveryAttempts is how often a delivery is tried before it is moved to the dead letters
+	maxDeliveryAttempts = 8
+	// retryBaseDelay doubles after every failed attempt up to maxRetryDelay,
+	// every attempt is spent a little over an hour after the event
+	retryBaseDelay = 30 * time.Second
+	maxRetryDelay  = time.Hour
+	// deliveryTimeout bounds a single attempt, a receiver should answer before doing slow work
+	deliveryTimeout = 10 * time.Second
+	// deliveryBatchSize is how many due deliveries are read at once
+	deliveryBatchSize = 50
+	// deliveryWorkers is how many subscriptions are sent to at the same time, so a slow receiver
+	// only holds up its own deliveries
+	deliveryWorkers = 8
+)
+
+const (
+	signatureHeader = "X-Webhook-Signature"
+	timestampHeader = "X-Webhook-Timestamp"
+	eventHeader     = "X-Webhook-Event"
+	deliveryHeader  = "X-Webhook-Delivery"
+)
+
+// DeliverDue sends the deliveries that are due and returns how many were attempted, a failed attempt is
+// retried later with an exponential backoff. Subscriptions are sent to concurrently, each one in the order
+// its deliveries were queued. A failed delivery holds back the newer deliveries of its subscription until
+// it is delivered or moved to the dead letters
+func (w *WebhookServiceImpl) DeliverDue(ctx context.Context) (int, *handler.CustomError) {
+	deliveries, err := w.webhookRepository.FindDueDeliveries(ctx, w.now(), deliveryBatchSize)
+	if err != nil {
+		return 0, handler.NewError(http.StatusInternalServerError, err.Error())
+	}
+
+	batches := make([]deliveryBatch, 0)
+	batchIndex := make(map[string]int)
+	for i := range deliveries {
+		subscriptionID := deliveries[i].SubscriptionID.String()
+		index, found := batchIndex[subscriptionID]
+		if !found {
+			subscription, err := w.webhookRepository.GetByID(ctx, subscriptionID)
+			if err != nil && err != gorm.ErrRecordNotFound {
+				return 0, handler.NewError(http.StatusInternalServerError, err.Error())
+			}
+
+			index = len(batches)
+			batchIndex[subscriptionID] = index
+			// the subscription was deleted while its deliveries were read, the batch is left empty
+			batches = append(batches, deliveryBatch{subscription: subscription, deleted: err == gorm.ErrRecordNotFound})
+		}
+		if !batches[index].deleted {
+			batches[index].deliveries = append(batches[index].deliveries, deliveries[i])
+		}
+	}
+
+	var attempted atomic.Int64
+	work := make(chan deliveryBatch)
+	var workers sync.WaitGroup
+	for range min(deliveryWorkers, len(batches)) {
+		workers.Add(1)
+		go func() {
+			defer workers.Done()
+			for batch := range work {
+				attempted.Add(int64(w.deliverBatch(ctx, batch)))
+			}
+		}()
+	}
+	for _, batch := range batches {
+		work <- batch
+	}
+	close(work)
+	workers.Wait()
+
+	return int(attempted.Load()), nil
+}
+
+// deliveryBatch holds the due deliveries of one subscription
+type deliveryBatch struct {
+	subscription domain.WebhookSubscription
+	deleted      bool
+	deliveries   []domain.WebhookDelivery
+}
+
+// deliverBatch sends the deliveries of the batch in order until one fails and returns how many were attempted
+func (w *WebhookServiceImpl) deliverBatch(ctx context.Context, batch deliveryBatch) int {
+	for i := range batch.deliveries {
+		delivery := &batch.deliveries[i]
+
+		w.attempt(batch.subscription, delivery)
+		if errUpdate := w.webhookRepository.UpdateDelivery(ctx, *delivery); errUpdate != nil {
+			logging.Error(ctx, "failed to save webhook delivery", errUpdate, "delivery_id", delivery.ID)
+		}
+
+		if delivery.Status != enum.DeliveryDelivered {
+			return i + 1
+		}
+	}
+
+	return len(batch.deliveries)
+}
+
+// attempt sends the delivery once and records the outcome on it
+func (w *WebhookServiceImpl) attempt(subscription domain.WebhookSubscription, delivery *domain.WebhookDelivery) {
+	delivery.Attempts++
+	statusCode, err := w.send(subscription, *delivery)
+	delivery.LastStatusCode = statusCode
+
+	now := w.now()
+	if err == nil {
+		delivery.Status = enum.DeliveryDelivered
+		delivery.DeliveredAt = &now
+		delivery.NextAttemptAt = nil
+		delivery.LastError = ""
+		return
+	}
+
+	delivery.LastError = err.Error()
+	if delivery.Attempts >= maxDeliveryAttempts {
+		delivery.Status = enum.DeliveryDead
+		delivery.NextAttemptAt = nil
+		slog.Warn("webhook delivery moved to dead letters", "delivery_id", delivery.ID, "url", subscription.URL, "attempts", delivery.Attempts, "error", err)
+		return
+	}
+
+	nextAttemptAt := now.Add(retryDelay(delivery.Attempts))
+	delivery.NextAttemptAt = &nextAttemptAt
+}
+
+// send posts the signed payload, any response other than 2xx is a failed attempt
+func (w *WebhookServiceImpl) send(subscription domain.WebhookSubscription, delivery domain.WebhookDelivery) (int, error) {
+	body := []byte(delivery.Payload)
+	req, err := http.NewRequest(http.MethodPost, subscription.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+
+	timestamp := w.now().Unix()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "unit-management-webhooks")
+	req.Header.Set(eventHeader, string(delivery.Event))
+	req.Header.Set(deliveryHeader, delivery.ID.String())
+	req.Header.Set(timestampHeader, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(signatureHeader, signPayload(subscription.Secret, timestamp, body))
+
+	res, err := w.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer res.Body.Close()
+	// drained so the connection can be reused
+	_, _ = io.Copy(io.Discard, io.LimitReader(res.Body, 64<<10))
+
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return res.StatusCode, fmt.Errorf("webhook responded with status %d", res.StatusCode)
+	}
+
+	return res.StatusCode, nil
+}
+
+// signPayload signs "timestamp.body" with HMAC-SHA256, receivers recompute it with the shared secret
+// and reject old timestamps so a captured request cannot be replayed
+func signPayload(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10) + "."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// retryDelay is the wait after the given number of failed attempts
+func retryDelay(attempts int) time.Duration {
+	delay := retryBaseDelay
+	for i := 1; i < attempts && delay < maxRetryDelay; i++ {
+		delay *= 2
+	}
+
+	return min(delay, maxRetryDelay)
+}
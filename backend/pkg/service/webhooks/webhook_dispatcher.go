@@ -0,0 +1,41 @@
+package webhooks
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+// RunWebhookDispatcher delivers the due webhook deliveries every interval and as soon as new ones are queued,
+// it returns once ctx is done
+func RunWebhookDispatcher(ctx context.Context, webhookService WebhookService, interval time.Duration) {
+	deliver := func() {
+		// a full batch means more deliveries may be due. A started batch is finished even when ctx is done,
+		// so the outcome of the deliveries sent is recorded
+		for {
+			attempted, err := webhookService.DeliverDue(context.WithoutCancel(ctx))
+			if err != nil {
+				slog.ErrorContext(ctx, "failed to deliver webhooks", "error", err.Message)
+				return
+			}
+			if attempted < deliveryBatchSize || ctx.Err() != nil {
+				return
+			}
+		}
+	}
+
+	deliver()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			deliver()
+		case <-webhookService.Queued():
+			deliver()
+		}
+	}
+}
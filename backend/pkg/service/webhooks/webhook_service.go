@@ -0,0 +1,27 @@
+package webhooks
+
+import (
+	"context"
+	"unit-management-be/pkg/handler"
+	"unit-management-be/pkg/model/domain"
+	"unit-management-be/pkg/model/domain/enum"
+	"unit-management-be/pkg/model/dto"
+	"unit-management-be/pkg/model/dto/request"
+	"unit-management-be/pkg/model/dto/response"
+)
+
+type WebhookService interface {
+	CreateWebhook(ctx context.Context, request request.CreateWebhookDto) (*response.WebhookResponse, *handler.CustomError)
+	GetWebhookByID(ctx context.Context, id string) (response.WebhookResponse, *handler.CustomError)
+	FindWebhooks(ctx context.Context, page, size int) (*dto.PaginationResponse, *handler.CustomError)
+	UpdateWebhook(ctx context.Context, id string, request request.UpdateWebhookDto) (*response.WebhookResponse, *handler.CustomError)
+	DeleteWebhook(ctx context.Context, id string) *handler.CustomError
+	FindDeliveries(ctx context.Context, webhookID, status string, page, size int) (*dto.PaginationResponse, *handler.CustomError)
+	ReplayDelivery(ctx context.Context, id string) (*domain.WebhookDelivery, *handler.CustomError)
+	ReplayDeadLetters(ctx context.Context, webhookID string) (*response.ReplayWebhookDeliveriesResponse, *handler.CustomError)
+	DeliverDue(ctx context.Context) (int, *handler.CustomError)
+	// Queued signals that deliveries were queued and can be delivered right away
+	Queued() <-chan struct{}
+	OnUnitChanged(ctx context.Context, eventType enum.UnitEventType, unit domain.Units) error
+	OnUnitStatusChanged(ctx context.Context, unit domain.Units, history domain.UnitStatusHistory) error
+}
@@ -0,0 +1,349 @@
+package webhooks
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+	"unit-management-be/pkg/handler"
+	"unit-management-be/pkg/logging"
+	"unit-management-be/pkg/model/domain"
+	"unit-management-be/pkg/model/domain/enum"
+	"unit-management-be/pkg/model/dto"
+	"unit-management-be/pkg/model/dto/request"
+	"unit-management-be/pkg/model/dto/response"
+	webhookrepository "unit-management-be/pkg/repository/webhooks"
+	"unit-management-be/pkg/transaction"
+	"unit-management-be/pkg/utils"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// minSecretLength keeps signing secrets long enough not to be guessed
+const minSecretLength = 16
+
+const invalidEventMessage = "invalid webhook event '%s', must be one of 'unit.created', 'unit.updated', 'unit.status_changed', 'unit.deleted'"
+
+type WebhookServiceImpl struct {
+	webhookRepository webhookrepository.WebhookRepository
+	client            *http.Client
+	queued            chan struct{}
+	now               func() time.Time
+}
+
+func NewWebhookService(webhookRepository webhookrepository.WebhookRepository) WebhookService {
+	return &WebhookServiceImpl{
+		webhookRepository: webhookRepository,
+		client:            &http.Client{Timeout: deliveryTimeout},
+		queued:            make(chan struct{}, 1),
+		now:               time.Now,
+	}
+}
+
+func (w *WebhookServiceImpl) CreateWebhook(ctx context.Context, webhookRequest request.CreateWebhookDto) (*response.WebhookResponse, *handler.CustomError) {
+	if errURL := validateWebhookURL(webhookRequest.URL); errURL != nil {
+		return nil, errURL
+	}
+
+	events, errEvents := parseWebhookEvents(webhookRequest.Events)
+	if errEvents != nil {
+		return nil, errEvents
+	}
+
+	if len(webhookRequest.Secret) < minSecretLength {
+		return nil, handler.NewError(http.StatusBadRequest, fmt.Sprintf("webhook secret must be at least %d characters", minSecretLength))
+	}
+
+	subscription := domain.WebhookSubscription{
+		URL:       webhookRequest.URL,
+		Secret:    webhookRequest.Secret,
+		Active:    webhookRequest.Active == nil || *webhookRequest.Active,
+		CreatedBy: webhookRequest.CreatedBy,
+	}
+	subscription.SetEvents(events)
+
+	createdSubscription, err := w.webhookRepository.Create(ctx, subscription)
+	if err != nil {
+		return nil, handler.NewError(http.StatusInternalServerError, err.Error())
+	}
+
+	webhookResponse := response.BuildWebhookResponse(createdSubscription)
+	return &webhookResponse, nil
+}
+
+func (w *WebhookServiceImpl) findByID(ctx context.Context, id string) (domain.WebhookSubscription, *handler.CustomError) {
+	subscription, err := w.webhookRepository.GetByID(ctx, id)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return subscription, handler.NewError(http.StatusNotFound, "webhook with that id was not found")
+		}
+		return subscription, handler.NewError(http.StatusInternalServerError, err.Error())
+	}
+
+	return subscription, nil
+}
+
+func (w *WebhookServiceImpl) GetWebhookByID(ctx context.Context, id string) (response.WebhookResponse, *handler.CustomError) {
+	subscription, err := w.findByID(ctx, id)
+	if err != nil {
+		return response.WebhookResponse{}, err
+	}
+
+	return response.BuildWebhookResponse(subscription), nil
+}
+
+func (w *WebhookServiceImpl) FindWebhooks(ctx context.Context, page, size int) (*dto.PaginationResponse, *handler.CustomError) {
+	subscriptions, total, err := w.webhookRepository.FindAll(ctx, page, size)
+	if err != nil {
+		return nil, handler.NewError(http.StatusInternalServerError, err.Error())
+	}
+
+	webhooks := make([]response.WebhookResponse, 0, len(subscriptions))
+	for _, subscription := range subscriptions {
+		webhooks = append(webhooks, response.BuildWebhookResponse(subscription))
+	}
+
+	return dto.NewPaginationResponse(page, size, int(total), webhooks), nil
+}
+
+func (w *WebhookServiceImpl) UpdateWebhook(ctx context.Context, id string, webhookRequest request.UpdateWebhookDto) (*response.WebhookResponse, *handler.CustomError) {
+	subscription, err := w.findByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if errURL := validateWebhookURL(webhookRequest.URL); errURL != nil {
+		return nil, errURL
+	}
+
+	events, errEvents := parseWebhookEvents(webhookRequest.Events)
+	if errEvents != nil {
+		return nil, errEvents
+	}
+
+	if !utils.IsEmptyString(webhookRequest.Secret) {
+		if len(webhookRequest.Secret) < minSecretLength {
+			return nil, handler.NewError(http.StatusBadRequest, fmt.Sprintf("webhook secret must be at least %d characters", minSecretLength))
+		}
+		subscription.Secret = webhookRequest.Secret
+	}
+
+	if webhookRequest.Active != nil {
+		subscription.Active = *webhookRequest.Active
+	}
+
+	subscription.URL = webhookRequest.URL
+	subscription.SetEvents(events)
+	if errUpdate := w.webhookRepository.Update(ctx, subscription); errUpdate != nil {
+		return nil, handler.NewError(http.StatusInternalServerError, errUpdate.Error())
+	}
+
+	webhookResponse := response.BuildWebhookResponse(subscription)
+	return &webhookResponse, nil
+}
+
+func (w *WebhookServiceImpl) DeleteWebhook(ctx context.Context, id string) *handler.CustomError {
+	subscription, err := w.findByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if errDelete := w.webhookRepository.Delete(ctx, subscription); errDelete != nil {
+		return handler.NewError(http.StatusInternalServerError, errDelete.Error())
+	}
+
+	return nil
+}
+
+func (w *WebhookServiceImpl) FindDeliveries(ctx context.Context, webhookID, status string, page, size int) (*dto.PaginationResponse, *handler.CustomError) {
+	if !utils.IsEmptyString(status) {
+		if _, isValidStatus := enum.ParseWebhookDeliveryStatus(status); !isValidStatus {
+			return nil, handler.NewError(http.StatusBadRequest, "invalid delivery status, must be one of 'pending', 'delivered', 'dead'")
+		}
+	}
+
+	if !utils.IsEmptyString(webhookID) {
+		if _, err := w.findByID(ctx, webhookID); err != nil {
+			return nil, err
+		}
+	}
+
+	deliveries, total, err := w.webhookRepository.FindDeliveries(ctx, webhookID, status, page, size)
+	if err != nil {
+		return nil, handler.NewError(http.StatusInternalServerError, err.Error())
+	}
+
+	return dto.NewPaginationResponse(page, size, int(total), deliveries), nil
+}
+
+// ReplayDelivery queues a dead letter to be delivered again with a fresh set of attempts
+func (w *WebhookServiceImpl) ReplayDelivery(ctx context.Context, id string) (*domain.WebhookDelivery, *handler.CustomError) {
+	delivery, err := w.webhookRepository.GetDeliveryByID(ctx, id)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, handler.NewError(http.StatusNotFound, "webhook delivery with that id was not found")
+		}
+		return nil, handler.NewError(http.StatusInternalServerError, err.Error())
+	}
+
+	if delivery.Status != enum.DeliveryDead {
+		return nil, handler.NewError(http.StatusConflict, fmt.Sprintf("delivery is '%s', only dead letters can be replayed", delivery.Status))
+	}
+
+	now := w.now()
+	delivery.Status = enum.DeliveryPending
+	delivery.Attempts = 0
+	delivery.NextAttemptAt = &now
+	if errUpdate := w.webhookRepository.UpdateDelivery(ctx, delivery); errUpdate != nil {
+		return nil, handler.NewError(http.StatusInternalServerError, errUpdate.Error())
+	}
+
+	w.notifyQueued()
+	return &delivery, nil
+}
+
+// ReplayDeadLetters queues every dead letter of the webhook to be delivered again
+func (w *WebhookServiceImpl) ReplayDeadLetters(ctx context.Context, webhookID string) (*response.ReplayWebhookDeliveriesResponse, *handler.CustomError) {
+	subscription, err := w.findByID(ctx, webhookID)
+	if err != nil {
+		return nil, err
+	}
+
+	requeued, errRequeue := w.webhookRepository.RequeueDeliveries(ctx, subscription.ID.String(), enum.DeliveryDead, w.now())
+	if errRequeue != nil {
+		return nil, handler.NewError(http.StatusInternalServerError, errRequeue.Error())
+	}
+
+	if requeued > 0 {
+		w.notifyQueued()
+	}
+	return &response.ReplayWebhookDeliveriesResponse{Requeued: requeued}, nil
+}
+
+func (w *WebhookServiceImpl) Queued() <-chan struct{} {
+	return w.queued
+}
+
+// OnUnitChanged queues the unit.created, unit.updated and unit.deleted deliveries in the transaction of the change,
+// a unit restored from the trash is announced as updated
+func (w *WebhookServiceImpl) OnUnitChanged(ctx context.Context, eventType enum.UnitEventType, unit domain.Units) error {
+	var event enum.WebhookEvent
+	switch eventType {
+	case enum.UnitCreated:
+		event = enum.WebhookUnitCreated
+	case enum.UnitUpdated, enum.UnitRestored:
+		event = enum.WebhookUnitUpdated
+	case enum.UnitDeleted:
+		event = enum.WebhookUnitDeleted
+	default:
+		return nil
+	}
+
+	return w.enqueue(ctx, event, response.WebhookUnitData{Unit: response.BuildUnitDetailResponseFromUnit(unit)})
+}
+
+// OnUnitStatusChanged queues the unit.status_changed deliveries in the transaction of the change, the first
+// status of a new unit is part of unit.created
+func (w *WebhookServiceImpl) OnUnitStatusChanged(ctx context.Context, unit domain.Units, history domain.UnitStatusHistory) error {
+	if history.FromStatus == nil {
+		return nil
+	}
+
+	toStatus := history.ToStatus
+	return w.enqueue(ctx, enum.WebhookUnitStatusChanged, response.WebhookUnitData{
+		Unit:       response.BuildUnitDetailResponseFromUnit(unit),
+		FromStatus: history.FromStatus,
+		ToStatus:   &toStatus,
+		Actor:      history.Actor,
+		Reason:     history.Reason,
+	})
+}
+
+// enqueue stores a delivery for every active webhook subscribed to the event, with the transaction carried by ctx
+// so a delivery exists exactly when the unit change was committed. The dispatcher is woken after the commit
+func (w *WebhookServiceImpl) enqueue(ctx context.Context, event enum.WebhookEvent, data response.WebhookUnitData) error {
+	subscriptions, err := w.webhookRepository.FindActive(ctx)
+	if err != nil {
+		logging.Error(ctx, "failed to find webhooks", err, "event", event, "unit_id", data.Unit.ID)
+		return err
+	}
+
+	now := w.now()
+	deliveries := make([]domain.WebhookDelivery, 0, len(subscriptions))
+	for _, subscription := range subscriptions {
+		if !subscription.Subscribes(event) {
+			continue
+		}
+
+		payload := response.WebhookPayload{ID: uuid.New(), Event: event, OccurredAt: now, Data: data}
+		content, err := json.Marshal(payload)
+		if err != nil {
+			slog.ErrorContext(ctx, "failed to encode webhook payload", "event", event, "unit_id", data.Unit.ID, "error", err)
+			return err
+		}
+
+		deliveries = append(deliveries, domain.WebhookDelivery{
+			ID:             payload.ID,
+			SubscriptionID: subscription.ID,
+			Event:          event,
+			Payload:        string(content),
+			Status:         enum.DeliveryPending,
+			NextAttemptAt:  &now,
+		})
+	}
+
+	if len(deliveries) == 0 {
+		return nil
+	}
+
+	if err := w.webhookRepository.CreateDeliveries(ctx, deliveries); err != nil {
+		logging.Error(ctx, "failed to queue webhook deliveries", err, "event", event, "unit_id", data.Unit.ID)
+		return err
+	}
+
+	transaction.AfterCommit(ctx, func(ctx context.Context) { w.notifyQueued() })
+	return nil
+}
+
+// notifyQueued wakes the dispatcher without blocking, one pending signal is enough
+func (w *WebhookServiceImpl) notifyQueued() {
+	select {
+	case w.queued <- struct{}{}:
+	default:
+	}
+}
+
+func validateWebhookURL(value string) *handler.CustomError {
+	parsedURL, err := url.Parse(value)
+	if err != nil || (parsedURL.Scheme != "http" && parsedURL.Scheme != "https") || utils.IsEmptyString(parsedURL.Host) {
+		return handler.NewError(http.StatusBadRequest, "invalid webhook url, must be an absolute http or https url")
+	}
+
+	return nil
+}
+
+// parseWebhookEvents reads the event filter, an empty filter subscribes to every event
+func parseWebhookEvents(values []string) ([]enum.WebhookEvent, *handler.CustomError) {
+	if len(values) == 0 {
+		return enum.WebhookEvents(), nil
+	}
+
+	events := make([]enum.WebhookEvent, 0, len(values))
+	for _, value := range values {
+		event, isValidEvent := enum.ParseWebhookEvent(strings.TrimSpace(value))
+		if !isValidEvent {
+			return nil, handler.NewError(http.StatusBadRequest, fmt.Sprintf(invalidEventMessage, value))
+		}
+		if !slices.Contains(events, event) {
+			events = append(events, event)
+		}
+	}
+
+	return events, nil
+}
@@ -0,0 +1,492 @@
+package webhooks
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"unit-management-be/pkg/model/domain"
+	"unit-management-be/pkg/model/domain/enum"
+	"unit-management-be/pkg/model/dto/request"
+	"unit-management-be/pkg/model/dto/response"
+	webhookrepository "unit-management-be/pkg/repository/webhooks"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+// MockWebhookRepository of webhook repository
+type MockWebhookRepository struct {
+	mock.Mock
+}
+
+func (m *MockWebhookRepository) Create(ctx context.Context, subscription domain.WebhookSubscription) (domain.WebhookSubscription, error) {
+	args := m.Called(ctx, subscription)
+	// the subscription is returned as stored unless the test returns another one
+	if created, ok := args.Get(0).(domain.WebhookSubscription); ok {
+		return created, args.Error(1)
+	}
+	return subscription, args.Error(1)
+}
+
+func (m *MockWebhookRepository) GetByID(ctx context.Context, id string) (domain.WebhookSubscription, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(domain.WebhookSubscription), args.Error(1)
+}
+
+func (m *MockWebhookRepository) FindAll(ctx context.Context, page, size int) ([]domain.WebhookSubscription, int64, error) {
+	args := m.Called(ctx, page, size)
+	return args.Get(0).([]domain.WebhookSubscription), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockWebhookRepository) FindActive(ctx context.Context) ([]domain.WebhookSubscription, error) {
+	args := m.Called(ctx)
+	return args.Get(0).([]domain.WebhookSubscription), args.Error(1)
+}
+
+func (m *MockWebhookRepository) Update(ctx context.Context, subscription domain.WebhookSubscription) error {
+	args := m.Called(ctx, subscription)
+	return args.Error(0)
+}
+
+func (m *MockWebhookRepository) Delete(ctx context.Context, subscription domain.WebhookSubscription) error {
+	args := m.Called(ctx, subscription)
+	return args.Error(0)
+}
+
+func (m *MockWebhookRepository) CreateDeliveries(ctx context.Context, deliveries []domain.WebhookDelivery) error {
+	args := m.Called(ctx, deliveries)
+	return args.Error(0)
+}
+
+func (m *MockWebhookRepository) GetDeliveryByID(ctx context.Context, id string) (domain.WebhookDelivery, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(domain.WebhookDelivery), args.Error(1)
+}
+
+func (m *MockWebhookRepository) FindDeliveries(ctx context.Context, subscriptionID, status string, page, size int) ([]domain.WebhookDelivery, int64, error) {
+	args := m.Called(ctx, subscriptionID, status, page, size)
+	return args.Get(0).([]domain.WebhookDelivery), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockWebhookRepository) FindDueDeliveries(ctx context.Context, now time.Time, limit int) ([]domain.WebhookDelivery, error) {
+	args := m.Called(ctx, now, limit)
+	return args.Get(0).([]domain.WebhookDelivery), args.Error(1)
+}
+
+func (m *MockWebhookRepository) UpdateDelivery(ctx context.Context, delivery domain.WebhookDelivery) error {
+	args := m.Called(ctx, delivery)
+	return args.Error(0)
+}
+
+func (m *MockWebhookRepository) RequeueDeliveries(ctx context.Context, subscriptionID string, status enum.WebhookDeliveryStatus, now time.Time) (int64, error) {
+	args := m.Called(ctx, subscriptionID, status, now)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+var _ webhookrepository.WebhookRepository = &MockWebhookRepository{}
+
+var testNow = time.Date(2025, 10, 21, 9, 0, 0, 0, time.UTC)
+
+// initialization service and webhook repository
+func setupTest(t *testing.T) (*MockWebhookRepository, *WebhookServiceImpl) {
+	mockRepo := new(MockWebhookRepository)
+	webhookService := &WebhookServiceImpl{
+		webhookRepository: mockRepo,
+		client:            &http.Client{Timeout: time.Second},
+		queued:            make(chan struct{}, 1),
+		now:               func() time.Time { return testNow },
+	}
+	return mockRepo, webhookService
+}
+
+func newSubscription(url string, events ...enum.WebhookEvent) domain.WebhookSubscription {
+	subscription := domain.WebhookSubscription{ID: uuid.New(), URL: url, Secret: "0123456789abcdef", Active: true}
+	subscription.SetEvents(events)
+	return subscription
+}
+
+func TestCreateWebhook(t *testing.T) {
+	t.Run("Positive Case: Create webhook subscribed to every event by default", func(t *testing.T) {
+		mockRepo, webhookService := setupTest(t)
+
+		mockRepo.On("Create", mock.Anything, mock.Anything).Return(nil, nil).Once()
+
+		result, err := webhookService.CreateWebhook(context.Background(), request.CreateWebhookDto{
+			URL:       "https://locks.example.com/hooks/units",
+			Secret:    "0123456789abcdef",
+			CreatedBy: "admin",
+		})
+
+		assert.Nil(t, err)
+		assert.True(t, result.Active)
+		assert.Equal(t, enum.WebhookEvents(), result.Events)
+		assert.Equal(t, "admin", result.CreatedBy)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Positive Case: Event filter is kept without duplicates", func(t *testing.T) {
+		mockRepo, webhookService := setupTest(t)
+		inactive := false
+
+		mockRepo.On("Create", mock.Anything, mock.Anything).Return(nil, nil).Once()
+
+		result, err := webhookService.CreateWebhook(context.Background(), request.CreateWebhookDto{
+			URL:    "http://booking.internal:8080/units",
+			Events: []string{"unit.status_changed", "unit.deleted", "unit.status_changed"},
+			Secret: "0123456789abcdef",
+			Active: &inactive,
+		})
+
+		assert.Nil(t, err)
+		assert.False(t, result.Active)
+		assert.Equal(t, []enum.WebhookEvent{enum.WebhookUnitStatusChanged, enum.WebhookUnitDeleted}, result.Events)
+	})
+
+	t.Run("Negative Case: Invalid url, event or secret", func(t *testing.T) {
+		mockRepo, webhookService := setupTest(t)
+
+		testCases := []request.CreateWebhookDto{
+			{URL: "locks.example.com/hooks", Secret: "0123456789abcdef"},
+			{URL: "ftp://locks.example.com/hooks", Secret: "0123456789abcdef"},
+			{URL: "https://locks.example.com/hooks", Events: []string{"unit.moved"}, Secret: "0123456789abcdef"},
+			{URL: "https://locks.example.com/hooks", Secret: "short"},
+		}
+
+		for _, testCase := range testCases {
+			result, err := webhookService.CreateWebhook(context.Background(), testCase)
+			assert.Nil(t, result)
+			assert.Equal(t, http.StatusBadRequest, err.Code, err.Message)
+		}
+		mockRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+}
+
+func TestUpdateWebhook(t *testing.T) {
+	t.Run("Positive Case: Empty secret keeps the current secret", func(t *testing.T) {
+		mockRepo, webhookService := setupTest(t)
+		subscription := newSubscription("https://locks.example.com/hooks", enum.WebhookUnitCreated)
+
+		mockRepo.On("GetByID", mock.Anything, subscription.ID.String()).Return(subscription, nil).Once()
+		mockRepo.On("Update", mock.Anything, mock.MatchedBy(func(updated domain.WebhookSubscription) bool {
+			return updated.Secret == subscription.Secret && updated.URL == "https://locks.example.com/v2" && !updated.Active
+		})).Return(nil).Once()
+
+		inactive := false
+		result, err := webhookService.UpdateWebhook(context.Background(), subscription.ID.String(), request.UpdateWebhookDto{
+			URL:    "https://locks.example.com/v2",
+			Events: []string{"unit.deleted"},
+			Active: &inactive,
+		})
+
+		assert.Nil(t, err)
+		assert.Equal(t, []enum.WebhookEvent{enum.WebhookUnitDeleted}, result.Events)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Negative Case: Webhook not found", func(t *testing.T) {
+		mockRepo, webhookService := setupTest(t)
+		id := uuid.New().String()
+
+		mockRepo.On("GetByID", mock.Anything, id).Return(domain.WebhookSubscription{}, gorm.ErrRecordNotFound).Once()
+
+		result, err := webhookService.UpdateWebhook(context.Background(), id, request.UpdateWebhookDto{URL: "https://locks.example.com/v2"})
+		assert.Nil(t, result)
+		assert.Equal(t, http.StatusNotFound, err.Code)
+	})
+}
+
+func TestUnitEvents(t *testing.T) {
+	unit := domain.Units{ID: uuid.New(), Name: "Cabin 1", Type: enum.Cabin, Status: enum.CleaningInProgress, Version: 3}
+
+	t.Run("Positive Case: Delivery queued for every subscribed webhook", func(t *testing.T) {
+		mockRepo, webhookService := setupTest(t)
+		statusHook := newSubscription("https://locks.example.com/hooks", enum.WebhookUnitStatusChanged)
+		allHook := newSubscription("https://booking.example.com/hooks", enum.WebhookEvents()...)
+		createdHook := newSubscription("https://audit.example.com/hooks", enum.WebhookUnitCreated)
+
+		var queued []domain.WebhookDelivery
+		mockRepo.On("FindActive", mock.Anything).Return([]domain.WebhookSubscription{statusHook, allHook, createdHook}, nil).Once()
+		mockRepo.On("CreateDeliveries", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+			queued = args.Get(1).([]domain.WebhookDelivery)
+		}).Return(nil).Once()
+
+		from := enum.Occupied
+		err := webhookService.OnUnitStatusChanged(context.Background(), unit, domain.UnitStatusHistory{
+			UnitID:     unit.ID,
+			FromStatus: &from,
+			ToStatus:   enum.CleaningInProgress,
+			Actor:      "maria",
+			Reason:     "guest checked out",
+		})
+		assert.NoError(t, err)
+
+		assert.Len(t, queued, 2)
+		assert.Equal(t, statusHook.ID, queued[0].SubscriptionID)
+		assert.Equal(t, allHook.ID, queued[1].SubscriptionID)
+
+		var payload response.WebhookPayload
+		assert.NoError(t, json.Unmarshal([]byte(queued[0].Payload), &payload))
+		assert.Equal(t, queued[0].ID, payload.ID)
+		assert.Equal(t, enum.WebhookUnitStatusChanged, payload.Event)
+		assert.Equal(t, unit.ID, payload.Data.Unit.ID)
+		assert.Equal(t, enum.Occupied, *payload.Data.FromStatus)
+		assert.Equal(t, enum.CleaningInProgress, *payload.Data.ToStatus)
+		assert.Equal(t, "maria", payload.Data.Actor)
+
+		assert.Equal(t, enum.DeliveryPending, queued[0].Status)
+		assert.Equal(t, testNow, *queued[0].NextAttemptAt)
+		assert.Len(t, webhookService.Queued(), 1)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Positive Case: Unit changes map to webhook events", func(t *testing.T) {
+		testCases := []struct {
+			eventType enum.UnitEventType
+			event     enum.WebhookEvent
+		}{
+			{enum.UnitCreated, enum.WebhookUnitCreated},
+			{enum.UnitUpdated, enum.WebhookUnitUpdated},
+			{enum.UnitRestored, enum.WebhookUnitUpdated},
+			{enum.UnitDeleted, enum.WebhookUnitDeleted},
+		}
+
+		for _, testCase := range testCases {
+			mockRepo, webhookService := setupTest(t)
+			mockRepo.On("FindActive", mock.Anything).Return([]domain.WebhookSubscription{newSubscription("https://booking.example.com/hooks", enum.WebhookEvents()...)}, nil).Once()
+			mockRepo.On("CreateDeliveries", mock.Anything, mock.MatchedBy(func(deliveries []domain.WebhookDelivery) bool {
+				return len(deliveries) == 1 && deliveries[0].Event == testCase.event
+			})).Return(nil).Once()
+
+			assert.NoError(t, webhookService.OnUnitChanged(context.Background(), testCase.eventType, unit))
+			mockRepo.AssertExpectations(t)
+		}
+	})
+
+	t.Run("Negative Case: Nothing queued without a subscribed webhook or for the status of a new unit", func(t *testing.T) {
+		mockRepo, webhookService := setupTest(t)
+		inactive := newSubscription("https://booking.example.com/hooks", enum.WebhookEvents()...)
+		inactive.Active = false
+
+		mockRepo.On("FindActive", mock.Anything).Return([]domain.WebhookSubscription{inactive, newSubscription("https://locks.example.com/hooks", enum.WebhookUnitDeleted)}, nil).Once()
+		assert.NoError(t, webhookService.OnUnitChanged(context.Background(), enum.UnitCreated, unit))
+
+		assert.NoError(t, webhookService.OnUnitStatusChanged(context.Background(), unit, domain.UnitStatusHistory{UnitID: unit.ID, ToStatus: enum.Available}))
+
+		mockRepo.AssertNotCalled(t, "CreateDeliveries", mock.Anything, mock.Anything)
+		assert.Len(t, webhookService.Queued(), 0)
+	})
+
+	t.Run("Negative Case: Failed queueing is returned so the unit change is rolled back", func(t *testing.T) {
+		mockRepo, webhookService := setupTest(t)
+		mockRepo.On("FindActive", mock.Anything).Return([]domain.WebhookSubscription{newSubscription("https://booking.example.com/hooks", enum.WebhookEvents()...)}, nil).Once()
+		mockRepo.On("CreateDeliveries", mock.Anything, mock.Anything).Return(gorm.ErrInvalidDB).Once()
+
+		assert.ErrorIs(t, webhookService.OnUnitChanged(context.Background(), enum.UnitUpdated, unit), gorm.ErrInvalidDB)
+		assert.Len(t, webhookService.Queued(), 0)
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+func TestDeliverDue(t *testing.T) {
+	t.Run("Positive Case: Signed payload delivered", func(t *testing.T) {
+		var received *http.Request
+		var receivedBody []byte
+		receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			received = r
+			receivedBody, _ = io.ReadAll(r.Body)
+			w.WriteHeader(http.StatusNoContent)
+		}))
+		defer receiver.Close()
+
+		mockRepo, webhookService := setupTest(t)
+		subscription := newSubscription(receiver.URL, enum.WebhookUnitCreated)
+		delivery := domain.WebhookDelivery{ID: uuid.New(), SubscriptionID: subscription.ID, Event: enum.WebhookUnitCreated, Payload: `{"event":"unit.created"}`, Status: enum.DeliveryPending}
+
+		mockRepo.On("FindDueDeliveries", mock.Anything, testNow, deliveryBatchSize).Return([]domain.WebhookDelivery{delivery}, nil).Once()
+		mockRepo.On("GetByID", mock.Anything, subscription.ID.String()).Return(subscription, nil).Once()
+		mockRepo.On("UpdateDelivery", mock.Anything, mock.MatchedBy(func(updated domain.WebhookDelivery) bool {
+			return updated.Status == enum.DeliveryDelivered && updated.Attempts == 1 &&
+				updated.LastStatusCode == http.StatusNoContent && updated.DeliveredAt.Equal(testNow) && updated.NextAttemptAt == nil
+		})).Return(nil).Once()
+
+		attempted, err := webhookService.DeliverDue(context.Background())
+
+		assert.Nil(t, err)
+		assert.Equal(t, 1, attempted)
+		assert.Equal(t, `{"event":"unit.created"}`, string(receivedBody))
+		assert.Equal(t, "unit.created", received.Header.Get(eventHeader))
+		assert.Equal(t, delivery.ID.String(), received.Header.Get(deliveryHeader))
+		assert.Equal(t, strconv.FormatInt(testNow.Unix(), 10), received.Header.Get(timestampHeader))
+		assert.Equal(t, signPayload(subscription.Secret, testNow.Unix(), receivedBody), received.Header.Get(signatureHeader))
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Negative Case: Failed attempt is retried with backoff", func(t *testing.T) {
+		receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer receiver.Close()
+
+		mockRepo, webhookService := setupTest(t)
+		subscription := newSubscription(receiver.URL, enum.WebhookUnitCreated)
+		delivery := domain.WebhookDelivery{ID: uuid.New(), SubscriptionID: subscription.ID, Payload: `{}`, Status: enum.DeliveryPending, Attempts: 2}
+
+		mockRepo.On("FindDueDeliveries", mock.Anything, testNow, deliveryBatchSize).Return([]domain.WebhookDelivery{delivery}, nil).Once()
+		mockRepo.On("GetByID", mock.Anything, subscription.ID.String()).Return(subscription, nil).Once()
+		mockRepo.On("UpdateDelivery", mock.Anything, mock.MatchedBy(func(updated domain.WebhookDelivery) bool {
+			return updated.Status == enum.DeliveryPending && updated.Attempts == 3 &&
+				updated.LastStatusCode == http.StatusServiceUnavailable &&
+				updated.LastError == "webhook responded with status 503" &&
+				updated.NextAttemptAt.Equal(testNow.Add(2*time.Minute))
+		})).Return(nil).Once()
+
+		_, err := webhookService.DeliverDue(context.Background())
+		assert.Nil(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Negative Case: Last failed attempt moves the delivery to the dead letters", func(t *testing.T) {
+		receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+		receiver.Close()
+
+		mockRepo, webhookService := setupTest(t)
+		subscription := newSubscription(receiver.URL, enum.WebhookUnitCreated)
+		delivery := domain.WebhookDelivery{ID: uuid.New(), SubscriptionID: subscription.ID, Payload: `{}`, Status: enum.DeliveryPending, Attempts: maxDeliveryAttempts - 1}
+
+		mockRepo.On("FindDueDeliveries", mock.Anything, testNow, deliveryBatchSize).Return([]domain.WebhookDelivery{delivery}, nil).Once()
+		mockRepo.On("GetByID", mock.Anything, subscription.ID.String()).Return(subscription, nil).Once()
+		mockRepo.On("UpdateDelivery", mock.Anything, mock.MatchedBy(func(updated domain.WebhookDelivery) bool {
+			return updated.Status == enum.DeliveryDead && updated.Attempts == maxDeliveryAttempts &&
+				updated.LastStatusCode == 0 && updated.LastError != "" && updated.NextAttemptAt == nil
+		})).Return(nil).Once()
+
+		_, err := webhookService.DeliverDue(context.Background())
+		assert.Nil(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Negative Case: Failing receiver only holds up its own deliveries", func(t *testing.T) {
+		var failedRequests atomic.Int32
+		failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			failedRequests.Add(1)
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer failing.Close()
+		healthy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer healthy.Close()
+
+		mockRepo, webhookService := setupTest(t)
+		failingHook := newSubscription(failing.URL, enum.WebhookUnitCreated)
+		healthyHook := newSubscription(healthy.URL, enum.WebhookUnitCreated)
+		deliveries := []domain.WebhookDelivery{
+			{ID: uuid.New(), SubscriptionID: failingHook.ID, Payload: `{}`, Status: enum.DeliveryPending},
+			{ID: uuid.New(), SubscriptionID: healthyHook.ID, Payload: `{}`, Status: enum.DeliveryPending},
+			{ID: uuid.New(), SubscriptionID: failingHook.ID, Payload: `{}`, Status: enum.DeliveryPending},
+		}
+
+		mockRepo.On("FindDueDeliveries", mock.Anything, testNow, deliveryBatchSize).Return(deliveries, nil).Once()
+		mockRepo.On("GetByID", mock.Anything, failingHook.ID.String()).Return(failingHook, nil).Once()
+		mockRepo.On("GetByID", mock.Anything, healthyHook.ID.String()).Return(healthyHook, nil).Once()
+		mockRepo.On("UpdateDelivery", mock.Anything, mock.MatchedBy(func(updated domain.WebhookDelivery) bool {
+			return updated.ID == deliveries[0].ID && updated.Status == enum.DeliveryPending
+		})).Return(nil).Once()
+		mockRepo.On("UpdateDelivery", mock.Anything, mock.MatchedBy(func(updated domain.WebhookDelivery) bool {
+			return updated.ID == deliveries[1].ID && updated.Status == enum.DeliveryDelivered
+		})).Return(nil).Once()
+
+		attempted, err := webhookService.DeliverDue(context.Background())
+		assert.Nil(t, err)
+		assert.Equal(t, 2, attempted)
+		assert.Equal(t, int32(1), failedRequests.Load())
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+func TestRetryDelay(t *testing.T) {
+	assert.Equal(t, 30*time.Second, retryDelay(1))
+	assert.Equal(t, time.Minute, retryDelay(2))
+	assert.Equal(t, 32*time.Minute, retryDelay(7))
+	assert.Equal(t, time.Hour, retryDelay(20))
+}
+
+func TestReplayDelivery(t *testing.T) {
+	t.Run("Positive Case: Dead letter queued again", func(t *testing.T) {
+		mockRepo, webhookService := setupTest(t)
+		delivery := domain.WebhookDelivery{ID: uuid.New(), Status: enum.DeliveryDead, Attempts: maxDeliveryAttempts, LastError: "timeout"}
+
+		mockRepo.On("GetDeliveryByID", mock.Anything, delivery.ID.String()).Return(delivery, nil).Once()
+		mockRepo.On("UpdateDelivery", mock.Anything, mock.MatchedBy(func(updated domain.WebhookDelivery) bool {
+			return updated.Status == enum.DeliveryPending && updated.Attempts == 0 && updated.NextAttemptAt.Equal(testNow)
+		})).Return(nil).Once()
+
+		result, err := webhookService.ReplayDelivery(context.Background(), delivery.ID.String())
+
+		assert.Nil(t, err)
+		assert.Equal(t, enum.DeliveryPending, result.Status)
+		assert.Len(t, webhookService.Queued(), 1)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Negative Case: Only dead letters can be replayed", func(t *testing.T) {
+		mockRepo, webhookService := setupTest(t)
+		delivery := domain.WebhookDelivery{ID: uuid.New(), Status: enum.DeliveryDelivered}
+
+		mockRepo.On("GetDeliveryByID", mock.Anything, delivery.ID.String()).Return(delivery, nil).Once()
+
+		result, err := webhookService.ReplayDelivery(context.Background(), delivery.ID.String())
+		assert.Nil(t, result)
+		assert.Equal(t, http.StatusConflict, err.Code)
+		mockRepo.AssertNotCalled(t, "UpdateDelivery", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Negative Case: Delivery not found", func(t *testing.T) {
+		mockRepo, webhookService := setupTest(t)
+		id := uuid.New().String()
+
+		mockRepo.On("GetDeliveryByID", mock.Anything, id).Return(domain.WebhookDelivery{}, gorm.ErrRecordNotFound).Once()
+
+		_, err := webhookService.ReplayDelivery(context.Background(), id)
+		assert.Equal(t, http.StatusNotFound, err.Code)
+	})
+}
+
+func TestReplayDeadLetters(t *testing.T) {
+	t.Run("Positive Case: Dead letters of the webhook queued again", func(t *testing.T) {
+		mockRepo, webhookService := setupTest(t)
+		subscription := newSubscription("https://locks.example.com/hooks", enum.WebhookUnitCreated)
+
+		mockRepo.On("GetByID", mock.Anything, subscription.ID.String()).Return(subscription, nil).Once()
+		mockRepo.On("RequeueDeliveries", mock.Anything, subscription.ID.String(), enum.DeliveryDead, testNow).Return(int64(3), nil).Once()
+
+		result, err := webhookService.ReplayDeadLetters(context.Background(), subscription.ID.String())
+
+		assert.Nil(t, err)
+		assert.Equal(t, int64(3), result.Requeued)
+		assert.Len(t, webhookService.Queued(), 1)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Negative Case: Webhook not found", func(t *testing.T) {
+		mockRepo, webhookService := setupTest(t)
+		id := uuid.New().String()
+
+		mockRepo.On("GetByID", mock.Anything, id).Return(domain.WebhookSubscription{}, gorm.ErrRecordNotFound).Once()
+
+		_, err := webhookService.ReplayDeadLetters(context.Background(), id)
+		assert.Equal(t, http.StatusNotFound, err.Code)
+		mockRepo.AssertNotCalled(t, "RequeueDeliveries", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
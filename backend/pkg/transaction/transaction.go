@@ -0,0 +1,67 @@
+// Package transaction shares a database transaction between repositories through the context,
+// so a service can commit the writes of several repositories, or of other services, at once
+package transaction
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+)
+
+type scopeKey struct{}
+
+// scope is the transaction carried by a context and the callbacks waiting for its commit
+type scope struct {
+	tx          *gorm.DB
+	afterCommit []func(ctx context.Context)
+}
+
+// Run runs fn in a transaction carried by the context fn receives, repositories reading their connection
+// with DB join it. The transaction is committed when fn returns nil and rolled back otherwise. Inside
+// another transaction fn runs in a savepoint, and nothing is committed before the outer transaction is
+func Run(ctx context.Context, db *gorm.DB, fn func(ctx context.Context) error) error {
+	parent, _ := ctx.Value(scopeKey{}).(*scope)
+	conn := db
+	if parent != nil {
+		conn = parent.tx
+	}
+
+	current := &scope{}
+	err := conn.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		current.tx = tx
+		return fn(context.WithValue(ctx, scopeKey{}, current))
+	})
+	if err != nil {
+		return err
+	}
+
+	if parent != nil {
+		parent.afterCommit = append(parent.afterCommit, current.afterCommit...)
+		return nil
+	}
+	for _, callback := range current.afterCommit {
+		callback(ctx)
+	}
+	return nil
+}
+
+// DB returns the transaction carried by ctx, or db outside a transaction, bound to ctx
+func DB(ctx context.Context, db *gorm.DB) *gorm.DB {
+	if current, ok := ctx.Value(scopeKey{}).(*scope); ok {
+		return current.tx.WithContext(ctx)
+	}
+
+	return db.WithContext(ctx)
+}
+
+// AfterCommit runs callback once the transaction carried by ctx is committed and drops it when the
+// transaction is rolled back. Outside a transaction callback runs right away, the context callback
+// receives carries no transaction
+func AfterCommit(ctx context.Context, callback func(ctx context.Context)) {
+	if current, ok := ctx.Value(scopeKey{}).(*scope); ok {
+		current.afterCommit = append(current.afterCommit, callback)
+		return
+	}
+
+	callback(ctx)
+}
@@ -0,0 +1,126 @@
+package transaction_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"unit-management-be/internal/db"
+	"unit-management-be/pkg/transaction"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+type item struct {
+	Name string
+}
+
+var errRollback = errors.New("rollback")
+
+func setupTest(t *testing.T) *gorm.DB {
+	database, err := db.Open(db.DriverSQLite, "file::memory:?_time_format=sqlite", db.DefaultPool)
+	require.NoError(t, err)
+	sqlDB, err := database.DB()
+	require.NoError(t, err)
+	t.Cleanup(func() { sqlDB.Close() })
+
+	require.NoError(t, database.Exec("CREATE TABLE items (name TEXT NOT NULL)").Error)
+	return database
+}
+
+func insert(ctx context.Context, database *gorm.DB, name string) error {
+	return transaction.DB(ctx, database).Create(&item{Name: name}).Error
+}
+
+func names(t *testing.T, database *gorm.DB) []string {
+	var result []string
+	require.NoError(t, database.Model(&item{}).Order("name").Pluck("name", &result).Error)
+	return result
+}
+
+func TestRun(t *testing.T) {
+	t.Run("Positive Case: Writes are committed together and callbacks run after the commit", func(t *testing.T) {
+		database := setupTest(t)
+		var committed []string
+
+		err := transaction.Run(context.Background(), database, func(ctx context.Context) error {
+			transaction.AfterCommit(ctx, func(ctx context.Context) {
+				committed = names(t, database)
+			})
+			if err := insert(ctx, database, "a"); err != nil {
+				return err
+			}
+			return insert(ctx, database, "b")
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"a", "b"}, names(t, database))
+		assert.Equal(t, []string{"a", "b"}, committed)
+	})
+
+	t.Run("Negative Case: Error rolls back every write and drops the callbacks", func(t *testing.T) {
+		database := setupTest(t)
+		called := false
+
+		err := transaction.Run(context.Background(), database, func(ctx context.Context) error {
+			transaction.AfterCommit(ctx, func(ctx context.Context) { called = true })
+			if err := insert(ctx, database, "a"); err != nil {
+				return err
+			}
+			return errRollback
+		})
+		assert.ErrorIs(t, err, errRollback)
+		assert.Empty(t, names(t, database))
+		assert.False(t, called)
+	})
+
+	t.Run("Positive Case: Nested transaction waits for the outer commit", func(t *testing.T) {
+		database := setupTest(t)
+		called := false
+
+		err := transaction.Run(context.Background(), database, func(ctx context.Context) error {
+			errNested := transaction.Run(ctx, database, func(ctx context.Context) error {
+				transaction.AfterCommit(ctx, func(ctx context.Context) { called = true })
+				return insert(ctx, database, "a")
+			})
+			if errNested != nil {
+				return errNested
+			}
+
+			assert.False(t, called)
+			return errRollback
+		})
+		assert.ErrorIs(t, err, errRollback)
+		assert.Empty(t, names(t, database))
+		assert.False(t, called)
+	})
+
+	t.Run("Positive Case: Failed nested transaction only rolls back its own writes", func(t *testing.T) {
+		database := setupTest(t)
+
+		err := transaction.Run(context.Background(), database, func(ctx context.Context) error {
+			if err := insert(ctx, database, "a"); err != nil {
+				return err
+			}
+
+			errNested := transaction.Run(ctx, database, func(ctx context.Context) error {
+				if err := insert(ctx, database, "b"); err != nil {
+					return err
+				}
+				return errRollback
+			})
+			assert.ErrorIs(t, errNested, errRollback)
+			return nil
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"a"}, names(t, database))
+	})
+}
+
+func TestAfterCommit(t *testing.T) {
+	t.Run("Positive Case: Runs right away outside a transaction", func(t *testing.T) {
+		called := false
+		transaction.AfterCommit(context.Background(), func(ctx context.Context) { called = true })
+		assert.True(t, called)
+	})
+}
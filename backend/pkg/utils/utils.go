@@ -2,6 +2,45 @@ package utils
 
 import "strings"
 
+// LikeEscape is the escape character used with EscapeLike, backslash is avoided because
+// MySQL and PostgreSQL treat it differently inside string literals
+const LikeEscape = "!"
+
+var likeReplacer = strings.NewReplacer(LikeEscape, LikeEscape+LikeEscape, "%", LikeEscape+"%", "_", LikeEscape+"_")
+
 func IsEmptyString(s string) bool {
 	return strings.TrimSpace(s) == ""
 }
+
+// EscapeLike escapes the LIKE wildcards in s so it is matched literally, use it with ESCAPE '!'
+func EscapeLike(s string) string {
+	return likeReplacer.Replace(s)
+}
+
+// csvQuote is put in front of a CSV cell that a spreadsheet would otherwise run as a formula
+const csvQuote = "'"
+
+// EscapeCSVCell prefixes s with a quote when it starts like a spreadsheet formula, a cell already
+// starting with the quote gets one more so UnescapeCSVCell gives the original value back
+func EscapeCSVCell(s string) string {
+	if s == "" {
+		return s
+	}
+	switch s[0] {
+	case '=', '+', '-', '@', '\t', '\r', csvQuote[0]:
+		return csvQuote + s
+	}
+	return s
+}
+
+// UnescapeCSVCell removes the quote added by EscapeCSVCell, a quote in front of any other value is kept
+func UnescapeCSVCell(s string) string {
+	if len(s) < 2 || !strings.HasPrefix(s, csvQuote) {
+		return s
+	}
+	switch s[1] {
+	case '=', '+', '-', '@', '\t', '\r', csvQuote[0]:
+		return s[1:]
+	}
+	return s
+}
@@ -25,3 +25,32 @@ func TestIsEmptyString(t *testing.T) {
 		assert.False(t, IsEmptyString("!@#$"))
 	})
 }
+
+func TestEscapeLike(t *testing.T) {
+	assert.Equal(t, "cabin", EscapeLike("cabin"))
+	assert.Equal(t, "50!%", EscapeLike("50%"))
+	assert.Equal(t, "unit!_a", EscapeLike("unit_a"))
+	assert.Equal(t, "wow!!", EscapeLike("wow!"))
+	assert.Equal(t, "!!!%!_", EscapeLike("!%_"))
+}
+
+func TestEscapeCSVCell(t *testing.T) {
+	assert.Equal(t, "", EscapeCSVCell(""))
+	assert.Equal(t, "C-01", EscapeCSVCell("C-01"))
+	assert.Equal(t, "'=HYPERLINK(\"x\")", EscapeCSVCell("=HYPERLINK(\"x\")"))
+	assert.Equal(t, "'+1", EscapeCSVCell("+1"))
+	assert.Equal(t, "'-1", EscapeCSVCell("-1"))
+	assert.Equal(t, "'@SUM(A1)", EscapeCSVCell("@SUM(A1)"))
+	assert.Equal(t, "'\tcabin", EscapeCSVCell("\tcabin"))
+	assert.Equal(t, "'\rcabin", EscapeCSVCell("\rcabin"))
+	assert.Equal(t, "''quoted", EscapeCSVCell("'quoted"))
+}
+
+func TestUnescapeCSVCell(t *testing.T) {
+	for _, value := range []string{"", "'", "C-01", "=1+1", "-1", "'quoted", "''", "'=1"} {
+		assert.Equal(t, value, UnescapeCSVCell(EscapeCSVCell(value)))
+	}
+
+	assert.Equal(t, "'quoted", UnescapeCSVCell("'quoted"))
+	assert.Equal(t, "=1", UnescapeCSVCell("'=1"))
+}
@@ -1,13 +1,25 @@
 package tests
 
 import (
+	"bufio"
 	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
 	"testing"
+	"time"
+	"unit-management-be/pkg/model/domain/enum"
 	"unit-management-be/pkg/model/dto"
 	"unit-management-be/pkg/model/dto/request"
+	"unit-management-be/pkg/model/dto/response"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/suite"
@@ -17,10 +29,71 @@ const baseURL = "http://localhost:5000/api"
 
 type UnitTestSuite struct {
 	suite.Suite
+	token   string
 	unitIDs []string
 }
 
+// getEnv returns the environment variable or fallback, credentials default to the docker compose admin
+func getEnv(key, fallback string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return fallback
+}
+
+// do sends an authenticated request to the API
+func (s *UnitTestSuite) do(method, path string, body io.Reader) (*http.Response, error) {
+	return s.doWithIfMatch(method, path, body, "")
+}
+
+// doWithIfMatch sends an authenticated request carrying the If-Match header when ifMatch is not empty
+func (s *UnitTestSuite) doWithIfMatch(method, path string, body io.Reader, ifMatch string) (*http.Response, error) {
+	req, err := http.NewRequest(method, baseURL+path, body)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+s.token)
+	if ifMatch != "" {
+		req.Header.Set("If-Match", ifMatch)
+	}
+
+	return http.DefaultClient.Do(req)
+}
+
+// etag reads the current ETag of the unit
+func (s *UnitTestSuite) etag(unitID string) string {
+	resp, err := s.do(http.MethodGet, "/unit/"+unitID, nil)
+	s.Require().NoError(err)
+	defer resp.Body.Close()
+	s.Require().Equal(http.StatusOK, resp.StatusCode)
+
+	return resp.Header.Get("ETag")
+}
+
 func (s *UnitTestSuite) SetupSuite() {
+	// login as admin to get access token
+	loginDto := request.LoginDto{
+		Username: getEnv("ADMIN_USERNAME", "admin"),
+		Password: getEnv("ADMIN_PASSWORD", "Admin12345!"),
+	}
+	loginBody, err := json.Marshal(loginDto)
+	s.Require().NoError(err)
+
+	loginResp, err := http.Post(baseURL+"/auth/login", "application/json", bytes.NewBuffer(loginBody))
+	s.Require().NoError(err)
+	defer loginResp.Body.Close()
+	s.Require().Equal(http.StatusOK, loginResp.StatusCode)
+
+	var login struct {
+		Data struct {
+			AccessToken string `json:"accessToken"`
+		} `json:"data"`
+	}
+	err = json.NewDecoder(loginResp.Body).Decode(&login)
+	s.Require().NoError(err)
+	s.token = login.Data.AccessToken
+
 	s.unitIDs = make([]string, 5)
 
 	// create 5 initial units
@@ -33,7 +106,7 @@ func (s *UnitTestSuite) SetupSuite() {
 		body, err := json.Marshal(createDto)
 		s.Require().NoError(err)
 
-		resp, err := http.Post(baseURL+"/unit", "application/json", bytes.NewBuffer(body))
+		resp, err := s.do(http.MethodPost, "/unit", bytes.NewBuffer(body))
 		s.Require().NoError(err)
 		defer resp.Body.Close()
 		s.Require().Equal(http.StatusCreated, resp.StatusCode)
@@ -67,7 +140,7 @@ func (s *UnitTestSuite) TestCreateUnit() {
 		body, err := json.Marshal(createDto)
 		s.NoError(err)
 
-		resp, err := http.Post(baseURL+"/unit", "application/json", bytes.NewBuffer(body))
+		resp, err := s.do(http.MethodPost, "/unit", bytes.NewBuffer(body))
 		s.NoError(err)
 		defer resp.Body.Close()
 		s.Equal(http.StatusCreated, resp.StatusCode)
@@ -82,7 +155,7 @@ func (s *UnitTestSuite) TestCreateUnit() {
 		body, err := json.Marshal(createDto)
 		s.NoError(err)
 
-		resp, err := http.Post(baseURL+"/unit", "application/json", bytes.NewBuffer(body))
+		resp, err := s.do(http.MethodPost, "/unit", bytes.NewBuffer(body))
 		s.NoError(err)
 		defer resp.Body.Close()
 		s.Equal(http.StatusBadRequest, resp.StatusCode)
@@ -91,7 +164,7 @@ func (s *UnitTestSuite) TestCreateUnit() {
 
 func (s *UnitTestSuite) TestGetUnits() {
 	s.Run("Positive Case: Should get all units with pagination", func() {
-		resp, err := http.Get(baseURL + "/unit?page=1&size=2")
+		resp, err := s.do(http.MethodGet, "/unit?page=1&size=2", nil)
 		s.NoError(err)
 		defer resp.Body.Close()
 		s.Equal(http.StatusOK, resp.StatusCode)
@@ -104,8 +177,109 @@ func (s *UnitTestSuite) TestGetUnits() {
 		s.Len(res.Data.Content.([]interface{}), 2)
 	})
 
+	s.Run("Positive Case: Should sort and filter by several statuses", func() {
+		resp, err := s.do(http.MethodGet, "/unit?size=100&sort=lastUpdated:desc,name:asc&status=Available,Occupied&updatedFrom=2000-01-01", nil)
+		s.NoError(err)
+		defer resp.Body.Close()
+		s.Equal(http.StatusOK, resp.StatusCode)
+
+		var res struct {
+			Data struct {
+				Content []struct {
+					Status      string    `json:"status"`
+					LastUpdated time.Time `json:"lastUpdated"`
+				} `json:"content"`
+			} `json:"data"`
+		}
+		s.NoError(json.NewDecoder(resp.Body).Decode(&res))
+		s.NotEmpty(res.Data.Content)
+		for i, unit := range res.Data.Content {
+			s.Contains([]string{"Available", "Occupied"}, unit.Status)
+			if i > 0 {
+				s.False(unit.LastUpdated.After(res.Data.Content[i-1].LastUpdated))
+			}
+		}
+	})
+
 	s.Run("Negative Case: Should return 400 for invalid page parameter", func() {
-		resp, err := http.Get(baseURL + "/unit?page=abc")
+		resp, err := s.do(http.MethodGet, "/unit?page=abc", nil)
+		s.NoError(err)
+		defer resp.Body.Close()
+		s.Equal(http.StatusBadRequest, resp.StatusCode)
+	})
+
+	s.Run("Negative Case: Should return 400 for an empty or oversized page", func() {
+		for _, query := range []string{"size=0", "size=-1", "size=101", "page=0"} {
+			resp, err := s.do(http.MethodGet, "/unit?"+query, nil)
+			s.NoError(err)
+			resp.Body.Close()
+			s.Equal(http.StatusBadRequest, resp.StatusCode, query)
+		}
+	})
+
+	s.Run("Positive Case: Should walk through every unit with cursors", func() {
+		type cursorPage struct {
+			Data struct {
+				Content []struct {
+					ID string `json:"id"`
+				} `json:"content"`
+				Pagination struct {
+					NextCursor *string `json:"nextCursor"`
+					PrevCursor *string `json:"prevCursor"`
+				} `json:"pagination"`
+			} `json:"data"`
+		}
+		getPage := func(query string) cursorPage {
+			resp, err := s.do(http.MethodGet, "/unit?limit=2&sort=lastUpdated:desc"+query, nil)
+			s.Require().NoError(err)
+			defer resp.Body.Close()
+			s.Require().Equal(http.StatusOK, resp.StatusCode)
+
+			var page cursorPage
+			s.Require().NoError(json.NewDecoder(resp.Body).Decode(&page))
+			return page
+		}
+
+		seen := make(map[string]bool)
+		page := getPage("")
+		s.Nil(page.Data.Pagination.PrevCursor)
+		firstID := page.Data.Content[0].ID
+		for {
+			for _, unit := range page.Data.Content {
+				s.False(seen[unit.ID], "unit %s is listed twice", unit.ID)
+				seen[unit.ID] = true
+			}
+			if page.Data.Pagination.NextCursor == nil {
+				break
+			}
+			page = getPage("&cursor=" + *page.Data.Pagination.NextCursor)
+		}
+
+		resp, err := s.do(http.MethodGet, "/unit?size=1", nil)
+		s.Require().NoError(err)
+		defer resp.Body.Close()
+		var offsetPage struct {
+			Data dto.PaginationResponse `json:"data"`
+		}
+		s.Require().NoError(json.NewDecoder(resp.Body).Decode(&offsetPage))
+		s.Len(seen, offsetPage.Data.Pagination.Total)
+
+		// going back from the second page returns the first one
+		second := getPage("&cursor=" + *getPage("").Data.Pagination.NextCursor)
+		s.Require().NotNil(second.Data.Pagination.PrevCursor)
+		first := getPage("&cursor=" + *second.Data.Pagination.PrevCursor)
+		s.Equal(firstID, first.Data.Content[0].ID)
+	})
+
+	s.Run("Negative Case: Should return 400 when mixing page and cursor", func() {
+		resp, err := s.do(http.MethodGet, "/unit?page=2&limit=5", nil)
+		s.NoError(err)
+		defer resp.Body.Close()
+		s.Equal(http.StatusBadRequest, resp.StatusCode)
+	})
+
+	s.Run("Negative Case: Should return 400 for a field that cannot be sorted", func() {
+		resp, err := s.do(http.MethodGet, "/unit?sort=version:desc", nil)
 		s.NoError(err)
 		defer resp.Body.Close()
 		s.Equal(http.StatusBadRequest, resp.StatusCode)
@@ -115,7 +289,7 @@ func (s *UnitTestSuite) TestGetUnits() {
 func (s *UnitTestSuite) TestGetDetailUnitByID() {
 	s.Run("Positive Case: Should get unit detail by ID", func() {
 		unitID := s.unitIDs[0]
-		resp, err := http.Get(baseURL + "/unit/" + unitID)
+		resp, err := s.do(http.MethodGet, "/unit/"+unitID, nil)
 		s.NoError(err)
 		defer resp.Body.Close()
 		s.Equal(http.StatusOK, resp.StatusCode)
@@ -123,7 +297,7 @@ func (s *UnitTestSuite) TestGetDetailUnitByID() {
 
 	s.Run("Negative Case: Should return 404 for non-existent unit ID", func() {
 		nonExistentID := uuid.New().String()
-		resp, err := http.Get(baseURL + "/unit/" + nonExistentID)
+		resp, err := s.do(http.MethodGet, "/unit/"+nonExistentID, nil)
 		s.NoError(err)
 		defer resp.Body.Close()
 		s.Equal(http.StatusNotFound, resp.StatusCode)
@@ -143,11 +317,7 @@ func (s *UnitTestSuite) TestUpdateUnit() {
 		body, err := json.Marshal(updateDto)
 		s.NoError(err)
 
-		req, err := http.NewRequest(http.MethodPut, baseURL+"/unit/"+unitID, bytes.NewBuffer(body))
-		s.NoError(err)
-		req.Header.Set("Content-Type", "application/json")
-
-		resp, err := http.DefaultClient.Do(req)
+		resp, err := s.doWithIfMatch(http.MethodPut, "/unit/"+unitID, bytes.NewBuffer(body), s.etag(unitID))
 		s.NoError(err)
 		defer resp.Body.Close()
 		s.Equal(http.StatusOK, resp.StatusCode)
@@ -160,40 +330,245 @@ func (s *UnitTestSuite) TestUpdateUnit() {
 		occupiedDto := request.UpdateUnitDto{CreateUnitDto: request.CreateUnitDto{Name: "Unit B", Type: "cabin", Status: "Occupied"}}
 		body, err := json.Marshal(occupiedDto)
 		s.NoError(err)
-		req, err := http.NewRequest(http.MethodPut, baseURL+"/unit/"+unitID, bytes.NewBuffer(body))
-		s.NoError(err)
-		req.Header.Set("Content-Type", "application/json")
-		_, err = http.DefaultClient.Do(req)
+		_, err = s.doWithIfMatch(http.MethodPut, "/unit/"+unitID, bytes.NewBuffer(body), s.etag(unitID))
 		s.NoError(err)
 
 		// then try to set it to Available directly
 		availableDto := request.UpdateUnitDto{CreateUnitDto: request.CreateUnitDto{Name: "Unit B", Type: "cabin", Status: "Available"}}
 		body, err = json.Marshal(availableDto)
 		s.NoError(err)
-		req, err = http.NewRequest(http.MethodPut, baseURL+"/unit/"+unitID, bytes.NewBuffer(body))
+		resp, err := s.doWithIfMatch(http.MethodPut, "/unit/"+unitID, bytes.NewBuffer(body), s.etag(unitID))
+		s.NoError(err)
+		defer resp.Body.Close()
+		s.Equal(http.StatusBadRequest, resp.StatusCode)
+	})
+}
+
+func (s *UnitTestSuite) TestUpdateUnitConcurrency() {
+	updateBody := func(name string) *bytes.Buffer {
+		body, err := json.Marshal(request.UpdateUnitDto{CreateUnitDto: request.CreateUnitDto{Name: name, Type: "capsule", Status: "Available"}})
 		s.NoError(err)
-		req.Header.Set("Content-Type", "application/json")
+		return bytes.NewBuffer(body)
+	}
+
+	s.Run("Negative Case: Should return 428 without If-Match", func() {
+		resp, err := s.do(http.MethodPut, "/unit/"+s.unitIDs[4], updateBody("No If-Match"))
+		s.NoError(err)
+		defer resp.Body.Close()
+		s.Equal(http.StatusPreconditionRequired, resp.StatusCode)
+	})
+
+	s.Run("Negative Case: Should return 412 for stale ETag", func() {
+		staleETag := s.etag(s.unitIDs[4])
+
+		resp, err := s.doWithIfMatch(http.MethodPut, "/unit/"+s.unitIDs[4], updateBody("First Writer"), staleETag)
+		s.NoError(err)
+		resp.Body.Close()
+		s.Equal(http.StatusOK, resp.StatusCode)
+		s.NotEqual(staleETag, resp.Header.Get("ETag"))
+
+		resp, err = s.doWithIfMatch(http.MethodPut, "/unit/"+s.unitIDs[4], updateBody("Second Writer"), staleETag)
+		s.NoError(err)
+		defer resp.Body.Close()
+		s.Equal(http.StatusPreconditionFailed, resp.StatusCode)
+		s.Equal(s.etag(s.unitIDs[4]), resp.Header.Get("ETag"))
+	})
+}
+
+func (s *UnitTestSuite) TestPatchUnit() {
+	body, err := json.Marshal(request.CreateUnitDto{Name: "Unit Test Patch", Type: "capsule", Status: "Available"})
+	s.Require().NoError(err)
+
+	resp, err := s.do(http.MethodPost, "/unit", bytes.NewBuffer(body))
+	s.Require().NoError(err)
+	defer resp.Body.Close()
+	s.Require().Equal(http.StatusCreated, resp.StatusCode)
+
+	var created struct {
+		Data struct {
+			ID string `json:"id"`
+		} `json:"data"`
+	}
+	s.Require().NoError(json.NewDecoder(resp.Body).Decode(&created))
+	unitID := created.Data.ID
+
+	patch := func(contentType, document string) *http.Response {
+		req, err := http.NewRequest(http.MethodPatch, baseURL+"/unit/"+unitID, strings.NewReader(document))
+		s.Require().NoError(err)
+		req.Header.Set("Content-Type", contentType)
+		req.Header.Set("Authorization", "Bearer "+s.token)
 
 		resp, err := http.DefaultClient.Do(req)
+		s.Require().NoError(err)
+		return resp
+	}
+
+	s.Run("Positive Case: Should change only the status with a merge patch", func() {
+		resp := patch("application/merge-patch+json", `{"status": "Occupied"}`)
+		defer resp.Body.Close()
+		s.Equal(http.StatusOK, resp.StatusCode)
+		s.Equal(s.etag(unitID), resp.Header.Get("ETag"))
+
+		var res struct {
+			Data struct {
+				Name   string `json:"name"`
+				Status string `json:"status"`
+			} `json:"data"`
+		}
+		s.NoError(json.NewDecoder(resp.Body).Decode(&res))
+		s.Equal("Unit Test Patch", res.Data.Name)
+		s.Equal("Occupied", res.Data.Status)
+	})
+
+	s.Run("Positive Case: Should apply a json patch", func() {
+		resp := patch("application/json-patch+json", `[
+			{"op": "test", "path": "/status", "value": "Occupied"},
+			{"op": "replace", "path": "/status", "value": "Cleaning In Progress"},
+			{"op": "replace", "path": "/reason", "value": "guest checked out"}
+		]`)
+		defer resp.Body.Close()
+		s.Equal(http.StatusOK, resp.StatusCode)
+	})
+
+	s.Run("Negative Case: Should return 409 when a test operation fails", func() {
+		resp := patch("application/json-patch+json", `[{"op": "test", "path": "/status", "value": "Occupied"}]`)
+		defer resp.Body.Close()
+		s.Equal(http.StatusConflict, resp.StatusCode)
+	})
+
+	s.Run("Negative Case: Should return 400 for a transition that is not allowed", func() {
+		resp := patch("application/merge-patch+json", `{"status": "Occupied"}`)
+		defer resp.Body.Close()
+		s.Equal(http.StatusBadRequest, resp.StatusCode)
+	})
+
+	s.Run("Negative Case: Should return 415 for an unsupported content type", func() {
+		resp := patch("text/plain", `status=Available`)
+		defer resp.Body.Close()
+		s.Equal(http.StatusUnsupportedMediaType, resp.StatusCode)
+	})
+}
+
+func (s *UnitTestSuite) TestBulkChangeStatus() {
+	createUnit := func(name string) string {
+		body, err := json.Marshal(request.CreateUnitDto{Name: name, Type: "capsule", Status: "Available"})
+		s.Require().NoError(err)
+		resp, err := s.do(http.MethodPost, "/unit", bytes.NewBuffer(body))
+		s.Require().NoError(err)
+		defer resp.Body.Close()
+
+		var res struct {
+			Data struct {
+				ID string `json:"id"`
+			} `json:"data"`
+		}
+		s.Require().NoError(json.NewDecoder(resp.Body).Decode(&res))
+		return res.Data.ID
+	}
+	firstID, secondID := createUnit("Bulk Unit 1"), createUnit("Bulk Unit 2")
+
+	s.Run("Positive Case: Should change every unit atomically", func() {
+		body, err := json.Marshal(request.BulkChangeUnitStatusDto{UnitIDs: []string{firstID, secondID}, Status: "Cleaning In Progress"})
+		s.NoError(err)
+
+		resp, err := s.do(http.MethodPost, "/unit/bulk/status", bytes.NewBuffer(body))
+		s.NoError(err)
+		defer resp.Body.Close()
+		s.Equal(http.StatusOK, resp.StatusCode)
+	})
+
+	s.Run("Negative Case: Should roll back when a unit does not exist", func() {
+		body, err := json.Marshal(request.BulkChangeUnitStatusDto{UnitIDs: []string{firstID, uuid.New().String()}, Status: "Available", Mode: "atomic"})
+		s.NoError(err)
+
+		resp, err := s.do(http.MethodPost, "/unit/bulk/status", bytes.NewBuffer(body))
+		s.NoError(err)
+		defer resp.Body.Close()
+		s.Equal(http.StatusConflict, resp.StatusCode)
+	})
+
+	s.Run("Positive Case: Should report failures in best effort mode", func() {
+		body, err := json.Marshal(request.BulkChangeUnitStatusDto{UnitIDs: []string{secondID, uuid.New().String()}, Status: "Available", Mode: "best_effort"})
+		s.NoError(err)
+
+		resp, err := s.do(http.MethodPost, "/unit/bulk/status", bytes.NewBuffer(body))
+		s.NoError(err)
+		defer resp.Body.Close()
+		s.Equal(http.StatusOK, resp.StatusCode)
+
+		var res struct {
+			Data struct {
+				Updated int `json:"updated"`
+				Failed  int `json:"failed"`
+			} `json:"data"`
+		}
+		s.NoError(json.NewDecoder(resp.Body).Decode(&res))
+		s.Equal(1, res.Data.Updated)
+		s.Equal(1, res.Data.Failed)
+	})
+}
+
+func (s *UnitTestSuite) TestExportImportUnits() {
+	s.Run("Positive Case: Should export units as csv", func() {
+		resp, err := s.do(http.MethodGet, "/unit/export?format=csv&type=capsule", nil)
+		s.NoError(err)
+		defer resp.Body.Close()
+		s.Equal(http.StatusOK, resp.StatusCode)
+		s.Contains(resp.Header.Get("Content-Type"), "text/csv")
+
+		content, err := io.ReadAll(resp.Body)
+		s.NoError(err)
+		s.True(strings.HasPrefix(string(content), "id,name,type,status,version,lastUpdated"))
+	})
+
+	s.Run("Negative Case: Should return 400 for unsupported export format", func() {
+		resp, err := s.do(http.MethodGet, "/unit/export?format=xlsx", nil)
 		s.NoError(err)
 		defer resp.Body.Close()
 		s.Equal(http.StatusBadRequest, resp.StatusCode)
 	})
+
+	s.Run("Positive Case: Should report row errors in dry run", func() {
+		content := "name,type,status\nImported Unit,capsule,Available\nBroken Unit,suite,Available\n"
+		resp, err := s.do(http.MethodPost, "/unit/import?dryRun=true", strings.NewReader(content))
+		s.NoError(err)
+		defer resp.Body.Close()
+		s.Equal(http.StatusOK, resp.StatusCode)
+
+		var res struct {
+			Data struct {
+				ValidRows int `json:"validRows"`
+				Imported  int `json:"imported"`
+				Errors    []struct {
+					Row int `json:"row"`
+				} `json:"errors"`
+			} `json:"data"`
+		}
+		s.NoError(json.NewDecoder(resp.Body).Decode(&res))
+		s.Equal(1, res.Data.ValidRows)
+		s.Equal(0, res.Data.Imported)
+		s.Equal(3, res.Data.Errors[0].Row)
+	})
+
+	s.Run("Positive Case: Should import valid rows", func() {
+		content := "name,type,status\nImported Unit,capsule,Available\n"
+		resp, err := s.do(http.MethodPost, "/unit/import", strings.NewReader(content))
+		s.NoError(err)
+		defer resp.Body.Close()
+		s.Equal(http.StatusCreated, resp.StatusCode)
+	})
 }
 
 func (s *UnitTestSuite) TestDeleteUnit() {
 	s.Run("Positive Case: Should delete a unit successfully", func() {
 		unitIDToDelete := s.unitIDs[3]
-		req, err := http.NewRequest(http.MethodDelete, baseURL+"/unit/"+unitIDToDelete, nil)
-		s.NoError(err)
-
-		resp, err := http.DefaultClient.Do(req)
+		resp, err := s.do(http.MethodDelete, "/unit/"+unitIDToDelete, nil)
 		s.NoError(err)
 		defer resp.Body.Close()
 		s.Equal(http.StatusOK, resp.StatusCode)
 
 		// verify unit is no longer found
-		resp, err = http.Get(baseURL + "/unit/" + unitIDToDelete)
+		resp, err = s.do(http.MethodGet, "/unit/"+unitIDToDelete, nil)
 		s.NoError(err)
 		defer resp.Body.Close()
 		s.Equal(http.StatusNotFound, resp.StatusCode)
@@ -201,14 +576,424 @@ func (s *UnitTestSuite) TestDeleteUnit() {
 
 	s.Run("Negative Case: Should return 404 for non-existent unit ID", func() {
 		nonExistentID := uuid.New().String()
-		req, err := http.NewRequest(http.MethodDelete, baseURL+"/unit/"+nonExistentID, nil)
+		resp, err := s.do(http.MethodDelete, "/unit/"+nonExistentID, nil)
 		s.NoError(err)
+		defer resp.Body.Close()
+		s.Equal(http.StatusNotFound, resp.StatusCode)
+	})
+}
 
-		resp, err := http.DefaultClient.Do(req)
+func (s *UnitTestSuite) TestTrash() {
+	body, err := json.Marshal(request.CreateUnitDto{Name: "Unit Test Trash", Type: "cabin", Status: "Available"})
+	s.Require().NoError(err)
+
+	resp, err := s.do(http.MethodPost, "/unit", bytes.NewBuffer(body))
+	s.Require().NoError(err)
+	defer resp.Body.Close()
+	s.Require().Equal(http.StatusCreated, resp.StatusCode)
+
+	var created struct {
+		Data struct {
+			ID string `json:"id"`
+		} `json:"data"`
+	}
+	s.Require().NoError(json.NewDecoder(resp.Body).Decode(&created))
+	unitID := created.Data.ID
+
+	resp, err = s.do(http.MethodDelete, "/unit/"+unitID, nil)
+	s.Require().NoError(err)
+	defer resp.Body.Close()
+	s.Require().Equal(http.StatusOK, resp.StatusCode)
+
+	s.Run("Positive Case: Should list the deleted unit in the trash", func() {
+		resp, err := s.do(http.MethodGet, "/unit/trash?name=Unit%20Test%20Trash", nil)
+		s.NoError(err)
+		defer resp.Body.Close()
+		s.Equal(http.StatusOK, resp.StatusCode)
+
+		var res struct {
+			Data struct {
+				Content []struct {
+					ID string `json:"id"`
+				} `json:"content"`
+			} `json:"data"`
+		}
+		s.NoError(json.NewDecoder(resp.Body).Decode(&res))
+		s.Len(res.Data.Content, 1)
+		s.Equal(unitID, res.Data.Content[0].ID)
+	})
+
+	s.Run("Positive Case: Should restore the unit", func() {
+		resp, err := s.do(http.MethodPost, "/unit/"+unitID+"/restore", nil)
+		s.NoError(err)
+		defer resp.Body.Close()
+		s.Equal(http.StatusOK, resp.StatusCode)
+		s.Equal(s.etag(unitID), resp.Header.Get("ETag"))
+	})
+
+	s.Run("Negative Case: Should return 404 when purging a unit outside the trash", func() {
+		resp, err := s.do(http.MethodDelete, "/unit/"+unitID+"/purge", nil)
 		s.NoError(err)
 		defer resp.Body.Close()
 		s.Equal(http.StatusNotFound, resp.StatusCode)
 	})
+
+	s.Run("Positive Case: Should purge the unit permanently", func() {
+		resp, err := s.do(http.MethodDelete, "/unit/"+unitID, nil)
+		s.NoError(err)
+		defer resp.Body.Close()
+		s.Equal(http.StatusOK, resp.StatusCode)
+
+		resp, err = s.do(http.MethodDelete, "/unit/"+unitID+"/purge", nil)
+		s.NoError(err)
+		defer resp.Body.Close()
+		s.Equal(http.StatusOK, resp.StatusCode)
+
+		resp, err = s.do(http.MethodPost, "/unit/"+unitID+"/restore", nil)
+		s.NoError(err)
+		defer resp.Body.Close()
+		s.Equal(http.StatusNotFound, resp.StatusCode)
+	})
+}
+
+func (s *UnitTestSuite) TestUnitStats() {
+	s.Run("Positive Case: Should count every unit listed by type and status", func() {
+		resp, err := s.do(http.MethodGet, "/unit?size=1", nil)
+		s.Require().NoError(err)
+		defer resp.Body.Close()
+		s.Require().Equal(http.StatusOK, resp.StatusCode)
+
+		var list struct {
+			Data struct {
+				Pagination dto.PaginationData `json:"pagination"`
+			} `json:"data"`
+		}
+		s.Require().NoError(json.NewDecoder(resp.Body).Decode(&list))
+
+		resp, err = s.do(http.MethodGet, "/unit/stats", nil)
+		s.Require().NoError(err)
+		defer resp.Body.Close()
+		s.Require().Equal(http.StatusOK, resp.StatusCode)
+
+		var stats struct {
+			Data response.UnitStatsResponse `json:"data"`
+		}
+		s.Require().NoError(json.NewDecoder(resp.Body).Decode(&stats))
+		s.Equal(int64(list.Data.Pagination.Total), stats.Data.Total)
+		s.Len(stats.Data.Types, len(enum.UnitTypes()))
+		s.Len(stats.Data.Statuses, len(enum.UnitStatuses()))
+
+		var typeTotal, statusTotal int64
+		for _, typeStats := range stats.Data.Types {
+			var counted int64
+			for _, count := range typeStats.Counts {
+				counted += count
+			}
+			s.Equal(typeStats.Total, counted, typeStats.Type)
+			s.GreaterOrEqual(typeStats.OccupancyPercentage, 0.0)
+			s.LessOrEqual(typeStats.OccupancyPercentage, 100.0)
+			typeTotal += typeStats.Total
+		}
+		for _, statusStats := range stats.Data.Statuses {
+			s.Equal(statusStats.Total > 0, statusStats.OldestLastUpdated != nil, statusStats.Status)
+			statusTotal += statusStats.Total
+		}
+		s.Equal(stats.Data.Total, typeTotal)
+		s.Equal(stats.Data.Total, statusTotal)
+	})
+}
+
+func (s *UnitTestSuite) TestUtilizationReport() {
+	today := time.Now().Format("2006-01-02")
+
+	s.Run("Positive Case: Should report the utilization of every unit per day", func() {
+		resp, err := s.do(http.MethodGet, "/reports/utilization?from="+today+"&to="+today+"&granularity=day", nil)
+		s.Require().NoError(err)
+		defer resp.Body.Close()
+		s.Require().Equal(http.StatusOK, resp.StatusCode)
+
+		var report struct {
+			Data response.UtilizationReportResponse `json:"data"`
+		}
+		s.Require().NoError(json.NewDecoder(resp.Body).Decode(&report))
+		s.Equal(enum.GranularityDay, report.Data.Granularity)
+		s.Len(report.Data.Types, len(enum.UnitTypes()))
+		s.NotEmpty(report.Data.Units)
+		for _, unit := range report.Data.Units {
+			s.Require().Len(unit.Periods, 1)
+			// units are only tracked from their creation up to now
+			s.LessOrEqual(unit.Periods[0].TrackedSeconds, int64(24*time.Hour/time.Second), unit.Name)
+		}
+	})
+
+	s.Run("Positive Case: Should export the report as CSV", func() {
+		resp, err := s.do(http.MethodGet, "/reports/utilization?from="+today+"&to="+today+"&format=csv", nil)
+		s.Require().NoError(err)
+		defer resp.Body.Close()
+		s.Require().Equal(http.StatusOK, resp.StatusCode)
+		s.Contains(resp.Header.Get("Content-Type"), "text/csv")
+
+		body, err := io.ReadAll(resp.Body)
+		s.Require().NoError(err)
+		s.True(strings.HasPrefix(string(body), "periodStart,periodEnd,scope,type,unitId,unitName"))
+	})
+
+	s.Run("Negative Case: Should reject a missing range or unknown granularity", func() {
+		resp, err := s.do(http.MethodGet, "/reports/utilization?from="+today, nil)
+		s.Require().NoError(err)
+		defer resp.Body.Close()
+		s.Equal(http.StatusBadRequest, resp.StatusCode)
+
+		resp, err = s.do(http.MethodGet, "/reports/utilization?from="+today+"&to="+today+"&granularity=month", nil)
+		s.Require().NoError(err)
+		defer resp.Body.Close()
+		s.Equal(http.StatusBadRequest, resp.StatusCode)
+	})
+}
+
+func (s *UnitTestSuite) TestMetrics() {
+	s.Run("Positive Case: Should expose request, database and unit metrics", func() {
+		resp, err := s.do(http.MethodGet, "/unit/"+s.unitIDs[0], nil)
+		s.Require().NoError(err)
+		resp.Body.Close()
+
+		resp, err = http.Get(strings.TrimSuffix(baseURL, "/api") + "/metrics")
+		s.Require().NoError(err)
+		defer resp.Body.Close()
+		s.Require().Equal(http.StatusOK, resp.StatusCode)
+
+		body, err := io.ReadAll(resp.Body)
+		s.Require().NoError(err)
+		s.Contains(string(body), `unit_management_http_requests_total{code="200",method="GET",route="/api/unit/:unitId"}`)
+		s.Contains(string(body), "unit_management_http_request_duration_seconds_bucket")
+		s.Contains(string(body), "go_sql_open_connections")
+		s.Contains(string(body), `unit_management_units{status="Available"}`)
+	})
+}
+
+func (s *UnitTestSuite) TestHealth() {
+	s.Run("Positive Case: Should be alive", func() {
+		resp, err := http.Get(strings.TrimSuffix(baseURL, "/api") + "/healthz")
+		s.Require().NoError(err)
+		defer resp.Body.Close()
+		s.Equal(http.StatusOK, resp.StatusCode)
+	})
+
+	s.Run("Positive Case: Should be ready with the database migrated", func() {
+		resp, err := http.Get(strings.TrimSuffix(baseURL, "/api") + "/readyz")
+		s.Require().NoError(err)
+		defer resp.Body.Close()
+		s.Require().Equal(http.StatusOK, resp.StatusCode)
+
+		var res dto.Response
+		s.Require().NoError(json.NewDecoder(resp.Body).Decode(&res))
+		s.True(res.Success)
+		s.Equal(map[string]interface{}{
+			"status": "ok",
+			"checks": map[string]interface{}{"database": "ok", "migrations": "ok"},
+		}, res.Data)
+	})
+}
+
+func (s *UnitTestSuite) TestRequestID() {
+	s.Run("Positive Case: Should return the request ID sent by the client", func() {
+		req, err := http.NewRequest(http.MethodGet, baseURL+"/unit/"+s.unitIDs[0], nil)
+		s.Require().NoError(err)
+		req.Header.Set("Authorization", "Bearer "+s.token)
+		req.Header.Set("X-Request-ID", "api-test-request")
+
+		resp, err := http.DefaultClient.Do(req)
+		s.Require().NoError(err)
+		defer resp.Body.Close()
+		s.Equal(http.StatusOK, resp.StatusCode)
+		s.Equal("api-test-request", resp.Header.Get("X-Request-ID"))
+	})
+
+	s.Run("Negative Case: Should return the request ID with an error", func() {
+		resp, err := s.do(http.MethodGet, "/unit/00000000-0000-0000-0000-000000000000", nil)
+		s.Require().NoError(err)
+		defer resp.Body.Close()
+		s.Equal(http.StatusNotFound, resp.StatusCode)
+
+		var res dto.Response
+		s.Require().NoError(json.NewDecoder(resp.Body).Decode(&res))
+		s.False(res.Success)
+		s.NotEmpty(resp.Header.Get("X-Request-ID"))
+		s.Equal(resp.Header.Get("X-Request-ID"), res.RequestID)
+	})
+}
+
+func (s *UnitTestSuite) TestUnitEvents() {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, baseURL+"/unit/events", nil)
+	s.Require().NoError(err)
+	req.Header.Set("Authorization", "Bearer "+s.token)
+
+	stream, err := http.DefaultClient.Do(req)
+	s.Require().NoError(err)
+	defer stream.Body.Close()
+	s.Require().Equal(http.StatusOK, stream.StatusCode)
+	s.Equal("text/event-stream", stream.Header.Get("Content-Type"))
+
+	s.Run("Positive Case: Should stream the change of a unit", func() {
+		body, err := json.Marshal(request.CreateUnitDto{Name: "Unit Test Events", Type: "cabin", Status: "Available"})
+		s.NoError(err)
+
+		resp, err := s.do(http.MethodPost, "/unit", bytes.NewBuffer(body))
+		s.NoError(err)
+		defer resp.Body.Close()
+		s.Equal(http.StatusCreated, resp.StatusCode)
+
+		var eventType, data string
+		scanner := bufio.NewScanner(stream.Body)
+		for scanner.Scan() {
+			line := scanner.Text()
+			if value, found := strings.CutPrefix(line, "event: "); found {
+				eventType = value
+			}
+			if value, found := strings.CutPrefix(line, "data: "); found {
+				data = value
+				break
+			}
+		}
+
+		s.Equal("unit.created", eventType)
+		s.Contains(data, `"name":"Unit Test Events"`)
+	})
+
+	s.Run("Negative Case: Should return 400 for invalid Last-Event-ID", func() {
+		req, err := http.NewRequest(http.MethodGet, baseURL+"/unit/events", nil)
+		s.NoError(err)
+		req.Header.Set("Authorization", "Bearer "+s.token)
+		req.Header.Set("Last-Event-ID", "latest")
+
+		resp, err := http.DefaultClient.Do(req)
+		s.NoError(err)
+		defer resp.Body.Close()
+		s.Equal(http.StatusBadRequest, resp.StatusCode)
+	})
+}
+
+func (s *UnitTestSuite) TestWebhooks() {
+	const secret = "api-test-webhook-secret"
+	received := make(chan *http.Request, 10)
+	receivedBodies := make(chan []byte, 10)
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		received <- r
+		receivedBodies <- body
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer receiver.Close()
+
+	body, err := json.Marshal(request.CreateWebhookDto{URL: receiver.URL, Events: []string{"unit.status_changed"}, Secret: secret})
+	s.Require().NoError(err)
+
+	resp, err := s.do(http.MethodPost, "/webhook", bytes.NewBuffer(body))
+	s.Require().NoError(err)
+	defer resp.Body.Close()
+	s.Require().Equal(http.StatusCreated, resp.StatusCode)
+
+	var created struct {
+		Data struct {
+			ID string `json:"id"`
+		} `json:"data"`
+	}
+	s.Require().NoError(json.NewDecoder(resp.Body).Decode(&created))
+	defer func() {
+		resp, err := s.do(http.MethodDelete, "/webhook/"+created.Data.ID, nil)
+		s.NoError(err)
+		resp.Body.Close()
+	}()
+
+	s.Run("Positive Case: Should deliver a signed status change", func() {
+		unitBody, err := json.Marshal(request.CreateUnitDto{Name: "Unit Test Webhook", Type: "cabin", Status: "Available"})
+		s.NoError(err)
+
+		resp, err := s.do(http.MethodPost, "/unit", bytes.NewBuffer(unitBody))
+		s.NoError(err)
+		defer resp.Body.Close()
+		s.Equal(http.StatusCreated, resp.StatusCode)
+
+		var unit struct {
+			Data struct {
+				ID string `json:"id"`
+			} `json:"data"`
+		}
+		s.NoError(json.NewDecoder(resp.Body).Decode(&unit))
+
+		resp, err = s.do(http.MethodPut, "/unit/"+unit.Data.ID+"/status", strings.NewReader(`{"status": "Occupied", "reason": "guest checked in"}`))
+		s.NoError(err)
+		defer resp.Body.Close()
+		s.Equal(http.StatusOK, resp.StatusCode)
+
+		select {
+		case req := <-received:
+			payload := <-receivedBodies
+			mac := hmac.New(sha256.New, []byte(secret))
+			mac.Write([]byte(req.Header.Get("X-Webhook-Timestamp") + "."))
+			mac.Write(payload)
+			s.Equal("sha256="+hex.EncodeToString(mac.Sum(nil)), req.Header.Get("X-Webhook-Signature"))
+			s.Equal("unit.status_changed", req.Header.Get("X-Webhook-Event"))
+			s.Contains(string(payload), `"toStatus":"Occupied"`)
+			s.Contains(string(payload), unit.Data.ID)
+		case <-time.After(5 * time.Second):
+			s.Fail("webhook was not delivered")
+		}
+	})
+
+	s.Run("Negative Case: Should return 400 for invalid webhook url", func() {
+		body, err := json.Marshal(request.CreateWebhookDto{URL: "not a url", Secret: secret})
+		s.NoError(err)
+
+		resp, err := s.do(http.MethodPost, "/webhook", bytes.NewBuffer(body))
+		s.NoError(err)
+		defer resp.Body.Close()
+		s.Equal(http.StatusBadRequest, resp.StatusCode)
+	})
+
+	s.Run("Negative Case: Should return 409 when replaying a delivery that is not dead", func() {
+		resp, err := s.do(http.MethodGet, "/webhook/deliveries?webhookId="+created.Data.ID, nil)
+		s.NoError(err)
+		defer resp.Body.Close()
+		s.Equal(http.StatusOK, resp.StatusCode)
+
+		var deliveries struct {
+			Data struct {
+				Content []struct {
+					ID string `json:"id"`
+				} `json:"content"`
+			} `json:"data"`
+		}
+		s.NoError(json.NewDecoder(resp.Body).Decode(&deliveries))
+		s.Require().Len(deliveries.Data.Content, 1)
+
+		resp, err = s.do(http.MethodPost, "/webhook/deliveries/"+deliveries.Data.Content[0].ID+"/replay", nil)
+		s.NoError(err)
+		defer resp.Body.Close()
+		s.Equal(http.StatusConflict, resp.StatusCode)
+	})
+}
+
+func (s *UnitTestSuite) TestAuthorization() {
+	s.Run("Negative Case: Should return 401 without access token", func() {
+		resp, err := http.Get(baseURL + "/unit")
+		s.NoError(err)
+		defer resp.Body.Close()
+		s.Equal(http.StatusUnauthorized, resp.StatusCode)
+	})
+
+	s.Run("Negative Case: Should return 401 for wrong password", func() {
+		body, err := json.Marshal(request.LoginDto{Username: getEnv("ADMIN_USERNAME", "admin"), Password: "wrong-password"})
+		s.NoError(err)
+
+		resp, err := http.Post(baseURL+"/auth/login", "application/json", bytes.NewBuffer(body))
+		s.NoError(err)
+		defer resp.Body.Close()
+		s.Equal(http.StatusUnauthorized, resp.StatusCode)
+	})
 }
 
 func TestUnitSuite(t *testing.T) {
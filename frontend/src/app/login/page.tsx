@@ -0,0 +1,72 @@
+"use client";
+
+import { useState } from "react";
+import { useRouter } from "next/navigation";
+import { Formik, Form, Field } from "formik";
+import { Loader2 } from "lucide-react";
+import { Button } from "@/components/ui/button";
+import { Input } from "@/components/ui/input";
+import { Label } from "@/components/ui/label";
+import {
+  Card,
+  CardContent,
+  CardDescription,
+  CardFooter,
+  CardHeader,
+  CardTitle,
+} from "@/components/ui/card";
+
+import { login } from "@/lib/api";
+
+export default function LoginPage() {
+  const router = useRouter();
+  const [error, setError] = useState<string>("");
+
+  return (
+    <div className="container mx-auto p-4 flex min-h-screen items-center justify-center">
+      <Formik
+        initialValues={{ username: "", password: "" }}
+        onSubmit={async (values, { setSubmitting }) => {
+          setError("");
+          try {
+            await login(values.username, values.password);
+            router.push("/");
+          } catch (err: any) {
+            console.error("Error login:", err);
+            setError(err.message);
+          } finally {
+            setSubmitting(false);
+          }
+        }}
+      >
+        {({ isSubmitting }) => (
+          <Card className="w-full max-w-sm">
+            <Form>
+              <CardHeader>
+                <CardTitle>Unit Management</CardTitle>
+                <CardDescription>Log in to manage the units</CardDescription>
+              </CardHeader>
+              <CardContent className="grid gap-4 py-4">
+                <div className="grid gap-2">
+                  <Label htmlFor="username">Username</Label>
+                  <Field as={Input} id="username" name="username" autoComplete="username" required />
+                </div>
+                <div className="grid gap-2">
+                  <Label htmlFor="password">Password</Label>
+                  <Field as={Input} id="password" name="password" type="password" autoComplete="current-password" required />
+                </div>
+                {error && <p className="text-sm text-red-600">{error}</p>}
+              </CardContent>
+              <CardFooter>
+                <Button type="submit" className="w-full text-white" disabled={isSubmitting}>
+                  {isSubmitting && <Loader2 className="mr-2 h-4 w-4 animate-spin" />}
+                  Log in
+                </Button>
+              </CardFooter>
+            </Form>
+          </Card>
+        )}
+      </Formik>
+    </div>
+  );
+}
@@ -1,6 +1,6 @@
 "use client";
 
-import { useEffect, useState } from "react";
+import { useEffect, useRef, useState } from "react";
 import { Button } from "@/components/ui/button";
 import { Input } from "@/components/ui/input";
 import { Badge } from "@/components/ui/badge";
@@ -20,10 +20,11 @@ import {
   SelectValue,
 } from "@/components/ui/select";
 
-import { Loader2, Plus, Edit, Trash2, ChevronRight, ChevronLeft } from "lucide-react";
+import { Loader2, Plus, Edit, Trash2, ChevronRight, ChevronLeft, LogOut } from "lucide-react";
 import { Unit } from "@/types/Unit";
 
-import { fetchUnits, deleteUnit } from "@/lib/api";
+import { fetchUnits, deleteUnit, subscribeUnitEvents } from "@/lib/api";
+import { redirectToLogin } from "@/lib/auth";
 
 import UnitModal from "@/components/modal/unit-modal";
 import DeleteConfirmationModal from "@/components/modal/delete-unit-modal";
@@ -50,8 +51,8 @@ export default function UnitPage() {
   const [statusMessage, setStatusMessage] = useState<string>("")
   const [isSuccess, setIsSuccess] = useState<boolean>(false)
 
-  const loadUnits = async () => {
-    setLoading(true);
+  const loadUnits = async (showLoading: boolean = true) => {
+    if (showLoading) setLoading(true);
     try {
       const res = await fetchUnits(
         page,
@@ -73,6 +74,15 @@ export default function UnitPage() {
     loadUnits();
   }, [page, size, searchParams]);
 
+  // the stream is opened once, the ref keeps the reload on the current page and filters
+  const loadUnitsRef = useRef(loadUnits);
+  loadUnitsRef.current = loadUnits;
+
+  useEffect(() => {
+    // refresh quietly when a unit is changed, including by other staff
+    return subscribeUnitEvents(() => loadUnitsRef.current(false));
+  }, []);
+
   const handleCreate = () => {
     setSelectedUnit(null);
     setModalOpen(true)
@@ -139,9 +149,14 @@ export default function UnitPage() {
 
   return (
     <div className="container mx-auto p-4">
-      <h1 className="text-3xl font-semibold mb-8 text-foreground">
-        Unit Management
-      </h1>
+      <div className="flex items-center justify-between mb-8">
+        <h1 className="text-3xl font-semibold text-foreground">
+          Unit Management
+        </h1>
+        <Button variant="outline" onClick={redirectToLogin}>
+          Log out <LogOut className="ml-2 h-4 w-4" />
+        </Button>
+      </div>
 
       <div className="flex flex-col md:flex-row gap-4 mb-6 items-end">
         <div className="flex-1">
@@ -46,7 +46,7 @@ export default function UnitModal({ open, onClose, unit }: { open: boolean; onCl
 
                     try {
                         const result = unit
-                            ? await updateUnit(unit.id, values)
+                            ? await updateUnit(unit.id, values, unit.version)
                             : await createUnit(values);
 
                         if (result && result.success) {
@@ -1,12 +1,48 @@
+import { LoginResponse } from "@/types/Auth";
 import { PaginatedUnits } from "@/types/Pagination";
 import { Unit } from "@/types/Unit";
+import { getToken, redirectToLogin, setToken } from "@/lib/auth";
 
 const apiUrl = process.env.NEXT_PUBLIC_API_URL || 'http://127.0.0.1:5000/api'
 
+// authFetch sends the stored token with the request, a missing or rejected token leads back to the login page
+async function authFetch(path: string, init: RequestInit = {}): Promise<Response> {
+    const token = getToken()
+    if (!token) {
+        redirectToLogin()
+        throw new Error("Please log in")
+    }
+
+    const headers = new Headers(init.headers)
+    headers.set("Authorization", `Bearer ${token}`)
+
+    const res = await fetch(`${apiUrl}${path}`, { ...init, headers })
+    if (res.status === 401) {
+        redirectToLogin()
+        throw new Error("Session expired, please log in again")
+    }
+
+    return res
+}
+
+export async function login(username: string, password: string): Promise<LoginResponse> {
+    const res = await fetch(`${apiUrl}/auth/login`, {
+        method: "POST",
+        headers: { "Content-Type": "application/json" },
+        body: JSON.stringify({ username, password }),
+    })
+
+    const result = await res.json()
+    if (!res.ok || !result.success) throw new Error(result.message || "Failed to log in")
+
+    setToken(result.data.accessToken)
+    return result.data
+}
+
 export async function fetchUnits(page: number = 0, size: number = 10, name: string = "", status: string = ""): Promise<PaginatedUnits> {
     if (status == "all") status = "";
 
-    const res = await fetch(`${apiUrl}/unit?page=${page}&size=${size}&name=${name}&status=${status}`, {
+    const res = await authFetch(`/unit?page=${page}&size=${size}&name=${name}&status=${status}`, {
         cache: "no-store",
     });
 
@@ -16,7 +52,7 @@ export async function fetchUnits(page: number = 0, size: number = 10, name: stri
 }
 
 export async function createUnit(data: Omit<Unit, "id">) {
-    const res = await fetch(`${apiUrl}/unit`, {
+    const res = await authFetch(`/unit`, {
         method: "POST",
         headers: { "Content-Type": "application/json" },
         body: JSON.stringify(data),
@@ -25,10 +61,28 @@ export async function createUnit(data: Omit<Unit, "id">) {
     return res.json()
 }
 
-export async function updateUnit(id: string, data: Partial<Unit>) {
-    const res = await fetch(`${apiUrl}/unit/${id}`, {
+export async function fetchUnit(id: string): Promise<Unit> {
+    const res = await authFetch(`/unit/${id}`, {
+        cache: "no-store",
+    });
+
+    if (!res.ok) throw new Error("Failed to fetch unit")
+
+    return res.json().then((data) => data.data);
+}
+
+export async function updateUnit(id: string, data: Partial<Unit>, version?: number) {
+    // an update never overwrites blindly, without a known version the unit is read again to get it
+    if (version === undefined) version = (await fetchUnit(id)).version
+    if (version === undefined) throw new Error("Failed to read the unit version")
+
+    const res = await authFetch(`/unit/${id}`, {
         method: "PUT",
-        headers: { "Content-Type": "application/json" },
+        headers: {
+            "Content-Type": "application/json",
+            // the unit version read with the list, the API rejects the update when someone changed the unit meanwhile
+            "If-Match": `"${version}"`,
+        },
         body: JSON.stringify(data),
     })
 
@@ -36,10 +90,69 @@ export async function updateUnit(id: string, data: Partial<Unit>) {
 }
 
 export async function deleteUnit(id: string) {
-    const res = await fetch(`${apiUrl}/unit/${id}`, {
+    const res = await authFetch(`/unit/${id}`, {
         method: "DELETE",
         headers: { "Content-Type": "application/json" }
     })
 
     return res.json()
-}
\ No newline at end of file
+}
+
+// subscribeUnitEvents calls onChange whenever a unit is changed by anyone, returns the function closing the stream.
+// EventSource cannot send the bearer token, so the stream is read with fetch and reconnected here with the
+// last event id, the API replays the missed events
+export function subscribeUnitEvents(onChange: () => void): () => void {
+    const events = ["unit.created", "unit.updated", "unit.deleted", "unit.restored", "reset"]
+    const controller = new AbortController()
+    let lastEventId = ""
+    let retry = 3000
+
+    const dispatch = (block: string) => {
+        let event = "message"
+        for (const line of block.split("\n")) {
+            const separator = line.indexOf(":")
+            if (separator <= 0) continue
+
+            const field = line.slice(0, separator)
+            const value = line.slice(separator + 1).replace(/^ /, "")
+            if (field === "id") lastEventId = value
+            if (field === "event") event = value
+            if (field === "retry" && Number(value) > 0) retry = Number(value)
+        }
+        if (events.includes(event)) onChange()
+    }
+
+    const connect = async () => {
+        while (!controller.signal.aborted) {
+            try {
+                const headers: Record<string, string> = { Accept: "text/event-stream" }
+                if (lastEventId) headers["Last-Event-ID"] = lastEventId
+
+                const res = await authFetch(`/unit/events`, { headers, signal: controller.signal, cache: "no-store" })
+                if (!res.ok || !res.body) throw new Error("Failed to open the unit events stream")
+
+                const reader = res.body.pipeThrough(new TextDecoderStream()).getReader()
+                let buffer = ""
+                while (true) {
+                    const { value, done } = await reader.read()
+                    if (done) break
+
+                    buffer += value.replace(/\r\n?/g, "\n")
+                    let end: number
+                    while ((end = buffer.indexOf("\n\n")) >= 0) {
+                        dispatch(buffer.slice(0, end))
+                        buffer = buffer.slice(end + 2)
+                    }
+                }
+            } catch (err) {
+                if (controller.signal.aborted || !getToken()) return
+                console.error("Error unit events:", err)
+            }
+
+            await new Promise((resolve) => setTimeout(resolve, retry))
+        }
+    }
+    connect()
+
+    return () => controller.abort()
+}
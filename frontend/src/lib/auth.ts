@@ -0,0 +1,24 @@
+const tokenKey = "accessToken"
+
+export const loginPath = "/login"
+
+// the token lives in the browser storage, so it is only read on the client
+export function getToken(): string | null {
+    if (typeof window === "undefined") return null;
+
+    return window.localStorage.getItem(tokenKey)
+}
+
+export function setToken(token: string) {
+    window.localStorage.setItem(tokenKey, token)
+}
+
+export function clearToken() {
+    window.localStorage.removeItem(tokenKey)
+}
+
+// redirectToLogin drops the token and sends the user to the login page, unless they are already there
+export function redirectToLogin() {
+    clearToken()
+    if (window.location.pathname !== loginPath) window.location.assign(loginPath)
+}
@@ -0,0 +1,12 @@
+export type User = {
+    id: string;
+    username: string;
+    role: string;
+};
+
+export type LoginResponse = {
+    accessToken: string;
+    tokenType: string;
+    expiresAt: string;
+    user: User;
+};
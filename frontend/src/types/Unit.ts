@@ -3,4 +3,5 @@ export type Unit = {
     name: string;
     type: string;
     status: string;
+    version?: number;
 };
\ No newline at end of file